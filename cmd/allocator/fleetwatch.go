@@ -0,0 +1,175 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	informerv1alpha1 "agones.dev/agones/pkg/client/informers/externalversions/stable/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+const fleetWatchPath = "/v1alpha1/fleets/watch"
+
+// FleetCapacity is a point in time snapshot of a single Fleet's
+// capacity, pushed to watch subscribers whenever it changes
+type FleetCapacity struct {
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
+	Replicas          int32  `json:"replicas"`
+	ReadyReplicas     int32  `json:"readyReplicas"`
+	AllocatedReplicas int32  `json:"allocatedReplicas"`
+	// Deleted is true when the Fleet has been removed
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// fleetWatchHandler streams fleet capacity snapshots to subscribers
+// over chunked HTTP responses, one JSON object per line, so matchmakers
+// can react to capacity changes without polling Fleet status
+type fleetWatchHandler struct {
+	mu          sync.Mutex
+	fleetLister func(namespace string) ([]*v1alpha1.Fleet, error)
+	subscribers map[chan FleetCapacity]string
+}
+
+// newFleetWatchHandler returns a fleetWatchHandler subscribed to the
+// passed in fleet informer's events
+func newFleetWatchHandler(fleetInformer informerv1alpha1.FleetInformer) *fleetWatchHandler {
+	h := &fleetWatchHandler{
+		fleetLister: func(namespace string) ([]*v1alpha1.Fleet, error) {
+			if namespace == "" {
+				return fleetInformer.Lister().List(labels.Everything())
+			}
+			return fleetInformer.Lister().Fleets(namespace).List(labels.Everything())
+		},
+		subscribers: map[chan FleetCapacity]string{},
+	}
+
+	fleetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			h.broadcast(capacity(obj.(*v1alpha1.Fleet), false))
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			h.broadcast(capacity(newObj.(*v1alpha1.Fleet), false))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if fleet, ok := obj.(*v1alpha1.Fleet); ok {
+				h.broadcast(capacity(fleet, true))
+			}
+		},
+	})
+
+	return h
+}
+
+// capacity converts a Fleet into its FleetCapacity snapshot
+func capacity(fleet *v1alpha1.Fleet, deleted bool) FleetCapacity {
+	return FleetCapacity{
+		Name:              fleet.ObjectMeta.Name,
+		Namespace:         fleet.ObjectMeta.Namespace,
+		Replicas:          fleet.Status.Replicas,
+		ReadyReplicas:     fleet.Status.ReadyReplicas,
+		AllocatedReplicas: fleet.Status.AllocatedReplicas,
+		Deleted:           deleted,
+	}
+}
+
+// subscribe registers a new subscriber channel, optionally filtered
+// to a single namespace
+func (h *fleetWatchHandler) subscribe(namespace string) chan FleetCapacity {
+	ch := make(chan FleetCapacity, 64)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[ch] = namespace
+	return ch
+}
+
+// unsubscribe removes a subscriber channel
+func (h *fleetWatchHandler) unsubscribe(ch chan FleetCapacity) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, ch)
+}
+
+// broadcast sends a snapshot to every subscriber whose namespace filter
+// matches. Slow subscribers that have filled their buffer miss updates
+// rather than blocking the informer event handler
+func (h *fleetWatchHandler) broadcast(fc FleetCapacity) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, namespace := range h.subscribers {
+		if namespace != "" && namespace != fc.Namespace {
+			continue
+		}
+		select {
+		case ch <- fc:
+		default:
+		}
+	}
+}
+
+// watchHandler streams capacity snapshots for all fleets (or those in
+// the namespace query parameter) - first the current state of each
+// fleet, and then a snapshot per change, until the client disconnects
+func (h *fleetWatchHandler) watchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+
+	ch := h.subscribe(namespace)
+	defer h.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	// send the current state of the world first, so subscribers don't
+	// need a separate list call to prime themselves
+	fleets, err := h.fleetLister(namespace)
+	if err != nil {
+		http.Error(w, "could not list Fleets", http.StatusInternalServerError)
+		return
+	}
+	for _, fleet := range fleets {
+		if err := encoder.Encode(capacity(fleet, false)); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case fc := <-ch:
+			if err := encoder.Encode(fc); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestFleetWatchHandlerBroadcast(t *testing.T) {
+	t.Parallel()
+
+	h := &fleetWatchHandler{subscribers: map[chan FleetCapacity]string{}}
+
+	all := h.subscribe("")
+	scoped := h.subscribe("team-a")
+	defer h.unsubscribe(all)
+	defer h.unsubscribe(scoped)
+
+	h.broadcast(FleetCapacity{Name: "fleet-1", Namespace: "default", ReadyReplicas: 5})
+	h.broadcast(FleetCapacity{Name: "fleet-2", Namespace: "team-a", ReadyReplicas: 3})
+
+	assert.Len(t, all, 2)
+	assert.Len(t, scoped, 1)
+	fc := <-scoped
+	assert.Equal(t, "fleet-2", fc.Name)
+	assert.Equal(t, int32(3), fc.ReadyReplicas)
+
+	h.unsubscribe(scoped)
+	h.broadcast(FleetCapacity{Name: "fleet-2", Namespace: "team-a"})
+	assert.Empty(t, scoped)
+}
+
+func TestFleetWatchHandlerInitialSnapshot(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	fleetList := &v1alpha1.FleetList{Items: []v1alpha1.Fleet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fleet-1", Namespace: "default"},
+			Status: v1alpha1.FleetStatus{Replicas: 10, ReadyReplicas: 8, AllocatedReplicas: 2}},
+	}}
+
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, fleetList, nil
+	})
+
+	fleetInformer := m.AgonesInformerFactory.Stable().V1alpha1().Fleets()
+	h := newFleetWatchHandler(fleetInformer)
+
+	_, cancel := agtesting.StartInformers(m, fleetInformer.Informer().HasSynced)
+	defer cancel()
+
+	// cancelled context, so the handler returns once the initial
+	// snapshot has been written
+	ctx, requestCancel := context.WithCancel(context.Background())
+	requestCancel()
+
+	r := httptest.NewRequest(http.MethodGet, fleetWatchPath, nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	h.watchHandler(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	fc := FleetCapacity{}
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(&fc))
+	assert.Equal(t, "fleet-1", fc.Name)
+	assert.Equal(t, int32(8), fc.ReadyReplicas)
+	assert.Equal(t, int32(2), fc.AllocatedReplicas)
+}
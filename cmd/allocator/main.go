@@ -0,0 +1,405 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// binary for the allocator service, a standalone endpoint for
+// allocating GameServers on behalf of multiple (authenticated) clients.
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"agones.dev/agones/pkg"
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"agones.dev/agones/pkg/client/clientset/versioned"
+	"agones.dev/agones/pkg/client/informers/externalversions"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"agones.dev/agones/pkg/metrics"
+	"agones.dev/agones/pkg/util/runtime"
+	"agones.dev/agones/pkg/util/signals"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/pkg/errors"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	certFileFlag                = "cert-file"
+	keyFileFlag                 = "key-file"
+	clientCaFileFlag            = "client-ca-file"
+	clientPolicyFileFlag        = "client-policy-file"
+	clientPolicyCRDFlag         = "client-policy-crd"
+	kubeconfigFlag              = "kubeconfig"
+	enablePrometheusMetricsFlag = "prometheus-exporter"
+	rateLimitQPSFlag            = "rate-limit-qps"
+	rateLimitBurstFlag          = "rate-limit-burst"
+	grpcPortFlag                = "grpc-port"
+
+	allocationPath = "/v1alpha1/gameserverallocation"
+	defaultResync  = 30 * time.Second
+
+	// readyReplicasHeader reports the Ready-pool depth the allocation could
+	// draw from, so matchmakers can track remaining capacity per response
+	readyReplicasHeader = "X-Agones-Ready-Replicas"
+	// retryAfterHeader is the standard backoff hint header
+	retryAfterHeader = "Retry-After"
+
+	// lowReadyPoolThreshold is the Ready-pool depth at or below which the
+	// allocator returns a Retry-After backoff hint, so matchmakers can slow
+	// down or queue players instead of hammering a nearly empty pool
+	lowReadyPoolThreshold = 5
+	// retryAfterSeconds is the backoff hint returned when the Ready pool is low
+	retryAfterSeconds = "5"
+)
+
+var (
+	logger = runtime.NewLoggerWithSource("main")
+)
+
+func main() {
+	ctlConf := parseEnvFlags()
+	logger.WithField("version", pkg.Version).
+		WithField("ctlConf", ctlConf).Info("starting allocator service...")
+
+	if err := ctlConf.validate(); err != nil {
+		logger.WithError(err).Fatal("could not create allocator service from environment or flags")
+	}
+
+	// if the kubeconfig fails BuildConfigFromFlags will try in cluster config
+	clientConf, err := clientcmd.BuildConfigFromFlags("", ctlConf.KubeConfig)
+	if err != nil {
+		logger.WithError(err).Fatal("could not create in cluster config")
+	}
+
+	agonesClient, err := versioned.NewForConfig(clientConf)
+	if err != nil {
+		logger.WithError(err).Fatal("could not create the agones api clientset")
+	}
+
+	policies, err := newClientPolicySet(ctlConf.ClientPolicyFile)
+	if err != nil {
+		logger.WithError(err).Fatal("could not load the client policy file")
+	}
+
+	stop := signals.NewStopChannel()
+
+	agonesInformerFactory := externalversions.NewSharedInformerFactory(agonesClient, defaultResync)
+	gameServerInformer := agonesInformerFactory.Stable().V1alpha1().GameServers()
+	gameServerSynced := gameServerInformer.Informer().HasSynced
+	fleetInformer := agonesInformerFactory.Stable().V1alpha1().Fleets()
+	fleetSynced := fleetInformer.Informer().HasSynced
+
+	synced := []cache.InformerSynced{gameServerSynced, fleetSynced}
+	if ctlConf.ClientPolicyCRD {
+		allocatorPolicyInformer := agonesInformerFactory.Stable().V1alpha1().AllocatorPolicies()
+		synced = append(synced, allocatorPolicyInformer.Informer().HasSynced)
+		if policies == nil {
+			policies = &clientPolicySet{}
+		}
+		policies.watchAllocatorPolicies(allocatorPolicyInformer.Lister())
+	}
+
+	h := newServiceHandler(agonesClient, gameServerInformer.Lister())
+	q := newQueryHandler(gameServerInformer.Lister())
+	fw := newFleetWatchHandler(fleetInformer)
+
+	mw := []middleware{
+		authMiddleware(policies),
+		rateLimitMiddleware(ctlConf.RateLimitQPS, ctlConf.RateLimitBurst),
+		metricsMiddleware(),
+		logMiddleware(),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(allocationPath, chain(http.HandlerFunc(h.allocateHandler), mw...))
+	mux.Handle(gameServersPath, chain(http.HandlerFunc(q.listHandler), mw...))
+	mux.Handle(fleetWatchPath, chain(http.HandlerFunc(fw.watchHandler), mw...))
+
+	health := healthcheck.NewHandler()
+	mux.HandleFunc("/live", health.LiveEndpoint)
+
+	oa, err := newOpenAPIHandler()
+	if err != nil {
+		logger.WithError(err).Fatal("could not build the OpenAPI document")
+	}
+	mux.Handle(openAPIPath, oa)
+
+	if ctlConf.PrometheusMetrics {
+		registry := prom.NewRegistry()
+		metricHandler, err := metrics.RegisterPrometheusExporter(registry)
+		if err != nil {
+			logger.WithError(err).Fatal("could not register prometheus exporter")
+		}
+		registerViews()
+		mux.Handle("/metrics", metricHandler)
+	}
+
+	srv := &http.Server{
+		Addr:    ":8443",
+		Handler: mux,
+	}
+
+	if ctlConf.ClientCaFile != "" {
+		tlsConfig, err := clientCATLSConfig(ctlConf.ClientCaFile)
+		if err != nil {
+			logger.WithError(err).Fatal("could not load the client CA certificate")
+		}
+		srv.TLSConfig = tlsConfig
+	}
+
+	agonesInformerFactory.Start(stop)
+	logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, synced...) {
+		logger.Fatal("failed to wait for caches to sync")
+	}
+
+	if ctlConf.GRPCPort > 0 {
+		grpcServer, err := runGRPCHealth(ctlConf.GRPCPort)
+		if err != nil {
+			logger.WithError(err).Fatal("could not start the grpc health service")
+		}
+		defer grpcServer.Stop()
+	}
+
+	go func() {
+		err := srv.ListenAndServeTLS(ctlConf.CertFile, ctlConf.KeyFile)
+		logger.WithError(err).Fatal("allocator service crashed")
+	}()
+
+	<-stop
+	logger.Info("shutting down...")
+	srv.Close() // nolint: errcheck
+}
+
+// runGRPCHealth starts a grpc server on the given port, serving standard
+// grpc health checking and server reflection. The allocator's API is
+// http/json, but L7 load balancers such as Envoy health check over grpc,
+// and reflection lets engineers explore the endpoint with grpcurl
+func runGRPCHealth(port int) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not listen on grpc port %d", port)
+	}
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, grpchealth.NewServer())
+	reflection.Register(grpcServer)
+
+	go func() {
+		logger.WithField("grpcPort", port).Info("Starting grpc health service...")
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.WithError(err).Fatal("grpc health service crashed")
+		}
+	}()
+
+	return grpcServer, nil
+}
+
+// clientCATLSConfig returns a tls.Config that requires and verifies
+// client certificates against the passed in CA certificate bundle
+func clientCATLSConfig(caFile string) (*tls.Config, error) {
+	caCert, err := ioutil.ReadFile(caFile) // nolint: gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read client CA certificate %s", caFile)
+	}
+
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.Errorf("no certificates could be parsed from %s", caFile)
+	}
+
+	return &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  caCertPool,
+	}, nil
+}
+
+// serviceHandler implements the allocator service's http endpoints
+type serviceHandler struct {
+	agonesClient     versioned.Interface
+	gameServerLister listerv1alpha1.GameServerLister
+}
+
+// newServiceHandler returns a serviceHandler for the passed in clientset
+// and lister
+func newServiceHandler(agonesClient versioned.Interface, gameServerLister listerv1alpha1.GameServerLister) *serviceHandler {
+	return &serviceHandler{
+		agonesClient:     agonesClient,
+		gameServerLister: gameServerLister,
+	}
+}
+
+// allocateHandler accepts a POST'd GameServerAllocation, checks that the
+// authenticated client is allowed to allocate in the target namespace and
+// fleet, and creates the allocation through the Kubernetes API
+func (h *serviceHandler) allocateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gsa := &v1alpha1.GameServerAllocation{}
+	if err := json.NewDecoder(r.Body).Decode(gsa); err != nil {
+		http.Error(w, errors.Wrap(err, "could not decode GameServerAllocation").Error(), http.StatusBadRequest)
+		return
+	}
+
+	if gsa.ObjectMeta.Namespace == "" {
+		gsa.ObjectMeta.Namespace = metav1.NamespaceDefault
+	}
+
+	if policy, ok := clientPolicyFromContext(r.Context()); ok {
+		if err := policy.authorize(gsa); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	result, err := h.agonesClient.StableV1alpha1().GameServerAllocations(gsa.ObjectMeta.Namespace).Create(gsa)
+	if err != nil {
+		logger.WithError(err).Error("could not create GameServerAllocation")
+		http.Error(w, errors.Wrap(err, "could not create GameServerAllocation").Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if depth, err := h.readyPoolDepth(gsa); err != nil {
+		logger.WithError(err).Warning("could not measure the Ready pool depth")
+	} else {
+		w.Header().Set(readyReplicasHeader, strconv.Itoa(depth))
+		if depth <= lowReadyPoolThreshold {
+			w.Header().Set(retryAfterHeader, retryAfterSeconds)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.WithError(err).Error("error encoding GameServerAllocation response")
+	}
+}
+
+// readyPoolDepth returns the number of Ready GameServers the allocation
+// could still draw from - those in its namespace matching the required
+// selector - measured from the informer cache
+func (h *serviceHandler) readyPoolDepth(gsa *v1alpha1.GameServerAllocation) (int, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&gsa.Spec.Required)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not convert the GameServerAllocation selector")
+	}
+
+	list, err := h.gameServerLister.GameServers(gsa.ObjectMeta.Namespace).List(selector)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list GameServers")
+	}
+
+	depth := 0
+	for _, gs := range list {
+		if gs.ObjectMeta.DeletionTimestamp.IsZero() && gs.Status.State == v1alpha1.GameServerStateReady {
+			depth++
+		}
+	}
+	return depth, nil
+}
+
+// config retains the configuration information
+type config struct {
+	CertFile          string
+	KeyFile           string
+	ClientCaFile      string
+	ClientPolicyFile  string
+	ClientPolicyCRD   bool
+	KubeConfig        string
+	PrometheusMetrics bool
+	RateLimitQPS      rate.Limit
+	RateLimitBurst    int
+	GRPCPort          int
+}
+
+// validate returns an error if there is a validation problem
+func (c *config) validate() error {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return errors.New("a server certificate and key are required")
+	}
+	if c.RateLimitQPS < 0 {
+		return errors.New("rate limit qps must be greater than or equal to zero")
+	}
+
+	return nil
+}
+
+func parseEnvFlags() config {
+	viper.SetDefault(certFileFlag, "")
+	viper.SetDefault(keyFileFlag, "")
+	viper.SetDefault(clientCaFileFlag, "")
+	viper.SetDefault(clientPolicyFileFlag, "")
+	viper.SetDefault(clientPolicyCRDFlag, true)
+	viper.SetDefault(enablePrometheusMetricsFlag, true)
+	viper.SetDefault(rateLimitQPSFlag, 0)
+	viper.SetDefault(rateLimitBurstFlag, 20)
+	viper.SetDefault(grpcPortFlag, 8444)
+
+	pflag.String(certFileFlag, viper.GetString(certFileFlag), "Required. Path to the crt file for serving TLS. Can also use CERT_FILE env variable")
+	pflag.String(keyFileFlag, viper.GetString(keyFileFlag), "Required. Path to the key file for serving TLS. Can also use KEY_FILE env variable")
+	pflag.String(clientCaFileFlag, viper.GetString(clientCaFileFlag), "Optional. Path to a CA bundle used to require and verify client certificates (mTLS). Can also use CLIENT_CA_FILE env variable")
+	pflag.String(clientPolicyFileFlag, viper.GetString(clientPolicyFileFlag), "Optional. Path to a JSON file of client policies, mapping client identities to allowed namespaces and fleets. If not set, all requests are allowed. Can also use CLIENT_POLICY_FILE env variable")
+	pflag.Bool(clientPolicyCRDFlag, viper.GetBool(clientPolicyCRDFlag), "Optional. Watch AllocatorPolicy resources for client policies, in addition to the client policy file, so policies can be managed declaratively without allocator restarts. Can also use CLIENT_POLICY_CRD env variable")
+	pflag.String(kubeconfigFlag, viper.GetString(kubeconfigFlag), "Optional. kubeconfig to run the allocator service out of the cluster.")
+	pflag.Bool(enablePrometheusMetricsFlag, viper.GetBool(enablePrometheusMetricsFlag), "Flag to activate metrics of the allocator service. Can also use PROMETHEUS_EXPORTER env variable.")
+	pflag.Float64(rateLimitQPSFlag, viper.GetFloat64(rateLimitQPSFlag), "Flag to set how many allocation requests a single client can make per second. 0 disables rate limiting. Can also use RATE_LIMIT_QPS env variable")
+	pflag.Int(rateLimitBurstFlag, viper.GetInt(rateLimitBurstFlag), "Flag to set the burst size of the per client rate limit. Can also use RATE_LIMIT_BURST env variable")
+	pflag.Int(grpcPortFlag, viper.GetInt(grpcPortFlag), "Port to serve standard grpc health checking and reflection on. Set to 0 to disable. Can also use GRPC_PORT env variable")
+	pflag.Parse()
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	runtime.Must(viper.BindEnv(certFileFlag))
+	runtime.Must(viper.BindEnv(keyFileFlag))
+	runtime.Must(viper.BindEnv(clientCaFileFlag))
+	runtime.Must(viper.BindEnv(clientPolicyFileFlag))
+	runtime.Must(viper.BindEnv(clientPolicyCRDFlag))
+	runtime.Must(viper.BindEnv(kubeconfigFlag))
+	runtime.Must(viper.BindEnv(enablePrometheusMetricsFlag))
+	runtime.Must(viper.BindEnv(rateLimitQPSFlag))
+	runtime.Must(viper.BindEnv(rateLimitBurstFlag))
+	runtime.Must(viper.BindEnv(grpcPortFlag))
+	runtime.Must(viper.BindPFlags(pflag.CommandLine))
+
+	return config{
+		CertFile:          viper.GetString(certFileFlag),
+		KeyFile:           viper.GetString(keyFileFlag),
+		ClientCaFile:      viper.GetString(clientCaFileFlag),
+		ClientPolicyFile:  viper.GetString(clientPolicyFileFlag),
+		ClientPolicyCRD:   viper.GetBool(clientPolicyCRDFlag),
+		KubeConfig:        viper.GetString(kubeconfigFlag),
+		PrometheusMetrics: viper.GetBool(enablePrometheusMetricsFlag),
+		RateLimitQPS:      rate.Limit(viper.GetFloat64(rateLimitQPSFlag)),
+		RateLimitBurst:    viper.GetInt(rateLimitBurstFlag),
+		GRPCPort:          viper.GetInt(grpcPortFlag),
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestServiceHandlerAllocateReadyPoolHeaders(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		readyCount       int
+		expectRetryAfter bool
+	}{
+		"deep pool":    {readyCount: lowReadyPoolThreshold + 5, expectRetryAfter: false},
+		"shallow pool": {readyCount: 2, expectRetryAfter: true},
+		"empty pool":   {readyCount: 0, expectRetryAfter: true},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			m := agtesting.NewMocks()
+
+			gsList := &v1alpha1.GameServerList{}
+			for i := 0; i < v.readyCount; i++ {
+				gsList.Items = append(gsList.Items, v1alpha1.GameServer{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("gs-%d", i), Namespace: "default"},
+					Status:     v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady},
+				})
+			}
+			m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+				return true, gsList, nil
+			})
+			m.AgonesClient.AddReactor("create", "gameserverallocations", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+				ca := action.(k8stesting.CreateAction)
+				return true, ca.GetObject(), nil
+			})
+
+			gameServerInformer := m.AgonesInformerFactory.Stable().V1alpha1().GameServers()
+			h := newServiceHandler(m.AgonesClient, gameServerInformer.Lister())
+
+			_, cancel := agtesting.StartInformers(m, gameServerInformer.Informer().HasSynced)
+			defer cancel()
+
+			gsa := &v1alpha1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "allocation", Namespace: "default"}}
+			body, err := json.Marshal(gsa)
+			assert.Nil(t, err)
+
+			r := httptest.NewRequest(http.MethodPost, allocationPath, bytes.NewReader(body))
+			w := httptest.NewRecorder()
+			h.allocateHandler(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, fmt.Sprint(v.readyCount), w.Header().Get(readyReplicasHeader))
+			if v.expectRetryAfter {
+				assert.Equal(t, retryAfterSeconds, w.Header().Get(retryAfterHeader))
+			} else {
+				assert.Empty(t, w.Header().Get(retryAfterHeader))
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	keyClient     = mustTagKey("client")
+	keyStatusCode = mustTagKey("status_code")
+
+	requestTotalStats   = stats.Int64("allocator/requests_total", "The total of allocation requests", "1")
+	requestLatencyStats = stats.Float64("allocator/request_latency", "The latency of allocation requests", "ms")
+
+	allocatorViews = []*view.View{
+		{
+			Name:        "allocator_requests_total",
+			Measure:     requestTotalStats,
+			Description: "The total of allocation requests per client",
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{keyClient, keyStatusCode},
+		},
+		{
+			Name:        "allocator_request_latency",
+			Measure:     requestLatencyStats,
+			Description: "The latency of allocation requests per client",
+			Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000),
+			TagKeys:     []tag.Key{keyClient, keyStatusCode},
+		},
+	}
+)
+
+// registerViews registers all the allocator views to OpenCensus
+func registerViews() {
+	for _, v := range allocatorViews {
+		if err := view.Register(v); err != nil {
+			logger.WithError(err).Error("could not register view")
+		}
+	}
+}
+
+// recordRequest records a single request's count and latency, tagged by
+// client identity and response status code
+func recordRequest(ctx context.Context, client string, status int, duration time.Duration) {
+	mutators := []tag.Mutator{
+		tag.Upsert(keyClient, client),
+		tag.Upsert(keyStatusCode, strconv.Itoa(status)),
+	}
+
+	err := stats.RecordWithTags(ctx, mutators,
+		requestTotalStats.M(1),
+		requestLatencyStats.M(float64(duration)/float64(time.Millisecond)))
+	if err != nil {
+		logger.WithError(err).Warn("error while recording stats")
+	}
+}
+
+func mustTagKey(key string) tag.Key {
+	t, err := tag.NewKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
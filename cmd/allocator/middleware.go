@@ -0,0 +1,173 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// middleware wraps a http.Handler with additional behaviour. Middleware
+// are applied to the allocator service endpoints as a chain - auth,
+// rate limiting, logging and metrics
+type middleware func(http.Handler) http.Handler
+
+// chain applies the passed in middleware to the handler in order, such
+// that the first middleware is the outermost one
+func chain(h http.Handler, mw ...middleware) http.Handler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// contextKey is a private type for context keys in this package
+type contextKey int
+
+const clientPolicyKey contextKey = iota
+
+// clientPolicyFromContext returns the authenticated ClientPolicy for
+// this request, if there is one
+func clientPolicyFromContext(ctx context.Context) (*ClientPolicy, bool) {
+	p, ok := ctx.Value(clientPolicyKey).(*ClientPolicy)
+	return p, ok
+}
+
+// clientName returns the identity this request is attributed to in
+// logs, metrics and rate limits
+func clientName(r *http.Request) string {
+	if p, ok := clientPolicyFromContext(r.Context()); ok {
+		return p.Name
+	}
+	return "anonymous"
+}
+
+// authMiddleware authenticates each request against the clientPolicySet
+// and stores the matched ClientPolicy on the request context. If the
+// policy set is nil, all requests pass through unauthenticated
+func authMiddleware(policies *clientPolicySet) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policies != nil {
+				policy, err := policies.authenticate(r)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
+				// a nil policy means no policies are configured, and the
+				// request is unrestricted
+				if policy != nil {
+					r = r.WithContext(context.WithValue(r.Context(), clientPolicyKey, policy))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// statusCapturingWriter is a http.ResponseWriter that records the
+// response status code for logging and metrics
+type statusCapturingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader captures the status code before delegating
+func (w *statusCapturingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// logMiddleware writes a structured log line for each request, tagged
+// with the client identity, method, path, status and duration
+func logMiddleware() middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			cw := &statusCapturingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(cw, r)
+
+			logger.WithField("client", clientName(r)).
+				WithField("method", r.Method).
+				WithField("path", r.URL.Path).
+				WithField("status", cw.status).
+				WithField("duration", time.Since(start).String()).
+				Info("allocation request")
+		})
+	}
+}
+
+// metricsMiddleware records per-client request counts and latencies
+// to OpenCensus
+func metricsMiddleware() middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			cw := &statusCapturingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(cw, r)
+			recordRequest(r.Context(), clientName(r), cw.status, time.Since(start))
+		})
+	}
+}
+
+// rateLimiters tracks a token bucket per client identity
+type rateLimiters struct {
+	mu       sync.Mutex
+	qps      rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+// newRateLimiters returns a rateLimiters with the given per client
+// queries per second and burst values
+func newRateLimiters(qps rate.Limit, burst int) *rateLimiters {
+	return &rateLimiters{
+		qps:      qps,
+		burst:    burst,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// limiter returns (creating if necessary) the rate.Limiter for a client
+func (rl *rateLimiters) limiter(client string) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	l, ok := rl.limiters[client]
+	if !ok {
+		l = rate.NewLimiter(rl.qps, rl.burst)
+		rl.limiters[client] = l
+	}
+	return l
+}
+
+// rateLimitMiddleware applies a token-bucket rate limit per client
+// identity. A qps of 0 disables rate limiting
+func rateLimitMiddleware(qps rate.Limit, burst int) middleware {
+	limiters := newRateLimiters(qps, burst)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if qps > 0 && !limiters.limiter(clientName(r)).Allow() {
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
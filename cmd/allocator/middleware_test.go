@@ -0,0 +1,82 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	ps := &clientPolicySet{static: []ClientPolicy{
+		{Name: "team-a", APIKey: "secret", Namespaces: []string{"team-a"}},
+	}}
+
+	var captured *ClientPolicy
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured, _ = clientPolicyFromContext(r.Context())
+	}), authMiddleware(ps))
+
+	r := httptest.NewRequest(http.MethodPost, allocationPath, nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotNil(t, captured)
+	assert.Equal(t, "team-a", captured.Name)
+
+	r = httptest.NewRequest(http.MethodPost, allocationPath, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// nil policy set lets everything through
+	h = chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), authMiddleware(nil))
+	r = httptest.NewRequest(http.MethodPost, allocationPath, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		rateLimitMiddleware(1, 1))
+
+	r := httptest.NewRequest(http.MethodPost, allocationPath, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// 0 qps disables rate limiting
+	h = chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		rateLimitMiddleware(0, 0))
+	for i := 0; i < 10; i++ {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
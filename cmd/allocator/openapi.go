@@ -0,0 +1,128 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"agones.dev/agones/pkg"
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"agones.dev/agones/pkg/util/openapi"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const openAPIPath = "/openapi/v2"
+
+// newOpenAPIHandler builds the OpenAPI document describing the allocator's
+// REST surface and the Agones CRDs from their Go types, and returns a
+// handler serving it as JSON, so client code in other languages can be
+// generated from the running service rather than handwritten
+func newOpenAPIHandler() (http.HandlerFunc, error) {
+	spec := &openapi.Spec{
+		Swagger: "2.0",
+		Info: openapi.Info{
+			Title:       "Agones Allocator Service",
+			Description: "Standalone endpoint for allocating and discovering GameServers on behalf of multiple (authenticated) clients",
+			Version:     pkg.Version,
+		},
+		Schemes:  []string{"https"},
+		Consumes: []string{"application/json"},
+		Produces: []string{"application/json"},
+		Paths:    map[string]openapi.PathItem{},
+	}
+
+	gsaRef := spec.AddDefinition(&v1alpha1.GameServerAllocation{})
+	gslRef := spec.AddDefinition(&v1alpha1.GameServerList{})
+	fcRef := spec.AddDefinition(FleetCapacity{})
+
+	// the CRDs themselves, so generated clients can work with the full
+	// resources and not just the allocator's own request and response types
+	spec.AddDefinition(&v1alpha1.GameServer{})
+	spec.AddDefinition(&v1alpha1.Fleet{})
+	spec.AddDefinition(&v1alpha1.GameServerSet{})
+	spec.AddDefinition(&v1alpha1.FleetAutoscaler{})
+
+	spec.SetExample("v1alpha1.GameServerAllocation", &v1alpha1.GameServerAllocation{
+		TypeMeta: metav1.TypeMeta{Kind: "GameServerAllocation", APIVersion: "stable.agones.dev/v1alpha1"},
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{
+				MatchLabels: map[string]string{v1alpha1.FleetNameLabel: "simple-udp"},
+			},
+		},
+	})
+	spec.SetExample("allocator.FleetCapacity", FleetCapacity{
+		Name:              "simple-udp",
+		Namespace:         "default",
+		Replicas:          5,
+		ReadyReplicas:     3,
+		AllocatedReplicas: 2,
+	})
+
+	spec.Paths[allocationPath] = openapi.PathItem{
+		Post: &openapi.Operation{
+			Summary:     "Allocate a GameServer",
+			Description: "Creates the posted GameServerAllocation, marking a matching Ready GameServer as Allocated and returning its connection details in the allocation's status",
+			Parameters: []openapi.Parameter{
+				{Name: "body", In: "body", Required: true, Schema: gsaRef},
+			},
+			Responses: map[string]openapi.Response{
+				"200": {Description: "The created GameServerAllocation, with its status filled in", Schema: gsaRef},
+				"403": {Description: "The client's policy does not allow allocating in the requested namespace or fleet"},
+			},
+		},
+	}
+	spec.Paths[gameServersPath] = openapi.PathItem{
+		Get: &openapi.Operation{
+			Summary:     "List GameServers",
+			Description: "Returns Ready and/or Allocated GameServers in a namespace, served out of the informer cache",
+			Parameters: []openapi.Parameter{
+				{Name: "namespace", In: "query", Type: "string", Description: "Namespace to list in. Defaults to \"default\""},
+				{Name: "labelSelector", In: "query", Type: "string", Description: "Optional label selector to filter by"},
+				{Name: "state", In: "query", Type: "string", Description: "Ready or Allocated. Defaults to both"},
+			},
+			Responses: map[string]openapi.Response{
+				"200": {Description: "The matching GameServers", Schema: gslRef},
+			},
+		},
+	}
+	spec.Paths[fleetWatchPath] = openapi.PathItem{
+		Get: &openapi.Operation{
+			Summary:     "Watch Fleet capacity",
+			Description: "Streams FleetCapacity snapshots over a chunked response, one JSON object per line - first the current state of each fleet, and then a snapshot per change, until the client disconnects",
+			Parameters: []openapi.Parameter{
+				{Name: "namespace", In: "query", Type: "string", Description: "Optional namespace to filter the stream to"},
+			},
+			Responses: map[string]openapi.Response{
+				"200": {Description: "A stream of FleetCapacity snapshots", Schema: fcRef},
+			},
+		},
+	}
+
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal the OpenAPI document")
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body) // nolint: errcheck
+	}, nil
+}
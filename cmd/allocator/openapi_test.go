@@ -0,0 +1,70 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"agones.dev/agones/pkg/util/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIHandler(t *testing.T) {
+	t.Parallel()
+
+	handler, err := newOpenAPIHandler()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	spec := &openapi.Spec{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), spec))
+	assert.Equal(t, "2.0", spec.Swagger)
+
+	// the allocator's own surface
+	assert.NotNil(t, spec.Paths[allocationPath].Post)
+	assert.NotNil(t, spec.Paths[gameServersPath].Get)
+	assert.NotNil(t, spec.Paths[fleetWatchPath].Get)
+
+	// definitions generated from the Go types, CRDs included
+	for _, name := range []string{
+		"v1alpha1.GameServerAllocation",
+		"v1alpha1.GameServerList",
+		"v1alpha1.GameServer",
+		"v1alpha1.Fleet",
+		"v1alpha1.GameServerSet",
+		"v1alpha1.FleetAutoscaler",
+		"allocator.FleetCapacity",
+	} {
+		assert.Contains(t, spec.Definitions, name, name)
+	}
+
+	gsa := spec.Definitions["v1alpha1.GameServerAllocation"]
+	assert.Equal(t, "object", gsa.Type)
+	assert.Contains(t, gsa.Properties, "spec")
+	assert.NotNil(t, gsa.Example, "the allocation definition should carry an example")
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, openAPIPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,205 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ClientPolicy maps a single allocator client identity to the
+// namespaces and fleets it is allowed to allocate from.
+// A client is identified either by the Common Name or DNS Subject
+// Alternative Names of its verified mTLS client certificate, or by a
+// shared API key passed as a `Authorization: Bearer` header.
+type ClientPolicy struct {
+	// Name is the client identity, matched against the mTLS client
+	// certificate's Common Name and DNS Subject Alternative Names
+	Name string `json:"name"`
+	// APIKey optionally authenticates this client via an
+	// `Authorization: Bearer <key>` header, for clients without certificates
+	APIKey string `json:"apiKey,omitempty"`
+	// Namespaces are the namespaces this client may allocate GameServers in
+	Namespaces []string `json:"namespaces"`
+	// Fleets optionally restricts this client to allocating from the given
+	// Fleet names. If empty, any fleet within the allowed namespaces can
+	// be allocated from
+	Fleets []string `json:"fleets,omitempty"`
+}
+
+// clientPolicySet is the set of configured client policies for this
+// allocator service - the statically configured ones from the client
+// policy file, plus one per AllocatorPolicy resource when a lister has
+// been attached with watchAllocatorPolicies
+type clientPolicySet struct {
+	static []ClientPolicy
+	lister listerv1alpha1.AllocatorPolicyLister
+}
+
+// newClientPolicySet loads a set of ClientPolicies from a JSON file.
+// An empty path returns a nil clientPolicySet - which allows all requests.
+func newClientPolicySet(path string) (*clientPolicySet, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read client policy file %s", path)
+	}
+
+	var policies []ClientPolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, errors.Wrapf(err, "could not parse client policy file %s", path)
+	}
+
+	for _, p := range policies {
+		if p.Name == "" && p.APIKey == "" {
+			return nil, errors.Errorf("client policy in %s must declare a name or an apiKey", path)
+		}
+	}
+
+	return &clientPolicySet{static: policies}, nil
+}
+
+// watchAllocatorPolicies adds AllocatorPolicy resources as a dynamic policy
+// source, read from the passed in informer cache on every request, so policy
+// changes apply without an allocator restart
+func (ps *clientPolicySet) watchAllocatorPolicies(lister listerv1alpha1.AllocatorPolicyLister) {
+	ps.lister = lister
+}
+
+// policies returns the current set of client policies. AllocatorPolicy
+// resources that declare neither a clientName nor an apiKey are skipped, as
+// they can never match a client
+func (ps *clientPolicySet) policies() []ClientPolicy {
+	result := append([]ClientPolicy(nil), ps.static...)
+	if ps.lister == nil {
+		return result
+	}
+
+	list, err := ps.lister.List(labels.Everything())
+	if err != nil {
+		logger.WithError(err).Error("could not list AllocatorPolicies")
+		return result
+	}
+	for _, ap := range list {
+		if ap.Spec.ClientName == "" && ap.Spec.APIKey == "" {
+			logger.WithField("allocatorpolicy", ap.ObjectMeta.Name).
+				Warn("AllocatorPolicy declares neither a clientName nor an apiKey, skipping")
+			continue
+		}
+		result = append(result, ClientPolicy{
+			Name:       ap.Spec.ClientName,
+			APIKey:     ap.Spec.APIKey,
+			Namespaces: ap.Spec.Namespaces,
+			Fleets:     ap.Spec.Fleets,
+		})
+	}
+
+	return result
+}
+
+// authenticate determines which ClientPolicy applies to the incoming
+// request, checking the verified client certificate chain first, and
+// then any bearer API key. Returns an error if no policy matches. If no
+// policies are configured at all, a nil policy is returned and the
+// request is unrestricted.
+func (ps *clientPolicySet) authenticate(r *http.Request) (*ClientPolicy, error) {
+	policies := ps.policies()
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		if p := policyForCertificate(policies, r.TLS.PeerCertificates[0]); p != nil {
+			return p, nil
+		}
+	}
+
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		key := strings.TrimPrefix(auth, "Bearer ")
+		for i, p := range policies {
+			if p.APIKey != "" && p.APIKey == key {
+				return &policies[i], nil
+			}
+		}
+	}
+
+	return nil, errors.New("no client policy matches the presented client certificate or API key")
+}
+
+// policyForCertificate returns the ClientPolicy whose name matches the
+// certificate's Common Name or one of its DNS Subject Alternative Names,
+// or nil if there is none
+func policyForCertificate(policies []ClientPolicy, cert *x509.Certificate) *ClientPolicy {
+	for i, p := range policies {
+		if p.Name == "" {
+			continue
+		}
+		if cert.Subject.CommonName == p.Name {
+			return &policies[i]
+		}
+		for _, san := range cert.DNSNames {
+			if san == p.Name {
+				return &policies[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// authorize checks that the GameServerAllocation this client is requesting
+// falls within the namespaces and fleets its policy allows
+func (p *ClientPolicy) authorize(gsa *v1alpha1.GameServerAllocation) error {
+	allowed := false
+	for _, ns := range p.Namespaces {
+		if ns == gsa.ObjectMeta.Namespace {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.Errorf("client %s is not permitted to allocate in namespace %s", p.Name, gsa.ObjectMeta.Namespace)
+	}
+
+	if len(p.Fleets) == 0 {
+		return nil
+	}
+
+	// when fleets are restricted, the allocation must explicitly target
+	// one of the allowed fleets via the fleet name label, otherwise the
+	// required selector could match another team's GameServers
+	fleet, ok := gsa.Spec.Required.MatchLabels[v1alpha1.FleetNameLabel]
+	if !ok {
+		return errors.Errorf("client %s must select a fleet with the %s label", p.Name, v1alpha1.FleetNameLabel)
+	}
+	for _, f := range p.Fleets {
+		if f == fleet {
+			return nil
+		}
+	}
+
+	return errors.Errorf("client %s is not permitted to allocate from fleet %s", p.Name, fleet)
+}
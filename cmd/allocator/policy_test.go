@@ -0,0 +1,176 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNewClientPolicySet(t *testing.T) {
+	t.Parallel()
+
+	ps, err := newClientPolicySet("")
+	assert.Nil(t, err)
+	assert.Nil(t, ps)
+
+	f, err := ioutil.TempFile("", "policy")
+	assert.Nil(t, err)
+	defer os.Remove(f.Name()) // nolint: errcheck
+
+	_, err = f.WriteString(`[{"name": "team-a", "namespaces": ["team-a"], "fleets": ["simple-udp"]}]`)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	ps, err = newClientPolicySet(f.Name())
+	assert.Nil(t, err)
+	assert.Len(t, ps.static, 1)
+	assert.Equal(t, "team-a", ps.static[0].Name)
+
+	_, err = newClientPolicySet("/does/not/exist")
+	assert.NotNil(t, err)
+}
+
+func TestClientPolicySetAllocatorPolicies(t *testing.T) {
+	t.Parallel()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	ps := &clientPolicySet{static: []ClientPolicy{
+		{Name: "team-a", Namespaces: []string{"team-a"}},
+	}}
+	ps.watchAllocatorPolicies(listerv1alpha1.NewAllocatorPolicyLister(indexer))
+
+	assert.Len(t, ps.policies(), 1)
+
+	assert.Nil(t, indexer.Add(&v1alpha1.AllocatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "agones-system"},
+		Spec: v1alpha1.AllocatorPolicySpec{
+			ClientName: "team-b",
+			APIKey:     "secret",
+			Namespaces: []string{"team-b"},
+		},
+	}))
+	// neither a clientName nor an apiKey - can never match, so skipped
+	assert.Nil(t, indexer.Add(&v1alpha1.AllocatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "agones-system"},
+		Spec:       v1alpha1.AllocatorPolicySpec{Namespaces: []string{"nowhere"}},
+	}))
+
+	policies := ps.policies()
+	assert.Len(t, policies, 2)
+
+	r := &http.Request{Header: http.Header{"Authorization": []string{"Bearer secret"}}}
+	p, err := ps.authenticate(r)
+	assert.Nil(t, err)
+	assert.Equal(t, "team-b", p.Name)
+	assert.Equal(t, []string{"team-b"}, p.Namespaces)
+
+	// an empty policy set leaves requests unrestricted
+	empty := &clientPolicySet{}
+	empty.watchAllocatorPolicies(listerv1alpha1.NewAllocatorPolicyLister(
+		cache.NewIndexer(cache.MetaNamespaceKeyFunc,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})))
+	p, err = empty.authenticate(r)
+	assert.Nil(t, err)
+	assert.Nil(t, p)
+}
+
+func TestClientPolicySetAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	ps := &clientPolicySet{static: []ClientPolicy{
+		{Name: "team-a", Namespaces: []string{"team-a"}},
+		{Name: "team-b", APIKey: "secret", Namespaces: []string{"team-b"}},
+	}}
+
+	t.Run("client certificate common name", func(t *testing.T) {
+		r := &http.Request{TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "team-a"}}},
+		}}
+
+		p, err := ps.authenticate(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "team-a", p.Name)
+	})
+
+	t.Run("client certificate dns san", func(t *testing.T) {
+		r := &http.Request{TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{DNSNames: []string{"team-a"}}},
+		}}
+
+		p, err := ps.authenticate(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "team-a", p.Name)
+	})
+
+	t.Run("api key", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{"Authorization": []string{"Bearer secret"}}}
+
+		p, err := ps.authenticate(r)
+		assert.Nil(t, err)
+		assert.Equal(t, "team-b", p.Name)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{"Authorization": []string{"Bearer wrong"}}}
+
+		_, err := ps.authenticate(r)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestClientPolicyAuthorize(t *testing.T) {
+	t.Parallel()
+
+	fixture := func() *v1alpha1.GameServerAllocation {
+		return &v1alpha1.GameServerAllocation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec: v1alpha1.GameServerAllocationSpec{
+				Required: metav1.LabelSelector{
+					MatchLabels: map[string]string{v1alpha1.FleetNameLabel: "simple-udp"},
+				},
+			},
+		}
+	}
+
+	p := &ClientPolicy{Name: "team-a", Namespaces: []string{"team-a"}}
+	assert.Nil(t, p.authorize(fixture()))
+
+	gsa := fixture()
+	gsa.ObjectMeta.Namespace = "team-b"
+	assert.NotNil(t, p.authorize(gsa))
+
+	p.Fleets = []string{"simple-udp"}
+	assert.Nil(t, p.authorize(fixture()))
+
+	p.Fleets = []string{"other-fleet"}
+	assert.NotNil(t, p.authorize(fixture()))
+
+	gsa = fixture()
+	gsa.Spec.Required.MatchLabels = nil
+	assert.NotNil(t, p.authorize(gsa))
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const gameServersPath = "/v1alpha1/gameservers"
+
+// queryHandler serves read-only GameServer discovery requests out of
+// the shared informer cache, so server browsers and matchmakers can
+// poll live server lists without hitting the Kubernetes API
+type queryHandler struct {
+	gameServerLister listerv1alpha1.GameServerLister
+}
+
+// newQueryHandler returns a queryHandler for the passed in lister
+func newQueryHandler(gameServerLister listerv1alpha1.GameServerLister) *queryHandler {
+	return &queryHandler{gameServerLister: gameServerLister}
+}
+
+// listHandler returns Ready and/or Allocated GameServers in a namespace,
+// filtered by an optional label selector and state. Query parameters:
+// namespace (defaults to "default"), labelSelector, and state
+// (Ready or Allocated, defaults to both)
+func (h *queryHandler) listHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	selector := labels.Everything()
+	if ls := r.URL.Query().Get("labelSelector"); ls != "" {
+		var err error
+		selector, err = labels.Parse(ls)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "could not parse labelSelector").Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	states := map[v1alpha1.GameServerState]bool{
+		v1alpha1.GameServerStateReady:     true,
+		v1alpha1.GameServerStateAllocated: true,
+	}
+	if s := r.URL.Query().Get("state"); s != "" {
+		state := v1alpha1.GameServerState(s)
+		if !states[state] {
+			http.Error(w, "state must be Ready or Allocated", http.StatusBadRequest)
+			return
+		}
+		states = map[v1alpha1.GameServerState]bool{state: true}
+	}
+
+	list, err := h.gameServerLister.GameServers(namespace).List(selector)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "could not list GameServers").Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := &v1alpha1.GameServerList{}
+	for _, gs := range list {
+		if gs.ObjectMeta.DeletionTimestamp.IsZero() && states[gs.Status.State] {
+			result.Items = append(result.Items, *gs)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.WithError(err).Error("error encoding GameServerList response")
+	}
+}
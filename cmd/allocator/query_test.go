@@ -0,0 +1,84 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestQueryHandlerListHandler(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	gsList := &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs-ready", Namespace: "default", Labels: map[string]string{"game": "udp"}},
+			Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs-allocated", Namespace: "default"},
+			Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateAllocated}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs-starting", Namespace: "default"},
+			Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateStarting}},
+	}}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, gsList, nil
+	})
+
+	gameServerInformer := m.AgonesInformerFactory.Stable().V1alpha1().GameServers()
+	h := newQueryHandler(gameServerInformer.Lister())
+
+	_, cancel := agtesting.StartInformers(m, gameServerInformer.Informer().HasSynced)
+	defer cancel()
+
+	list := func(url string) (*v1alpha1.GameServerList, int) {
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		h.listHandler(w, r)
+		result := &v1alpha1.GameServerList{}
+		if w.Code == http.StatusOK {
+			assert.Nil(t, json.NewDecoder(w.Body).Decode(result))
+		}
+		return result, w.Code
+	}
+
+	result, code := list(gameServersPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, result.Items, 2)
+
+	result, code = list(gameServersPath + "?state=Ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, "gs-ready", result.Items[0].ObjectMeta.Name)
+
+	result, code = list(gameServersPath + "?labelSelector=game%3Dudp")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, "gs-ready", result.Items[0].ObjectMeta.Name)
+
+	_, code = list(gameServersPath + "?state=Starting")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	_, code = list(gameServersPath + "?labelSelector=!!!")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -16,11 +16,11 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"agones.dev/agones/pkg"
@@ -31,8 +31,11 @@ import (
 	"agones.dev/agones/pkg/fleets"
 	"agones.dev/agones/pkg/gameserverallocations"
 	"agones.dev/agones/pkg/gameservers"
+	"agones.dev/agones/pkg/gameserversessions"
 	"agones.dev/agones/pkg/gameserversets"
 	"agones.dev/agones/pkg/metrics"
+	"agones.dev/agones/pkg/notifications"
+	"agones.dev/agones/pkg/util/locks"
 	"agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/signals"
 	"agones.dev/agones/pkg/util/webhooks"
@@ -53,6 +56,7 @@ const (
 	enablePrometheusMetricsFlag  = "prometheus-exporter"
 	projectIDFlag                = "gcp-project-id"
 	sidecarImageFlag             = "sidecar-image"
+	queryResponderImageFlag      = "query-responder-image"
 	sidecarCPURequestFlag        = "sidecar-cpu-request"
 	sidecarCPULimitFlag          = "sidecar-cpu-limit"
 	pullSidecarFlag              = "always-pull-sidecar"
@@ -61,10 +65,40 @@ const (
 	certFileFlag                 = "cert-file"
 	keyFileFlag                  = "key-file"
 	numWorkersFlag               = "num-workers"
+	gameServerSessionsFlag       = "game-server-sessions"
+	gsErrorTTLFlag               = "game-server-error-ttl"
+	gsErrorKeepFlag              = "game-server-error-keep"
+	maxGameServersPerNodeFlag    = "max-game-servers-per-node"
+	maxGameServersPerNSFlag      = "max-game-servers-per-namespace"
+	maxGameServersPerFleetFlag   = "max-game-servers-per-fleet"
+	maxCreationsPerBatchFlag     = "max-creations-per-batch"
+	maxDeletionsPerBatchFlag     = "max-deletions-per-batch"
+	maxPodPendingCountFlag       = "max-pod-pending-count"
+	addressProviderFlag          = "address-provider"
+	notificationsWebhookFlag     = "notifications-webhook-url"
+	unhealthyCollectorImageFlag  = "unhealthy-collector-image"
+	secondaryKubeconfigFlag      = "secondary-kubeconfig"
+	secondaryClusterNameFlag     = "secondary-cluster-name"
+	controllersFlag              = "controllers"
+	dumpAlertRulesFlag           = "dump-alert-rules"
+	podNamespaceFlag             = "pod-namespace"
+	simulateGameServersFlag      = "simulate-gameservers"
+	pprofPortFlag                = "pprof-port"
+	heapProfileDirFlag           = "heap-profile-dir"
+	heapProfileThresholdFlag     = "heap-profile-threshold-mb"
+	simulateLatencyFlag          = "simulate-latency"
 	apiServerSustainedQPSFlag    = "api-server-qps"
 	apiServerBurstQPSFlag        = "api-server-qps-burst"
 	kubeconfigFlag               = "kubeconfig"
 	defaultResync                = 30 * time.Second
+
+	// lifecycleGroup runs the singleton lifecycle controllers -
+	// gameservers, gameserversets, fleets and fleetautoscalers
+	lifecycleGroup = "lifecycle"
+	// allocationGroup runs the allocation serving components -
+	// gameserverallocations and fleetallocation - which are stateless,
+	// and can be scaled out independently of the lifecycle controllers
+	allocationGroup = "allocation"
 )
 
 var (
@@ -74,9 +108,21 @@ var (
 // main starts the operator for the gameserver CRD
 func main() {
 	ctlConf := parseEnvFlags()
+	if ctlConf.DumpAlertRules {
+		fmt.Print(metrics.AlertRulesYAML())
+		return
+	}
+
 	logger.WithField("version", pkg.Version).
 		WithField("ctlConf", ctlConf).Info("starting gameServer operator...")
 
+	if ctlConf.PprofPort > 0 {
+		startPprofServer(ctlConf.PprofPort)
+	}
+	if ctlConf.HeapProfileDir != "" {
+		startHeapProfiler(ctlConf.HeapProfileDir, ctlConf.HeapProfileThreshold)
+	}
+
 	if err := ctlConf.validate(); err != nil {
 		logger.WithError(err).Fatal("Could not create controller from environment or flags")
 	}
@@ -110,7 +156,7 @@ func main() {
 	kubeInformerFactory := informers.NewSharedInformerFactory(kubeClient, defaultResync)
 
 	server := &httpServer{}
-	var rs []runner
+	var controllers []runner
 	var health healthcheck.Handler
 
 	// Stackdriver metrics
@@ -143,41 +189,116 @@ func main() {
 
 	// Add metrics controller only if we configure one of metrics exporters
 	if ctlConf.PrometheusMetrics || ctlConf.Stackdriver {
-		rs = append(rs, metrics.NewController(kubeClient, agonesClient, kubeInformerFactory, agonesInformerFactory))
+		controllers = append(controllers, metrics.NewController(kubeClient, agonesClient, kubeInformerFactory, agonesInformerFactory))
 	}
 
 	server.Handle("/", health)
 
-	allocationMutex := &sync.Mutex{}
-
-	gsController := gameservers.NewController(wh, health,
-		ctlConf.MinPort, ctlConf.MaxPort, ctlConf.SidecarImage, ctlConf.AlwaysPullSidecar,
-		ctlConf.SidecarCPURequest, ctlConf.SidecarCPULimit,
-		kubeClient, kubeInformerFactory, extClient, agonesClient, agonesInformerFactory)
-	gsSetController := gameserversets.NewController(wh, health,
-		kubeClient, extClient, agonesClient, agonesInformerFactory)
-	fleetController := fleets.NewController(wh, health, kubeClient, extClient, agonesClient, agonesInformerFactory)
-	faController := fleetallocation.NewController(wh, allocationMutex,
-		kubeClient, extClient, agonesClient, agonesInformerFactory)
-	gasController := gameserverallocations.NewController(wh, health, allocationMutex, kubeClient,
-		kubeInformerFactory, extClient, agonesClient, agonesInformerFactory)
-	fasController := fleetautoscalers.NewController(wh, health,
-		kubeClient, extClient, agonesClient, agonesInformerFactory)
-
-	rs = append(rs,
-		wh, gsController, gsSetController, fleetController, faController, fasController, gasController, server)
+	allocationMutex := locks.NewKeyedMutex("allocation")
+
+	if ctlConf.NotificationsWebhook != "" {
+		notifier := notifications.NewNotifier(health, kubeInformerFactory,
+			&notifications.WebhookSink{URL: ctlConf.NotificationsWebhook})
+		controllers = append(controllers, notifier)
+	}
+
+	if ctlConf.runsGroup(lifecycleGroup) {
+		gsController := gameservers.NewController(wh, health,
+			ctlConf.MinPort, ctlConf.MaxPort, ctlConf.AddressProvider,
+			ctlConf.SidecarImage, ctlConf.AlwaysPullSidecar,
+			ctlConf.SidecarCPURequest, ctlConf.SidecarCPULimit,
+			kubeClient, kubeInformerFactory, extClient, agonesClient, agonesInformerFactory)
+		if ctlConf.UnhealthyCollector != "" {
+			gsController.RegisterUnhealthyCollector(ctlConf.UnhealthyCollector)
+		}
+		gsController.RegisterQueryResponderImage(ctlConf.QueryResponderImage)
+		gsController.RegisterGameServerLimits(ctlConf.MaxGameServersPerNS, ctlConf.MaxGameServersPerFlt)
+		if ctlConf.SimulateGameServers {
+			logger.WithField("latency", ctlConf.SimulateLatency).
+				Warn("Simulation mode is enabled. GameServers will not create Pods")
+			gsController.EnableSimulation(ctlConf.SimulateLatency)
+		}
+		if ctlConf.PodNamespace != "" {
+			gsController.RegisterPortSnapshotPersistence(agonesClient.StableV1alpha1(), ctlConf.PodNamespace)
+		}
+		gsSetController := gameserversets.NewController(wh, health,
+			ctlConf.MaxCreationsPerBatch, ctlConf.MaxDeletionsPerBatch, ctlConf.MaxPodPendingCount,
+			kubeClient, extClient, agonesClient, agonesInformerFactory)
+		gsSetController.RegisterGameServerLimits(ctlConf.MaxGameServersPerNS, ctlConf.MaxGameServersPerFlt)
+		fleetController := fleets.NewController(wh, health, kubeClient, extClient, agonesClient, agonesInformerFactory)
+		fleetLifecycle := fleets.NewLifecycleController(health, agonesInformerFactory)
+		fasController := fleetautoscalers.NewController(wh, health,
+			kubeClient, extClient, agonesClient, agonesInformerFactory)
+
+		controllers = append(controllers, gsController, gsSetController, fleetController, fleetLifecycle, fasController)
+
+		if ctlConf.GameServerSessions {
+			sessionController := gameserversessions.NewController(health,
+				kubeClient, extClient, agonesClient, agonesInformerFactory)
+			controllers = append(controllers, sessionController)
+		}
+
+		gsGC := gameservers.NewGarbageCollector(ctlConf.GameServerErrorTTL, ctlConf.GameServerErrorKeep,
+			kubeClient, agonesClient, agonesInformerFactory)
+		controllers = append(controllers, gsGC)
+	}
+
+	var secondaryInformerFactory externalversions.SharedInformerFactory
+
+	if ctlConf.runsGroup(allocationGroup) {
+		faController := fleetallocation.NewController(wh, allocationMutex,
+			kubeClient, extClient, agonesClient, agonesInformerFactory)
+		gasController := gameserverallocations.NewController(wh, health, ctlConf.MaxGameServersPerNode, allocationMutex, kubeClient,
+			kubeInformerFactory, extClient, agonesClient, agonesInformerFactory)
+
+		if ctlConf.SecondaryKubeconfig != "" {
+			secondaryConf, err := clientcmd.BuildConfigFromFlags("", ctlConf.SecondaryKubeconfig)
+			if err != nil {
+				logger.WithError(err).Fatal("Could not create secondary cluster config")
+			}
+			secondaryClient, err := versioned.NewForConfig(secondaryConf)
+			if err != nil {
+				logger.WithError(err).Fatal("Could not create the secondary cluster agones api clientset")
+			}
+			secondaryInformerFactory = externalversions.NewSharedInformerFactory(secondaryClient, defaultResync)
+			gasController.RegisterSecondaryCluster(ctlConf.SecondaryClusterName, secondaryInformerFactory)
+		}
+
+		controllers = append(controllers, faController, gasController)
+	}
 
 	stop := signals.NewStopChannel()
 
 	kubeInformerFactory.Start(stop)
 	agonesInformerFactory.Start(stop)
+	if secondaryInformerFactory != nil {
+		secondaryInformerFactory.Start(stop)
+	}
+
+	// the controllers each wait for their CRDs to be established, sync their
+	// caches and run their port allocators inside Run, and report readiness once
+	// they are processing events. Only then are the webhooks and http server
+	// started, so that nothing is served before the controllers are watching
+	stages := []stage{
+		{name: "controllers", runners: controllers},
+		{name: "serving", runners: []runner{wh, server}},
+	}
 
-	for _, r := range rs {
-		go func(rr runner) {
-			if runErr := rr.Run(ctlConf.NumWorkers, stop); runErr != nil {
-				logger.WithError(runErr).Fatalf("could not start runner: %T", rr)
+	for _, s := range stages {
+		for _, r := range s.runners {
+			go func(rr runner) {
+				if runErr := rr.Run(ctlConf.NumWorkers, stop); runErr != nil {
+					logger.WithError(runErr).Fatalf("could not start runner: %T", rr)
+				}
+			}(r)
+		}
+		for _, r := range s.runners {
+			if rr, ok := r.(readyRunner); ok && !rr.WaitUntilRunning(stop) {
+				logger.Info("Shut down agones controllers")
+				return
 			}
-		}(r)
+		}
+		logger.WithField("stage", s.name).Info("Stage is running")
 	}
 
 	<-stop
@@ -192,6 +313,7 @@ func parseEnvFlags() config {
 
 	base := filepath.Dir(exec)
 	viper.SetDefault(sidecarImageFlag, "gcr.io/agones-images/agones-sdk:"+pkg.Version)
+	viper.SetDefault(queryResponderImageFlag, "gcr.io/agones-images/agones-query-responder:"+pkg.Version)
 	viper.SetDefault(sidecarCPURequestFlag, "0")
 	viper.SetDefault(sidecarCPULimitFlag, "0")
 	viper.SetDefault(pullSidecarFlag, false)
@@ -201,10 +323,32 @@ func parseEnvFlags() config {
 	viper.SetDefault(enableStackdriverMetricsFlag, false)
 	viper.SetDefault(projectIDFlag, "")
 	viper.SetDefault(numWorkersFlag, 64)
+	viper.SetDefault(gameServerSessionsFlag, false)
+	viper.SetDefault(gsErrorTTLFlag, time.Duration(0))
+	viper.SetDefault(gsErrorKeepFlag, 100)
+	viper.SetDefault(maxGameServersPerNodeFlag, 0)
+	viper.SetDefault(maxGameServersPerNSFlag, 0)
+	viper.SetDefault(maxGameServersPerFleetFlag, 0)
+	viper.SetDefault(maxCreationsPerBatchFlag, 64)
+	viper.SetDefault(maxDeletionsPerBatchFlag, 64)
+	viper.SetDefault(maxPodPendingCountFlag, 5000)
+	viper.SetDefault(addressProviderFlag, "")
+	viper.SetDefault(notificationsWebhookFlag, "")
+	viper.SetDefault(unhealthyCollectorImageFlag, "")
+	viper.SetDefault(secondaryKubeconfigFlag, "")
+	viper.SetDefault(secondaryClusterNameFlag, "secondary")
+	viper.SetDefault(dumpAlertRulesFlag, false)
+	viper.SetDefault(podNamespaceFlag, "")
+	viper.SetDefault(simulateGameServersFlag, false)
+	viper.SetDefault(pprofPortFlag, 0)
+	viper.SetDefault(heapProfileDirFlag, "")
+	viper.SetDefault(heapProfileThresholdFlag, 1024)
+	viper.SetDefault(simulateLatencyFlag, time.Second)
 	viper.SetDefault(apiServerSustainedQPSFlag, 100)
 	viper.SetDefault(apiServerBurstQPSFlag, 200)
 
 	pflag.String(sidecarImageFlag, viper.GetString(sidecarImageFlag), "Flag to overwrite the GameServer sidecar image that is used. Can also use SIDECAR env variable")
+	pflag.String(queryResponderImageFlag, viper.GetString(queryResponderImageFlag), "Flag to overwrite the query responder sidecar image that is used. Can also use QUERY_RESPONDER_IMAGE env variable")
 	pflag.String(sidecarCPULimitFlag, viper.GetString(sidecarCPULimitFlag), "Flag to overwrite the GameServer sidecar container's cpu limit. Can also use SIDECAR_CPU_LIMIT env variable")
 	pflag.String(sidecarCPURequestFlag, viper.GetString(sidecarCPURequestFlag), "Flag to overwrite the GameServer sidecar container's cpu request. Can also use SIDECAR_CPU_REQUEST env variable")
 	pflag.Bool(pullSidecarFlag, viper.GetBool(pullSidecarFlag), "For development purposes, set the sidecar image to have a ImagePullPolicy of Always. Can also use ALWAYS_PULL_SIDECAR env variable")
@@ -217,12 +361,35 @@ func parseEnvFlags() config {
 	pflag.Bool(enableStackdriverMetricsFlag, viper.GetBool(enableStackdriverMetricsFlag), "Flag to activate stackdriver monitoring metrics for Agones. Can also use STACKDRIVER_EXPORTER env variable.")
 	pflag.String(projectIDFlag, viper.GetString(projectIDFlag), "GCP ProjectID used for Stackdriver, if not specified ProjectID from Application Default Credentials would be used. Can also use GCP_PROJECT_ID env variable.")
 	pflag.Int32(numWorkersFlag, 64, "Number of controller workers per resource type")
+	pflag.Bool(gameServerSessionsFlag, viper.GetBool(gameServerSessionsFlag), "Optional. Record the allocation history of GameServers as GameServerSession resources. Can also use GAME_SERVER_SESSIONS env variable")
+	pflag.Duration(gsErrorTTLFlag, viper.GetDuration(gsErrorTTLFlag), "Optional. TTL after which GameServers in an Error state are deleted. 0 disables collection. Can also use GAME_SERVER_ERROR_TTL env variable")
+	pflag.Int32(gsErrorKeepFlag, viper.GetInt32(gsErrorKeepFlag), "Optional. Number of most recent Error GameServers to keep for debugging when the TTL collector is enabled. Can also use GAME_SERVER_ERROR_KEEP env variable")
+	pflag.Int64(maxGameServersPerNodeFlag, viper.GetInt64(maxGameServersPerNodeFlag), "Optional. Maximum number of Allocated GameServers per node before allocations skip that node. 0 disables the cap. Can also use MAX_GAME_SERVERS_PER_NODE env variable")
+	pflag.Int64(maxGameServersPerNSFlag, viper.GetInt64(maxGameServersPerNSFlag), "Optional. Maximum number of GameServers per namespace, enforced by the validating webhook and scale up batching. 0 disables the cap. Can also use MAX_GAME_SERVERS_PER_NAMESPACE env variable")
+	pflag.Int64(maxGameServersPerFleetFlag, viper.GetInt64(maxGameServersPerFleetFlag), "Optional. Maximum number of GameServers per fleet, enforced by the validating webhook and scale up batching. 0 disables the cap. Can also use MAX_GAME_SERVERS_PER_FLEET env variable")
+	pflag.Int32(maxCreationsPerBatchFlag, viper.GetInt32(maxCreationsPerBatchFlag), "Maximum number of GameServers a GameServerSet creates per reconcile. Can also use MAX_CREATIONS_PER_BATCH env variable")
+	pflag.Int32(maxDeletionsPerBatchFlag, viper.GetInt32(maxDeletionsPerBatchFlag), "Maximum number of GameServers a GameServerSet deletes per reconcile. Can also use MAX_DELETIONS_PER_BATCH env variable")
+	pflag.Int32(maxPodPendingCountFlag, viper.GetInt32(maxPodPendingCountFlag), "Maximum number of pending Pods per GameServerSet before creations pause. Can also use MAX_POD_PENDING_COUNT env variable")
+	pflag.String(addressProviderFlag, viper.GetString(addressProviderFlag), "Optional. Name of the provider used to resolve GameServer addresses from Nodes - 'gce', 'ec2' or 'azure'. Defaults to the Node's ExternalIP. Can also use ADDRESS_PROVIDER env variable")
+	pflag.String(notificationsWebhookFlag, viper.GetString(notificationsWebhookFlag), "Optional. URL to POST Warning events on Agones resources to, for external alerting. Can also use NOTIFICATIONS_WEBHOOK_URL env variable")
+	pflag.String(unhealthyCollectorImageFlag, viper.GetString(unhealthyCollectorImageFlag), "Experimental. Image run as a Job on an Unhealthy GameServer's node to collect crash artifacts before the Pod is replaced. Empty disables collection. Can also use UNHEALTHY_COLLECTOR_IMAGE env variable")
+	pflag.String(secondaryKubeconfigFlag, viper.GetString(secondaryKubeconfigFlag), "Experimental. kubeconfig for a read only secondary cluster whose Ready GameServers are included in allocations when the local cluster has none. Can also use SECONDARY_KUBECONFIG env variable")
+	pflag.String(secondaryClusterNameFlag, viper.GetString(secondaryClusterNameFlag), "Experimental. Name used to tag allocations served from the secondary cluster. Can also use SECONDARY_CLUSTER_NAME env variable")
+	pflag.String(controllersFlag, viper.GetString(controllersFlag), "Optional. Comma separated list of controller groups to run in this binary - 'lifecycle' and/or 'allocation'. Defaults to running everything, set it to deploy and scale allocation separately. Can also use CONTROLLERS env variable")
+	pflag.Bool(dumpAlertRulesFlag, viper.GetBool(dumpAlertRulesFlag), "Optional. Print the recommended Prometheus alert rules for the controller metrics to stdout and exit. Can also use DUMP_ALERT_RULES env variable")
+	pflag.String(podNamespaceFlag, viper.GetString(podNamespaceFlag), "Namespace this controller Pod runs in, where the port allocation snapshot is stored. Can also use POD_NAMESPACE env variable")
+	pflag.Bool(simulateGameServersFlag, viper.GetBool(simulateGameServersFlag), "For scale testing only. GameServers skip Pod creation and simulate their lifecycle transitions instead. Can also use SIMULATE_GAMESERVERS env variable")
+	pflag.Int32(pprofPortFlag, viper.GetInt32(pprofPortFlag), "Optional. Port to serve the pprof profiling and trace endpoints on. 0 disables them. Can also use PPROF_PORT env variable")
+	pflag.String(heapProfileDirFlag, viper.GetString(heapProfileDirFlag), "Optional. Directory to write automatic heap profiles to when heap usage crosses the threshold. Empty disables them. Can also use HEAP_PROFILE_DIR env variable")
+	pflag.Int32(heapProfileThresholdFlag, viper.GetInt32(heapProfileThresholdFlag), "Heap usage in MB above which an automatic heap profile is written. Can also use HEAP_PROFILE_THRESHOLD_MB env variable")
+	pflag.Duration(simulateLatencyFlag, viper.GetDuration(simulateLatencyFlag), "For scale testing only. Latency between simulated GameServer lifecycle transitions. Can also use SIMULATE_LATENCY env variable")
 	pflag.Int32(apiServerSustainedQPSFlag, 100, "Maximum sustained queries per second to send to the API server")
 	pflag.Int32(apiServerBurstQPSFlag, 200, "Maximum burst queries per second to send to the API server")
 	pflag.Parse()
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	runtime.Must(viper.BindEnv(sidecarImageFlag))
+	runtime.Must(viper.BindEnv(queryResponderImageFlag))
 	runtime.Must(viper.BindEnv(sidecarCPULimitFlag))
 	runtime.Must(viper.BindEnv(sidecarCPURequestFlag))
 	runtime.Must(viper.BindEnv(pullSidecarFlag))
@@ -236,6 +403,28 @@ func parseEnvFlags() config {
 	runtime.Must(viper.BindEnv(projectIDFlag))
 	runtime.Must(viper.BindPFlags(pflag.CommandLine))
 	runtime.Must(viper.BindEnv(numWorkersFlag))
+	runtime.Must(viper.BindEnv(gameServerSessionsFlag))
+	runtime.Must(viper.BindEnv(gsErrorTTLFlag))
+	runtime.Must(viper.BindEnv(gsErrorKeepFlag))
+	runtime.Must(viper.BindEnv(maxGameServersPerNodeFlag))
+	runtime.Must(viper.BindEnv(maxGameServersPerNSFlag))
+	runtime.Must(viper.BindEnv(maxGameServersPerFleetFlag))
+	runtime.Must(viper.BindEnv(maxCreationsPerBatchFlag))
+	runtime.Must(viper.BindEnv(maxDeletionsPerBatchFlag))
+	runtime.Must(viper.BindEnv(maxPodPendingCountFlag))
+	runtime.Must(viper.BindEnv(addressProviderFlag))
+	runtime.Must(viper.BindEnv(notificationsWebhookFlag))
+	runtime.Must(viper.BindEnv(unhealthyCollectorImageFlag))
+	runtime.Must(viper.BindEnv(secondaryKubeconfigFlag))
+	runtime.Must(viper.BindEnv(secondaryClusterNameFlag))
+	runtime.Must(viper.BindEnv(controllersFlag))
+	runtime.Must(viper.BindEnv(dumpAlertRulesFlag))
+	runtime.Must(viper.BindEnv(podNamespaceFlag))
+	runtime.Must(viper.BindEnv(simulateGameServersFlag))
+	runtime.Must(viper.BindEnv(pprofPortFlag))
+	runtime.Must(viper.BindEnv(heapProfileDirFlag))
+	runtime.Must(viper.BindEnv(heapProfileThresholdFlag))
+	runtime.Must(viper.BindEnv(simulateLatencyFlag))
 	runtime.Must(viper.BindEnv(apiServerSustainedQPSFlag))
 	runtime.Must(viper.BindEnv(apiServerBurstQPSFlag))
 
@@ -253,6 +442,7 @@ func parseEnvFlags() config {
 		MinPort:               int32(viper.GetInt64(minPortFlag)),
 		MaxPort:               int32(viper.GetInt64(maxPortFlag)),
 		SidecarImage:          viper.GetString(sidecarImageFlag),
+		QueryResponderImage:   viper.GetString(queryResponderImageFlag),
 		SidecarCPURequest:     request,
 		SidecarCPULimit:       limit,
 		AlwaysPullSidecar:     viper.GetBool(pullSidecarFlag),
@@ -263,16 +453,51 @@ func parseEnvFlags() config {
 		Stackdriver:           viper.GetBool(enableStackdriverMetricsFlag),
 		GCPProjectID:          viper.GetString(projectIDFlag),
 		NumWorkers:            int(viper.GetInt32(numWorkersFlag)),
+		GameServerSessions:    viper.GetBool(gameServerSessionsFlag),
+		GameServerErrorTTL:    viper.GetDuration(gsErrorTTLFlag),
+		GameServerErrorKeep:   int(viper.GetInt32(gsErrorKeepFlag)),
+		MaxGameServersPerNode: viper.GetInt64(maxGameServersPerNodeFlag),
+		MaxGameServersPerNS:   viper.GetInt64(maxGameServersPerNSFlag),
+		MaxGameServersPerFlt:  viper.GetInt64(maxGameServersPerFleetFlag),
+		MaxCreationsPerBatch:  int(viper.GetInt32(maxCreationsPerBatchFlag)),
+		MaxDeletionsPerBatch:  int(viper.GetInt32(maxDeletionsPerBatchFlag)),
+		MaxPodPendingCount:    int(viper.GetInt32(maxPodPendingCountFlag)),
+		AddressProvider:       viper.GetString(addressProviderFlag),
+		NotificationsWebhook:  viper.GetString(notificationsWebhookFlag),
+		UnhealthyCollector:    viper.GetString(unhealthyCollectorImageFlag),
+		SecondaryKubeconfig:   viper.GetString(secondaryKubeconfigFlag),
+		SecondaryClusterName:  viper.GetString(secondaryClusterNameFlag),
 		APIServerSustainedQPS: int(viper.GetInt32(apiServerSustainedQPSFlag)),
 		APIServerBurstQPS:     int(viper.GetInt32(apiServerBurstQPSFlag)),
+		Controllers:           parseControllers(viper.GetString(controllersFlag)),
+		DumpAlertRules:        viper.GetBool(dumpAlertRulesFlag),
+		PodNamespace:          viper.GetString(podNamespaceFlag),
+		SimulateGameServers:   viper.GetBool(simulateGameServersFlag),
+		SimulateLatency:       viper.GetDuration(simulateLatencyFlag),
+		PprofPort:             int(viper.GetInt32(pprofPortFlag)),
+		HeapProfileDir:        viper.GetString(heapProfileDirFlag),
+		HeapProfileThreshold:  int(viper.GetInt32(heapProfileThresholdFlag)),
 	}
 }
 
+// parseControllers splits the comma separated controllers flag value
+// into the list of controller groups to run
+func parseControllers(value string) []string {
+	var groups []string
+	for _, g := range strings.Split(value, ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
 // config stores all required configuration to create a game server controller.
 type config struct {
 	MinPort               int32
 	MaxPort               int32
 	SidecarImage          string
+	QueryResponderImage   string
 	SidecarCPURequest     resource.Quantity
 	SidecarCPULimit       resource.Quantity
 	AlwaysPullSidecar     bool
@@ -283,8 +508,44 @@ type config struct {
 	KubeConfig            string
 	GCPProjectID          string
 	NumWorkers            int
+	GameServerSessions    bool
+	GameServerErrorTTL    time.Duration
+	GameServerErrorKeep   int
+	MaxGameServersPerNode int64
+	MaxGameServersPerNS   int64
+	MaxGameServersPerFlt  int64
+	MaxCreationsPerBatch  int
+	MaxDeletionsPerBatch  int
+	MaxPodPendingCount    int
+	AddressProvider       string
+	NotificationsWebhook  string
+	UnhealthyCollector    string
+	SecondaryKubeconfig   string
+	SecondaryClusterName  string
 	APIServerSustainedQPS int
 	APIServerBurstQPS     int
+	Controllers           []string
+	DumpAlertRules        bool
+	PodNamespace          string
+	SimulateGameServers   bool
+	SimulateLatency       time.Duration
+	PprofPort             int
+	HeapProfileDir        string
+	HeapProfileThreshold  int
+}
+
+// runsGroup returns true if the passed in controller group should be
+// run by this binary. An empty controllers list runs every group
+func (c config) runsGroup(group string) bool {
+	if len(c.Controllers) == 0 {
+		return true
+	}
+	for _, g := range c.Controllers {
+		if g == group {
+			return true
+		}
+	}
+	return false
 }
 
 // validate ensures the ctlConfig data is valid.
@@ -295,6 +556,11 @@ func (c config) validate() error {
 	if c.MaxPort < c.MinPort {
 		return errors.New("max Port cannot be set less that the Min Port")
 	}
+	for _, g := range c.Controllers {
+		if g != lifecycleGroup && g != allocationGroup {
+			return errors.Errorf("controller group %s is not one of %s or %s", g, lifecycleGroup, allocationGroup)
+		}
+	}
 	return nil
 }
 
@@ -302,6 +568,21 @@ type runner interface {
 	Run(workers int, stop <-chan struct{}) error
 }
 
+// readyRunner is a runner that can report when its startup - waiting for
+// CRDs to be established, syncing caches - is complete and it is
+// processing events
+type readyRunner interface {
+	runner
+	WaitUntilRunning(stop <-chan struct{}) bool
+}
+
+// stage is a set of runners that are started together, once every
+// readyRunner in the previous stage has reported it is running
+type stage struct {
+	name    string
+	runners []runner
+}
+
 type httpServer struct {
 	http.ServeMux
 }
@@ -12,20 +12,70 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// +build profile
-
 package main
 
 import (
+	"fmt"
 	"net/http"
-	_ "net/http/pprof"
-
-	"github.com/sirupsen/logrus"
+	_ "net/http/pprof" // registers the /debug/pprof handlers, including trace
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"runtime/pprof"
+	"time"
 )
 
-func init() {
+// heapCheckPeriod is how often the automatic heap profiler samples heap usage
+const heapCheckPeriod = 30 * time.Second
+
+// startPprofServer serves the net/http/pprof profiling and trace endpoints
+// on the given port, so CPU profiles and execution traces can be captured
+// from a production controller without rebuilding it
+func startPprofServer(port int) {
 	go func() {
-		logrus.WithError(http.ListenAndServe(":6060", nil)).Info("Closed pprof server")
+		logger.WithError(http.ListenAndServe(fmt.Sprintf(":%d", port), nil)).Info("Closed pprof server")
 	}()
-	logrus.Info("*** PPROF PROFILER STARTED on :6060 ***")
+	logger.WithField("port", port).Info("Serving pprof and trace endpoints")
+}
+
+// startHeapProfiler periodically samples heap usage, and writes a heap
+// profile to dir each time the heap grows past thresholdMB, so out of memory
+// investigations have a profile from before the Pod was killed. Another
+// profile is only written once usage has dropped back under the threshold
+func startHeapProfiler(dir string, thresholdMB int) {
+	logger.WithField("dir", dir).WithField("thresholdMB", thresholdMB).Info("Starting automatic heap profiler")
+	go func() {
+		threshold := uint64(thresholdMB) * 1024 * 1024
+		over := false
+		for range time.Tick(heapCheckPeriod) {
+			var stats goruntime.MemStats
+			goruntime.ReadMemStats(&stats)
+
+			if stats.HeapAlloc < threshold {
+				over = false
+				continue
+			}
+			if !over {
+				over = true
+				writeHeapProfile(dir)
+			}
+		}
+	}()
+}
+
+// writeHeapProfile writes a timestamped heap profile into dir
+func writeHeapProfile(dir string) {
+	name := filepath.Join(dir, fmt.Sprintf("heap-%s.pprof", time.Now().Format("20060102-150405")))
+	f, err := os.Create(name)
+	if err != nil {
+		logger.WithError(err).WithField("profile", name).Error("could not create heap profile")
+		return
+	}
+	defer f.Close() // nolint: errcheck
+
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		logger.WithError(err).WithField("profile", name).Error("could not write heap profile")
+		return
+	}
+	logger.WithField("profile", name).Info("Wrote heap profile")
 }
@@ -0,0 +1,266 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// externalMetricsPath is the path the external metrics API is served on
+	externalMetricsPath = "/apis/external.metrics.k8s.io/v1beta1"
+	// externalMetricsGroupVersion is the group and version of the external
+	// metrics API
+	externalMetricsGroupVersion = "external.metrics.k8s.io/v1beta1"
+
+	metricFleetReplicas             = "fleet_replicas"
+	metricFleetReadyReplicas        = "fleet_ready_replicas"
+	metricFleetAllocatedReplicas    = "fleet_allocated_replicas"
+	metricFleetPlayerCount          = "fleet_player_count"
+	metricFleetAllocationsPerMinute = "fleet_allocations_per_minute"
+
+	// allocationRateWindow is the window fleet_allocations_per_minute is
+	// counted over
+	allocationRateWindow = time.Minute
+)
+
+// metricNames are the metrics served for each Fleet, in the order they are
+// returned by API discovery
+var metricNames = []string{
+	metricFleetReplicas,
+	metricFleetReadyReplicas,
+	metricFleetAllocatedReplicas,
+	metricFleetPlayerCount,
+	metricFleetAllocationsPerMinute,
+}
+
+// externalMetricValue mirrors the ExternalMetricValue type of the external
+// metrics API, which is not vendored into this project
+type externalMetricValue struct {
+	MetricName   string            `json:"metricName"`
+	MetricLabels map[string]string `json:"metricLabels,omitempty"`
+	Timestamp    metav1.Time       `json:"timestamp"`
+	Value        string            `json:"value"`
+}
+
+// externalMetricValueList mirrors the ExternalMetricValueList type of the
+// external metrics API
+type externalMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []externalMetricValue `json:"items"`
+}
+
+// externalMetricsHandler serves Fleet metrics out of the shared informer
+// caches in the shape of the external.metrics.k8s.io API, so HPA and KEDA
+// objects can scale Fleets from them
+type externalMetricsHandler struct {
+	fleetLister      listerv1alpha1.FleetLister
+	gameServerLister listerv1alpha1.GameServerLister
+	sessionLister    listerv1alpha1.GameServerSessionLister
+	now              func() time.Time
+}
+
+// newExternalMetricsHandler returns an externalMetricsHandler for the passed
+// in listers
+func newExternalMetricsHandler(fleetLister listerv1alpha1.FleetLister,
+	gameServerLister listerv1alpha1.GameServerLister,
+	sessionLister listerv1alpha1.GameServerSessionLister) *externalMetricsHandler {
+	return &externalMetricsHandler{
+		fleetLister:      fleetLister,
+		gameServerLister: gameServerLister,
+		sessionLister:    sessionLister,
+		now:              time.Now,
+	}
+}
+
+// metricsHandler serves the discovery and metric endpoints of the external
+// metrics API. Metrics are served per namespace, with one value per Fleet,
+// filtered by the metricSelector the autoscaler passes as a labelSelector
+func (h *externalMetricsHandler) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, externalMetricsPath), "/")
+	if rest == "" {
+		h.writeDiscovery(w)
+		return
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 || parts[0] != "namespaces" {
+		http.NotFound(w, r)
+		return
+	}
+	namespace, metric := parts[1], parts[2]
+
+	selector := labels.Everything()
+	if ls := r.URL.Query().Get("labelSelector"); ls != "" {
+		var err error
+		selector, err = labels.Parse(ls)
+		if err != nil {
+			http.Error(w, errors.Wrap(err, "could not parse labelSelector").Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	items, ok, err := h.metricValues(namespace, metric, selector)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	result := &externalMetricValueList{
+		TypeMeta: metav1.TypeMeta{Kind: "ExternalMetricValueList", APIVersion: externalMetricsGroupVersion},
+		Items:    items,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.WithError(err).Error("error encoding ExternalMetricValueList response")
+	}
+}
+
+// writeDiscovery writes the APIResourceList for the metrics this adapter
+// serves, so the API aggregator and autoscalers can discover them
+func (h *externalMetricsHandler) writeDiscovery(w http.ResponseWriter) {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: externalMetricsGroupVersion,
+	}
+	for _, name := range metricNames {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       name,
+			Namespaced: true,
+			Kind:       "ExternalMetricValueList",
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		logger.WithError(err).Error("error encoding APIResourceList response")
+	}
+}
+
+// metricValues returns one value of the named metric for each Fleet in the
+// namespace whose labels match the selector. Returns false if the metric is
+// not one this adapter serves
+func (h *externalMetricsHandler) metricValues(namespace, metric string, selector labels.Selector) ([]externalMetricValue, bool, error) {
+	fleets, err := h.fleetLister.Fleets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "could not list Fleets in namespace %s", namespace)
+	}
+
+	now := metav1.NewTime(h.now())
+	var items []externalMetricValue
+	for _, fleet := range fleets {
+		if !selector.Matches(labels.Set(fleetMetricLabels(fleet))) {
+			continue
+		}
+
+		var value int64
+		switch metric {
+		case metricFleetReplicas:
+			value = int64(fleet.Status.Replicas)
+		case metricFleetReadyReplicas:
+			value = int64(fleet.Status.ReadyReplicas)
+		case metricFleetAllocatedReplicas:
+			value = int64(fleet.Status.AllocatedReplicas)
+		case metricFleetPlayerCount:
+			value, err = h.playerCount(fleet)
+		case metricFleetAllocationsPerMinute:
+			value, err = h.allocationCount(fleet, now.Time)
+		default:
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, true, err
+		}
+
+		items = append(items, externalMetricValue{
+			MetricName:   metric,
+			MetricLabels: fleetMetricLabels(fleet),
+			Timestamp:    now,
+			Value:        strconv.FormatInt(value, 10),
+		})
+	}
+
+	return items, true, nil
+}
+
+// playerCount sums the reported player counts of the Fleet's GameServers
+func (h *externalMetricsHandler) playerCount(fleet *v1alpha1.Fleet) (int64, error) {
+	selector := labels.SelectorFromSet(labels.Set{v1alpha1.FleetNameLabel: fleet.ObjectMeta.Name})
+	list, err := h.gameServerLister.GameServers(fleet.ObjectMeta.Namespace).List(selector)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not list GameServers for Fleet %s", fleet.ObjectMeta.Name)
+	}
+
+	var total int64
+	for _, gs := range list {
+		if gs.Status.Players != nil {
+			total += gs.Status.Players.Count
+		}
+	}
+	return total, nil
+}
+
+// allocationCount counts the GameServerSessions opened for the Fleet within
+// the allocation rate window
+func (h *externalMetricsHandler) allocationCount(fleet *v1alpha1.Fleet, now time.Time) (int64, error) {
+	selector := labels.SelectorFromSet(labels.Set{v1alpha1.FleetNameLabel: fleet.ObjectMeta.Name})
+	list, err := h.sessionLister.GameServerSessions(fleet.ObjectMeta.Namespace).List(selector)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not list GameServerSessions for Fleet %s", fleet.ObjectMeta.Name)
+	}
+
+	var count int64
+	for _, session := range list {
+		if now.Sub(session.ObjectMeta.CreationTimestamp.Time) <= allocationRateWindow {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fleetMetricLabels are the labels served with each metric value - the
+// Fleet's own labels, plus the fleet name, so a metricSelector of
+// fleet=<name> selects a single Fleet
+func fleetMetricLabels(fleet *v1alpha1.Fleet) map[string]string {
+	result := map[string]string{}
+	for k, v := range fleet.ObjectMeta.Labels {
+		result[k] = v
+	}
+	result["fleet"] = fleet.ObjectMeta.Name
+	return result
+}
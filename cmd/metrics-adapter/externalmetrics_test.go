@@ -0,0 +1,124 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestExternalMetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	m := agtesting.NewMocks()
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-udp", Namespace: "default", Labels: map[string]string{"game": "udp"}},
+				Status: v1alpha1.FleetStatus{Replicas: 10, ReadyReplicas: 7, AllocatedReplicas: 3}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+				Status: v1alpha1.FleetStatus{Replicas: 5, ReadyReplicas: 5}},
+		}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-udp-1", Namespace: "default",
+				Labels: map[string]string{v1alpha1.FleetNameLabel: "simple-udp"}},
+				Status: v1alpha1.GameServerStatus{Players: &v1alpha1.PlayerStatus{Count: 12}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-udp-2", Namespace: "default",
+				Labels: map[string]string{v1alpha1.FleetNameLabel: "simple-udp"}},
+				Status: v1alpha1.GameServerStatus{Players: &v1alpha1.PlayerStatus{Count: 8}}},
+		}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "gameserversessions", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.GameServerSessionList{Items: []v1alpha1.GameServerSession{
+			{ObjectMeta: metav1.ObjectMeta{Name: "recent", Namespace: "default",
+				Labels:            map[string]string{v1alpha1.FleetNameLabel: "simple-udp"},
+				CreationTimestamp: metav1.NewTime(now.Add(-30 * time.Second))}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default",
+				Labels:            map[string]string{v1alpha1.FleetNameLabel: "simple-udp"},
+				CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute))}},
+		}}, nil
+	})
+
+	fleets := m.AgonesInformerFactory.Stable().V1alpha1().Fleets()
+	gameServers := m.AgonesInformerFactory.Stable().V1alpha1().GameServers()
+	sessions := m.AgonesInformerFactory.Stable().V1alpha1().GameServerSessions()
+
+	h := newExternalMetricsHandler(fleets.Lister(), gameServers.Lister(), sessions.Lister())
+	h.now = func() time.Time { return now }
+
+	_, cancel := agtesting.StartInformers(m, fleets.Informer().HasSynced,
+		gameServers.Informer().HasSynced, sessions.Informer().HasSynced)
+	defer cancel()
+
+	get := func(url string) (*externalMetricValueList, int) {
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		h.metricsHandler(w, r)
+		result := &externalMetricValueList{}
+		if w.Code == http.StatusOK {
+			assert.Nil(t, json.NewDecoder(w.Body).Decode(result))
+		}
+		return result, w.Code
+	}
+
+	values := func(list *externalMetricValueList) map[string]string {
+		result := map[string]string{}
+		for _, item := range list.Items {
+			result[item.MetricLabels["fleet"]] = item.Value
+		}
+		return result
+	}
+
+	result, code := get(externalMetricsPath + "/namespaces/default/" + metricFleetReadyReplicas)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"simple-udp": "7", "other": "5"}, values(result))
+
+	result, code = get(externalMetricsPath + "/namespaces/default/" + metricFleetPlayerCount)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"simple-udp": "20", "other": "0"}, values(result))
+
+	result, code = get(externalMetricsPath + "/namespaces/default/" + metricFleetAllocationsPerMinute)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"simple-udp": "1", "other": "0"}, values(result))
+
+	result, code = get(externalMetricsPath + "/namespaces/default/" + metricFleetAllocatedReplicas + "?labelSelector=fleet%3Dsimple-udp")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"simple-udp": "3"}, values(result))
+
+	_, code = get(externalMetricsPath + "/namespaces/default/not_a_metric")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	r := httptest.NewRequest(http.MethodGet, externalMetricsPath, nil)
+	w := httptest.NewRecorder()
+	h.metricsHandler(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	discovery := &metav1.APIResourceList{}
+	assert.Nil(t, json.NewDecoder(w.Body).Decode(discovery))
+	assert.Equal(t, externalMetricsGroupVersion, discovery.GroupVersion)
+	assert.Len(t, discovery.APIResources, len(metricNames))
+}
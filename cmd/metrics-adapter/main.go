@@ -0,0 +1,137 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// binary for the metrics adapter, exposing Fleet metrics through the
+// external metrics API, so standard HorizontalPodAutoscaler and KEDA
+// objects can scale Fleets through the scale subresource
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"agones.dev/agones/pkg"
+	"agones.dev/agones/pkg/client/clientset/versioned"
+	"agones.dev/agones/pkg/client/informers/externalversions"
+	"agones.dev/agones/pkg/util/runtime"
+	"agones.dev/agones/pkg/util/signals"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	certFileFlag   = "cert-file"
+	keyFileFlag    = "key-file"
+	kubeconfigFlag = "kubeconfig"
+
+	defaultResync = 30 * time.Second
+)
+
+var (
+	logger = runtime.NewLoggerWithSource("main")
+)
+
+func main() {
+	ctlConf := parseEnvFlags()
+	logger.WithField("version", pkg.Version).
+		WithField("ctlConf", ctlConf).Info("starting metrics adapter...")
+
+	// if the kubeconfig fails BuildConfigFromFlags will try in cluster config
+	clientConf, err := clientcmd.BuildConfigFromFlags("", ctlConf.KubeConfig)
+	if err != nil {
+		logger.WithError(err).Fatal("could not create in cluster config")
+	}
+
+	agonesClient, err := versioned.NewForConfig(clientConf)
+	if err != nil {
+		logger.WithError(err).Fatal("could not create the agones api clientset")
+	}
+
+	stop := signals.NewStopChannel()
+
+	agonesInformerFactory := externalversions.NewSharedInformerFactory(agonesClient, defaultResync)
+	fleetInformer := agonesInformerFactory.Stable().V1alpha1().Fleets()
+	fleetSynced := fleetInformer.Informer().HasSynced
+	gameServerInformer := agonesInformerFactory.Stable().V1alpha1().GameServers()
+	gameServerSynced := gameServerInformer.Informer().HasSynced
+	sessionInformer := agonesInformerFactory.Stable().V1alpha1().GameServerSessions()
+	sessionSynced := sessionInformer.Informer().HasSynced
+
+	h := newExternalMetricsHandler(fleetInformer.Lister(), gameServerInformer.Lister(), sessionInformer.Lister())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(externalMetricsPath, h.metricsHandler)
+	mux.HandleFunc(externalMetricsPath+"/", h.metricsHandler)
+
+	health := healthcheck.NewHandler()
+	mux.HandleFunc("/live", health.LiveEndpoint)
+
+	srv := &http.Server{
+		Addr:    ":8443",
+		Handler: mux,
+	}
+
+	agonesInformerFactory.Start(stop)
+	logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, fleetSynced, gameServerSynced, sessionSynced) {
+		logger.Fatal("failed to wait for caches to sync")
+	}
+
+	go func() {
+		var err error
+		if ctlConf.CertFile != "" && ctlConf.KeyFile != "" {
+			err = srv.ListenAndServeTLS(ctlConf.CertFile, ctlConf.KeyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		logger.WithError(err).Fatal("metrics adapter crashed")
+	}()
+
+	<-stop
+	logger.Info("shutting down...")
+	srv.Close() // nolint: errcheck
+}
+
+// config retains the configuration information
+type config struct {
+	CertFile   string
+	KeyFile    string
+	KubeConfig string
+}
+
+func parseEnvFlags() config {
+	viper.SetDefault(certFileFlag, "")
+	viper.SetDefault(keyFileFlag, "")
+
+	pflag.String(certFileFlag, viper.GetString(certFileFlag), "Optional. Path to the crt file for serving TLS. Can also use CERT_FILE env variable")
+	pflag.String(keyFileFlag, viper.GetString(keyFileFlag), "Optional. Path to the key file for serving TLS. Can also use KEY_FILE env variable")
+	pflag.String(kubeconfigFlag, viper.GetString(kubeconfigFlag), "Optional. kubeconfig to run the metrics adapter out of the cluster.")
+	pflag.Parse()
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	runtime.Must(viper.BindEnv(certFileFlag))
+	runtime.Must(viper.BindEnv(keyFileFlag))
+	runtime.Must(viper.BindEnv(kubeconfigFlag))
+	runtime.Must(viper.BindPFlags(pflag.CommandLine))
+
+	return config{
+		CertFile:   viper.GetString(certFileFlag),
+		KeyFile:    viper.GetString(keyFileFlag),
+		KubeConfig: viper.GetString(kubeconfigFlag),
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// binary for the probing agent, which measures reachability and round trip
+// time to each Ready GameServer's ports from within the cluster, and records
+// failures as the PortsReachable status condition
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"agones.dev/agones/pkg"
+	"agones.dev/agones/pkg/client/clientset/versioned"
+	"agones.dev/agones/pkg/client/informers/externalversions"
+	"agones.dev/agones/pkg/util/runtime"
+	"agones.dev/agones/pkg/util/signals"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	probeIntervalFlag = "probe-interval"
+	probeTimeoutFlag  = "probe-timeout"
+	kubeconfigFlag    = "kubeconfig"
+
+	defaultResync = 30 * time.Second
+)
+
+var (
+	logger = runtime.NewLoggerWithSource("main")
+)
+
+func main() {
+	ctlConf := parseEnvFlags()
+	logger.WithField("version", pkg.Version).
+		WithField("ctlConf", ctlConf).Info("starting prober...")
+
+	// if the kubeconfig fails BuildConfigFromFlags will try in cluster config
+	clientConf, err := clientcmd.BuildConfigFromFlags("", ctlConf.KubeConfig)
+	if err != nil {
+		logger.WithError(err).Fatal("could not create in cluster config")
+	}
+
+	agonesClient, err := versioned.NewForConfig(clientConf)
+	if err != nil {
+		logger.WithError(err).Fatal("could not create the agones api clientset")
+	}
+
+	stop := signals.NewStopChannel()
+
+	agonesInformerFactory := externalversions.NewSharedInformerFactory(agonesClient, defaultResync)
+	gameServerInformer := agonesInformerFactory.Stable().V1alpha1().GameServers()
+	gameServerSynced := gameServerInformer.Informer().HasSynced
+
+	p := newProber(agonesClient.StableV1alpha1(), gameServerInformer.Lister(), ctlConf.ProbeTimeout)
+
+	health := healthcheck.NewHandler()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/live", health.LiveEndpoint)
+	srv := &http.Server{
+		Addr:    ":8080",
+		Handler: mux,
+	}
+
+	agonesInformerFactory.Start(stop)
+	logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, gameServerSynced) {
+		logger.Fatal("failed to wait for caches to sync")
+	}
+
+	go func() {
+		logger.WithError(srv.ListenAndServe()).Fatal("prober health endpoint crashed")
+	}()
+
+	p.run(ctlConf.ProbeInterval, stop)
+	logger.Info("shutting down...")
+	srv.Close() // nolint: errcheck
+}
+
+// config retains the configuration information
+type config struct {
+	ProbeInterval time.Duration
+	ProbeTimeout  time.Duration
+	KubeConfig    string
+}
+
+func parseEnvFlags() config {
+	viper.SetDefault(probeIntervalFlag, 30*time.Second)
+	viper.SetDefault(probeTimeoutFlag, 3*time.Second)
+
+	pflag.Duration(probeIntervalFlag, viper.GetDuration(probeIntervalFlag), "Optional. How often each GameServer's ports are probed. Can also use PROBE_INTERVAL env variable")
+	pflag.Duration(probeTimeoutFlag, viper.GetDuration(probeTimeoutFlag), "Optional. Timeout for a single port probe. Can also use PROBE_TIMEOUT env variable")
+	pflag.String(kubeconfigFlag, viper.GetString(kubeconfigFlag), "Optional. kubeconfig to run the prober out of the cluster.")
+	pflag.Parse()
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	runtime.Must(viper.BindEnv(probeIntervalFlag))
+	runtime.Must(viper.BindEnv(probeTimeoutFlag))
+	runtime.Must(viper.BindEnv(kubeconfigFlag))
+	runtime.Must(viper.BindPFlags(pflag.CommandLine))
+
+	return config{
+		ProbeInterval: viper.GetDuration(probeIntervalFlag),
+		ProbeTimeout:  viper.GetDuration(probeTimeoutFlag),
+		KubeConfig:    viper.GetString(kubeconfigFlag),
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	getterv1alpha1 "agones.dev/agones/pkg/client/clientset/versioned/typed/stable/v1alpha1"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// probeFailedReason and probeSucceededReason are the Reasons recorded on
+// the PortsReachable condition
+const (
+	probeFailedReason    = "ProbeFailed"
+	probeSucceededReason = "ProbeSucceeded"
+)
+
+// prober probes the ports of Ready and Allocated GameServers from within
+// the cluster, and records the result as the PortsReachable status
+// condition. This catches broken conntrack or hostPort mappings that the
+// in-pod health pings never see
+type prober struct {
+	gameServerGetter getterv1alpha1.GameServersGetter
+	gameServerLister listerv1alpha1.GameServerLister
+	timeout          time.Duration
+	// dial is swapped out in tests
+	dial func(network, address string, timeout time.Duration) (net.Conn, error)
+}
+
+// newProber returns a prober with the given probe timeout
+func newProber(gameServerGetter getterv1alpha1.GameServersGetter,
+	gameServerLister listerv1alpha1.GameServerLister, timeout time.Duration) *prober {
+	return &prober{
+		gameServerGetter: gameServerGetter,
+		gameServerLister: gameServerLister,
+		timeout:          timeout,
+		dial:             net.DialTimeout,
+	}
+}
+
+// run probes all GameServers every interval. Blocks until stop is closed
+func (p *prober) run(interval time.Duration, stop <-chan struct{}) {
+	wait.Until(func() {
+		if err := p.probeAll(); err != nil {
+			logger.WithError(err).Error("error probing gameservers")
+		}
+	}, interval, stop)
+}
+
+// probeAll probes every Ready and Allocated GameServer with an address
+// and at least one port
+func (p *prober) probeAll() error {
+	list, err := p.gameServerLister.List(labels.Everything())
+	if err != nil {
+		return errors.Wrap(err, "error listing gameservers")
+	}
+
+	for _, gs := range list {
+		if gs.Status.State != v1alpha1.GameServerStateReady && gs.Status.State != v1alpha1.GameServerStateAllocated {
+			continue
+		}
+		if gs.Status.Address == "" || len(gs.Status.Ports) == 0 {
+			continue
+		}
+		if err := p.probeGameServer(gs); err != nil {
+			logger.WithError(err).WithField("gs", gs.ObjectMeta.Name).Error("error probing gameserver")
+		}
+	}
+
+	return nil
+}
+
+// probeGameServer probes each of the GameServer's ports, and records the
+// outcome as the PortsReachable condition. The GameServer is only updated
+// when the condition changes, so steady state probing causes no writes
+func (p *prober) probeGameServer(gs *v1alpha1.GameServer) error {
+	var failures []string
+	for _, port := range gs.Status.Ports {
+		rtt, err := p.probePort(gs, port)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("port %s/%d: %v", port.Name, port.Port, err))
+			continue
+		}
+		logger.WithField("gs", gs.ObjectMeta.Name).WithField("port", port.Port).
+			WithField("rtt", rtt.String()).Debug("port probe")
+	}
+
+	condition := v1alpha1.GameServerCondition{
+		Type:          v1alpha1.GameServerPortsReachable,
+		Status:        corev1.ConditionTrue,
+		Reason:        probeSucceededReason,
+		LastProbeTime: metav1.Now(),
+	}
+	if len(failures) > 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = probeFailedReason
+		condition.Message = strings.Join(failures, "; ")
+	}
+
+	gsCopy := gs.DeepCopy()
+	if !gsCopy.Status.SetCondition(condition) {
+		return nil
+	}
+
+	_, err := p.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
+	return errors.Wrapf(err, "error updating GameServer %s", gs.ObjectMeta.Name)
+}
+
+// probePort measures a single round trip to a GameServer port. A TCP probe
+// measures the connect time. UDP is connectionless, so a probe datagram is
+// sent and an ICMP port unreachable - surfaced as a read error - marks the
+// port unreachable. No reply within the timeout is treated as reachable, as
+// game protocols won't respond to arbitrary packets
+func (p *prober) probePort(gs *v1alpha1.GameServer, port v1alpha1.GameServerStatusPort) (time.Duration, error) {
+	protocol := corev1.ProtocolUDP
+	for _, sp := range gs.Spec.Ports {
+		if sp.Name == port.Name && sp.Protocol != "" {
+			protocol = sp.Protocol
+			break
+		}
+	}
+
+	address := fmt.Sprintf("%s:%d", gs.Status.Address, port.Port)
+	start := time.Now()
+
+	if protocol == corev1.ProtocolTCP {
+		conn, err := p.dial("tcp", address, p.timeout)
+		if err != nil {
+			return 0, err
+		}
+		defer conn.Close() // nolint: errcheck
+		return time.Since(start), nil
+	}
+
+	conn, err := p.dial("udp", address, p.timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close() // nolint: errcheck
+
+	if _, err := conn.Write([]byte("agones-probe")); err != nil {
+		return 0, err
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(p.timeout)); err != nil {
+		return 0, err
+	}
+	if _, err := conn.Read(make([]byte, 64)); err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			// no reply within the timeout - the datagram was at least not rejected
+			return p.timeout, nil
+		}
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
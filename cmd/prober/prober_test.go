@@ -0,0 +1,223 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestProberProbeGameServer(t *testing.T) {
+	t.Parallel()
+
+	fixture := func() *v1alpha1.GameServer {
+		return &v1alpha1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "gs-1", Namespace: "default"},
+			Spec: v1alpha1.GameServerSpec{
+				Ports: []v1alpha1.GameServerPort{{Name: "default", Protocol: corev1.ProtocolTCP}},
+			},
+			Status: v1alpha1.GameServerStatus{
+				State:   v1alpha1.GameServerStateReady,
+				Address: "10.0.0.1",
+				Ports:   []v1alpha1.GameServerStatusPort{{Name: "default", Port: 7777}},
+			},
+		}
+	}
+
+	t.Run("reachable ports", func(t *testing.T) {
+		p, m := newFakeProber()
+		p.dial = func(network, address string, timeout time.Duration) (net.Conn, error) {
+			assert.Equal(t, "tcp", network)
+			assert.Equal(t, "10.0.0.1:7777", address)
+			return &fakeConn{}, nil
+		}
+
+		updated := false
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			ca := action.(k8stesting.UpdateAction)
+			gs := ca.GetObject().(*v1alpha1.GameServer)
+			condition := gs.Status.Condition(v1alpha1.GameServerPortsReachable)
+			assert.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionTrue, condition.Status)
+			assert.Equal(t, probeSucceededReason, condition.Reason)
+
+			return true, gs, nil
+		})
+
+		assert.Nil(t, p.probeGameServer(fixture()))
+		assert.True(t, updated, "GameServer should be updated")
+	})
+
+	t.Run("unreachable ports", func(t *testing.T) {
+		p, m := newFakeProber()
+		p.dial = func(network, address string, timeout time.Duration) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		}
+
+		updated := false
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			ca := action.(k8stesting.UpdateAction)
+			gs := ca.GetObject().(*v1alpha1.GameServer)
+			condition := gs.Status.Condition(v1alpha1.GameServerPortsReachable)
+			assert.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionFalse, condition.Status)
+			assert.Equal(t, probeFailedReason, condition.Reason)
+			assert.Contains(t, condition.Message, "port default/7777")
+
+			return true, gs, nil
+		})
+
+		assert.Nil(t, p.probeGameServer(fixture()))
+		assert.True(t, updated, "GameServer should be updated")
+	})
+
+	t.Run("steady state causes no update", func(t *testing.T) {
+		p, m := newFakeProber()
+		p.dial = func(network, address string, timeout time.Duration) (net.Conn, error) {
+			return &fakeConn{}, nil
+		}
+
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "gameserver should not update")
+			return false, nil, nil
+		})
+
+		gs := fixture()
+		gs.Status.Conditions = []v1alpha1.GameServerCondition{{
+			Type:   v1alpha1.GameServerPortsReachable,
+			Status: corev1.ConditionTrue,
+			Reason: probeSucceededReason,
+		}}
+
+		assert.Nil(t, p.probeGameServer(gs))
+	})
+
+	t.Run("udp timeout is treated as reachable", func(t *testing.T) {
+		p, m := newFakeProber()
+		p.dial = func(network, address string, timeout time.Duration) (net.Conn, error) {
+			assert.Equal(t, "udp", network)
+			return &fakeConn{readErr: &timeoutError{}}, nil
+		}
+
+		updated := false
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			ca := action.(k8stesting.UpdateAction)
+			gs := ca.GetObject().(*v1alpha1.GameServer)
+			condition := gs.Status.Condition(v1alpha1.GameServerPortsReachable)
+			assert.NotNil(t, condition)
+			assert.Equal(t, corev1.ConditionTrue, condition.Status)
+
+			return true, gs, nil
+		})
+
+		gs := fixture()
+		gs.Spec.Ports[0].Protocol = corev1.ProtocolUDP
+
+		assert.Nil(t, p.probeGameServer(gs))
+		assert.True(t, updated, "GameServer should be updated")
+	})
+}
+
+func TestProberProbeAll(t *testing.T) {
+	t.Parallel()
+
+	p, m := newFakeProber()
+	p.dial = func(network, address string, timeout time.Duration) (net.Conn, error) {
+		assert.Equal(t, "10.0.0.1:7777", address)
+		return &fakeConn{}, nil
+	}
+
+	ready := &v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "gs-ready", Namespace: "default"},
+		Status: v1alpha1.GameServerStatus{
+			State:   v1alpha1.GameServerStateReady,
+			Address: "10.0.0.1",
+			Ports:   []v1alpha1.GameServerStatusPort{{Name: "default", Port: 7777}},
+		},
+	}
+	creating := &v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "gs-creating", Namespace: "default"},
+		Status:     v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateCreating},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*ready, *creating}}, nil
+	})
+
+	updated := map[string]bool{}
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ca := action.(k8stesting.UpdateAction)
+		gs := ca.GetObject().(*v1alpha1.GameServer)
+		updated[gs.ObjectMeta.Name] = true
+		return true, gs, nil
+	})
+
+	gsInformer := m.AgonesInformerFactory.Stable().V1alpha1().GameServers()
+	p.gameServerLister = gsInformer.Lister()
+
+	_, cancel := agtesting.StartInformers(m, gsInformer.Informer().HasSynced)
+	defer cancel()
+
+	assert.Nil(t, p.probeAll())
+	assert.True(t, updated["gs-ready"], "Ready GameServer should be probed")
+	assert.False(t, updated["gs-creating"], "Creating GameServer should be skipped")
+}
+
+// newFakeProber returns a prober, backed by the fake Clientset
+func newFakeProber() (*prober, agtesting.Mocks) {
+	m := agtesting.NewMocks()
+	p := newProber(m.AgonesClient.StableV1alpha1(),
+		m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Lister(), time.Second)
+	return p, m
+}
+
+// fakeConn is a net.Conn with scripted read behaviour
+type fakeConn struct {
+	readErr error
+}
+
+func (f *fakeConn) Read(b []byte) (int, error) {
+	if f.readErr != nil {
+		return 0, f.readErr
+	}
+	return len(b), nil
+}
+func (f *fakeConn) Write(b []byte) (int, error)        { return len(b), nil }
+func (f *fakeConn) Close() error                       { return nil }
+func (f *fakeConn) LocalAddr() net.Addr                { return nil }
+func (f *fakeConn) RemoteAddr() net.Addr               { return nil }
+func (f *fakeConn) SetDeadline(t time.Time) error      { return nil }
+func (f *fakeConn) SetReadDeadline(t time.Time) error  { return nil }
+func (f *fakeConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// timeoutError is a net.Error that reports a timeout
+type timeoutError struct{}
+
+func (t *timeoutError) Error() string   { return "i/o timeout" }
+func (t *timeoutError) Timeout() bool   { return true }
+func (t *timeoutError) Temporary() bool { return true }
@@ -31,26 +31,33 @@ import (
 	"agones.dev/agones/pkg/util/signals"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 const (
-	grpcPort = 59357
-	httpPort = 59358
+	defaultGRPCPort = 59357
+	defaultHTTPPort = 59358
 
 	// specifically env vars
 	gameServerNameEnv = "GAMESERVER_NAME"
 	podNamespaceEnv   = "POD_NAMESPACE"
 
 	// Flags (that can also be env vars)
-	localFlag   = "local"
-	fileFlag    = "file"
-	addressFlag = "address"
+	localFlag    = "local"
+	fileFlag     = "file"
+	addressFlag  = "address"
+	grpcPortFlag = "grpc-port"
+	httpPortFlag = "http-port"
+	logLevelFlag = "log-level"
 )
 
 var (
@@ -59,14 +66,15 @@ var (
 
 func main() {
 	ctlConf := parseEnvFlags()
+	setLogLevel(ctlConf.LogLevel)
 	logger.WithField("version", pkg.Version).
-		WithField("grpcPort", grpcPort).WithField("httpPort", httpPort).
+		WithField("grpcPort", ctlConf.GRPCPort).WithField("httpPort", ctlConf.HTTPPort).
 		WithField("ctlConf", ctlConf).Info("Starting sdk sidecar")
 
-	grpcEndpoint := fmt.Sprintf("%s:%d", ctlConf.Address, grpcPort)
+	grpcEndpoint := fmt.Sprintf("%s:%d", ctlConf.Address, ctlConf.GRPCPort)
 	lis, err := net.Listen("tcp", grpcEndpoint)
 	if err != nil {
-		logger.WithField("grpcPort", grpcPort).WithField("Address", ctlConf.Address).Fatalf("Could not listen on grpcPort")
+		logger.WithField("grpcPort", ctlConf.GRPCPort).WithField("Address", ctlConf.Address).Fatalf("Could not listen on grpcPort")
 	}
 	stop := signals.NewStopChannel()
 	grpcServer := grpc.NewServer()
@@ -75,9 +83,17 @@ func main() {
 	// care about running RPC calls.
 	defer grpcServer.Stop()
 
+	// standard grpc health checking and server reflection, so L7 load
+	// balancers can health check the sidecar, and it can be explored
+	// with grpcurl without the proto files
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus("stable.agones.dev.sdk.SDK", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
 	mux := gwruntime.NewServeMux()
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf("%s:%d", ctlConf.Address, httpPort),
+		Addr:    fmt.Sprintf("%s:%d", ctlConf.Address, ctlConf.HTTPPort),
 		Handler: mux,
 	}
 	defer httpServer.Close() // nolint: errcheck
@@ -190,23 +206,46 @@ func parseEnvFlags() config {
 	viper.SetDefault(localFlag, false)
 	viper.SetDefault(fileFlag, "")
 	viper.SetDefault(addressFlag, "localhost")
+	viper.SetDefault(grpcPortFlag, defaultGRPCPort)
+	viper.SetDefault(httpPortFlag, defaultHTTPPort)
+	viper.SetDefault(logLevelFlag, "Info")
 	pflag.Bool(localFlag, viper.GetBool(localFlag),
 		"Set this, or LOCAL env, to 'true' to run this binary in local development mode. Defaults to 'false'")
 	pflag.StringP(fileFlag, "f", viper.GetString(fileFlag), "Set this, or FILE env var to the path of a local yaml or json file that contains your GameServer resoure configuration")
 	pflag.String(addressFlag, viper.GetString(addressFlag), "The Address to bind the server grpcPort to. Defaults to 'localhost")
+	pflag.Int(grpcPortFlag, viper.GetInt(grpcPortFlag), "Set this, or GRPC_PORT env var, to the port to bind the gRPC server to. Defaults to 59357")
+	pflag.Int(httpPortFlag, viper.GetInt(httpPortFlag), "Set this, or HTTP_PORT env var, to the port to bind the gRPC gateway to. Defaults to 59358")
+	pflag.String(logLevelFlag, viper.GetString(logLevelFlag), "Set this, or LOG_LEVEL env var, to one of Info, Debug or Error. Defaults to Info")
 	pflag.Parse()
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	runtime.Must(viper.BindEnv(localFlag))
 	runtime.Must(viper.BindEnv(gameServerNameEnv))
 	runtime.Must(viper.BindEnv(podNamespaceEnv))
+	runtime.Must(viper.BindEnv(grpcPortFlag))
+	runtime.Must(viper.BindEnv(httpPortFlag))
+	runtime.Must(viper.BindEnv(logLevelFlag))
 	runtime.Must(viper.BindPFlags(pflag.CommandLine))
 
 	return config{
 		IsLocal:   viper.GetBool(localFlag),
 		Address:   viper.GetString(addressFlag),
 		LocalFile: viper.GetString(fileFlag),
+		GRPCPort:  viper.GetInt(grpcPortFlag),
+		HTTPPort:  viper.GetInt(httpPortFlag),
+		LogLevel:  viper.GetString(logLevelFlag),
+	}
+}
+
+// setLogLevel applies the configured log level to the standard logger
+// that all the sidecar's loggers derive from
+func setLogLevel(level string) {
+	parsed, err := logrus.ParseLevel(strings.ToLower(level))
+	if err != nil {
+		logger.WithField("level", level).Warn("Could not parse log level. Defaulting to Info")
+		parsed = logrus.InfoLevel
 	}
+	logrus.SetLevel(parsed)
 }
 
 // config is all the configuration for this program
@@ -214,4 +253,7 @@ type config struct {
 	Address   string
 	IsLocal   bool
 	LocalFile string
+	GRPCPort  int
+	HTTPPort  int
+	LogLevel  string
 }
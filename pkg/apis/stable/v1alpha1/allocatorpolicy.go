@@ -0,0 +1,61 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AllocatorPolicy configures a single client of the allocator service -
+// its identity, and the namespaces and fleets it is allowed to allocate
+// from. The allocator service watches these, so access for multiple teams
+// can be managed declaratively, without allocator restarts
+type AllocatorPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AllocatorPolicySpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AllocatorPolicyList is a list of AllocatorPolicy resources
+type AllocatorPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []AllocatorPolicy `json:"items"`
+}
+
+// AllocatorPolicySpec is the specification of an AllocatorPolicy. Either
+// ClientName or APIKey must be set
+type AllocatorPolicySpec struct {
+	// ClientName is the client identity, matched against the mTLS client
+	// certificate's Common Name and DNS Subject Alternative Names
+	ClientName string `json:"clientName,omitempty"`
+	// APIKey optionally authenticates this client via an
+	// `Authorization: Bearer <key>` header, for clients without certificates
+	APIKey string `json:"apiKey,omitempty"`
+	// Namespaces are the namespaces this client may allocate GameServers in
+	Namespaces []string `json:"namespaces"`
+	// Fleets optionally restricts this client to allocating from the given
+	// Fleet names. If empty, any fleet within the allowed namespaces can
+	// be allocated from
+	Fleets []string `json:"fleets,omitempty"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"agones.dev/agones/pkg/apis/stable"
+)
+
+const (
+	// ControllerVersionAnnotation is the annotation recording the version of
+	// the controller that created a GameServer. It is set once at creation
+	// and preserved across controller upgrades, so a newer controller can
+	// tell which version's conventions an existing GameServer follows
+	ControllerVersionAnnotation = stable.GroupName + "/controller-version"
+)
+
+// SetControllerVersion records the given controller version against this
+// GameServer, if no version has been recorded yet. GameServers created by an
+// older controller keep their original version across upgrades
+func (gs *GameServer) SetControllerVersion(version string) {
+	if gs.ObjectMeta.Annotations == nil {
+		gs.ObjectMeta.Annotations = map[string]string{}
+	}
+	if _, ok := gs.ObjectMeta.Annotations[ControllerVersionAnnotation]; !ok {
+		gs.ObjectMeta.Annotations[ControllerVersionAnnotation] = version
+	}
+}
+
+// ControllerVersion returns the version of the controller that created this
+// GameServer, or an empty string for GameServers that predate version recording
+func (gs *GameServer) ControllerVersion() string {
+	return gs.ObjectMeta.Annotations[ControllerVersionAnnotation]
+}
+
+// IsControllerVersionChange returns true if this GameServer was created by a
+// different, known controller version than the one passed in - i.e. it has
+// survived an upgrade or downgrade of the controller
+func (gs *GameServer) IsControllerVersionChange(version string) bool {
+	recorded := gs.ControllerVersion()
+	return recorded != "" && recorded != version
+}
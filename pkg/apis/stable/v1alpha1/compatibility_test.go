@@ -0,0 +1,47 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGameServerSetControllerVersion(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	assert.Equal(t, "", gs.ControllerVersion())
+
+	gs.SetControllerVersion("0.8.0")
+	assert.Equal(t, "0.8.0", gs.ControllerVersion())
+
+	// an already recorded version survives later controllers
+	gs.SetControllerVersion("0.9.0")
+	assert.Equal(t, "0.8.0", gs.ControllerVersion())
+}
+
+func TestGameServerIsControllerVersionChange(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	assert.False(t, gs.IsControllerVersionChange("0.8.0"))
+
+	gs.SetControllerVersion("0.8.0")
+	assert.False(t, gs.IsControllerVersionChange("0.8.0"))
+	assert.True(t, gs.IsControllerVersionChange("0.9.0"))
+}
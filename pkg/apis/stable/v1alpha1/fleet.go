@@ -18,6 +18,7 @@ import (
 	"agones.dev/agones/pkg"
 	"agones.dev/agones/pkg/apis/stable"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -26,6 +27,12 @@ const (
 	// FleetNameLabel is the label that the name of the Fleet
 	// is set to on GameServerSet and GameServer  the Fleet controls
 	FleetNameLabel = stable.GroupName + "/fleet"
+
+	// ScaleDownRequestedAnnotation records when the autoscaler first requested
+	// a scale down that is being held back by Spec.ScaleDownDelaySeconds, as
+	// a RFC3339 timestamp. It is removed when the scale down is applied, or
+	// demand returns
+	ScaleDownRequestedAnnotation = stable.GroupName + "/scale-down-requested"
 )
 
 // +genclient
@@ -60,7 +67,91 @@ type FleetSpec struct {
 	Strategy appsv1.DeploymentStrategy `json:"strategy"`
 	// Scheduling strategy. Defaults to "Packed".
 	Scheduling SchedulingStrategy `json:"scheduling"`
-	// Template the GameServer template to apply for this Fleet
+	// ScaleDownDelaySeconds, when set, delays autoscaler driven scale downs by
+	// the given number of seconds, so Ready GameServers are only removed if
+	// demand does not return within the delay. This smooths out the
+	// create/delete churn of oscillating player counts
+	ScaleDownDelaySeconds int32 `json:"scaleDownDelaySeconds,omitempty"`
+	// MinReadySeconds is the minimum number of seconds a GameServer must
+	// have been Ready before it can be selected for deletion on scale down.
+	// Passed through to this Fleet's GameServerSets
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// Health, when set, overrides the health checking configuration in the
+	// GameServer template, so health policies can be managed fleet wide
+	// without editing the template
+	Health *Health `json:"health,omitempty"`
+	// Lifecycle, when set, configures a webhook that the controller invokes
+	// on key state transitions of this Fleet's GameServers, so external
+	// inventory and session services can stay in sync without running their
+	// own watch based operator
+	Lifecycle *LifecycleSpec `json:"lifecycle,omitempty"`
+	// Disruption, when set, is a fleet wide budget on how many GameServers
+	// may be disrupted at once, shared between rolling updates and scale
+	// downs, so combined churn can't crater Ready capacity
+	Disruption *DisruptionSpec `json:"disruption,omitempty"`
+	// TemplateRef optionally names a GameServerTemplate preset in the same
+	// namespace, inlined as the base of Template at reconcile time. Values
+	// set on Template override the preset
+	TemplateRef string `json:"templateRef,omitempty"`
+	// PinImageDigests, when true, resolves the template's container image
+	// tags to digests when a rollout starts, so every replica in a
+	// GameServerSet runs identical bytes even if a tag is re-pushed while
+	// the rollout is in flight
+	PinImageDigests bool `json:"pinImageDigests,omitempty"`
+	// Flavors, when set, are weighted variants of the GameServer template,
+	// passed through to this Fleet's GameServerSets so the replicas are
+	// split between them in proportion to their weights
+	Flavors []Flavor `json:"flavors,omitempty"`
+	// Canary, when set, runs the given percentage of the fleet's replicas
+	// with a second GameServer template, so a new server build can be
+	// soak-tested on real matches before a full rollout. Overrides Flavors
+	Canary *CanarySpec `json:"canary,omitempty"`
+	// Paused, when true, suspends replica and deployment reconciliation of
+	// this Fleet and its GameServerSets, so manual intervention during an
+	// incident isn't fought by the controller. Status is still kept up to
+	// date. Reconciliation resumes where it left off when set back to false
+	Paused bool `json:"paused,omitempty"`
+	// Template the GameServer template to apply for this Fleet.
+	// Ignored when Flavors are set
+	Template GameServerTemplateSpec `json:"template"`
+}
+
+// LifecycleSpec configures the webhook that is invoked when a GameServer
+// in this Fleet transitions to the Ready, Allocated or Shutdown state
+type LifecycleSpec struct {
+	// WebhookURL is the URL the transition payload is POSTed to
+	WebhookURL string `json:"webhookURL"`
+	// SigningKey, when set, is used to sign the payload with HMAC-SHA256.
+	// The hex encoded signature is sent in the X-Agones-Signature header,
+	// so the receiver can verify the payload came from this controller
+	SigningKey string `json:"signingKey,omitempty"`
+}
+
+// DisruptionSpec is a fleet wide budget on simultaneous disruption. It is
+// evaluated by both the rolling update and the scale down paths, so a
+// deployment happening at the same time as an autoscaler scale down cannot
+// remove more GameServers at once than the budget allows
+type DisruptionSpec struct {
+	// MaxDisruptedPercent is the maximum percentage of Spec.Replicas that may
+	// be disrupted - shutting down, or pending removal - at any one time
+	MaxDisruptedPercent int32 `json:"maxDisruptedPercent"`
+}
+
+const (
+	// StableFlavorName is the flavor name given to the fleet's own template
+	// when a canary split is configured
+	StableFlavorName = "stable"
+	// CanaryFlavorName is the flavor name given to the canary template
+	CanaryFlavorName = "canary"
+)
+
+// CanarySpec splits a Fleet between its own template and a canary
+// template. The replicas are divided by percentage, and since the Ready
+// pool carries the same proportions, allocations are weighted accordingly
+type CanarySpec struct {
+	// Percent of the fleet's replicas to run with the canary template, 1-99
+	Percent int32 `json:"percent"`
+	// Template is the canary GameServer template
 	Template GameServerTemplateSpec `json:"template"`
 }
 
@@ -72,6 +163,25 @@ type FleetStatus struct {
 	ReadyReplicas int32 `json:"readyReplicas"`
 	// AllocatedReplicas are the number of Allocated GameServer replicas
 	AllocatedReplicas int32 `json:"allocatedReplicas"`
+	// ShuttingDownReplicas are the number of GameServer replicas that have a
+	// DeletionTimestamp, and are waiting to be removed, so capacity dashboards
+	// don't show phantom dips during large scale downs
+	ShuttingDownReplicas int32 `json:"shuttingDownReplicas,omitempty"`
+	// Counters aggregates the game defined counters across the fleet's
+	// GameServers, keyed by counter name, so autoscalers and dashboards can
+	// act on totals without enumerating GameServers
+	Counters map[string]AggregatedCounterStatus `json:"counters,omitempty"`
+	// Lists aggregates the game defined lists across the fleet's
+	// GameServers, keyed by list name
+	Lists map[string]AggregatedListStatus `json:"lists,omitempty"`
+	// LabelSelector is the label selector for the GameServers owned by this
+	// Fleet, in string form, so the scale subresource can expose it to
+	// the HorizontalPodAutoscaler
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// ObservedGeneration is the generation of the Fleet spec that this status
+	// was calculated from, so deployment tooling can tell a spec change apart
+	// from one that has not been reconciled yet
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // GameServerSet returns a single GameServerSet for this Fleet definition
@@ -79,8 +189,11 @@ func (f *Fleet) GameServerSet() *GameServerSet {
 	gsSet := &GameServerSet{
 		ObjectMeta: *f.Spec.Template.ObjectMeta.DeepCopy(),
 		Spec: GameServerSetSpec{
-			Template:   f.Spec.Template,
-			Scheduling: f.Spec.Scheduling,
+			Template:        f.Spec.Template,
+			Scheduling:      f.Spec.Scheduling,
+			MinReadySeconds: f.Spec.MinReadySeconds,
+			Health:          f.Spec.Health,
+			Flavors:         f.DesiredFlavors(),
 		},
 	}
 
@@ -104,6 +217,108 @@ func (f *Fleet) GameServerSet() *GameServerSet {
 	return gsSet
 }
 
+// DesiredFlavors returns the weighted template flavors this Fleet's
+// GameServerSet should run - the stable/canary split when a canary is
+// configured, or the fleet's own Flavors otherwise
+func (f *Fleet) DesiredFlavors() []Flavor {
+	if f.Spec.Canary != nil {
+		return []Flavor{
+			{Name: StableFlavorName, Weight: 100 - f.Spec.Canary.Percent, Template: f.Spec.Template},
+			{Name: CanaryFlavorName, Weight: f.Spec.Canary.Percent, Template: f.Spec.Canary.Template},
+		}
+	}
+	return f.Spec.Flavors
+}
+
+// ResolveTemplate returns this Fleet's GameServer template with the given
+// preset inlined as its base. Values set on the fleet's own template win,
+// labels and annotations are merged with the fleet's values taking
+// precedence, and preset pod containers and volumes whose names don't clash
+// with the fleet's own - the common sidecar scaffolding - are appended
+func (f *Fleet) ResolveTemplate(preset *GameServerTemplate) GameServerTemplateSpec {
+	resolved := *preset.Spec.DeepCopy()
+	t := f.Spec.Template
+
+	for k, v := range t.ObjectMeta.Labels {
+		if resolved.ObjectMeta.Labels == nil {
+			resolved.ObjectMeta.Labels = map[string]string{}
+		}
+		resolved.ObjectMeta.Labels[k] = v
+	}
+	for k, v := range t.ObjectMeta.Annotations {
+		if resolved.ObjectMeta.Annotations == nil {
+			resolved.ObjectMeta.Annotations = map[string]string{}
+		}
+		resolved.ObjectMeta.Annotations[k] = v
+	}
+
+	if t.Spec.Container != "" {
+		resolved.Spec.Container = t.Spec.Container
+	}
+	if len(t.Spec.Ports) > 0 {
+		resolved.Spec.Ports = t.Spec.Ports
+	}
+	if t.Spec.Health != (Health{}) {
+		resolved.Spec.Health = t.Spec.Health
+	}
+	if t.Spec.Shutdown.Policy != "" || t.Spec.Shutdown.RetainSeconds != 0 ||
+		t.Spec.Shutdown.Notification != "" || len(t.Spec.Shutdown.ExecCommand) > 0 {
+		resolved.Spec.Shutdown = t.Spec.Shutdown
+	}
+	if t.Spec.Scheduling != "" {
+		resolved.Spec.Scheduling = t.Spec.Scheduling
+	}
+	if t.Spec.SdkServer.LogLevel != "" || t.Spec.SdkServer.GRPCPort != 0 || t.Spec.SdkServer.HTTPPort != 0 ||
+		len(t.Spec.SdkServer.Resources.Requests) > 0 || len(t.Spec.SdkServer.Resources.Limits) > 0 {
+		resolved.Spec.SdkServer = t.Spec.SdkServer
+	}
+	if t.Spec.Players != nil {
+		resolved.Spec.Players = t.Spec.Players
+	}
+	if len(t.Spec.Counters) > 0 {
+		resolved.Spec.Counters = t.Spec.Counters
+	}
+	if len(t.Spec.Lists) > 0 {
+		resolved.Spec.Lists = t.Spec.Lists
+	}
+	if t.Spec.Network != (NetworkSpec{}) {
+		resolved.Spec.Network = t.Spec.Network
+	}
+	if t.Spec.ServiceType != "" {
+		resolved.Spec.ServiceType = t.Spec.ServiceType
+	}
+
+	if len(t.Spec.Template.Spec.Containers) > 0 {
+		containers := append([]corev1.Container(nil), t.Spec.Template.Spec.Containers...)
+		names := map[string]bool{}
+		for _, c := range containers {
+			names[c.Name] = true
+		}
+		for _, c := range resolved.Spec.Template.Spec.Containers {
+			if !names[c.Name] {
+				containers = append(containers, c)
+			}
+		}
+		resolved.Spec.Template.Spec.Containers = containers
+	}
+
+	if len(t.Spec.Template.Spec.Volumes) > 0 {
+		volumes := append([]corev1.Volume(nil), t.Spec.Template.Spec.Volumes...)
+		names := map[string]bool{}
+		for _, v := range volumes {
+			names[v.Name] = true
+		}
+		for _, v := range resolved.Spec.Template.Spec.Volumes {
+			if !names[v.Name] {
+				volumes = append(volumes, v)
+			}
+		}
+		resolved.Spec.Template.Spec.Volumes = volumes
+	}
+
+	return resolved
+}
+
 // ApplyDefaults applies default values to the Fleet
 func (f *Fleet) ApplyDefaults() {
 	if f.Spec.Strategy.Type == "" {
@@ -152,6 +367,21 @@ func (f *Fleet) LowerBoundReplicas(i int32) int32 {
 	return i
 }
 
+// MaxDisruptedReplicas returns the maximum number of GameServers that may be
+// disrupted at once under this Fleet's disruption budget, and whether a
+// budget has been configured at all. The budget is never less than one, so
+// rolling updates and scale downs can always make progress
+func (f *Fleet) MaxDisruptedReplicas() (int32, bool) {
+	if f.Spec.Disruption == nil {
+		return 0, false
+	}
+	budget := f.Spec.Replicas * f.Spec.Disruption.MaxDisruptedPercent / 100
+	if budget < 1 {
+		budget = 1
+	}
+	return budget, true
+}
+
 // SumStatusAllocatedReplicas returns the total number of
 // Status.AllocatedReplicas in the list of GameServerSets
 func SumStatusAllocatedReplicas(list []*GameServerSet) int32 {
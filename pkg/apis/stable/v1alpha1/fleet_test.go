@@ -33,6 +33,7 @@ func TestFleetGameServerSetGameServer(t *testing.T) {
 		Spec: FleetSpec{
 			Replicas:   10,
 			Scheduling: Packed,
+			Health:     &Health{FailureThreshold: 10},
 			Template: GameServerTemplateSpec{
 				Spec: GameServerSpec{
 					Ports: []GameServerPort{{ContainerPort: 1234}},
@@ -53,10 +54,51 @@ func TestFleetGameServerSetGameServer(t *testing.T) {
 	assert.Equal(t, f.ObjectMeta.Name, gsSet.ObjectMeta.Labels[FleetNameLabel])
 	assert.Equal(t, int32(0), gsSet.Spec.Replicas)
 	assert.Equal(t, f.Spec.Scheduling, gsSet.Spec.Scheduling)
+	assert.Equal(t, f.Spec.Health, gsSet.Spec.Health)
 	assert.Equal(t, f.Spec.Template, gsSet.Spec.Template)
 	assert.True(t, metav1.IsControlledBy(gsSet, &f))
 }
 
+func TestFleetDesiredFlavors(t *testing.T) {
+	f := &Fleet{
+		Spec: FleetSpec{
+			Replicas: 10,
+			Template: GameServerTemplateSpec{
+				Spec: GameServerSpec{Container: "stable-container"},
+			},
+		},
+	}
+
+	assert.Empty(t, f.DesiredFlavors())
+
+	f.Spec.Flavors = []Flavor{{Name: "small", Weight: 1}}
+	assert.Equal(t, f.Spec.Flavors, f.DesiredFlavors())
+
+	// a canary split overrides explicit flavors
+	f.Spec.Canary = &CanarySpec{
+		Percent: 10,
+		Template: GameServerTemplateSpec{
+			Spec: GameServerSpec{Container: "canary-container"},
+		},
+	}
+
+	flavors := f.DesiredFlavors()
+	if !assert.Len(t, flavors, 2) {
+		return
+	}
+	assert.Equal(t, StableFlavorName, flavors[0].Name)
+	assert.Equal(t, int32(90), flavors[0].Weight)
+	assert.Equal(t, f.Spec.Template, flavors[0].Template)
+	assert.Equal(t, CanaryFlavorName, flavors[1].Name)
+	assert.Equal(t, int32(10), flavors[1].Weight)
+	assert.Equal(t, f.Spec.Canary.Template, flavors[1].Template)
+
+	gsSet := f.GameServerSet()
+	assert.Equal(t, flavors, gsSet.Spec.Flavors)
+	assert.Equal(t, map[string]int32{StableFlavorName: 9, CanaryFlavorName: 1},
+		gsSet.FlavorDistribution(f.Spec.Replicas))
+}
+
 func TestFleetApplyDefaults(t *testing.T) {
 	f := &Fleet{}
 
@@ -87,6 +129,25 @@ func TestFleetLowerBoundReplicas(t *testing.T) {
 	assert.Equal(t, int32(0), f.LowerBoundReplicas(-5))
 }
 
+func TestFleetMaxDisruptedReplicas(t *testing.T) {
+	f := &Fleet{Spec: FleetSpec{Replicas: 10}}
+
+	budget, ok := f.MaxDisruptedReplicas()
+	assert.False(t, ok)
+	assert.Equal(t, int32(0), budget)
+
+	f.Spec.Disruption = &DisruptionSpec{MaxDisruptedPercent: 20}
+	budget, ok = f.MaxDisruptedReplicas()
+	assert.True(t, ok)
+	assert.Equal(t, int32(2), budget)
+
+	// the budget never drops below one, so progress can always be made
+	f.Spec.Disruption.MaxDisruptedPercent = 1
+	budget, ok = f.MaxDisruptedReplicas()
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), budget)
+}
+
 func TestSumStatusAllocatedReplicas(t *testing.T) {
 	f := Fleet{}
 	gsSet1 := f.GameServerSet()
@@ -107,3 +168,62 @@ func TestSumStatusReplicas(t *testing.T) {
 
 	assert.Equal(t, int32(30), SumStatusReplicas(fixture))
 }
+
+func TestFleetResolveTemplate(t *testing.T) {
+	preset := &GameServerTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Spec: GameServerTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"studio": "example", "tier": "free"},
+				Annotations: map[string]string{"owner": "platform"},
+			},
+			Spec: GameServerSpec{
+				Health: Health{FailureThreshold: 5},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "game", Image: "preset/game"},
+							{Name: "logger", Image: "preset/logger"},
+						},
+						Volumes: []corev1.Volume{{Name: "logs"}},
+					},
+				},
+			},
+		},
+	}
+
+	f := &Fleet{
+		Spec: FleetSpec{
+			TemplateRef: "base",
+			Template: GameServerTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"tier": "premium"},
+				},
+				Spec: GameServerSpec{
+					Ports: []GameServerPort{{ContainerPort: 7777}},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "game", Image: "fleet/game"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resolved := f.ResolveTemplate(preset)
+
+	assert.Equal(t, "example", resolved.ObjectMeta.Labels["studio"])
+	assert.Equal(t, "premium", resolved.ObjectMeta.Labels["tier"])
+	assert.Equal(t, "platform", resolved.ObjectMeta.Annotations["owner"])
+	assert.Equal(t, Health{FailureThreshold: 5}, resolved.Spec.Health)
+	assert.Equal(t, f.Spec.Template.Spec.Ports, resolved.Spec.Ports)
+	assert.Len(t, resolved.Spec.Template.Spec.Containers, 2)
+	assert.Equal(t, "fleet/game", resolved.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "preset/logger", resolved.Spec.Template.Spec.Containers[1].Image)
+	assert.Len(t, resolved.Spec.Template.Spec.Volumes, 1)
+
+	// the fleet and preset should be untouched
+	assert.Len(t, f.Spec.Template.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "preset/game", preset.Spec.Spec.Template.Spec.Containers[0].Image)
+}
@@ -65,6 +65,12 @@ type FleetAutoscalerPolicy struct {
 	// Webhook policy config params. Present only if FleetAutoscalerPolicyType = Webhook.
 	// +optional
 	Webhook *WebhookPolicy `json:"webhook,omitempty"`
+	// Counter policy config params. Present only if FleetAutoscalerPolicyType = Counter.
+	// +optional
+	Counter *CounterPolicy `json:"counter,omitempty"`
+	// List policy config params. Present only if FleetAutoscalerPolicyType = List.
+	// +optional
+	List *ListPolicy `json:"list,omitempty"`
 }
 
 // FleetAutoscalerPolicyType is the policy for autoscaling
@@ -78,6 +84,13 @@ const (
 	// WebhookPolicyType is a simple webhook strategy used for horizontal fleet scaling
 	// GameServers
 	WebhookPolicyType FleetAutoscalerPolicyType = "Webhook"
+	// CounterPolicyType is a buffering strategy for free capacity on an
+	// aggregated counter, for high density GameServers hosting many sessions
+	// per process
+	CounterPolicyType FleetAutoscalerPolicyType = "Counter"
+	// ListPolicyType is a buffering strategy for free capacity on an
+	// aggregated list
+	ListPolicyType FleetAutoscalerPolicyType = "List"
 )
 
 // BufferPolicy controls the desired behavior of the buffer policy.
@@ -109,6 +122,48 @@ type BufferPolicy struct {
 // used to form url which is accessible inside the cluster
 type WebhookPolicy admregv1b.WebhookClientConfig
 
+// CounterPolicy scales a Fleet on the named aggregated counter in its status,
+// rather than on whole GameServer buffers
+type CounterPolicy struct {
+	// Key is the name of the counter to scale on
+	Key string `json:"key"`
+
+	// MaxCapacity is the aggregated counter capacity that the fleet may not
+	// grow beyond. It must be bigger than both MinCapacity and BufferSize
+	MaxCapacity int64 `json:"maxCapacity"`
+
+	// MinCapacity is the aggregated counter capacity that the fleet may not
+	// shrink below. If zero, it is ignored
+	MinCapacity int64 `json:"minCapacity"`
+
+	// BufferSize is the free counter capacity the autoscaler tries to keep
+	// available across the fleet at all times.
+	// Value can be an absolute number (ex: 500 free rooms) or a percentage
+	// of the aggregated capacity (ex: 15%)
+	// Must be bigger than 0
+	BufferSize intstr.IntOrString `json:"bufferSize"`
+}
+
+// ListPolicy scales a Fleet on the named aggregated list in its status.
+// The fields have the same meaning as on CounterPolicy, with free capacity
+// measured in list entries
+type ListPolicy struct {
+	// Key is the name of the list to scale on
+	Key string `json:"key"`
+
+	// MaxCapacity is the aggregated list capacity that the fleet may not
+	// grow beyond
+	MaxCapacity int64 `json:"maxCapacity"`
+
+	// MinCapacity is the aggregated list capacity that the fleet may not
+	// shrink below. If zero, it is ignored
+	MinCapacity int64 `json:"minCapacity"`
+
+	// BufferSize is the free list capacity the autoscaler tries to keep
+	// available across the fleet at all times, absolute or percentage
+	BufferSize intstr.IntOrString `json:"bufferSize"`
+}
+
 // FleetAutoscalerStatus defines the current status of a FleetAutoscaler
 type FleetAutoscalerStatus struct {
 	// CurrentReplicas is the current number of gameserver replicas
@@ -129,6 +184,11 @@ type FleetAutoscalerStatus struct {
 	// ScalingLimited indicates that the calculated scale would be above or below the range
 	// defined by MinReplicas and MaxReplicas, and has thus been capped.
 	ScalingLimited bool `json:"scalingLimited"`
+
+	// ObservedGeneration is the generation of the FleetAutoscaler spec that this
+	// status was calculated from, so deployment tooling can tell a spec change
+	// apart from one that has not been reconciled yet
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // FleetAutoscaleRequest defines the request to webhook autoscaler endpoint
@@ -172,6 +232,12 @@ func (fas *FleetAutoscaler) Validate(causes []metav1.StatusCause) []metav1.Statu
 
 	case WebhookPolicyType:
 		causes = fas.Spec.Policy.Webhook.ValidateWebhookPolicy(causes)
+
+	case CounterPolicyType:
+		causes = fas.Spec.Policy.Counter.ValidateCounterPolicy(causes)
+
+	case ListPolicyType:
+		causes = fas.Spec.Policy.List.ValidateListPolicy(causes)
 	}
 	return causes
 }
@@ -282,3 +348,72 @@ func (b *BufferPolicy) ValidateBufferPolicy(causes []metav1.StatusCause) []metav
 	}
 	return causes
 }
+
+// ValidateCounterPolicy validates the FleetAutoscaler Counter policy settings
+func (c *CounterPolicy) ValidateCounterPolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if c == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "counter",
+			Message: "Counter policy config params are missing",
+		})
+	}
+	return validateCapacityPolicy(c.Key, c.MinCapacity, c.MaxCapacity, c.BufferSize, causes)
+}
+
+// ValidateListPolicy validates the FleetAutoscaler List policy settings
+func (l *ListPolicy) ValidateListPolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if l == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "list",
+			Message: "List policy config params are missing",
+		})
+	}
+	return validateCapacityPolicy(l.Key, l.MinCapacity, l.MaxCapacity, l.BufferSize, causes)
+}
+
+// validateCapacityPolicy holds the validation shared by the Counter and List
+// policies
+func validateCapacityPolicy(key string, minCapacity, maxCapacity int64, bufferSize intstr.IntOrString, causes []metav1.StatusCause) []metav1.StatusCause {
+	if key == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   "key",
+			Message: "key is required",
+		})
+	}
+	if minCapacity > maxCapacity {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "minCapacity",
+			Message: "minCapacity is bigger than maxCapacity",
+		})
+	}
+	if bufferSize.Type == intstr.Int {
+		if bufferSize.IntValue() <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "bufferSize",
+				Message: "bufferSize must be bigger than 0",
+			})
+		}
+		if maxCapacity < int64(bufferSize.IntValue()) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "maxCapacity",
+				Message: "maxCapacity must be bigger than bufferSize",
+			})
+		}
+	} else {
+		r, err := intstr.GetValueFromIntOrPercent(&bufferSize, 100, true)
+		if err != nil || r < 1 || r > 99 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "bufferSize",
+				Message: "bufferSize does not have a valid percentage value (1%-99%)",
+			})
+		}
+	}
+	return causes
+}
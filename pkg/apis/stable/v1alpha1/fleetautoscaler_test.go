@@ -192,10 +192,95 @@ func TestFleetAutoscalerWebhookValidateUpdate(t *testing.T) {
 
 }
 
+func TestFleetAutoscalerCounterValidateUpdate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("good counter policy", func(t *testing.T) {
+		fas := counterFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("good list policy", func(t *testing.T) {
+		fas := customFixture(ListPolicyType)
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("missing counter params", func(t *testing.T) {
+		fas := counterFixture()
+		fas.Spec.Policy.Counter = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "counter", causes[0].Field)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		fas := counterFixture()
+		fas.Spec.Policy.Counter.Key = ""
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "key", causes[0].Field)
+	})
+
+	t.Run("bad buffer size", func(t *testing.T) {
+		fas := counterFixture()
+		fas.Spec.Policy.Counter.BufferSize = intstr.FromInt(0)
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "bufferSize", causes[0].Field)
+	})
+
+	t.Run("minCapacity > maxCapacity", func(t *testing.T) {
+		fas := counterFixture()
+		fas.Spec.Policy.Counter.MinCapacity = 1000
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "minCapacity", causes[0].Field)
+	})
+
+	t.Run("bufferSize bigger than maxCapacity", func(t *testing.T) {
+		fas := counterFixture()
+		fas.Spec.Policy.Counter.BufferSize = intstr.FromInt(600)
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "maxCapacity", causes[0].Field)
+	})
+
+	t.Run("bufferSize bad percent", func(t *testing.T) {
+		fas := counterFixture()
+		fas.Spec.Policy.Counter.BufferSize = intstr.FromString("120%")
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "bufferSize", causes[0].Field)
+	})
+
+	t.Run("missing list params", func(t *testing.T) {
+		fas := customFixture(ListPolicyType)
+		fas.Spec.Policy.List = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "list", causes[0].Field)
+	})
+}
+
 func defaultFixture() *FleetAutoscaler {
 	return customFixture(BufferPolicyType)
 }
 
+func counterFixture() *FleetAutoscaler {
+	return customFixture(CounterPolicyType)
+}
+
 func webhookFixture() *FleetAutoscaler {
 	return customFixture(WebhookPolicyType)
 }
@@ -227,6 +312,22 @@ func customFixture(t FleetAutoscalerPolicyType) *FleetAutoscaler {
 				Path:      &url,
 			},
 		}
+	case CounterPolicyType:
+		res.Spec.Policy.Type = CounterPolicyType
+		res.Spec.Policy.Buffer = nil
+		res.Spec.Policy.Counter = &CounterPolicy{
+			Key:         "rooms",
+			BufferSize:  intstr.FromInt(50),
+			MaxCapacity: 500,
+		}
+	case ListPolicyType:
+		res.Spec.Policy.Type = ListPolicyType
+		res.Spec.Policy.Buffer = nil
+		res.Spec.Policy.List = &ListPolicy{
+			Key:         "players",
+			BufferSize:  intstr.FromInt(50),
+			MaxCapacity: 500,
+		}
 	}
 	return res
 }
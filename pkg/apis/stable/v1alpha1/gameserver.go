@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/mattbaird/jsonpatch"
 
@@ -25,8 +26,10 @@ import (
 	"agones.dev/agones/pkg/apis/stable"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -49,6 +52,10 @@ const (
 	// GameServerStateShutdown is when the GameServer has shutdown and everything needs to be
 	// deleted from the cluster
 	GameServerStateShutdown GameServerState = "Shutdown"
+	// GameServerStateCompleted is the terminal state for a Shutdown GameServer
+	// with a Retain policy - the Pod is gone, but the object is kept around
+	// for the retain period
+	GameServerStateCompleted GameServerState = "Completed"
 	// GameServerStateError is when something has gone wrong with the Gameserver and
 	// it cannot be resolved
 	GameServerStateError GameServerState = "Error"
@@ -56,6 +63,41 @@ const (
 	GameServerStateUnhealthy GameServerState = "Unhealthy"
 	// GameServerStateAllocated is when the GameServer has been allocated to a session
 	GameServerStateAllocated GameServerState = "Allocated"
+	// GameServerStateReserved is when the GameServer is held out of allocation and
+	// scale down for a period of time, via the SDK. Once ReservedUntil passes,
+	// it is moved back to Ready
+	GameServerStateReserved GameServerState = "Reserved"
+
+	// ShutdownPolicyDelete deletes a Shutdown GameServer immediately
+	ShutdownPolicyDelete ShutdownPolicy = "Delete"
+	// ShutdownPolicyRetain deletes the backing Pod of a Shutdown GameServer,
+	// but keeps the object around in the Completed state for the retain period
+	ShutdownPolicyRetain ShutdownPolicy = "Retain"
+
+	// ShutdownNotificationSignal relies on the SIGTERM the container runtime
+	// sends to the game server container when the backing Pod is deleted.
+	// The default
+	ShutdownNotificationSignal ShutdownNotification = "Signal"
+	// ShutdownNotificationSDK tells the game process through the SDK - the
+	// Shutdown state transition is delivered over the SDK's WatchGameServer
+	// stream before the backing Pod is deleted
+	ShutdownNotificationSDK ShutdownNotification = "SDK"
+	// ShutdownNotificationExec runs Shutdown.ExecCommand in the game server
+	// container as a preStop hook, for engines that need more than a bare
+	// signal to flush saves before exiting
+	ShutdownNotificationExec ShutdownNotification = "Exec"
+
+	// EvictionNever blocks the cluster autoscaler from evicting this
+	// GameServer's Pod. The default for Packed GameServers
+	EvictionNever EvictionPolicy = "Never"
+	// EvictionOnUpgrade keeps the cluster autoscaler away, but treats a Pod
+	// deletion - such as a node drain during a cluster upgrade - as a
+	// graceful shutdown rather than a health failure
+	EvictionOnUpgrade EvictionPolicy = "OnUpgrade"
+	// EvictionAlways marks this GameServer's Pod as safe to evict, for
+	// autopilot style clusters where eviction cannot be disabled. Pod
+	// deletion is treated as a graceful shutdown
+	EvictionAlways EvictionPolicy = "Always"
 
 	// Static PortPolicy means that the user defines the hostPort to be used
 	// in the configuration.
@@ -63,6 +105,35 @@ const (
 	// Dynamic PortPolicy means that the system will choose an open
 	// port for the GameServer in question
 	Dynamic PortPolicy = "dynamic"
+	// Passthrough PortPolicy means that the system will choose an open
+	// port for the GameServer, and use it for both the HostPort and the
+	// ContainerPort - so a game server binary that reads its listen port
+	// from configuration doesn't need to know it ahead of time
+	Passthrough PortPolicy = "passthrough"
+
+	// PortRoleGame marks the port game clients connect to for play
+	PortRoleGame PortRole = "game"
+	// PortRoleQuery marks the port that serves server queries, such as
+	// player counts and map rotation
+	PortRoleQuery PortRole = "query"
+	// PortRoleSpectator marks the port spectator clients connect to
+	PortRoleSpectator PortRole = "spectator"
+	// PortRoleMetrics marks the port that serves metrics about the game
+	// server process
+	PortRoleMetrics PortRole = "metrics"
+
+	// QueryProtocolA2S is the Source engine (A2S) server-query protocol
+	QueryProtocolA2S QueryProtocol = "A2S"
+
+	// defaultQueryPort is the port the query responder sidecar listens on
+	// when none is specified - the conventional Source query port
+	defaultQueryPort int32 = 27015
+
+	// ProtocolTCPUDP Protocol exposes the same port for both TCP and UDP,
+	// for game servers that multiplex both protocols over one port, such
+	// as WebRTC signalling alongside game traffic. The port is allocated
+	// once, and opened on the container for each protocol
+	ProtocolTCPUDP corev1.Protocol = "TCPUDP"
 
 	// RoleLabel is the label in which the Agones role is specified.
 	// Pods from a GameServer will have the value "gameserver"
@@ -80,6 +151,28 @@ const (
 	// DevAddressAnnotation is an annotation to indicate that a GameServer hosted outside of Agones.
 	// A locally hosted GameServer is not managed by Agones it is just simply registered.
 	DevAddressAnnotation = "stable.agones.dev/dev-address"
+	// UnmanagedAnnotation is an annotation that marks a GameServer as
+	// externally managed. An unmanaged GameServer is never counted or
+	// scaled down by a GameServerSet, but still gets port allocation,
+	// health checking and can be allocated
+	UnmanagedAnnotation = "stable.agones.dev/unmanaged"
+	// IngressBandwidthPodAnnotation is the standardised Pod annotation that
+	// CNI plugins read for inbound traffic shaping
+	IngressBandwidthPodAnnotation = "kubernetes.io/ingress-bandwidth"
+	// EgressBandwidthPodAnnotation is the standardised Pod annotation that
+	// CNI plugins read for outbound traffic shaping
+	EgressBandwidthPodAnnotation = "kubernetes.io/egress-bandwidth"
+	// NodeArchitectureLabel is the standard node label that records the
+	// architecture of a node, used to pin multi-architecture GameServers
+	NodeArchitectureLabel = "beta.kubernetes.io/arch"
+	// SafeToEvictPodAnnotation is the annotation the cluster autoscaler
+	// reads to decide if it can evict a Pod when scaling down a node
+	SafeToEvictPodAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// GameServerDeletionCostAnnotation is an annotation holding the relative
+	// cost of deleting this GameServer on scale down - lower cost GameServers
+	// are deleted first. Operators and matchmakers can raise it to protect
+	// GameServers that are about to be allocated
+	GameServerDeletionCostAnnotation = stable.GroupName + "/gameserver-deletion-cost"
 )
 
 var (
@@ -124,8 +217,50 @@ type GameServerSpec struct {
 	Ports []GameServerPort `json:"ports"`
 	// Health configures health checking
 	Health Health `json:"health,omitempty"`
+	// Shutdown configures what happens when this GameServer reaches the
+	// Shutdown state. Defaults to deleting it immediately
+	Shutdown ShutdownSpec `json:"shutdown,omitempty"`
 	// Scheduling strategy. Defaults to "Packed".
 	Scheduling SchedulingStrategy `json:"scheduling,omitempty"`
+	// SdkServer tunes the SDK sidecar that is injected next to the game
+	// server container - its log level, the ports it binds, and its
+	// resource requests and limits
+	SdkServer SdkServerSpec `json:"sdkServer,omitempty"`
+	// QueryResponder, when set, injects a sidecar that answers common
+	// server-query protocols from SDK state, so legacy server browsers and
+	// monitoring tools can query this GameServer without game code changes
+	QueryResponder *QueryResponderSpec `json:"queryResponder,omitempty"`
+	// Eviction controls whether the cluster autoscaler may evict this
+	// GameServer's Pod, and whether a Pod deletion is treated as a health
+	// failure. One of Never, OnUpgrade or Always. Defaults to Never for
+	// Packed GameServers
+	Eviction EvictionPolicy `json:"eviction,omitempty"`
+	// Players, when set, enables player tracking for this GameServer. The
+	// game process reports connected players through the SDK, and backfill
+	// allocations can target this GameServer while it has spare capacity
+	Players *PlayersSpec `json:"players,omitempty"`
+	// Counters are game defined named counters, such as room or match
+	// counts, copied to the Status when the GameServer becomes Ready, and
+	// updated at runtime by the game process through the SDK
+	Counters map[string]CounterStatus `json:"counters,omitempty"`
+	// Lists are game defined named lists of values, such as match or
+	// session IDs, copied to the Status when the GameServer becomes Ready,
+	// and updated at runtime by the game process through the SDK
+	Lists map[string]ListStatus `json:"lists,omitempty"`
+	// Network declares the expected network usage of this GameServer,
+	// applied to its Pod as the standard bandwidth annotations, so CNI
+	// level QoS can take network into account
+	Network NetworkSpec `json:"network,omitempty"`
+	// Architectures optionally declares a container image per node
+	// architecture, letting one Fleet span node pools of different
+	// architectures. Each GameServer is pinned to one of the declared
+	// architectures via a node selector, and runs that architecture's image
+	Architectures []ArchitectureSpec `json:"architectures,omitempty"`
+	// ServiceType optionally provisions a Service of the given type
+	// (LoadBalancer or NodePort) in front of this GameServer's ports, and
+	// publishes its address in Status instead of the node address. This is
+	// for clusters where direct hostPort access is blocked
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 	// Template describes the Pod that will be created for the GameServer
 	Template corev1.PodTemplateSpec `json:"template"`
 }
@@ -133,9 +268,178 @@ type GameServerSpec struct {
 // GameServerState is the state for the GameServer
 type GameServerState string
 
+// GameServerConditionType is the type of a GameServerCondition
+type GameServerConditionType string
+
+const (
+	// GameServerPortsReachable is the condition recorded by the probing
+	// agent - False when one or more of the GameServer's ports cannot be
+	// reached from within the cluster, which catches broken conntrack or
+	// hostPort mappings that the in-pod health pings never see
+	GameServerPortsReachable GameServerConditionType = "PortsReachable"
+	// GameServerPodScheduled is True once the backing Pod has been
+	// scheduled to a node
+	GameServerPodScheduled GameServerConditionType = "PodScheduled"
+	// GameServerAddressable is True once the Status Address and Ports
+	// have been populated
+	GameServerAddressable GameServerConditionType = "Addressable"
+	// GameServerSDKConnected is True once the game process has connected
+	// to the SDK sidecar and called Ready
+	GameServerSDKConnected GameServerConditionType = "SDKConnected"
+	// GameServerHealthy is False when the health controller has marked
+	// this GameServer Unhealthy
+	GameServerHealthy GameServerConditionType = "Healthy"
+)
+
 // PortPolicy is the port policy for the GameServer
 type PortPolicy string
 
+// PortRole describes what a port is used for - game, query, spectator or
+// metrics traffic - so clients can pick the right port without relying on
+// naming conventions
+type PortRole string
+
+// QueryProtocol is a server-query protocol served by the query
+// responder sidecar
+type QueryProtocol string
+
+// ShutdownPolicy is the policy for what happens to a Shutdown GameServer
+type ShutdownPolicy string
+
+// ShutdownNotification is how the game process is told it is being
+// shut down
+type ShutdownNotification string
+
+// EvictionPolicy is the policy for whether a GameServer's Pod
+// can be evicted, and how eviction is handled when it happens
+type EvictionPolicy string
+
+// ShutdownSpec configures what happens when a GameServer reaches the
+// Shutdown state. The Retain policy deletes the backing Pod, but keeps the
+// GameServer object in the Completed state for RetainSeconds, so log
+// shippers and crash dump collectors can finish before the object vanishes
+type ShutdownSpec struct {
+	// Policy is either Delete (the default) or Retain
+	Policy ShutdownPolicy `json:"policy,omitempty"`
+	// RetainSeconds is how long a Retained GameServer stays in the Completed
+	// state before it is deleted. Defaults to 600 seconds
+	RetainSeconds int32 `json:"retainSeconds,omitempty"`
+	// Notification is how the game process is told it is being shut down.
+	// One of Signal (the default), SDK or Exec
+	Notification ShutdownNotification `json:"notification,omitempty"`
+	// ExecCommand is the command run in the game server container before
+	// it is stopped, when Notification is Exec
+	ExecCommand []string `json:"execCommand,omitempty"`
+}
+
+// SdkServerLogLevel is the log level the SDK sidecar runs with
+type SdkServerLogLevel string
+
+const (
+	// SdkServerLogLevelInfo is the default log level for the SDK sidecar
+	SdkServerLogLevelInfo SdkServerLogLevel = "Info"
+	// SdkServerLogLevelDebug turns on verbose logging in the SDK sidecar
+	SdkServerLogLevelDebug SdkServerLogLevel = "Debug"
+	// SdkServerLogLevelError limits the SDK sidecar to error logging only
+	SdkServerLogLevelError SdkServerLogLevel = "Error"
+)
+
+// SdkServerSpec tunes the SDK sidecar for an individual GameServer
+type SdkServerSpec struct {
+	// LogLevel for the SDK sidecar. One of Info (the default), Debug or Error
+	LogLevel SdkServerLogLevel `json:"logLevel,omitempty"`
+	// GRPCPort is the port the SDK sidecar binds its gRPC server to.
+	// Defaults to 59357
+	GRPCPort int32 `json:"grpcPort,omitempty"`
+	// HTTPPort is the port the SDK sidecar binds its gRPC gateway to.
+	// Defaults to 59358
+	HTTPPort int32 `json:"httpPort,omitempty"`
+	// Resources overrides the sidecar container's resource requests and
+	// limits, taking precedence over the namespace wide GameServerDefaults
+	// and the install wide controller settings
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// QueryResponderSpec configures the query responder sidecar that is injected
+// next to the game server container, answering server-query protocol requests
+// from SDK state - GameServer metadata and player counts
+type QueryResponderSpec struct {
+	// Protocol is the query protocol the responder serves.
+	// Defaults to A2S, the only currently supported protocol
+	Protocol QueryProtocol `json:"protocol,omitempty"`
+	// Port is the UDP port the responder listens on. Defaults to 27015,
+	// the conventional Source query port
+	Port int32 `json:"port,omitempty"`
+}
+
+// ArchitectureSpec declares the container image to run the game server
+// container with on nodes of a given architecture
+type ArchitectureSpec struct {
+	// Arch is the node architecture, as recorded in the node's
+	// architecture label, e.g. amd64 or arm64
+	Arch string `json:"arch"`
+	// Image is the container image to run on nodes of this architecture
+	Image string `json:"image"`
+}
+
+// PlayersSpec enables and configures player tracking on a GameServer
+type PlayersSpec struct {
+	// InitialCapacity is the maximum number of players this GameServer can
+	// host when it starts. The capacity can be changed at runtime through
+	// the SDK
+	InitialCapacity int64 `json:"initialCapacity,omitempty"`
+}
+
+// PlayerStatus tracks the players on a GameServer
+type PlayerStatus struct {
+	// Count is the number of players currently connected
+	Count int64 `json:"count"`
+	// Capacity is the maximum number of players this GameServer can host
+	Capacity int64 `json:"capacity"`
+	// IDs are the identifiers of the connected players, as reported by the
+	// game process through the SDK
+	IDs []string `json:"ids,omitempty"`
+}
+
+// CounterStatus is a game defined named counter, with a count and an
+// optional capacity the count may not exceed
+type CounterStatus struct {
+	// Count is the current value of the counter
+	Count int64 `json:"count"`
+	// Capacity is the maximum value Count can be set to. 0 means unbounded
+	Capacity int64 `json:"capacity,omitempty"`
+}
+
+// ListStatus is a game defined named list of values, with an optional
+// capacity the number of values may not exceed
+type ListStatus struct {
+	// Capacity is the maximum number of Values the list can hold.
+	// 0 means unbounded
+	Capacity int64 `json:"capacity,omitempty"`
+	// Values are the values in the list
+	Values []string `json:"values,omitempty"`
+}
+
+// AggregatedCounterStatus is the total count and capacity of a game defined
+// counter, summed across a set of GameServers
+type AggregatedCounterStatus struct {
+	// Count is the sum of the counter's count across the GameServers
+	Count int64 `json:"count"`
+	// Capacity is the sum of the counter's capacity across the GameServers.
+	// Unbounded counters contribute 0
+	Capacity int64 `json:"capacity"`
+}
+
+// AggregatedListStatus is the total number of values and total capacity of
+// a game defined list, summed across a set of GameServers
+type AggregatedListStatus struct {
+	// Count is the total number of values in the list across the GameServers
+	Count int64 `json:"count"`
+	// Capacity is the sum of the list's capacity across the GameServers.
+	// Unbounded lists contribute 0
+	Capacity int64 `json:"capacity"`
+}
+
 // Health configures health checking on the GameServer
 type Health struct {
 	// Disabled is whether health checking is disabled or not
@@ -148,6 +452,18 @@ type Health struct {
 	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
 }
 
+// NetworkSpec declares the expected bandwidth usage of a GameServer
+type NetworkSpec struct {
+	// IngressBandwidth is the expected inbound bandwidth, as a resource
+	// quantity such as "10M", applied to the Pod as the
+	// kubernetes.io/ingress-bandwidth annotation
+	IngressBandwidth string `json:"ingressBandwidth,omitempty"`
+	// EgressBandwidth is the expected outbound bandwidth, as a resource
+	// quantity such as "10M", applied to the Pod as the
+	// kubernetes.io/egress-bandwidth annotation
+	EgressBandwidth string `json:"egressBandwidth,omitempty"`
+}
+
 // GameServerPort defines a set of Ports that
 // are to be exposed via the GameServer
 type GameServerPort struct {
@@ -157,14 +473,27 @@ type GameServerPort struct {
 	// Dynamic port will allocate a HostPort within the selected MIN_PORT and MAX_PORT range passed to the controller
 	// at installation time.
 	// When `static` is the policy specified, `HostPort` is required, to specify the port that game clients will
-	// connect to
+	// connect to.
+	// `passthrough` dynamically allocates a HostPort in the same way as `dynamic`, and also sets the
+	// ContainerPort to the same value, so the game server process doesn't need to know its port ahead of time
 	PortPolicy PortPolicy `json:"portPolicy,omitempty"`
-	// ContainerPort is the port that is being opened on the game server process
-	ContainerPort int32 `json:"containerPort"`
+	// ContainerPort is the port that is being opened on the game server process.
+	// Set to the allocated HostPort with a Passthrough PortPolicy
+	ContainerPort int32 `json:"containerPort,omitempty"`
 	// HostPort the port exposed on the host for clients to connect to
 	HostPort int32 `json:"hostPort,omitempty"`
-	// Protocol is the network protocol being used. Defaults to UDP. TCP is the only other option
+	// Range is the number of contiguous host ports this entry requests, for
+	// game servers that need a block of ports, such as one per voice channel.
+	// HostPort (and the Status port) is the first port of the block, and
+	// ContainerPort the first container port. Only supported with a Dynamic
+	// PortPolicy. 0 or 1 means a single port
+	Range int32 `json:"range,omitempty"`
+	// Protocol is the network protocol being used. Defaults to UDP. TCP and TCPUDP are the other options
 	Protocol corev1.Protocol `json:"protocol,omitempty"`
+	// Role describes what this port is used for - game, query, spectator or
+	// metrics traffic - so allocation responses and discovery APIs can tell
+	// clients which port to use. Optional
+	Role PortRole `json:"role,omitempty"`
 }
 
 // GameServerStatus is the status for a GameServer resource
@@ -174,13 +503,157 @@ type GameServerStatus struct {
 	Ports    []GameServerStatusPort `json:"ports"`
 	Address  string                 `json:"address"`
 	NodeName string                 `json:"nodeName"`
+	// ReservedUntil is the time a Reserved GameServer is held until, after
+	// which it is moved back to Ready. Only set while the State is Reserved
+	ReservedUntil *metav1.Time `json:"reservedUntil,omitempty"`
+	// Load is an optional game defined load or quality score for this
+	// GameServer, such as player or match count, reported by the game
+	// process. Lower values are considered less loaded, and are preferred
+	// by the LeastLoaded allocation strategy
+	Load float64 `json:"load,omitempty"`
+	// Players tracks the players on this GameServer - incremented by
+	// backfill allocations, and kept accurate by the game process reporting
+	// player connections through the SDK. Only maintained when player
+	// tracking is enabled on the spec
+	Players *PlayerStatus `json:"players,omitempty"`
+	// Counters are the game defined counters declared on the spec, updated
+	// at runtime by the game process through the SDK
+	Counters map[string]CounterStatus `json:"counters,omitempty"`
+	// Lists are the game defined lists declared on the spec, updated at
+	// runtime by the game process through the SDK
+	Lists map[string]ListStatus `json:"lists,omitempty"`
+	// Network is a copy of the spec's declared network usage, populated
+	// alongside the address details, so schedulers and allocators can take
+	// network into account without loading the full spec
+	Network NetworkSpec `json:"network,omitempty"`
+	// Timeline records recent state transitions with their timestamps, so the
+	// lifecycle of a GameServer can be inspected after its Events have expired
+	Timeline []GameServerStateTransition `json:"timeline,omitempty"`
+	// PodCreationAttempts counts failed attempts to create the backing Pod,
+	// so transient failures such as exceeded quota can be retried with
+	// backoff before the GameServer is moved to Error
+	PodCreationAttempts int32 `json:"podCreationAttempts,omitempty"`
+	// PodCreationFailure records the reason of the most recent failed
+	// attempt to create the backing Pod
+	PodCreationFailure string `json:"podCreationFailure,omitempty"`
+	// Conditions are observations of this GameServer made from outside the
+	// game process, maintained by the controllers as the GameServer moves
+	// through its lifecycle, so it is visible why a GameServer is stuck
+	// without digging through Pod events
+	Conditions []GameServerCondition `json:"conditions,omitempty"`
+}
+
+// GameServerCondition contains details of an observed condition
+// of this GameServer
+type GameServerCondition struct {
+	// Type of the condition
+	Type GameServerConditionType `json:"type"`
+	// Status of the condition - True, False or Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time the condition was measured
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the Status changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a one word, CamelCase reason for the last transition
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable description of the last transition
+	Message string `json:"message,omitempty"`
+}
+
+// GameServerStateTransition records a single state change
+// in a GameServer's lifecycle
+type GameServerStateTransition struct {
+	State GameServerState `json:"state"`
+	Time  metav1.Time     `json:"time"`
 }
 
 // GameServerStatusPort shows the port that was allocated to a
-// GameServer.
+// GameServer. For a port entry with a Range, Port is the first
+// port of the contiguous block
 type GameServerStatusPort struct {
-	Name string `json:"name,omitempty"`
-	Port int32  `json:"port"`
+	Name  string `json:"name,omitempty"`
+	Port  int32  `json:"port"`
+	Range int32  `json:"range,omitempty"`
+	// Role is a copy of the spec port's Role, so consumers of the status
+	// don't need to join back to the spec to know what the port is for
+	Role PortRole `json:"role,omitempty"`
+}
+
+// maxTimelineEntries is the maximum number of state transitions kept
+// in a GameServer's lifecycle timeline
+const maxTimelineEntries = 20
+
+// RecordStateTransition appends the current State to the lifecycle Timeline,
+// if it differs from the most recent entry. Only the most recent transitions
+// are kept, so the timeline doesn't grow unbounded. Should be called whenever
+// Status.State changes, before the GameServer is updated
+func (gss *GameServerStatus) RecordStateTransition() {
+	if n := len(gss.Timeline); n > 0 && gss.Timeline[n-1].State == gss.State {
+		return
+	}
+
+	gss.Timeline = append(gss.Timeline, GameServerStateTransition{State: gss.State, Time: metav1.Now()})
+	if len(gss.Timeline) > maxTimelineEntries {
+		gss.Timeline = gss.Timeline[len(gss.Timeline)-maxTimelineEntries:]
+	}
+}
+
+// TransitionTime returns the time the GameServer most recently entered the
+// given state, as recorded in the Timeline. Returns the zero Time if no
+// transition to that state has been recorded
+func (gss GameServerStatus) TransitionTime(state GameServerState) metav1.Time {
+	for i := len(gss.Timeline) - 1; i >= 0; i-- {
+		if gss.Timeline[i].State == state {
+			return gss.Timeline[i].Time
+		}
+	}
+	return metav1.Time{}
+}
+
+// ReadyAt returns the time the GameServer most recently became Ready
+func (gss GameServerStatus) ReadyAt() metav1.Time {
+	return gss.TransitionTime(GameServerStateReady)
+}
+
+// AllocatedAt returns the time the GameServer most recently became Allocated
+func (gss GameServerStatus) AllocatedAt() metav1.Time {
+	return gss.TransitionTime(GameServerStateAllocated)
+}
+
+// Condition returns the condition of the given type, or nil if it has
+// not been recorded
+func (gss GameServerStatus) Condition(t GameServerConditionType) *GameServerCondition {
+	for i := range gss.Conditions {
+		if gss.Conditions[i].Type == t {
+			return &gss.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition records the given condition, maintaining LastTransitionTime
+// across updates that don't change the Status. Returns true if the condition's
+// Status, Reason or Message changed, and the GameServer should be updated
+func (gss *GameServerStatus) SetCondition(condition GameServerCondition) bool {
+	current := gss.Condition(condition.Type)
+	if current == nil {
+		condition.LastTransitionTime = condition.LastProbeTime
+		gss.Conditions = append(gss.Conditions, condition)
+		return true
+	}
+
+	if current.Status == condition.Status {
+		condition.LastTransitionTime = current.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = condition.LastProbeTime
+	}
+
+	changed := current.Status != condition.Status ||
+		current.Reason != condition.Reason ||
+		current.Message != condition.Message
+	*current = condition
+
+	return changed
 }
 
 // ApplyDefaults applies default values to the GameServer if they are not already populated
@@ -192,6 +665,11 @@ func (gs *GameServer) ApplyDefaults() {
 	gs.applyStateDefaults()
 	gs.applyHealthDefaults()
 	gs.applySchedulingDefaults()
+	gs.applyShutdownDefaults()
+	gs.applyEvictionDefaults()
+	gs.applySdkServerDefaults()
+	gs.applyQueryResponderDefaults()
+	gs.applyArchitectureDefaults()
 }
 
 // applyContainerDefaults applues the container defaults
@@ -220,9 +698,10 @@ func (gs *GameServer) applyHealthDefaults() {
 func (gs *GameServer) applyStateDefaults() {
 	if gs.Status.State == "" {
 		gs.Status.State = GameServerStateCreating
-		if gs.HasPortPolicy(Dynamic) {
+		if gs.HasPortPolicy(Dynamic) || gs.HasPortPolicy(Passthrough) {
 			gs.Status.State = GameServerStatePortAllocation
 		}
+		gs.Status.RecordStateTransition()
 	}
 }
 
@@ -246,6 +725,110 @@ func (gs *GameServer) applySchedulingDefaults() {
 	}
 }
 
+// applyShutdownDefaults applies shutdown policy defaults
+func (gs *GameServer) applyShutdownDefaults() {
+	if gs.Spec.Shutdown.Policy == "" {
+		gs.Spec.Shutdown.Policy = ShutdownPolicyDelete
+	}
+	if gs.Spec.Shutdown.Policy == ShutdownPolicyRetain && gs.Spec.Shutdown.RetainSeconds <= 0 {
+		gs.Spec.Shutdown.RetainSeconds = 600
+	}
+	if gs.Spec.Shutdown.Notification == "" {
+		gs.Spec.Shutdown.Notification = ShutdownNotificationSignal
+	}
+}
+
+// applySdkServerDefaults applies the SDK sidecar defaults
+func (gs *GameServer) applySdkServerDefaults() {
+	if gs.Spec.SdkServer.LogLevel == "" {
+		gs.Spec.SdkServer.LogLevel = SdkServerLogLevelInfo
+	}
+	if gs.Spec.SdkServer.GRPCPort == 0 {
+		gs.Spec.SdkServer.GRPCPort = 59357
+	}
+	if gs.Spec.SdkServer.HTTPPort == 0 {
+		gs.Spec.SdkServer.HTTPPort = 59358
+	}
+}
+
+// applyQueryResponderDefaults applies the query responder sidecar defaults
+func (gs *GameServer) applyQueryResponderDefaults() {
+	if gs.Spec.QueryResponder == nil {
+		return
+	}
+	if gs.Spec.QueryResponder.Protocol == "" {
+		gs.Spec.QueryResponder.Protocol = QueryProtocolA2S
+	}
+	if gs.Spec.QueryResponder.Port == 0 {
+		gs.Spec.QueryResponder.Port = defaultQueryPort
+	}
+}
+
+// applyEvictionDefaults protects Packed GameServers from cluster
+// autoscaler eviction, unless an explicit policy has been chosen
+func (gs *GameServer) applyEvictionDefaults() {
+	if gs.Spec.Eviction == "" && gs.Spec.Scheduling == Packed {
+		gs.Spec.Eviction = EvictionNever
+	}
+}
+
+// TolerateEviction returns true if a deletion of this GameServer's Pod -
+// such as an autoscaler eviction, or a node drain during a cluster
+// upgrade - should be treated as a graceful shutdown rather than a
+// health failure
+func (gs *GameServer) TolerateEviction() bool {
+	return gs.Spec.Eviction == EvictionOnUpgrade || gs.Spec.Eviction == EvictionAlways
+}
+
+// DeletionCost returns the value of the GameServerDeletionCostAnnotation on
+// this GameServer, or 0 if the annotation is not set or not a valid integer
+func (gs *GameServer) DeletionCost() int64 {
+	value, ok := gs.ObjectMeta.Annotations[GameServerDeletionCostAnnotation]
+	if !ok {
+		return 0
+	}
+	cost, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return cost
+}
+
+// applyArchitectureDefaults pins a multi-architecture GameServer to the first
+// declared architecture, if it has not been pinned already
+func (gs *GameServer) applyArchitectureDefaults() {
+	if len(gs.Spec.Architectures) > 0 && gs.Architecture() == "" {
+		gs.PinArchitecture(gs.Spec.Architectures[0].Arch)
+	}
+}
+
+// Architecture returns the node architecture this GameServer is pinned to
+// through the node selector on its Pod template, or "" if it is not pinned
+func (gs *GameServer) Architecture() string {
+	return gs.Spec.Template.Spec.NodeSelector[NodeArchitectureLabel]
+}
+
+// PinArchitecture pins this GameServer to the given node architecture, setting
+// the architecture node selector on the Pod template, and switching the game
+// server container to the image declared for that architecture
+func (gs *GameServer) PinArchitecture(arch string) {
+	if gs.Spec.Template.Spec.NodeSelector == nil {
+		gs.Spec.Template.Spec.NodeSelector = map[string]string{}
+	}
+	gs.Spec.Template.Spec.NodeSelector[NodeArchitectureLabel] = arch
+
+	for _, a := range gs.Spec.Architectures {
+		if a.Arch != arch {
+			continue
+		}
+		for i, c := range gs.Spec.Template.Spec.Containers {
+			if c.Name == gs.Spec.Container {
+				gs.Spec.Template.Spec.Containers[i].Image = a.Image
+			}
+		}
+	}
+}
+
 // Validate validates the GameServer configuration.
 // If a GameServer is invalid there will be > 0 values in
 // the returned array
@@ -290,13 +873,37 @@ func (gs *GameServer) Validate() (bool, []metav1.StatusCause) {
 			})
 		}
 
-		// no host port when using dynamic PortPolicy
+		// no host port when using dynamic or passthrough PortPolicy,
+		// and no container port with passthrough, since both are allocated
 		for _, p := range gs.Spec.Ports {
-			if p.HostPort > 0 && p.PortPolicy == Dynamic {
+			if p.HostPort > 0 && (p.PortPolicy == Dynamic || p.PortPolicy == Passthrough) {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
 					Field:   fmt.Sprintf("%s.hostPort", p.Name),
-					Message: "HostPort cannot be specified with a Dynamic PortPolicy",
+					Message: fmt.Sprintf("HostPort cannot be specified with a %s PortPolicy", p.PortPolicy),
+				})
+			}
+			if p.ContainerPort > 0 && p.PortPolicy == Passthrough {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Field:   fmt.Sprintf("%s.containerPort", p.Name),
+					Message: "ContainerPort cannot be specified with a Passthrough PortPolicy",
+				})
+			}
+			if p.Range > 1 && p.PortPolicy != Dynamic {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Field:   fmt.Sprintf("%s.range", p.Name),
+					Message: "Range is only supported with a Dynamic PortPolicy",
+				})
+			}
+			switch p.Role {
+			case "", PortRoleGame, PortRoleQuery, PortRoleSpectator, PortRoleMetrics:
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Field:   fmt.Sprintf("%s.role", p.Name),
+					Message: fmt.Sprintf("Role must be one of %s, %s, %s or %s", PortRoleGame, PortRoleQuery, PortRoleSpectator, PortRoleMetrics),
 				})
 			}
 		}
@@ -312,15 +919,262 @@ func (gs *GameServer) Validate() (bool, []metav1.StatusCause) {
 		}
 	}
 
+	// only Service types that can expose traffic outside the cluster make sense
+	if gs.Spec.ServiceType != "" &&
+		gs.Spec.ServiceType != corev1.ServiceTypeLoadBalancer && gs.Spec.ServiceType != corev1.ServiceTypeNodePort {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "serviceType",
+			Message: fmt.Sprintf("Value '%s' must be LoadBalancer or NodePort", gs.Spec.ServiceType),
+		})
+	}
+
+	if qr := gs.Spec.QueryResponder; qr != nil {
+		if qr.Protocol != "" && qr.Protocol != QueryProtocolA2S {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "queryResponder.protocol",
+				Message: fmt.Sprintf("Value '%s' must be %s", qr.Protocol, QueryProtocolA2S),
+			})
+		}
+		if qr.Port < 0 || qr.Port > 65535 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "queryResponder.port",
+				Message: "Port must be between 1 and 65535",
+			})
+		}
+	}
+
+	if gs.Spec.Shutdown.Policy != "" &&
+		gs.Spec.Shutdown.Policy != ShutdownPolicyDelete && gs.Spec.Shutdown.Policy != ShutdownPolicyRetain {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "shutdown.policy",
+			Message: fmt.Sprintf("Value '%s' must be Delete or Retain", gs.Spec.Shutdown.Policy),
+		})
+	}
+
+	if n := gs.Spec.Shutdown.Notification; n != "" &&
+		n != ShutdownNotificationSignal && n != ShutdownNotificationSDK && n != ShutdownNotificationExec {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "shutdown.notification",
+			Message: fmt.Sprintf("Value '%s' must be Signal, SDK or Exec", n),
+		})
+	}
+	if gs.Spec.Shutdown.Notification == ShutdownNotificationExec && len(gs.Spec.Shutdown.ExecCommand) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   "shutdown.execCommand",
+			Message: "ExecCommand is required with an Exec shutdown notification",
+		})
+	}
+	if len(gs.Spec.Shutdown.ExecCommand) > 0 && gs.Spec.Shutdown.Notification != ShutdownNotificationExec {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "shutdown.execCommand",
+			Message: "ExecCommand can only be set with an Exec shutdown notification",
+		})
+	}
+
+	if gs.Spec.Eviction != "" &&
+		gs.Spec.Eviction != EvictionNever && gs.Spec.Eviction != EvictionOnUpgrade && gs.Spec.Eviction != EvictionAlways {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "eviction",
+			Message: fmt.Sprintf("Value '%s' must be Never, OnUpgrade or Always", gs.Spec.Eviction),
+		})
+	}
+
+	if lvl := gs.Spec.SdkServer.LogLevel; lvl != "" &&
+		lvl != SdkServerLogLevelInfo && lvl != SdkServerLogLevelDebug && lvl != SdkServerLogLevelError {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "sdkServer.logLevel",
+			Message: fmt.Sprintf("Value '%s' must be Info, Debug or Error", lvl),
+		})
+	}
+	if gs.Spec.SdkServer.GRPCPort != 0 && gs.Spec.SdkServer.GRPCPort == gs.Spec.SdkServer.HTTPPort {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "sdkServer.httpPort",
+			Message: "GRPCPort and HTTPPort cannot be the same port",
+		})
+	}
+
+	// declared architectures need an arch and an image, and no duplicates;
+	// if the Pod template pins an architecture, it must be declared
+	if len(gs.Spec.Architectures) > 0 {
+		declared := map[string]bool{}
+		for i, a := range gs.Spec.Architectures {
+			if a.Arch == "" || a.Image == "" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueRequired,
+					Field:   fmt.Sprintf("architectures[%d]", i),
+					Message: "Arch and Image are required",
+				})
+				continue
+			}
+			if declared[a.Arch] {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueDuplicate,
+					Field:   fmt.Sprintf("architectures[%d]", i),
+					Message: fmt.Sprintf("Architecture '%s' is declared more than once", a.Arch),
+				})
+			}
+			declared[a.Arch] = true
+		}
+		if arch := gs.Architecture(); arch != "" && !declared[arch] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "architectures",
+				Message: fmt.Sprintf("Pod template is pinned to architecture '%s', which is not declared", arch),
+			})
+		}
+	}
+
+	// counters and lists may not start over their declared capacity
+	for name, counter := range gs.Spec.Counters {
+		if counter.Count < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("counters.%s.count", name),
+				Message: "Count cannot be negative",
+			})
+		}
+		if counter.Capacity < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("counters.%s.capacity", name),
+				Message: "Capacity cannot be negative",
+			})
+		}
+		if counter.Capacity > 0 && counter.Count > counter.Capacity {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("counters.%s.count", name),
+				Message: fmt.Sprintf("Count must be less than or equal to Capacity %d", counter.Capacity),
+			})
+		}
+	}
+	for name, list := range gs.Spec.Lists {
+		if list.Capacity < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("lists.%s.capacity", name),
+				Message: "Capacity cannot be negative",
+			})
+		}
+		if list.Capacity > 0 && int64(len(list.Values)) > list.Capacity {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("lists.%s.values", name),
+				Message: fmt.Sprintf("Values must hold no more than Capacity %d entries", list.Capacity),
+			})
+		}
+	}
+
+	// make sure declared bandwidth expectations parse as quantities
+	if gs.Spec.Network.IngressBandwidth != "" {
+		if _, err := resource.ParseQuantity(gs.Spec.Network.IngressBandwidth); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "network.ingressBandwidth",
+				Message: fmt.Sprintf("Value '%s' must be a valid resource quantity", gs.Spec.Network.IngressBandwidth),
+			})
+		}
+	}
+	if gs.Spec.Network.EgressBandwidth != "" {
+		if _, err := resource.ParseQuantity(gs.Spec.Network.EgressBandwidth); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "network.egressBandwidth",
+				Message: fmt.Sprintf("Value '%s' must be a valid resource quantity", gs.Spec.Network.EgressBandwidth),
+			})
+		}
+	}
+
+	causes = append(causes, gs.validatePodDNS()...)
+
 	return len(causes) == 0, causes
 }
 
+// validatePodDNS checks the Pod template's DNS policy and host aliases,
+// rejecting combinations that Kubernetes accepts but that leave the pod
+// unable to resolve what the game server expects
+func (gs *GameServer) validatePodDNS() []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	podSpec := gs.Spec.Template.Spec
+
+	switch podSpec.DNSPolicy {
+	case "", corev1.DNSClusterFirst, corev1.DNSDefault:
+	case corev1.DNSClusterFirstWithHostNet:
+		if !podSpec.HostNetwork {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "template.spec.dnsPolicy",
+				Message: "ClusterFirstWithHostNet DNSPolicy requires HostNetwork to be enabled",
+			})
+		}
+	case corev1.DNSNone:
+		if podSpec.DNSConfig == nil || len(podSpec.DNSConfig.Nameservers) == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   "template.spec.dnsConfig",
+				Message: "None DNSPolicy requires a DNSConfig with at least one nameserver",
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "template.spec.dnsPolicy",
+			Message: fmt.Sprintf("Value '%s' must be ClusterFirst, ClusterFirstWithHostNet, Default or None", podSpec.DNSPolicy),
+		})
+	}
+
+	// with host networking, ClusterFirst silently falls back to the node's
+	// DNS, which is rarely what was intended
+	if podSpec.HostNetwork && podSpec.DNSPolicy == corev1.DNSClusterFirst {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "template.spec.dnsPolicy",
+			Message: "ClusterFirst DNSPolicy is ignored with HostNetwork enabled. Use ClusterFirstWithHostNet instead",
+		})
+	}
+
+	for i, alias := range podSpec.HostAliases {
+		if net.ParseIP(alias.IP) == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("template.spec.hostAliases[%d].ip", i),
+				Message: fmt.Sprintf("Value '%s' must be a valid IP address", alias.IP),
+			})
+		}
+		if len(alias.Hostnames) == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   fmt.Sprintf("template.spec.hostAliases[%d].hostnames", i),
+				Message: "At least one hostname is required",
+			})
+		}
+	}
+
+	return causes
+}
+
 // GetDevAddress returns the address for game server.
 func (gs *GameServer) GetDevAddress() (string, bool) {
 	devAddress, hasDevAddress := gs.ObjectMeta.Annotations[DevAddressAnnotation]
 	return devAddress, hasDevAddress
 }
 
+// IsUnmanaged returns whether this GameServer is marked as externally
+// managed, and should be left alone by GameServerSets
+func (gs *GameServer) IsUnmanaged() bool {
+	return gs.ObjectMeta.Annotations[UnmanagedAnnotation] == "true"
+}
+
 // FindGameServerContainer returns the container that is specified in
 // spec.gameServer.container. Returns the index and the value.
 // Returns an error if not found
@@ -355,13 +1209,36 @@ func (gs *GameServer) Pod(sidecars ...corev1.Container) (*corev1.Pod, error) {
 	}
 
 	for _, p := range gs.Spec.Ports {
-		cp := corev1.ContainerPort{
-			ContainerPort: p.ContainerPort,
-			HostPort:      p.HostPort,
-			Protocol:      p.Protocol,
+		// a port entry with a Range opens a container port
+		// for each host port of the block
+		for offset := int32(0); offset < p.BlockSize(); offset++ {
+			cp := corev1.ContainerPort{
+				ContainerPort: p.ContainerPort + offset,
+				HostPort:      p.HostPort + offset,
+				Protocol:      p.Protocol,
+			}
+			if p.PortPolicy == Passthrough {
+				cp.ContainerPort = cp.HostPort
+			}
+			if p.Protocol == ProtocolTCPUDP {
+				// one allocated port, opened for each protocol
+				cp.Protocol = corev1.ProtocolTCP
+				gsContainer.Ports = append(gsContainer.Ports, cp)
+				cp.Protocol = corev1.ProtocolUDP
+			}
+			gsContainer.Ports = append(gsContainer.Ports, cp)
+		}
+	}
+	// an Exec shutdown notification runs its command in the game server
+	// container before the container runtime sends SIGTERM, so engines
+	// that corrupt saves on a bare signal can flush first. A preStop hook
+	// on the Pod template wins over the generated one
+	if gs.Spec.Shutdown.Notification == ShutdownNotificationExec && gsContainer.Lifecycle == nil {
+		gsContainer.Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.Handler{Exec: &corev1.ExecAction{Command: gs.Spec.Shutdown.ExecCommand}},
 		}
-		gsContainer.Ports = append(gsContainer.Ports, cp)
 	}
+
 	pod.Spec.Containers[i] = gsContainer
 
 	pod.Spec.Containers = append(pod.Spec.Containers, sidecars...)
@@ -396,10 +1273,27 @@ func (gs *GameServer) podObjectMeta(pod *corev1.Pod) {
 	ref := metav1.NewControllerRef(gs, SchemeGroupVersion.WithKind("GameServer"))
 	pod.ObjectMeta.OwnerReferences = append(pod.ObjectMeta.OwnerReferences, *ref)
 
-	if gs.Spec.Scheduling == Packed {
-		// This means that the autoscaler cannot remove the Node that this Pod is on.
+	switch gs.Spec.Eviction {
+	case EvictionAlways:
+		// the autoscaler is free to remove the Node that this Pod is on
 		// (and evict the Pod in the process)
-		pod.ObjectMeta.Annotations["cluster-autoscaler.kubernetes.io/safe-to-evict"] = "false"
+		pod.ObjectMeta.Annotations[SafeToEvictPodAnnotation] = "true"
+	case EvictionNever, EvictionOnUpgrade:
+		// the autoscaler cannot remove the Node that this Pod is on
+		pod.ObjectMeta.Annotations[SafeToEvictPodAnnotation] = "false"
+	default:
+		// no explicit policy, so fall back on protecting Packed GameServers
+		if gs.Spec.Scheduling == Packed {
+			pod.ObjectMeta.Annotations[SafeToEvictPodAnnotation] = "false"
+		}
+	}
+
+	// pass the declared bandwidth expectations through to the CNI
+	if gs.Spec.Network.IngressBandwidth != "" {
+		pod.ObjectMeta.Annotations[IngressBandwidthPodAnnotation] = gs.Spec.Network.IngressBandwidth
+	}
+	if gs.Spec.Network.EgressBandwidth != "" {
+		pod.ObjectMeta.Annotations[EgressBandwidthPodAnnotation] = gs.Spec.Network.EgressBandwidth
 	}
 
 	// Add Agones version into Pod Annotations
@@ -413,28 +1307,55 @@ func (gs *GameServer) podObjectMeta(pod *corev1.Pod) {
 }
 
 // podScheduling applies the Fleet scheduling strategy to the passed in Pod
-// this sets the a PreferredDuringSchedulingIgnoredDuringExecution for GameServer
-// pods to a host topology. Basically doing a half decent job of packing GameServer
-// pods together.
+// looking up the registered Strategy for the GameServer's scheduling
+// strategy, and applying its Pod scheduling hook, if there is one
 func (gs *GameServer) podScheduling(pod *corev1.Pod) {
-	if gs.Spec.Scheduling == Packed {
-		if pod.Spec.Affinity == nil {
-			pod.Spec.Affinity = &corev1.Affinity{}
-		}
-		if pod.Spec.Affinity.PodAffinity == nil {
-			pod.Spec.Affinity.PodAffinity = &corev1.PodAffinity{}
-		}
+	if strategy, ok := GetSchedulingStrategy(gs.Spec.Scheduling); ok && strategy.PodScheduling != nil {
+		strategy.PodScheduling(gs, pod)
+	}
+}
 
-		wpat := corev1.WeightedPodAffinityTerm{
-			Weight: 100,
-			PodAffinityTerm: corev1.PodAffinityTerm{
-				TopologyKey:   "kubernetes.io/hostname",
-				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{RoleLabel: GameServerLabelRole}},
-			},
-		}
+// Service creates a new Service of the configured ServiceType, fronting
+// this GameServer's ports. Returns nil if no ServiceType is set
+func (gs *GameServer) Service() *corev1.Service {
+	if gs.Spec.ServiceType == "" {
+		return nil
+	}
 
-		pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, wpat)
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gs.ObjectMeta.Name,
+			Namespace: gs.ObjectMeta.Namespace,
+			Labels:    map[string]string{GameServerPodLabel: gs.ObjectMeta.Name},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     gs.Spec.ServiceType,
+			Selector: map[string]string{GameServerPodLabel: gs.ObjectMeta.Name},
+		},
 	}
+
+	ref := metav1.NewControllerRef(gs, SchemeGroupVersion.WithKind("GameServer"))
+	svc.ObjectMeta.OwnerReferences = append(svc.ObjectMeta.OwnerReferences, *ref)
+
+	for _, p := range gs.Spec.Ports {
+		sp := corev1.ServicePort{
+			Name:       p.Name,
+			Port:       p.ContainerPort,
+			TargetPort: intstr.FromInt(int(p.ContainerPort)),
+			Protocol:   p.Protocol,
+		}
+		if p.Protocol == ProtocolTCPUDP {
+			// Services require distinct names per protocol
+			sp.Name = p.Name + "-tcp"
+			sp.Protocol = corev1.ProtocolTCP
+			svc.Spec.Ports = append(svc.Spec.Ports, sp)
+			sp.Name = p.Name + "-udp"
+			sp.Protocol = corev1.ProtocolUDP
+		}
+		svc.Spec.Ports = append(svc.Spec.Ports, sp)
+	}
+
+	return svc
 }
 
 // HasPortPolicy checks if there is a port with a given
@@ -450,7 +1371,16 @@ func (gs *GameServer) HasPortPolicy(policy PortPolicy) bool {
 
 // Status returns a GameServerSatusPort for this GameServerPort
 func (p GameServerPort) Status() GameServerStatusPort {
-	return GameServerStatusPort{Name: p.Name, Port: p.HostPort}
+	return GameServerStatusPort{Name: p.Name, Port: p.HostPort, Range: p.Range, Role: p.Role}
+}
+
+// BlockSize returns the number of contiguous host ports
+// this entry occupies
+func (p GameServerPort) BlockSize() int32 {
+	if p.Range > 1 {
+		return p.Range
+	}
+	return 1
 }
 
 // CountPorts returns the number of
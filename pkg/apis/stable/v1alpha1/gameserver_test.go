@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"agones.dev/agones/pkg/apis/stable"
 	"github.com/stretchr/testify/assert"
@@ -149,6 +150,27 @@ func TestGameServerApplyDefaults(t *testing.T) {
 				},
 			},
 		},
+		"set basic defaults on a passthrough gameserver": {
+			gameServer: GameServer{
+				Spec: GameServerSpec{
+					Ports: []GameServerPort{{PortPolicy: Passthrough}},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+			},
+			container: "testing",
+			expected: expected{
+				protocol:   "UDP",
+				state:      GameServerStatePortAllocation,
+				policy:     Passthrough,
+				scheduling: Packed,
+				health: Health{
+					Disabled:            false,
+					FailureThreshold:    3,
+					InitialDelaySeconds: 5,
+					PeriodSeconds:       5,
+				},
+			},
+		},
 		"health is disabled": {
 			gameServer: GameServer{
 				Spec: GameServerSpec{
@@ -264,6 +286,347 @@ func TestGameServerValidate(t *testing.T) {
 	assert.Contains(t, fields, fmt.Sprintf("annotations.%s", DevAddressAnnotation))
 	assert.Contains(t, fields, "main.hostPort")
 	assert.Equal(t, causes[1].Type, metav1.CauseTypeFieldValueRequired)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Ports: []GameServerPort{{
+				Name:          "main",
+				ContainerPort: 7777,
+				HostPort:      5001,
+				PortPolicy:    Passthrough,
+			}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	fields = nil
+	for _, f := range causes {
+		fields = append(fields, f.Field)
+	}
+	assert.False(t, ok)
+	assert.Len(t, causes, 2)
+	assert.Contains(t, fields, "main.hostPort")
+	assert.Contains(t, fields, "main.containerPort")
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Ports: []GameServerPort{{
+				Name:          "main",
+				ContainerPort: 7777,
+				HostPort:      5001,
+				PortPolicy:    Static,
+				Range:         4,
+			}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "main.range", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Network: NetworkSpec{IngressBandwidth: "10M", EgressBandwidth: "not-a-quantity"},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "network.egressBandwidth", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Shutdown: ShutdownSpec{Policy: "KeepForever"},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "shutdown.policy", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Counters: map[string]CounterStatus{"rooms": {Count: 5, Capacity: 3}},
+			Lists:    map[string]ListStatus{"matches": {Capacity: 1, Values: []string{"one", "two"}}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	fields = nil
+	for _, f := range causes {
+		fields = append(fields, f.Field)
+	}
+	assert.False(t, ok)
+	assert.Len(t, causes, 2)
+	assert.Contains(t, fields, "counters.rooms.count")
+	assert.Contains(t, fields, "lists.matches.values")
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Eviction: "Sometimes",
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "eviction", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			SdkServer: SdkServerSpec{LogLevel: "Verbose", GRPCPort: 9357, HTTPPort: 9357},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	fields = nil
+	for _, f := range causes {
+		fields = append(fields, f.Field)
+	}
+	assert.False(t, ok)
+	assert.Len(t, causes, 2)
+	assert.Contains(t, fields, "sdkServer.logLevel")
+	assert.Contains(t, fields, "sdkServer.httpPort")
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Shutdown: ShutdownSpec{Notification: ShutdownNotificationExec},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "shutdown.execCommand", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Shutdown: ShutdownSpec{Notification: ShutdownNotificationSDK, ExecCommand: []string{"/bin/save"}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "shutdown.execCommand", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					HostNetwork: true,
+					DNSPolicy:   corev1.DNSClusterFirst,
+					Containers:  []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "template.spec.dnsPolicy", causes[0].Field)
+
+	gs.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	ok, causes = gs.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gs.Spec.Template.Spec.HostNetwork = false
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "template.spec.dnsPolicy", causes[0].Field)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					DNSPolicy:  corev1.DNSNone,
+					Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "template.spec.dnsConfig", causes[0].Field)
+
+	gs.Spec.Template.Spec.DNSConfig = &corev1.PodDNSConfig{Nameservers: []string{"8.8.8.8"}}
+	ok, causes = gs.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gs = GameServer{
+		Spec: GameServerSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					DNSPolicy: "Sometimes",
+					HostAliases: []corev1.HostAlias{
+						{IP: "not-an-ip", Hostnames: []string{"game.example"}},
+						{IP: "10.0.0.1"},
+					},
+					Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+	}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	fields = nil
+	for _, f := range causes {
+		fields = append(fields, f.Field)
+	}
+	assert.False(t, ok)
+	assert.Len(t, causes, 3)
+	assert.Contains(t, fields, "template.spec.dnsPolicy")
+	assert.Contains(t, fields, "template.spec.hostAliases[0].ip")
+	assert.Contains(t, fields, "template.spec.hostAliases[1].hostnames")
+}
+
+func TestGameServerApplyShutdownDefaults(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{}
+	gs.applyShutdownDefaults()
+	assert.Equal(t, ShutdownPolicyDelete, gs.Spec.Shutdown.Policy)
+	assert.Equal(t, int32(0), gs.Spec.Shutdown.RetainSeconds)
+	assert.Equal(t, ShutdownNotificationSignal, gs.Spec.Shutdown.Notification)
+
+	gs = &GameServer{Spec: GameServerSpec{Shutdown: ShutdownSpec{
+		Notification: ShutdownNotificationExec, ExecCommand: []string{"/bin/save"}}}}
+	gs.applyShutdownDefaults()
+	assert.Equal(t, ShutdownNotificationExec, gs.Spec.Shutdown.Notification)
+
+	gs = &GameServer{Spec: GameServerSpec{Shutdown: ShutdownSpec{Policy: ShutdownPolicyRetain}}}
+	gs.applyShutdownDefaults()
+	assert.Equal(t, ShutdownPolicyRetain, gs.Spec.Shutdown.Policy)
+	assert.Equal(t, int32(600), gs.Spec.Shutdown.RetainSeconds)
+
+	gs = &GameServer{Spec: GameServerSpec{Shutdown: ShutdownSpec{Policy: ShutdownPolicyRetain, RetainSeconds: 30}}}
+	gs.applyShutdownDefaults()
+	assert.Equal(t, int32(30), gs.Spec.Shutdown.RetainSeconds)
+}
+
+func TestGameServerDeletionCost(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{}
+	assert.Equal(t, int64(0), gs.DeletionCost())
+
+	gs.ObjectMeta.Annotations = map[string]string{GameServerDeletionCostAnnotation: "1000"}
+	assert.Equal(t, int64(1000), gs.DeletionCost())
+
+	gs.ObjectMeta.Annotations[GameServerDeletionCostAnnotation] = "-10"
+	assert.Equal(t, int64(-10), gs.DeletionCost())
+
+	gs.ObjectMeta.Annotations[GameServerDeletionCostAnnotation] = "not-a-number"
+	assert.Equal(t, int64(0), gs.DeletionCost())
+}
+
+func TestGameServerApplyEvictionDefaults(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{Spec: GameServerSpec{Scheduling: Packed}}
+	gs.applyEvictionDefaults()
+	assert.Equal(t, EvictionNever, gs.Spec.Eviction)
+	assert.False(t, gs.TolerateEviction())
+
+	gs = &GameServer{Spec: GameServerSpec{Scheduling: Distributed}}
+	gs.applyEvictionDefaults()
+	assert.Equal(t, EvictionPolicy(""), gs.Spec.Eviction)
+	assert.False(t, gs.TolerateEviction())
+
+	gs = &GameServer{Spec: GameServerSpec{Scheduling: Packed, Eviction: EvictionAlways}}
+	gs.applyEvictionDefaults()
+	assert.Equal(t, EvictionAlways, gs.Spec.Eviction)
+	assert.True(t, gs.TolerateEviction())
+
+	gs = &GameServer{Spec: GameServerSpec{Eviction: EvictionOnUpgrade}}
+	assert.True(t, gs.TolerateEviction())
+}
+
+func TestGameServerApplySdkServerDefaults(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{}
+	gs.applySdkServerDefaults()
+	assert.Equal(t, SdkServerLogLevelInfo, gs.Spec.SdkServer.LogLevel)
+	assert.Equal(t, int32(59357), gs.Spec.SdkServer.GRPCPort)
+	assert.Equal(t, int32(59358), gs.Spec.SdkServer.HTTPPort)
+
+	gs = &GameServer{Spec: GameServerSpec{SdkServer: SdkServerSpec{
+		LogLevel: SdkServerLogLevelDebug, GRPCPort: 9357, HTTPPort: 9358}}}
+	gs.applySdkServerDefaults()
+	assert.Equal(t, SdkServerLogLevelDebug, gs.Spec.SdkServer.LogLevel)
+	assert.Equal(t, int32(9357), gs.Spec.SdkServer.GRPCPort)
+	assert.Equal(t, int32(9358), gs.Spec.SdkServer.HTTPPort)
+}
+
+func TestGameServerPinArchitecture(t *testing.T) {
+	t.Parallel()
+
+	fixture := func() *GameServer {
+		return &GameServer{
+			Spec: GameServerSpec{
+				Architectures: []ArchitectureSpec{
+					{Arch: "amd64", Image: "testing/image:amd64"},
+					{Arch: "arm64", Image: "testing/image:arm64"},
+				},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+		}
+	}
+
+	gs := fixture()
+	gs.ApplyDefaults()
+	assert.Equal(t, "amd64", gs.Architecture())
+	assert.Equal(t, "amd64", gs.Spec.Template.Spec.NodeSelector[NodeArchitectureLabel])
+	assert.Equal(t, "testing/image:amd64", gs.Spec.Template.Spec.Containers[0].Image)
+	ok, causes := gs.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gs = fixture()
+	gs.Spec.Template.Spec.Containers = append(gs.Spec.Template.Spec.Containers,
+		corev1.Container{Name: "sidecar", Image: "testing/sidecar"})
+	gs.Spec.Container = "testing"
+	gs.PinArchitecture("arm64")
+	assert.Equal(t, "arm64", gs.Architecture())
+	assert.Equal(t, "testing/image:arm64", gs.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "testing/sidecar", gs.Spec.Template.Spec.Containers[1].Image)
+
+	gs = fixture()
+	gs.Spec.Architectures[1] = ArchitectureSpec{Arch: "arm64"}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, metav1.CauseTypeFieldValueRequired, causes[0].Type)
+	assert.Equal(t, "architectures[1]", causes[0].Field)
+
+	gs = fixture()
+	gs.Spec.Architectures[1] = ArchitectureSpec{Arch: "amd64", Image: "testing/image:other"}
+	gs.ApplyDefaults()
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, metav1.CauseTypeFieldValueDuplicate, causes[0].Type)
+	assert.Equal(t, "architectures[1]", causes[0].Field)
+
+	gs = fixture()
+	gs.ApplyDefaults()
+	gs.Spec.Template.Spec.NodeSelector[NodeArchitectureLabel] = "s390x"
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, metav1.CauseTypeFieldValueInvalid, causes[0].Type)
+	assert.Equal(t, "architectures", causes[0].Field)
 }
 
 func TestGameServerPod(t *testing.T) {
@@ -298,6 +661,38 @@ func TestGameServerPod(t *testing.T) {
 	assert.Equal(t, corev1.Protocol("UDP"), pod.Spec.Containers[0].Ports[0].Protocol)
 	assert.True(t, metav1.IsControlledBy(pod, fixture))
 
+	// a passthrough port exposes the allocated host port as the container port
+	passthrough := fixture.DeepCopy()
+	passthrough.Spec.Ports = []GameServerPort{{HostPort: 9999, PortPolicy: Passthrough, Protocol: corev1.ProtocolUDP}}
+	pod, err = passthrough.Pod()
+	assert.Nil(t, err, "Pod should not return an error")
+	assert.Equal(t, int32(9999), pod.Spec.Containers[0].Ports[0].HostPort)
+	assert.Equal(t, int32(9999), pod.Spec.Containers[0].Ports[0].ContainerPort)
+
+	// a port entry with a range opens a container port per host port of the block
+	ranged := fixture.DeepCopy()
+	ranged.Spec.Ports = []GameServerPort{{ContainerPort: 7000, HostPort: 8000, PortPolicy: Dynamic, Protocol: corev1.ProtocolUDP, Range: 3}}
+	pod, err = ranged.Pod()
+	assert.Nil(t, err, "Pod should not return an error")
+	assert.Len(t, pod.Spec.Containers[0].Ports, 3)
+	for i, cp := range pod.Spec.Containers[0].Ports {
+		assert.Equal(t, int32(7000+i), cp.ContainerPort)
+		assert.Equal(t, int32(8000+i), cp.HostPort)
+	}
+
+	// a TCPUDP port is opened on the container for each protocol
+	tcpudp := fixture.DeepCopy()
+	tcpudp.Spec.Ports = []GameServerPort{{ContainerPort: 7777, HostPort: 9999, PortPolicy: Static, Protocol: ProtocolTCPUDP}}
+	pod, err = tcpudp.Pod()
+	assert.Nil(t, err, "Pod should not return an error")
+	assert.Len(t, pod.Spec.Containers[0].Ports, 2)
+	assert.Equal(t, corev1.ProtocolTCP, pod.Spec.Containers[0].Ports[0].Protocol)
+	assert.Equal(t, corev1.ProtocolUDP, pod.Spec.Containers[0].Ports[1].Protocol)
+	for _, cp := range pod.Spec.Containers[0].Ports {
+		assert.Equal(t, int32(9999), cp.HostPort)
+		assert.Equal(t, int32(7777), cp.ContainerPort)
+	}
+
 	sidecar := corev1.Container{Name: "sidecar", Image: "container/sidecar"}
 	fixture.Spec.Template.Spec.ServiceAccountName = "other-agones-sdk"
 	pod, err = fixture.Pod(sidecar)
@@ -310,6 +705,32 @@ func TestGameServerPod(t *testing.T) {
 	assert.True(t, metav1.IsControlledBy(pod, fixture))
 }
 
+func TestGameServerPodShutdownExec(t *testing.T) {
+	t.Parallel()
+
+	fixture := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "logan", UID: "1234"},
+		Spec: GameServerSpec{
+			Container: "container",
+			Shutdown:  ShutdownSpec{Notification: ShutdownNotificationExec, ExecCommand: []string{"/bin/save", "--flush"}},
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "container", Image: "container/image"}}}},
+		},
+	}
+
+	pod, err := fixture.Pod()
+	assert.Nil(t, err)
+	if assert.NotNil(t, pod.Spec.Containers[0].Lifecycle) {
+		assert.Equal(t, []string{"/bin/save", "--flush"}, pod.Spec.Containers[0].Lifecycle.PreStop.Exec.Command)
+	}
+
+	// a preStop hook on the Pod template wins over the generated one
+	fixture.Spec.Template.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+		PreStop: &corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"/bin/custom"}}}}
+	pod, err = fixture.Pod()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/bin/custom"}, pod.Spec.Containers[0].Lifecycle.PreStop.Exec.Command)
+}
+
 func TestGameServerPodObjectMeta(t *testing.T) {
 	fixture := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "lucy"},
 		Spec: GameServerSpec{Container: "goat"}}
@@ -345,6 +766,42 @@ func TestGameServerPodObjectMeta(t *testing.T) {
 
 		assert.Equal(t, "", pod.ObjectMeta.Annotations["cluster-autoscaler.kubernetes.io/safe-to-evict"])
 	})
+
+	t.Run("eviction always", func(t *testing.T) {
+		gs := fixture.DeepCopy()
+		gs.Spec.Scheduling = Packed
+		gs.Spec.Eviction = EvictionAlways
+		pod := &corev1.Pod{}
+
+		gs.podObjectMeta(pod)
+		f(t, gs, pod)
+
+		assert.Equal(t, "true", pod.ObjectMeta.Annotations[SafeToEvictPodAnnotation])
+	})
+
+	t.Run("eviction on upgrade", func(t *testing.T) {
+		gs := fixture.DeepCopy()
+		gs.Spec.Scheduling = Distributed
+		gs.Spec.Eviction = EvictionOnUpgrade
+		pod := &corev1.Pod{}
+
+		gs.podObjectMeta(pod)
+		f(t, gs, pod)
+
+		assert.Equal(t, "false", pod.ObjectMeta.Annotations[SafeToEvictPodAnnotation])
+	})
+
+	t.Run("network", func(t *testing.T) {
+		gs := fixture.DeepCopy()
+		gs.Spec.Network = NetworkSpec{IngressBandwidth: "10M", EgressBandwidth: "5M"}
+		pod := &corev1.Pod{}
+
+		gs.podObjectMeta(pod)
+		f(t, gs, pod)
+
+		assert.Equal(t, "10M", pod.ObjectMeta.Annotations[IngressBandwidthPodAnnotation])
+		assert.Equal(t, "5M", pod.ObjectMeta.Annotations[EgressBandwidthPodAnnotation])
+	})
 }
 
 func TestGameServerPodScheduling(t *testing.T) {
@@ -370,6 +827,138 @@ func TestGameServerPodScheduling(t *testing.T) {
 	})
 }
 
+func TestGameServerService(t *testing.T) {
+	fixture := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "1234"},
+		Spec: GameServerSpec{
+			Ports: []GameServerPort{{Name: "main", ContainerPort: 7777, HostPort: 9999, PortPolicy: Static, Protocol: corev1.ProtocolUDP}},
+		}}
+
+	assert.Nil(t, fixture.Service(), "no ServiceType, no Service")
+
+	fixture.Spec.ServiceType = corev1.ServiceTypeLoadBalancer
+	svc := fixture.Service()
+	assert.NotNil(t, svc)
+	assert.Equal(t, fixture.ObjectMeta.Name, svc.ObjectMeta.Name)
+	assert.Equal(t, fixture.ObjectMeta.Namespace, svc.ObjectMeta.Namespace)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, fixture.ObjectMeta.Name, svc.Spec.Selector[GameServerPodLabel])
+	assert.True(t, metav1.IsControlledBy(svc, fixture))
+	assert.Len(t, svc.Spec.Ports, 1)
+	assert.Equal(t, "main", svc.Spec.Ports[0].Name)
+	assert.Equal(t, int32(7777), svc.Spec.Ports[0].Port)
+	assert.Equal(t, corev1.ProtocolUDP, svc.Spec.Ports[0].Protocol)
+
+	// a TCPUDP port becomes a service port per protocol
+	fixture.Spec.Ports[0].Protocol = ProtocolTCPUDP
+	svc = fixture.Service()
+	assert.Len(t, svc.Spec.Ports, 2)
+	assert.Equal(t, "main-tcp", svc.Spec.Ports[0].Name)
+	assert.Equal(t, corev1.ProtocolTCP, svc.Spec.Ports[0].Protocol)
+	assert.Equal(t, "main-udp", svc.Spec.Ports[1].Name)
+	assert.Equal(t, corev1.ProtocolUDP, svc.Spec.Ports[1].Protocol)
+	assert.Equal(t, svc.Spec.Ports[0].Port, svc.Spec.Ports[1].Port)
+}
+
+func TestGameServerStatusRecordStateTransition(t *testing.T) {
+	t.Parallel()
+
+	status := GameServerStatus{State: GameServerStateCreating}
+	status.RecordStateTransition()
+	assert.Len(t, status.Timeline, 1)
+	assert.Equal(t, GameServerStateCreating, status.Timeline[0].State)
+
+	// recording the same state again is a noop
+	status.RecordStateTransition()
+	assert.Len(t, status.Timeline, 1)
+
+	status.State = GameServerStateReady
+	status.RecordStateTransition()
+	assert.Len(t, status.Timeline, 2)
+	assert.Equal(t, GameServerStateReady, status.Timeline[1].State)
+
+	// the timeline is capped at the most recent transitions
+	for i := 0; i < 2*maxTimelineEntries; i++ {
+		if i%2 == 0 {
+			status.State = GameServerStateAllocated
+		} else {
+			status.State = GameServerStateReady
+		}
+		status.RecordStateTransition()
+	}
+	assert.Len(t, status.Timeline, maxTimelineEntries)
+	assert.Equal(t, status.State, status.Timeline[maxTimelineEntries-1].State)
+}
+
+func TestGameServerStatusTransitionTime(t *testing.T) {
+	t.Parallel()
+
+	status := GameServerStatus{State: GameServerStateCreating}
+	readyAt := status.ReadyAt()
+	allocatedAt := status.AllocatedAt()
+	assert.True(t, readyAt.IsZero())
+	assert.True(t, allocatedAt.IsZero())
+
+	status.RecordStateTransition()
+	status.State = GameServerStateReady
+	status.RecordStateTransition()
+
+	ready := status.ReadyAt()
+	assert.False(t, ready.IsZero())
+	assert.Equal(t, ready, status.TransitionTime(GameServerStateReady))
+
+	status.State = GameServerStateAllocated
+	status.RecordStateTransition()
+	allocatedAt = status.AllocatedAt()
+	assert.False(t, allocatedAt.IsZero())
+	// the Ready transition time is unchanged
+	assert.Equal(t, ready, status.ReadyAt())
+}
+
+func TestGameServerStatusSetCondition(t *testing.T) {
+	t.Parallel()
+
+	status := GameServerStatus{}
+	assert.Nil(t, status.Condition(GameServerPortsReachable))
+
+	first := metav1.Now()
+	changed := status.SetCondition(GameServerCondition{
+		Type:          GameServerPortsReachable,
+		Status:        corev1.ConditionTrue,
+		Reason:        "ProbeSucceeded",
+		LastProbeTime: first,
+	})
+	assert.True(t, changed)
+	condition := status.Condition(GameServerPortsReachable)
+	assert.NotNil(t, condition)
+	assert.Equal(t, first, condition.LastTransitionTime)
+
+	// re-recording the same status updates the probe time, but is not a change
+	second := metav1.NewTime(first.Add(time.Minute))
+	changed = status.SetCondition(GameServerCondition{
+		Type:          GameServerPortsReachable,
+		Status:        corev1.ConditionTrue,
+		Reason:        "ProbeSucceeded",
+		LastProbeTime: second,
+	})
+	assert.False(t, changed)
+	condition = status.Condition(GameServerPortsReachable)
+	assert.Equal(t, second, condition.LastProbeTime)
+	assert.Equal(t, first, condition.LastTransitionTime)
+
+	// flipping the status is a transition
+	third := metav1.NewTime(first.Add(2 * time.Minute))
+	changed = status.SetCondition(GameServerCondition{
+		Type:          GameServerPortsReachable,
+		Status:        corev1.ConditionFalse,
+		Reason:        "ProbeFailed",
+		LastProbeTime: third,
+	})
+	assert.True(t, changed)
+	condition = status.Condition(GameServerPortsReachable)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, third, condition.LastTransitionTime)
+}
+
 func TestGameServerCountPorts(t *testing.T) {
 	fixture := &GameServer{Spec: GameServerSpec{Ports: []GameServerPort{
 		{PortPolicy: Dynamic},
@@ -382,6 +971,77 @@ func TestGameServerCountPorts(t *testing.T) {
 	assert.Equal(t, 1, fixture.CountPorts(Static))
 }
 
+func TestGameServerPortBlockSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int32(1), GameServerPort{}.BlockSize())
+	assert.Equal(t, int32(1), GameServerPort{Range: 1}.BlockSize())
+	assert.Equal(t, int32(5), GameServerPort{Range: 5}.BlockSize())
+
+	status := GameServerPort{Name: "voice", HostPort: 7000, Range: 5}.Status()
+	assert.Equal(t, int32(7000), status.Port)
+	assert.Equal(t, int32(5), status.Range)
+}
+
+func TestGameServerPortRole(t *testing.T) {
+	t.Parallel()
+
+	status := GameServerPort{Name: "obs", HostPort: 7000, Role: PortRoleSpectator}.Status()
+	assert.Equal(t, PortRoleSpectator, status.Role)
+
+	gs := &GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-game"},
+		Spec: GameServerSpec{
+			Container: "testing",
+			Ports:     []GameServerPort{{Name: "main", PortPolicy: Dynamic, ContainerPort: 7777, Role: PortRoleGame}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}},
+			},
+		},
+	}
+	gs.ApplyDefaults()
+	ok, causes := gs.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gs.Spec.Ports[0].Role = "telemetry"
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 1) {
+		assert.Equal(t, "main.role", causes[0].Field)
+	}
+}
+
+func TestGameServerQueryResponder(t *testing.T) {
+	t.Parallel()
+
+	gs := &GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-game"},
+		Spec: GameServerSpec{
+			Container:      "testing",
+			Ports:          []GameServerPort{{Name: "main", PortPolicy: Dynamic, ContainerPort: 7777}},
+			QueryResponder: &QueryResponderSpec{},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}},
+			},
+		},
+	}
+	gs.ApplyDefaults()
+	assert.Equal(t, QueryProtocolA2S, gs.Spec.QueryResponder.Protocol)
+	assert.Equal(t, defaultQueryPort, gs.Spec.QueryResponder.Port)
+
+	ok, causes := gs.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gs.Spec.QueryResponder.Protocol = "gamespy"
+	ok, causes = gs.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 1) {
+		assert.Equal(t, "queryResponder.protocol", causes[0].Field)
+	}
+}
+
 func TestGameServerPatch(t *testing.T) {
 	fixture := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "lucy"},
 		Spec: GameServerSpec{Container: "goat"}}
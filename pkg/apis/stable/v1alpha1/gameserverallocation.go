@@ -66,9 +66,30 @@ type GameServerAllocationSpec struct {
 	// Scheduling strategy. Defaults to "Packed".
 	Scheduling SchedulingStrategy `json:"scheduling"`
 
+	// Topology is an optional set of Node labels, such as zone or region
+	// labels, that this allocation would prefer its GameServer's Node to have,
+	// so that in multi zone clusters players can be matched to nearby servers.
+	// GameServers on matching Nodes are selected ahead of the scheduling
+	// strategy; if no GameServer is available on a matching Node, the hint
+	// is ignored
+	Topology map[string]string `json:"topology,omitempty"`
+
 	// MetaPatch is optional custom metadata that is added to the game server at allocation
 	// You can use this to tell the server necessary session data
 	MetaPatch MetaPatch `json:"metadata,omitempty"`
+
+	// Backfill, when set, targets this allocation at already Allocated
+	// GameServers with spare player capacity, rather than Ready ones,
+	// returning the same connection details, to implement match backfill
+	Backfill *BackfillSpec `json:"backfill,omitempty"`
+}
+
+// BackfillSpec is the specification for backfilling an allocation
+// onto an already Allocated GameServer
+type BackfillSpec struct {
+	// Players is the number of players this allocation adds to the
+	// GameServer's player count. Defaults to 1
+	Players int64 `json:"players,omitempty"`
 }
 
 // PreferredSelectors converts all the the preferred label selectors into an array of
@@ -95,6 +116,10 @@ type GameServerAllocationStatus struct {
 	Ports          []GameServerStatusPort    `json:"ports,omitempty"`
 	Address        string                    `json:"address,omitempty"`
 	NodeName       string                    `json:"nodeName,omitempty"`
+	// Cluster is the name of the cluster the GameServer was allocated from,
+	// when it was served from a registered secondary cluster. Empty for
+	// allocations from the local cluster
+	Cluster string `json:"cluster,omitempty"`
 }
 
 // ApplyDefaults applies the default values to this GameServerAllocation
@@ -102,6 +127,9 @@ func (gsa *GameServerAllocation) ApplyDefaults() {
 	if gsa.Spec.Scheduling == "" {
 		gsa.Spec.Scheduling = Packed
 	}
+	if gsa.Spec.Backfill != nil && gsa.Spec.Backfill.Players <= 0 {
+		gsa.Spec.Backfill.Players = 1
+	}
 }
 
 // ValidateUpdate validates when an update occurs
@@ -0,0 +1,96 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GameServerDefaultsName is the well known name of the GameServerDefaults
+	// singleton that the mutating webhook looks up in each namespace
+	GameServerDefaultsName = "default"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GameServerDefaults holds the namespace wide default values that are
+// applied to GameServers created in its namespace, before the built in
+// defaults. Cluster operators create one named "default" per namespace to
+// enforce org wide settings without relying on every template being right
+type GameServerDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GameServerDefaultsSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GameServerDefaultsList is a list of GameServerDefaults resources
+type GameServerDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GameServerDefaults `json:"items"`
+}
+
+// GameServerDefaultsSpec is the spec for a GameServerDefaults resource.
+// All fields are optional - only set values are applied, and only to
+// GameServer fields that were left unset
+type GameServerDefaultsSpec struct {
+	// Health is the default health checking configuration
+	Health Health `json:"health,omitempty"`
+	// Scheduling is the default scheduling strategy
+	Scheduling SchedulingStrategy `json:"scheduling,omitempty"`
+	// PortPolicy is the default policy for ports that don't declare one
+	PortPolicy PortPolicy `json:"portPolicy,omitempty"`
+	// SidecarResources are the resource requirements for the SDK sidecar
+	// container, overriding the install wide settings
+	SidecarResources corev1.ResourceRequirements `json:"sidecarResources,omitempty"`
+}
+
+// Apply applies these defaults to the given GameServer, only setting fields
+// that are unset, so values from the GameServer's own spec always win.
+// Should be called before GameServer.ApplyDefaults()
+func (gsd *GameServerDefaults) Apply(gs *GameServer) {
+	if gsd.Spec.Health.Disabled && gs.Spec.Health == (Health{}) {
+		gs.Spec.Health.Disabled = true
+	}
+	if gs.Spec.Health.PeriodSeconds <= 0 && gsd.Spec.Health.PeriodSeconds > 0 {
+		gs.Spec.Health.PeriodSeconds = gsd.Spec.Health.PeriodSeconds
+	}
+	if gs.Spec.Health.FailureThreshold <= 0 && gsd.Spec.Health.FailureThreshold > 0 {
+		gs.Spec.Health.FailureThreshold = gsd.Spec.Health.FailureThreshold
+	}
+	if gs.Spec.Health.InitialDelaySeconds <= 0 && gsd.Spec.Health.InitialDelaySeconds > 0 {
+		gs.Spec.Health.InitialDelaySeconds = gsd.Spec.Health.InitialDelaySeconds
+	}
+
+	if gs.Spec.Scheduling == "" {
+		gs.Spec.Scheduling = gsd.Spec.Scheduling
+	}
+
+	if gsd.Spec.PortPolicy != "" {
+		for i, p := range gs.Spec.Ports {
+			if p.PortPolicy == "" {
+				gs.Spec.Ports[i].PortPolicy = gsd.Spec.PortPolicy
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGameServerDefaultsApply(t *testing.T) {
+	t.Parallel()
+
+	defaults := &GameServerDefaults{
+		ObjectMeta: metav1.ObjectMeta{Name: GameServerDefaultsName, Namespace: "default"},
+		Spec: GameServerDefaultsSpec{
+			Health:     Health{FailureThreshold: 10, PeriodSeconds: 20},
+			Scheduling: Distributed,
+			PortPolicy: Static,
+		},
+	}
+
+	t.Run("unset fields are defaulted", func(t *testing.T) {
+		gs := &GameServer{Spec: GameServerSpec{Ports: []GameServerPort{{ContainerPort: 7777}}}}
+		defaults.Apply(gs)
+
+		assert.Equal(t, int32(10), gs.Spec.Health.FailureThreshold)
+		assert.Equal(t, int32(20), gs.Spec.Health.PeriodSeconds)
+		assert.Equal(t, Distributed, gs.Spec.Scheduling)
+		assert.Equal(t, Static, gs.Spec.Ports[0].PortPolicy)
+	})
+
+	t.Run("the gameserver's own values win", func(t *testing.T) {
+		gs := &GameServer{Spec: GameServerSpec{
+			Health:     Health{FailureThreshold: 2},
+			Scheduling: Packed,
+			Ports:      []GameServerPort{{ContainerPort: 7777, PortPolicy: Dynamic}},
+		}}
+		defaults.Apply(gs)
+
+		assert.Equal(t, int32(2), gs.Spec.Health.FailureThreshold)
+		assert.Equal(t, int32(20), gs.Spec.Health.PeriodSeconds)
+		assert.Equal(t, Packed, gs.Spec.Scheduling)
+		assert.Equal(t, Dynamic, gs.Spec.Ports[0].PortPolicy)
+	})
+
+	t.Run("disabled health checking is only defaulted on an empty health", func(t *testing.T) {
+		disabled := defaults.DeepCopy()
+		disabled.Spec.Health = Health{Disabled: true}
+
+		gs := &GameServer{}
+		disabled.Apply(gs)
+		assert.True(t, gs.Spec.Health.Disabled)
+
+		gs = &GameServer{Spec: GameServerSpec{Health: Health{FailureThreshold: 2}}}
+		disabled.Apply(gs)
+		assert.False(t, gs.Spec.Health.Disabled)
+	})
+}
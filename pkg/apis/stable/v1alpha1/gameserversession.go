@@ -0,0 +1,85 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"agones.dev/agones/pkg/apis/stable"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GameServerSessionActive is when the session's GameServer is
+	// still Allocated
+	GameServerSessionActive GameServerSessionState = "Active"
+	// GameServerSessionClosed is when the session's GameServer has
+	// left the Allocated state, or has been deleted
+	GameServerSessionClosed GameServerSessionState = "Closed"
+
+	// GameServerSessionGameServerLabel is the label that the name of the
+	// GameServer is set to on the GameServerSession the records its allocation
+	GameServerSessionGameServerLabel = stable.GroupName + "/gameserver"
+)
+
+// GameServerSessionState is the state of a GameServerSession
+type GameServerSessionState string
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GameServerSession records a single allocation of a GameServer, from the
+// point it becomes Allocated until it shuts down, providing a queryable
+// in cluster history of which GameServer hosted which session
+type GameServerSession struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GameServerSessionSpec   `json:"spec"`
+	Status GameServerSessionStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GameServerSessionList is a list of GameServerSession resources
+type GameServerSessionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GameServerSession `json:"items"`
+}
+
+// GameServerSessionSpec is the spec for a GameServerSession,
+// recording which GameServer the session was hosted on
+type GameServerSessionSpec struct {
+	// GameServerName is the name of the GameServer this session was allocated on
+	GameServerName string `json:"gameServerName"`
+	// FleetName is the name of the Fleet the GameServer was a member of, if any
+	FleetName string `json:"fleetName,omitempty"`
+	// Version is the image of the game server container, to track which
+	// release hosted this session
+	Version string `json:"version,omitempty"`
+}
+
+// GameServerSessionStatus is the status of a GameServerSession
+type GameServerSessionStatus struct {
+	// State is Active while the GameServer is Allocated, and Closed afterwards
+	State GameServerSessionState `json:"state"`
+	// Started is when the GameServer was allocated
+	Started metav1.Time `json:"started,omitempty"`
+	// Finished is when the GameServer left the Allocated state
+	Finished *metav1.Time `json:"finished,omitempty"`
+	// GameServerState is the state the GameServer moved to when the
+	// session was closed
+	GameServerState GameServerState `json:"gameServerState,omitempty"`
+}
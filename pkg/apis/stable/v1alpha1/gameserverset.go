@@ -15,7 +15,12 @@
 package v1alpha1
 
 import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"reflect"
+	"strconv"
+	"time"
 
 	"agones.dev/agones/pkg/apis/stable"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +30,42 @@ const (
 	// GameServerSetGameServerLabel is the label that the name of the GameServerSet
 	// is set on the GameServer the GameServerSet controls
 	GameServerSetGameServerLabel = stable.GroupName + "/gameserverset"
+
+	// AllowTemplateUpdateAnnotation is an annotation that, when set to "true" on
+	// a GameServerSet that is not managed by a Fleet, allows the GameServer
+	// template to be updated in place. GameServers that are not Allocated will
+	// be replaced with ones created from the new template.
+	AllowTemplateUpdateAnnotation = stable.GroupName + "/allow-template-update"
+
+	// GameServerTemplateHashAnnotation is an annotation set on GameServers
+	// with a hash of the template they were created from, so that GameServers
+	// from an older template can be detected
+	GameServerTemplateHashAnnotation = stable.GroupName + "/gameserver-template-hash"
+
+	// LastScaleAnnotation is an annotation set by the mutating webhook whenever
+	// the replica count of a GameServerSet changes, recording who or what made
+	// the change, and when
+	LastScaleAnnotation = stable.GroupName + "/last-scale"
+
+	// MaxPodPendingCountAnnotation is an annotation that overrides the default
+	// pending Pod throttle for this GameServerSet, so small clusters can lower
+	// it and large ones can raise it
+	MaxPodPendingCountAnnotation = stable.GroupName + "/max-pod-pending-count"
+
+	// GameServerDrainingLabel is the label set to "true" on Allocated GameServers
+	// that a GameServerSet with a DrainStrategy has marked for shutdown on scale
+	// down. The change is visible to the game process through the SDK's
+	// WatchGameServer, so it can stop accepting new sessions
+	GameServerDrainingLabel = stable.GroupName + "/draining"
+
+	// DrainStartAnnotation is the annotation recording when draining of a
+	// GameServer began, in RFC3339 format, used to enforce the DrainStrategy
+	// timeout
+	DrainStartAnnotation = stable.GroupName + "/drain-start"
+
+	// GameServerFlavorLabel is the label set on GameServers recording which
+	// of their GameServerSet's Flavors they were created from
+	GameServerFlavorLabel = stable.GroupName + "/flavor"
 )
 
 // +genclient
@@ -59,10 +100,168 @@ type GameServerSetSpec struct {
 	Replicas int32 `json:"replicas"`
 	// Scheduling strategy. Defaults to "Packed".
 	Scheduling SchedulingStrategy `json:"scheduling,omitempty"`
-	// Template the GameServer template to apply for this GameServerSet
+	// MinReadySeconds is the minimum number of seconds a GameServer must
+	// have been Ready before it can be selected for deletion on scale down,
+	// so freshly booted GameServers aren't thrown away when an autoscaler
+	// briefly oscillates. 0 means GameServers are always eligible
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// Health, when set, overrides the health checking configuration in the
+	// GameServer template
+	Health *Health `json:"health,omitempty"`
+	// AllocationOverflow, when set, applies these labels and/or annotations to
+	// Allocated GameServers that exceed the desired replica count, so external
+	// systems can find and drain them
+	AllocationOverflow *AllocationOverflow `json:"allocationOverflow,omitempty"`
+	// DrainStrategy, when set, lets scale down remove Allocated GameServers:
+	// they are marked for shutdown with GameServerDrainingLabel, and deleted
+	// once they return to Ready, or once TimeoutSeconds has elapsed
+	DrainStrategy *DrainStrategy `json:"drainStrategy,omitempty"`
+	// Flavors, when set, are weighted variants of the GameServer template,
+	// reconciled so the replicas are split between them in proportion to
+	// their weights. GameServers record their flavor in GameServerFlavorLabel
+	Flavors []Flavor `json:"flavors,omitempty"`
+	// Paused, when true, suspends replica reconciliation of this
+	// GameServerSet, while still keeping status up to date. Managed by the
+	// owning Fleet's own Paused field when there is one
+	Paused bool `json:"paused,omitempty"`
+	// Template the GameServer template to apply for this GameServerSet.
+	// Ignored when Flavors are set
 	Template GameServerTemplateSpec `json:"template"`
 }
 
+// Flavor is a weighted variant of a GameServer template within a
+// GameServerSet, so a single set can run a mix - for example 4 player and 16
+// player versions of the same build - without a Fleet and autoscaler per
+// variant
+type Flavor struct {
+	// Name identifies the flavor
+	Name string `json:"name"`
+	// Weight is the share of the replicas this flavor should provide,
+	// relative to the total weight of all flavors
+	Weight int32 `json:"weight"`
+	// Template is the GameServer template for this flavor
+	Template GameServerTemplateSpec `json:"template"`
+}
+
+// TemplateHash returns a hash of this Flavor's template, so that its
+// GameServers can be detected as stale the same way the set's own are
+func (f *Flavor) TemplateHash() string {
+	return templateHash(f.Template)
+}
+
+// AllocationOverflow is the metadata to apply to Allocated GameServers that
+// exceed the desired replica count of their GameServerSet, which happens when
+// the replica count drops below the number of currently Allocated GameServers
+type AllocationOverflow struct {
+	// Labels to apply to the overflowing GameServers
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations to apply to the overflowing GameServers
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// CountMatches returns the number of GameServers that have all of this
+// AllocationOverflow's labels and annotations already applied, and the rest
+func (ao *AllocationOverflow) CountMatches(list []*GameServer) (int32, []*GameServer) {
+	var count int32
+	var rest []*GameServer
+	for _, gs := range list {
+		if ao.matches(gs) {
+			count++
+		} else {
+			rest = append(rest, gs)
+		}
+	}
+	return count, rest
+}
+
+// matches returns true if the GameServer already carries all of this
+// AllocationOverflow's labels and annotations
+func (ao *AllocationOverflow) matches(gs *GameServer) bool {
+	for k, v := range ao.Labels {
+		if gs.ObjectMeta.Labels[k] != v {
+			return false
+		}
+	}
+	for k, v := range ao.Annotations {
+		if gs.ObjectMeta.Annotations[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply applies this AllocationOverflow's labels and annotations to the
+// GameServer's metadata
+func (ao *AllocationOverflow) Apply(gs *GameServer) {
+	if len(ao.Labels) > 0 && gs.ObjectMeta.Labels == nil {
+		gs.ObjectMeta.Labels = map[string]string{}
+	}
+	for k, v := range ao.Labels {
+		gs.ObjectMeta.Labels[k] = v
+	}
+	if len(ao.Annotations) > 0 && gs.ObjectMeta.Annotations == nil {
+		gs.ObjectMeta.Annotations = map[string]string{}
+	}
+	for k, v := range ao.Annotations {
+		gs.ObjectMeta.Annotations[k] = v
+	}
+}
+
+// DrainStrategy controls how a GameServerSet scales down past its Allocated
+// GameServers. Instead of leaving them untouched, they are marked for shutdown
+// with GameServerDrainingLabel, and deleted once they return to Ready, or once
+// TimeoutSeconds has elapsed
+type DrainStrategy struct {
+	// TimeoutSeconds is how long to wait for a draining GameServer to return
+	// to Ready before deleting it regardless. 0 means wait indefinitely
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// StartDraining marks the GameServer for shutdown, recording when draining
+// began so the timeout can be enforced
+func (ds *DrainStrategy) StartDraining(gs *GameServer, now time.Time) {
+	if gs.ObjectMeta.Labels == nil {
+		gs.ObjectMeta.Labels = map[string]string{}
+	}
+	gs.ObjectMeta.Labels[GameServerDrainingLabel] = "true"
+	if gs.ObjectMeta.Annotations == nil {
+		gs.ObjectMeta.Annotations = map[string]string{}
+	}
+	gs.ObjectMeta.Annotations[DrainStartAnnotation] = now.UTC().Format(time.RFC3339)
+}
+
+// StopDraining removes the draining mark from the GameServer, for when a
+// scale up cancels the drain
+func (ds *DrainStrategy) StopDraining(gs *GameServer) {
+	delete(gs.ObjectMeta.Labels, GameServerDrainingLabel)
+	delete(gs.ObjectMeta.Annotations, DrainStartAnnotation)
+}
+
+// Draining returns true if the GameServer has been marked for shutdown
+func (ds *DrainStrategy) Draining(gs *GameServer) bool {
+	return gs.ObjectMeta.Labels[GameServerDrainingLabel] == "true"
+}
+
+// DrainDeadline returns when the draining GameServer will be deleted even if
+// it has not returned to Ready, and false if there is no timeout, or the
+// GameServer is not draining
+func (ds *DrainStrategy) DrainDeadline(gs *GameServer) (time.Time, bool) {
+	if ds.TimeoutSeconds <= 0 {
+		return time.Time{}, false
+	}
+	value, ok := gs.ObjectMeta.Annotations[DrainStartAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		// an unreadable start time would otherwise leave the GameServer
+		// draining forever - treat the deadline as already passed
+		return time.Time{}, true
+	}
+	return start.Add(time.Duration(ds.TimeoutSeconds) * time.Second), true
+}
+
 // GameServerSetStatus is the status of a GameServerSet
 type GameServerSetStatus struct {
 	// Replicas the total number of current GameServer replicas
@@ -71,13 +270,48 @@ type GameServerSetStatus struct {
 	ReadyReplicas int32 `json:"readyReplicas"`
 	// AllocatedReplicas are the number of Allocated GameServer replicas
 	AllocatedReplicas int32 `json:"allocatedReplicas"`
+	// ShuttingDownReplicas are the number of GameServer replicas that have a
+	// DeletionTimestamp, and are waiting to be removed, so capacity dashboards
+	// don't show phantom dips during large scale downs
+	ShuttingDownReplicas int32 `json:"shuttingDownReplicas,omitempty"`
+	// ShutdownReplicas are the number of GameServer replicas in the Shutdown
+	// state, waiting for their resources to be removed
+	ShutdownReplicas int32 `json:"shutdownReplicas,omitempty"`
+	// ReservedReplicas are the number of Reserved GameServer replicas
+	ReservedReplicas int32 `json:"reservedReplicas,omitempty"`
+	// PlayerCount is the total number of players reported connected across
+	// this set's GameServers that track players
+	PlayerCount int64 `json:"playerCount,omitempty"`
+	// PlayerCapacity is the total player capacity across this set's
+	// GameServers that track players
+	PlayerCapacity int64 `json:"playerCapacity,omitempty"`
+	// Players aggregates the player counts across this set's GameServers
+	// that track players, and is only set when at least one of them does
+	Players *PlayerStatus `json:"players,omitempty"`
+	// Counters aggregates the game defined counters across this set's
+	// GameServers, keyed by counter name, so autoscalers and dashboards can
+	// act on totals without enumerating GameServers
+	Counters map[string]AggregatedCounterStatus `json:"counters,omitempty"`
+	// Lists aggregates the game defined lists across this set's GameServers,
+	// keyed by list name
+	Lists map[string]AggregatedListStatus `json:"lists,omitempty"`
+	// LabelSelector is the label selector for the GameServers owned by this
+	// GameServerSet, in string form, so the scale subresource can expose it to
+	// the HorizontalPodAutoscaler
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// ObservedGeneration is the generation of the GameServerSet spec that this
+	// status was calculated from, so deployment tooling can tell a spec change
+	// apart from one that has not been reconciled yet
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ValidateUpdate validates when updates occur. The argument
 // is the new GameServerSet, being passed into the old GameServerSet
 func (gsSet *GameServerSet) ValidateUpdate(new *GameServerSet) (bool, []metav1.StatusCause) {
 	var causes []metav1.StatusCause
-	if !reflect.DeepEqual(gsSet.Spec.Template, new.Spec.Template) {
+	// label and annotation only template changes are always allowed, as they
+	// are patched onto existing GameServers rather than replacing them
+	if !reflect.DeepEqual(gsSet.Spec.Template.Spec, new.Spec.Template.Spec) && !new.AllowsTemplateUpdate() {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
 			Field:   "template",
@@ -88,16 +322,197 @@ func (gsSet *GameServerSet) ValidateUpdate(new *GameServerSet) (bool, []metav1.S
 	return len(causes) == 0, causes
 }
 
+// Validate validates the GameServerSet when it is created, checking the
+// GameServer that would be built from its template the same way a GameServer
+// is checked at admission, so a bad template is rejected up front rather than
+// producing Error state GameServers
+func (gsSet *GameServerSet) Validate() (bool, []metav1.StatusCause) {
+	var causes []metav1.StatusCause
+
+	validateTemplate := func(gs *GameServer) {
+		gs.ApplyDefaults()
+		_, templateCauses := gs.Validate()
+		causes = append(causes, templateCauses...)
+
+		// every replica of a template with a Static port asks for the same
+		// hostPort, so more than one can never become Ready on the same node
+		if gsSet.Spec.Replicas > 1 {
+			for _, p := range gs.Spec.Ports {
+				if p.PortPolicy == Static {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Field:   fmt.Sprintf("%s.portPolicy", p.Name),
+						Message: "PortPolicy cannot be Static with more than one replica, as each replica would require the same hostPort",
+					})
+				}
+			}
+		}
+	}
+
+	if len(gsSet.Spec.Flavors) == 0 {
+		validateTemplate(gsSet.GameServer())
+		return len(causes) == 0, causes
+	}
+
+	names := map[string]bool{}
+	for i := range gsSet.Spec.Flavors {
+		f := &gsSet.Spec.Flavors[i]
+		if f.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   fmt.Sprintf("flavors[%d].name", i),
+				Message: "Name is required for each flavor",
+			})
+		} else if names[f.Name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Field:   fmt.Sprintf("flavors[%d].name", i),
+				Message: fmt.Sprintf("Flavor name '%s' is used more than once", f.Name),
+			})
+		}
+		names[f.Name] = true
+
+		if f.Weight <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("flavors[%d].weight", i),
+				Message: "Weight must be a positive integer",
+			})
+		}
+
+		validateTemplate(gsSet.FlavorGameServer(f))
+	}
+
+	return len(causes) == 0, causes
+}
+
+// AllowsTemplateUpdate returns true if this GameServerSet has opted in to template
+// updates through the AllowTemplateUpdateAnnotation. GameServerSets managed by a
+// Fleet never allow template updates, as the Fleet owns the template through its
+// own deployment strategy.
+func (gsSet *GameServerSet) AllowsTemplateUpdate() bool {
+	if gsSet.ObjectMeta.Annotations[AllowTemplateUpdateAnnotation] != "true" {
+		return false
+	}
+
+	ref := metav1.GetControllerOf(gsSet)
+	return ref == nil || ref.Kind != "Fleet"
+}
+
+// MaxPodPendingCount returns the pending Pod throttle for this GameServerSet
+// from the MaxPodPendingCountAnnotation, or def if the annotation is not set
+// to a positive integer
+func (gsSet *GameServerSet) MaxPodPendingCount(def int) int {
+	value, ok := gsSet.ObjectMeta.Annotations[MaxPodPendingCountAnnotation]
+	if !ok {
+		return def
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i <= 0 {
+		return def
+	}
+	return i
+}
+
+// TemplateHash returns a hash of this GameServerSet's template, so that GameServers
+// created from an older template can be detected
+func (gsSet *GameServerSet) TemplateHash() string {
+	return templateHash(gsSet.Spec.Template)
+}
+
+// templateHash returns a hash of the given GameServer template's spec. The
+// template's labels and annotations are deliberately left out of the hash, as
+// metadata only changes are patched onto existing GameServers in place rather
+// than replacing them
+func templateHash(template GameServerTemplateSpec) string {
+	h := fnv.New32a()
+	b, err := json.Marshal(template.Spec)
+	if err == nil {
+		h.Write(b) // nolint: errcheck
+	}
+	return fmt.Sprint(h.Sum32())
+}
+
+// FlavorDistribution returns how many of the given replicas each flavor
+// should provide, splitting proportionally by weight. Any remainder is
+// handed out one replica at a time in declaration order, starting with the
+// flavor that was rounded down the furthest
+func (gsSet *GameServerSet) FlavorDistribution(replicas int32) map[string]int32 {
+	desired := make(map[string]int32, len(gsSet.Spec.Flavors))
+	var totalWeight int64
+	for _, f := range gsSet.Spec.Flavors {
+		if f.Weight > 0 {
+			totalWeight += int64(f.Weight)
+		}
+	}
+	if totalWeight == 0 || replicas <= 0 {
+		for _, f := range gsSet.Spec.Flavors {
+			desired[f.Name] = 0
+		}
+		return desired
+	}
+
+	var assigned int32
+	remainders := make([]int64, len(gsSet.Spec.Flavors))
+	for i, f := range gsSet.Spec.Flavors {
+		if f.Weight <= 0 {
+			desired[f.Name] = 0
+			continue
+		}
+		share := int64(replicas) * int64(f.Weight)
+		desired[f.Name] = int32(share / totalWeight)
+		remainders[i] = share % totalWeight
+		assigned += desired[f.Name]
+	}
+
+	for remaining := replicas - assigned; remaining > 0; {
+		// give the next leftover replica to the flavor that lost the most
+		// to rounding, preferring earlier declaration on ties
+		best := -1
+		for i := range gsSet.Spec.Flavors {
+			if remainders[i] > 0 && (best == -1 || remainders[i] > remainders[best]) {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		desired[gsSet.Spec.Flavors[best].Name]++
+		remainders[best] = 0
+		remaining--
+	}
+
+	return desired
+}
+
 // GameServer returns a single GameServer derived
 // from the GameSever template
 func (gsSet *GameServerSet) GameServer() *GameServer {
+	return gsSet.gameServerFromTemplate(gsSet.Spec.Template, gsSet.TemplateHash())
+}
+
+// FlavorGameServer returns a single GameServer derived from the given
+// flavor's template, labelled with the flavor's name
+func (gsSet *GameServerSet) FlavorGameServer(f *Flavor) *GameServer {
+	gs := gsSet.gameServerFromTemplate(f.Template, f.TemplateHash())
+	gs.ObjectMeta.Labels[GameServerFlavorLabel] = f.Name
+	return gs
+}
+
+// gameServerFromTemplate builds a GameServer owned by this GameServerSet
+// from the given template
+func (gsSet *GameServerSet) gameServerFromTemplate(template GameServerTemplateSpec, hash string) *GameServer {
 	gs := &GameServer{
-		ObjectMeta: *gsSet.Spec.Template.ObjectMeta.DeepCopy(),
-		Spec:       *gsSet.Spec.Template.Spec.DeepCopy(),
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
 	}
 
 	gs.Spec.Scheduling = gsSet.Spec.Scheduling
 
+	if gsSet.Spec.Health != nil {
+		gs.Spec.Health = *gsSet.Spec.Health
+	}
+
 	// Switch to GenerateName, so that we always get a Unique name for the GameServer, and there
 	// can be no collisions
 	gs.ObjectMeta.GenerateName = gsSet.ObjectMeta.Name + "-"
@@ -115,5 +530,10 @@ func (gsSet *GameServerSet) GameServer() *GameServer {
 
 	gs.ObjectMeta.Labels[GameServerSetGameServerLabel] = gsSet.ObjectMeta.Name
 	gs.ObjectMeta.Labels[FleetNameLabel] = gsSet.ObjectMeta.Labels[FleetNameLabel]
+
+	if gs.ObjectMeta.Annotations == nil {
+		gs.ObjectMeta.Annotations = make(map[string]string, 1)
+	}
+	gs.ObjectMeta.Annotations[GameServerTemplateHashAnnotation] = hash
 	return gs
 }
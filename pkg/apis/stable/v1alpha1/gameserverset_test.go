@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -56,6 +57,11 @@ func TestGameServerSetGameServer(t *testing.T) {
 
 	assert.Equal(t, gs.Spec, gsSet.Spec.Template.Spec)
 	assert.True(t, metav1.IsControlledBy(gs, &gsSet))
+
+	// a top level Health overrides the template's configuration
+	gsSet.Spec.Health = &Health{FailureThreshold: 7, PeriodSeconds: 11}
+	gs = gsSet.GameServer()
+	assert.Equal(t, *gsSet.Spec.Health, gs.Spec.Health)
 }
 
 func TestGameServerSetValidateUpdate(t *testing.T) {
@@ -84,4 +90,143 @@ func TestGameServerSetValidateUpdate(t *testing.T) {
 	assert.False(t, ok)
 	assert.Len(t, causes, 1)
 	assert.Equal(t, "template", causes[0].Field)
+
+	// opting in to template updates allows the change
+	newGSS.ObjectMeta.Annotations = map[string]string{AllowTemplateUpdateAnnotation: "true"}
+	ok, causes = gsSet.ValidateUpdate(newGSS)
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	// but not when the GameServerSet is managed by a Fleet
+	f := &Fleet{ObjectMeta: metav1.ObjectMeta{Name: "fleet", UID: "4321"}}
+	ref := metav1.NewControllerRef(f, SchemeGroupVersion.WithKind("Fleet"))
+	newGSS.ObjectMeta.OwnerReferences = append(newGSS.ObjectMeta.OwnerReferences, *ref)
+	ok, causes = gsSet.ValidateUpdate(newGSS)
+	assert.False(t, ok)
+	assert.Len(t, causes, 1)
+
+	// label and annotation only template changes are always allowed, as
+	// they are patched onto existing GameServers in place
+	newGSS.Spec.Template.Spec.Ports[0].ContainerPort = 1234
+	newGSS.Spec.Template.ObjectMeta.Labels = map[string]string{"version": "1.1"}
+	ok, causes = gsSet.ValidateUpdate(newGSS)
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+}
+
+func TestGameServerSetMaxPodPendingCount(t *testing.T) {
+	gsSet := GameServerSet{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	assert.Equal(t, 5000, gsSet.MaxPodPendingCount(5000))
+
+	gsSet.ObjectMeta.Annotations = map[string]string{MaxPodPendingCountAnnotation: "100"}
+	assert.Equal(t, 100, gsSet.MaxPodPendingCount(5000))
+
+	gsSet.ObjectMeta.Annotations[MaxPodPendingCountAnnotation] = "not-a-number"
+	assert.Equal(t, 5000, gsSet.MaxPodPendingCount(5000))
+
+	gsSet.ObjectMeta.Annotations[MaxPodPendingCountAnnotation] = "-1"
+	assert.Equal(t, 5000, gsSet.MaxPodPendingCount(5000))
+}
+
+func TestAllocationOverflowCountMatchesAndApply(t *testing.T) {
+	ao := &AllocationOverflow{
+		Labels:      map[string]string{"overflow": "true"},
+		Annotations: map[string]string{"drain": "please"},
+	}
+
+	marked := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "marked",
+		Labels:      map[string]string{"overflow": "true"},
+		Annotations: map[string]string{"drain": "please"},
+	}}
+	unmarked := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "unmarked"}}
+
+	count, rest := ao.CountMatches([]*GameServer{marked, unmarked})
+	assert.Equal(t, int32(1), count)
+	assert.Equal(t, []*GameServer{unmarked}, rest)
+
+	ao.Apply(unmarked)
+	assert.Equal(t, "true", unmarked.ObjectMeta.Labels["overflow"])
+	assert.Equal(t, "please", unmarked.ObjectMeta.Annotations["drain"])
+
+	count, rest = ao.CountMatches([]*GameServer{marked, unmarked})
+	assert.Equal(t, int32(2), count)
+	assert.Empty(t, rest)
+}
+
+func TestGameServerSetFlavors(t *testing.T) {
+	t.Parallel()
+
+	template := func(image string) GameServerTemplateSpec {
+		return GameServerTemplateSpec{
+			Spec: GameServerSpec{
+				Ports: []GameServerPort{{Name: "main", PortPolicy: Dynamic, ContainerPort: 7777}},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "game", Image: image}}},
+				},
+			},
+		}
+	}
+
+	gsSet := &GameServerSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "1234"},
+		Spec: GameServerSetSpec{
+			Replicas: 10,
+			Flavors: []Flavor{
+				{Name: "small", Weight: 3, Template: template("game:small")},
+				{Name: "large", Weight: 1, Template: template("game:large")},
+			},
+		},
+	}
+
+	desired := gsSet.FlavorDistribution(10)
+	assert.Equal(t, map[string]int32{"small": 8, "large": 2}, desired)
+
+	// the remainder goes to the flavor that was rounded down the furthest
+	desired = gsSet.FlavorDistribution(3)
+	assert.Equal(t, map[string]int32{"small": 2, "large": 1}, desired)
+
+	gs := gsSet.FlavorGameServer(&gsSet.Spec.Flavors[1])
+	assert.Equal(t, "large", gs.ObjectMeta.Labels[GameServerFlavorLabel])
+	assert.Equal(t, "game:large", gs.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, gsSet.Spec.Flavors[1].TemplateHash(), gs.ObjectMeta.Annotations[GameServerTemplateHashAnnotation])
+
+	ok, causes := gsSet.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gsSet.Spec.Flavors[1].Name = "small"
+	gsSet.Spec.Flavors[1].Weight = 0
+	ok, causes = gsSet.Validate()
+	assert.False(t, ok)
+	assert.Len(t, causes, 2)
+	assert.Equal(t, "flavors[1].name", causes[0].Field)
+	assert.Equal(t, "flavors[1].weight", causes[1].Field)
+}
+
+func TestDrainStrategy(t *testing.T) {
+	t.Parallel()
+
+	ds := &DrainStrategy{TimeoutSeconds: 60}
+	gs := &GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	assert.False(t, ds.Draining(gs))
+
+	_, ok := ds.DrainDeadline(gs)
+	assert.False(t, ok, "not yet draining, so no deadline")
+
+	now := time.Now()
+	ds.StartDraining(gs, now)
+	assert.True(t, ds.Draining(gs))
+	assert.Equal(t, "true", gs.ObjectMeta.Labels[GameServerDrainingLabel])
+
+	deadline, ok := ds.DrainDeadline(gs)
+	assert.True(t, ok)
+	assert.WithinDuration(t, now.Add(time.Minute), deadline, time.Second)
+
+	noTimeout := &DrainStrategy{}
+	_, ok = noTimeout.DrainDeadline(gs)
+	assert.False(t, ok, "no timeout means no deadline")
+
+	ds.StopDraining(gs)
+	assert.False(t, ds.Draining(gs))
+	assert.NotContains(t, gs.ObjectMeta.Annotations, DrainStartAnnotation)
 }
@@ -0,0 +1,77 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PortAllocationSnapshotName is the name of the single
+	// PortAllocationSnapshot the controller maintains in its own namespace
+	PortAllocationSnapshotName = "port-allocator"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PortAllocationSnapshot records the PortAllocator's view of which host
+// ports are held by which GameServers, so that a restarting controller can
+// restore its state without a full resync of every GameServer in the cluster
+type PortAllocationSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PortAllocationSnapshotSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PortAllocationSnapshotList is a list of PortAllocationSnapshot resources
+type PortAllocationSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PortAllocationSnapshot `json:"items"`
+}
+
+// PortAllocationSnapshotSpec is the spec for a PortAllocationSnapshot - the
+// port range it was taken for, and a record per GameServer with dynamically
+// allocated ports
+type PortAllocationSnapshotSpec struct {
+	// MinPort is the bottom of the dynamic port range the snapshot was taken for
+	MinPort int32 `json:"minPort"`
+	// MaxPort is the top of the dynamic port range the snapshot was taken for
+	MaxPort int32 `json:"maxPort"`
+	// GameServers are the GameServers with dynamically allocated ports
+	GameServers []GameServerPortRecord `json:"gameServers,omitempty"`
+}
+
+// GameServerPortRecord records the host ports held by a single GameServer
+// at the point the snapshot was taken
+type GameServerPortRecord struct {
+	// Name is the name of the GameServer
+	Name string `json:"name"`
+	// Namespace is the namespace of the GameServer
+	Namespace string `json:"namespace"`
+	// UID is the UID of the GameServer, used to detect that the record
+	// still refers to the same object on restore
+	UID string `json:"uid"`
+	// Node is the node the GameServer is scheduled on, if any
+	Node string `json:"node,omitempty"`
+	// Ports are the host ports allocated to the GameServer
+	Ports []int32 `json:"ports,omitempty"`
+}
@@ -62,6 +62,18 @@ func addKnownTypes(scheme *k8sruntime.Scheme) error {
 		&GameServerAllocationList{},
 		&FleetAutoscaler{},
 		&FleetAutoscalerList{},
+		&GameServerSession{},
+		&GameServerSessionList{},
+		&GameServerTemplate{},
+		&GameServerTemplateList{},
+		&GameServerDefaults{},
+		&GameServerDefaultsList{},
+		&PortAllocationSnapshot{},
+		&PortAllocationSnapshotList{},
+		&ScaleEvent{},
+		&ScaleEventList{},
+		&AllocatorPolicy{},
+		&AllocatorPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
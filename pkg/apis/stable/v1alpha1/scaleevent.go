@@ -0,0 +1,66 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"agones.dev/agones/pkg/apis/stable"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ScaleEventGameServerSetLabel is the label that the name of the
+	// GameServerSet is set to on the ScaleEvents that record its scaling
+	// decisions
+	ScaleEventGameServerSetLabel = stable.GroupName + "/gameserverset"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScaleEvent records a single scaling decision for a GameServerSet - the
+// replica count that was asked for, and the replica count that existed when
+// the decision was made. External autoscaling systems can watch these to
+// drive Fleets from their own metrics pipelines
+type ScaleEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaleEventSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScaleEventList is a list of ScaleEvent resources
+type ScaleEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ScaleEvent `json:"items"`
+}
+
+// ScaleEventSpec is the spec for a ScaleEvent, recording the desired and
+// observed replica counts at the point the scaling decision was made
+type ScaleEventSpec struct {
+	// GameServerSetName is the name of the GameServerSet that is scaling
+	GameServerSetName string `json:"gameServerSetName"`
+	// FleetName is the name of the Fleet the GameServerSet is a member of, if any
+	FleetName string `json:"fleetName,omitempty"`
+	// DesiredReplicas is the replica count the GameServerSet is scaling towards
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// ObservedReplicas is the replica count that existed when the
+	// scaling decision was made
+	ObservedReplicas int32 `json:"observedReplicas"`
+}
@@ -14,6 +14,13 @@
 
 package v1alpha1
 
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 const (
 	// Packed scheduling strategy will prioritise allocating GameServers
 	// on Nodes with the most Allocated, and then Ready GameServers
@@ -28,8 +35,130 @@ const (
 	// This is most useful for statically sized Kubernetes clusters - such as on physical hardware.
 	// In future versions, this will also impact Fleet scale down, and Pod Scheduling.
 	Distributed SchedulingStrategy = "Distributed"
+
+	// LeastLoaded scheduling strategy will prioritise allocating Ready GameServers
+	// with the lowest game-reported load score (GameServerStatus.Load).
+	// This is most useful for game servers that host multiple matches in a
+	// single process, and is only applicable to GameServerAllocation scheduling.
+	LeastLoaded SchedulingStrategy = "LeastLoaded"
 )
 
 // SchedulingStrategy is the strategy that a Fleet & GameServers will use
 // when scheduling GameServers' Pods across a cluster.
 type SchedulingStrategy string
+
+// Strategy is the pluggable behaviour behind a SchedulingStrategy name,
+// covering each of the places scheduling decisions are made: Pod scheduling,
+// allocation ordering, and Fleet scale down ordering.
+// Any of the hooks may be nil, in which case the default behaviour applies
+type Strategy struct {
+	// PodScheduling optionally mutates a GameServer's Pod before it is
+	// created, for example to apply affinity for packing
+	PodScheduling func(gs *GameServer, pod *corev1.Pod)
+	// NodeComparator returns true if the current Node is a better
+	// allocation candidate than the best Node found so far, given the
+	// counts of Ready and Allocated GameServers on each
+	NodeComparator func(bestReady, bestAllocated, currentReady, currentAllocated int64) bool
+	// ScaleDownComparator returns true if GameServer a should be deleted
+	// ahead of GameServer b when scaling down a GameServerSet
+	ScaleDownComparator func(a, b *GameServer) bool
+	// ScaleDownNodeComparator is a node aware version of ScaleDownComparator,
+	// also given the count of GameServers on each node across the whole
+	// GameServerSet. When set, it takes precedence over ScaleDownComparator
+	ScaleDownNodeComparator func(a, b *GameServer, nodeCount map[string]int) bool
+}
+
+var (
+	strategyMutex sync.RWMutex
+	strategies    = map[SchedulingStrategy]Strategy{}
+)
+
+func init() {
+	RegisterSchedulingStrategy(Packed, Strategy{
+		PodScheduling:           packedPodScheduling,
+		NodeComparator:          packedNodeComparator,
+		ScaleDownNodeComparator: packedScaleDownComparator,
+	})
+	RegisterSchedulingStrategy(Distributed, Strategy{
+		NodeComparator: distributedNodeComparator,
+	})
+	RegisterSchedulingStrategy(LeastLoaded, Strategy{})
+}
+
+// RegisterSchedulingStrategy registers the Strategy behind the given
+// SchedulingStrategy name, replacing any existing registration.
+// This allows custom strategies - such as zone balancing or static node
+// pinning - to be added without touching each controller
+func RegisterSchedulingStrategy(name SchedulingStrategy, strategy Strategy) {
+	strategyMutex.Lock()
+	defer strategyMutex.Unlock()
+	strategies[name] = strategy
+}
+
+// GetSchedulingStrategy returns the registered Strategy for the given
+// SchedulingStrategy name, and whether it was registered
+func GetSchedulingStrategy(name SchedulingStrategy) (Strategy, bool) {
+	strategyMutex.RLock()
+	defer strategyMutex.RUnlock()
+	strategy, ok := strategies[name]
+	return strategy, ok
+}
+
+// packedPodScheduling sets a PreferredDuringSchedulingIgnoredDuringExecution
+// pod affinity for GameServer Pods to a host topology. Basically doing a
+// half decent job of packing GameServer pods together
+func packedPodScheduling(gs *GameServer, pod *corev1.Pod) {
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAffinity == nil {
+		pod.Spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+
+	wpat := corev1.WeightedPodAffinityTerm{
+		Weight: 100,
+		PodAffinityTerm: corev1.PodAffinityTerm{
+			TopologyKey:   "kubernetes.io/hostname",
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{RoleLabel: GameServerLabelRole}},
+		},
+	}
+
+	pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, wpat)
+}
+
+// packedNodeComparator prioritises Nodes with GameServers that are allocated,
+// and then Nodes with the most Ready GameServers -- this will bin pack
+// allocated game servers together
+func packedNodeComparator(bestReady, bestAllocated, currentReady, currentAllocated int64) bool {
+	if currentAllocated == bestAllocated && currentReady > bestReady {
+		return true
+	} else if currentAllocated > bestAllocated {
+		return true
+	}
+
+	return false
+}
+
+// packedScaleDownComparator prefers deleting GameServers on the least packed
+// nodes - those hosting the fewest GameServers - so scale down consolidates
+// Pods onto fewer nodes, and the cluster autoscaler can remove the emptied
+// ones. GameServers not yet scheduled to a node are deleted first of all
+func packedScaleDownComparator(a, b *GameServer, nodeCount map[string]int) bool {
+	if ac, bc := nodeCount[a.Status.NodeName], nodeCount[b.Status.NodeName]; ac != bc {
+		return ac < bc
+	}
+
+	// nodes are equally packed, so fall back to deleting the most recently
+	// Ready GameServer, or the newest when no transition was recorded
+	at, bt := a.Status.ReadyAt(), b.Status.ReadyAt()
+	if at.IsZero() || bt.IsZero() || at.Equal(&bt) {
+		return a.ObjectMeta.CreationTimestamp.After(b.ObjectMeta.CreationTimestamp.Time)
+	}
+	return bt.Before(&at)
+}
+
+// distributedNodeComparator is the inverse of the packed comparator,
+// looking to distribute allocated gameservers on as many nodes as possible
+func distributedNodeComparator(bestReady, bestAllocated, currentReady, currentAllocated int64) bool {
+	return !packedNodeComparator(bestReady, bestAllocated, currentReady, currentAllocated)
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSchedulingStrategyRegistry(t *testing.T) {
+	t.Parallel()
+
+	packed, ok := GetSchedulingStrategy(Packed)
+	assert.True(t, ok)
+	assert.NotNil(t, packed.PodScheduling)
+	assert.NotNil(t, packed.NodeComparator)
+
+	distributed, ok := GetSchedulingStrategy(Distributed)
+	assert.True(t, ok)
+	assert.NotNil(t, distributed.NodeComparator)
+
+	_, ok = GetSchedulingStrategy("not-a-strategy")
+	assert.False(t, ok)
+
+	custom := SchedulingStrategy("test-zone-balanced")
+	RegisterSchedulingStrategy(custom, Strategy{
+		ScaleDownComparator: func(a, b *GameServer) bool {
+			return a.ObjectMeta.Name < b.ObjectMeta.Name
+		},
+	})
+
+	strategy, ok := GetSchedulingStrategy(custom)
+	assert.True(t, ok)
+	assert.Nil(t, strategy.NodeComparator)
+	assert.True(t, strategy.ScaleDownComparator(&GameServer{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&GameServer{ObjectMeta: metav1.ObjectMeta{Name: "b"}}))
+}
+
+func TestSchedulingStrategyNodeComparators(t *testing.T) {
+	t.Parallel()
+
+	// packed prefers nodes with more allocated, then more ready GameServers
+	assert.True(t, packedNodeComparator(0, 0, 0, 1))
+	assert.True(t, packedNodeComparator(0, 1, 1, 1))
+	assert.False(t, packedNodeComparator(1, 1, 0, 1))
+	assert.False(t, packedNodeComparator(0, 1, 0, 0))
+
+	// distributed is the inverse
+	assert.False(t, distributedNodeComparator(0, 0, 0, 1))
+	assert.True(t, distributedNodeComparator(0, 1, 0, 0))
+}
@@ -26,6 +26,186 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregatedCounterStatus) DeepCopyInto(out *AggregatedCounterStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregatedCounterStatus.
+func (in *AggregatedCounterStatus) DeepCopy() *AggregatedCounterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregatedCounterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregatedListStatus) DeepCopyInto(out *AggregatedListStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregatedListStatus.
+func (in *AggregatedListStatus) DeepCopy() *AggregatedListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregatedListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationOverflow) DeepCopyInto(out *AllocationOverflow) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationOverflow.
+func (in *AllocationOverflow) DeepCopy() *AllocationOverflow {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationOverflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocatorPolicy) DeepCopyInto(out *AllocatorPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocatorPolicy.
+func (in *AllocatorPolicy) DeepCopy() *AllocatorPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocatorPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AllocatorPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocatorPolicyList) DeepCopyInto(out *AllocatorPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AllocatorPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocatorPolicyList.
+func (in *AllocatorPolicyList) DeepCopy() *AllocatorPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocatorPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AllocatorPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocatorPolicySpec) DeepCopyInto(out *AllocatorPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fleets != nil {
+		in, out := &in.Fleets, &out.Fleets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocatorPolicySpec.
+func (in *AllocatorPolicySpec) DeepCopy() *AllocatorPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocatorPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchitectureSpec) DeepCopyInto(out *ArchitectureSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchitectureSpec.
+func (in *ArchitectureSpec) DeepCopy() *ArchitectureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchitectureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackfillSpec) DeepCopyInto(out *BackfillSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackfillSpec.
+func (in *BackfillSpec) DeepCopy() *BackfillSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackfillSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BufferPolicy) DeepCopyInto(out *BufferPolicy) {
 	*out = *in
@@ -43,6 +223,105 @@ func (in *BufferPolicy) DeepCopy() *BufferPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CounterPolicy) DeepCopyInto(out *CounterPolicy) {
+	*out = *in
+	out.BufferSize = in.BufferSize
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CounterPolicy.
+func (in *CounterPolicy) DeepCopy() *CounterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CounterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CounterStatus) DeepCopyInto(out *CounterStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CounterStatus.
+func (in *CounterStatus) DeepCopy() *CounterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CounterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionSpec.
+func (in *DisruptionSpec) DeepCopy() *DisruptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainStrategy) DeepCopyInto(out *DrainStrategy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainStrategy.
+func (in *DrainStrategy) DeepCopy() *DrainStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Flavor) DeepCopyInto(out *Flavor) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Flavor.
+func (in *Flavor) DeepCopy() *Flavor {
+	if in == nil {
+		return nil
+	}
+	out := new(Flavor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fleet) DeepCopyInto(out *Fleet) {
 	*out = *in
@@ -323,6 +602,24 @@ func (in *FleetAutoscalerPolicy) DeepCopyInto(out *FleetAutoscalerPolicy) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.Counter != nil {
+		in, out := &in.Counter, &out.Counter
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CounterPolicy)
+			**out = **in
+		}
+	}
+	if in.List != nil {
+		in, out := &in.List, &out.List
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ListPolicy)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -414,6 +711,49 @@ func (in *FleetList) DeepCopyObject() runtime.Object {
 func (in *FleetSpec) DeepCopyInto(out *FleetSpec) {
 	*out = *in
 	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Health)
+			**out = **in
+		}
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LifecycleSpec)
+			**out = **in
+		}
+	}
+	if in.Disruption != nil {
+		in, out := &in.Disruption, &out.Disruption
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(DisruptionSpec)
+			**out = **in
+		}
+	}
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]Flavor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(CanarySpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	in.Template.DeepCopyInto(&out.Template)
 	return
 }
@@ -431,8 +771,22 @@ func (in *FleetSpec) DeepCopy() *FleetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FleetStatus) DeepCopyInto(out *FleetStatus) {
 	*out = *in
-	return
-}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]AggregatedCounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]AggregatedListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetStatus.
 func (in *FleetStatus) DeepCopy() *FleetStatus {
@@ -544,7 +898,23 @@ func (in *GameServerAllocationSpec) DeepCopyInto(out *GameServerAllocationSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.MetaPatch.DeepCopyInto(&out.MetaPatch)
+	if in.Backfill != nil {
+		in, out := &in.Backfill, &out.Backfill
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(BackfillSpec)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -579,6 +949,102 @@ func (in *GameServerAllocationStatus) DeepCopy() *GameServerAllocationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerCondition) DeepCopyInto(out *GameServerCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerCondition.
+func (in *GameServerCondition) DeepCopy() *GameServerCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerDefaults) DeepCopyInto(out *GameServerDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerDefaults.
+func (in *GameServerDefaults) DeepCopy() *GameServerDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GameServerDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerDefaultsList) DeepCopyInto(out *GameServerDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GameServerDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerDefaultsList.
+func (in *GameServerDefaultsList) DeepCopy() *GameServerDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GameServerDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerDefaultsSpec) DeepCopyInto(out *GameServerDefaultsSpec) {
+	*out = *in
+	out.Health = in.Health
+	in.SidecarResources.DeepCopyInto(&out.SidecarResources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerDefaultsSpec.
+func (in *GameServerDefaultsSpec) DeepCopy() *GameServerDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerList) DeepCopyInto(out *GameServerList) {
 	*out = *in
@@ -628,13 +1094,132 @@ func (in *GameServerPort) DeepCopy() *GameServerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerPortRecord) DeepCopyInto(out *GameServerPortRecord) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerPortRecord.
+func (in *GameServerPortRecord) DeepCopy() *GameServerPortRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerPortRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerSession) DeepCopyInto(out *GameServerSession) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerSession.
+func (in *GameServerSession) DeepCopy() *GameServerSession {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerSession)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GameServerSession) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerSessionList) DeepCopyInto(out *GameServerSessionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GameServerSession, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerSessionList.
+func (in *GameServerSessionList) DeepCopy() *GameServerSessionList {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerSessionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GameServerSessionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerSessionSpec) DeepCopyInto(out *GameServerSessionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerSessionSpec.
+func (in *GameServerSessionSpec) DeepCopy() *GameServerSessionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerSessionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerSessionStatus) DeepCopyInto(out *GameServerSessionStatus) {
+	*out = *in
+	in.Started.DeepCopyInto(&out.Started)
+	if in.Finished != nil {
+		in, out := &in.Finished, &out.Finished
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerSessionStatus.
+func (in *GameServerSessionStatus) DeepCopy() *GameServerSessionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerSessionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerSet) DeepCopyInto(out *GameServerSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -692,6 +1277,40 @@ func (in *GameServerSetList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerSetSpec) DeepCopyInto(out *GameServerSetSpec) {
 	*out = *in
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Health)
+			**out = **in
+		}
+	}
+	if in.AllocationOverflow != nil {
+		in, out := &in.AllocationOverflow, &out.AllocationOverflow
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(AllocationOverflow)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.DrainStrategy != nil {
+		in, out := &in.DrainStrategy, &out.DrainStrategy
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(DrainStrategy)
+			**out = **in
+		}
+	}
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]Flavor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Template.DeepCopyInto(&out.Template)
 	return
 }
@@ -709,6 +1328,29 @@ func (in *GameServerSetSpec) DeepCopy() *GameServerSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerSetStatus) DeepCopyInto(out *GameServerSetStatus) {
 	*out = *in
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PlayerStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]AggregatedCounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]AggregatedListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -731,6 +1373,45 @@ func (in *GameServerSpec) DeepCopyInto(out *GameServerSpec) {
 		copy(*out, *in)
 	}
 	out.Health = in.Health
+	in.Shutdown.DeepCopyInto(&out.Shutdown)
+	in.SdkServer.DeepCopyInto(&out.SdkServer)
+	if in.QueryResponder != nil {
+		in, out := &in.QueryResponder, &out.QueryResponder
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(QueryResponderSpec)
+			**out = **in
+		}
+	}
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PlayersSpec)
+			**out = **in
+		}
+	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]CounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]ListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]ArchitectureSpec, len(*in))
+		copy(*out, *in)
+	}
 	in.Template.DeepCopyInto(&out.Template)
 	return
 }
@@ -746,30 +1427,88 @@ func (in *GameServerSpec) DeepCopy() *GameServerSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GameServerStatus) DeepCopyInto(out *GameServerStatus) {
+func (in *GameServerStateTransition) DeepCopyInto(out *GameServerStateTransition) {
 	*out = *in
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]GameServerStatusPort, len(*in))
-		copy(*out, *in)
-	}
+	in.Time.DeepCopyInto(&out.Time)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerStatus.
-func (in *GameServerStatus) DeepCopy() *GameServerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerStateTransition.
+func (in *GameServerStateTransition) DeepCopy() *GameServerStateTransition {
 	if in == nil {
 		return nil
 	}
-	out := new(GameServerStatus)
+	out := new(GameServerStateTransition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GameServerStatusPort) DeepCopyInto(out *GameServerStatusPort) {
+func (in *GameServerStatus) DeepCopyInto(out *GameServerStatus) {
 	*out = *in
-	return
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]GameServerStatusPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReservedUntil != nil {
+		in, out := &in.ReservedUntil, &out.ReservedUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PlayerStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]CounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]ListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = make([]GameServerStateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]GameServerCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerStatus.
+func (in *GameServerStatus) DeepCopy() *GameServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerStatusPort) DeepCopyInto(out *GameServerStatusPort) {
+	*out = *in
+	return
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerStatusPort.
@@ -782,6 +1521,66 @@ func (in *GameServerStatusPort) DeepCopy() *GameServerStatusPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerTemplate) DeepCopyInto(out *GameServerTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerTemplate.
+func (in *GameServerTemplate) DeepCopy() *GameServerTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GameServerTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerTemplateList) DeepCopyInto(out *GameServerTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GameServerTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerTemplateList.
+func (in *GameServerTemplateList) DeepCopy() *GameServerTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GameServerTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerTemplateSpec) DeepCopyInto(out *GameServerTemplateSpec) {
 	*out = *in
@@ -816,6 +1615,60 @@ func (in *Health) DeepCopy() *Health {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleSpec) DeepCopyInto(out *LifecycleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleSpec.
+func (in *LifecycleSpec) DeepCopy() *LifecycleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListPolicy) DeepCopyInto(out *ListPolicy) {
+	*out = *in
+	out.BufferSize = in.BufferSize
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListPolicy.
+func (in *ListPolicy) DeepCopy() *ListPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ListPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListStatus) DeepCopyInto(out *ListStatus) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListStatus.
+func (in *ListStatus) DeepCopy() *ListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaPatch) DeepCopyInto(out *MetaPatch) {
 	*out = *in
@@ -846,6 +1699,272 @@ func (in *MetaPatch) DeepCopy() *MetaPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlayerStatus) DeepCopyInto(out *PlayerStatus) {
+	*out = *in
+	if in.IDs != nil {
+		in, out := &in.IDs, &out.IDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlayerStatus.
+func (in *PlayerStatus) DeepCopy() *PlayerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlayerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlayersSpec) DeepCopyInto(out *PlayersSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlayersSpec.
+func (in *PlayersSpec) DeepCopy() *PlayersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlayersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortAllocationSnapshot) DeepCopyInto(out *PortAllocationSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortAllocationSnapshot.
+func (in *PortAllocationSnapshot) DeepCopy() *PortAllocationSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(PortAllocationSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortAllocationSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortAllocationSnapshotList) DeepCopyInto(out *PortAllocationSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PortAllocationSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortAllocationSnapshotList.
+func (in *PortAllocationSnapshotList) DeepCopy() *PortAllocationSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(PortAllocationSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PortAllocationSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortAllocationSnapshotSpec) DeepCopyInto(out *PortAllocationSnapshotSpec) {
+	*out = *in
+	if in.GameServers != nil {
+		in, out := &in.GameServers, &out.GameServers
+		*out = make([]GameServerPortRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortAllocationSnapshotSpec.
+func (in *PortAllocationSnapshotSpec) DeepCopy() *PortAllocationSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PortAllocationSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryResponderSpec) DeepCopyInto(out *QueryResponderSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryResponderSpec.
+func (in *QueryResponderSpec) DeepCopy() *QueryResponderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryResponderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleEvent) DeepCopyInto(out *ScaleEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleEvent.
+func (in *ScaleEvent) DeepCopy() *ScaleEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleEventList) DeepCopyInto(out *ScaleEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScaleEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleEventList.
+func (in *ScaleEventList) DeepCopy() *ScaleEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScaleEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleEventSpec) DeepCopyInto(out *ScaleEventSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleEventSpec.
+func (in *ScaleEventSpec) DeepCopy() *ScaleEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SdkServerSpec) DeepCopyInto(out *SdkServerSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SdkServerSpec.
+func (in *SdkServerSpec) DeepCopy() *SdkServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SdkServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShutdownSpec) DeepCopyInto(out *ShutdownSpec) {
+	*out = *in
+	if in.ExecCommand != nil {
+		in, out := &in.ExecCommand, &out.ExecCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShutdownSpec.
+func (in *ShutdownSpec) DeepCopy() *ShutdownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShutdownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookPolicy) DeepCopyInto(out *WebhookPolicy) {
 	*out = *in
@@ -28,6 +28,10 @@ type FakeStableV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeStableV1alpha1) AllocatorPolicies(namespace string) v1alpha1.AllocatorPolicyInterface {
+	return &FakeAllocatorPolicies{c, namespace}
+}
+
 func (c *FakeStableV1alpha1) Fleets(namespace string) v1alpha1.FleetInterface {
 	return &FakeFleets{c, namespace}
 }
@@ -48,10 +52,30 @@ func (c *FakeStableV1alpha1) GameServerAllocations(namespace string) v1alpha1.Ga
 	return &FakeGameServerAllocations{c, namespace}
 }
 
+func (c *FakeStableV1alpha1) GameServerDefaults(namespace string) v1alpha1.GameServerDefaultsInterface {
+	return &FakeGameServerDefaults{c, namespace}
+}
+
+func (c *FakeStableV1alpha1) GameServerSessions(namespace string) v1alpha1.GameServerSessionInterface {
+	return &FakeGameServerSessions{c, namespace}
+}
+
 func (c *FakeStableV1alpha1) GameServerSets(namespace string) v1alpha1.GameServerSetInterface {
 	return &FakeGameServerSets{c, namespace}
 }
 
+func (c *FakeStableV1alpha1) GameServerTemplates(namespace string) v1alpha1.GameServerTemplateInterface {
+	return &FakeGameServerTemplates{c, namespace}
+}
+
+func (c *FakeStableV1alpha1) PortAllocationSnapshots(namespace string) v1alpha1.PortAllocationSnapshotInterface {
+	return &FakePortAllocationSnapshots{c, namespace}
+}
+
+func (c *FakeStableV1alpha1) ScaleEvents(namespace string) v1alpha1.ScaleEventInterface {
+	return &FakeScaleEvents{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeStableV1alpha1) RESTClient() rest.Interface {
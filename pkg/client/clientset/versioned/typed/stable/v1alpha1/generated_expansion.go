@@ -18,6 +18,8 @@
 
 package v1alpha1
 
+type AllocatorPolicyExpansion interface{}
+
 type FleetExpansion interface{}
 
 type FleetAllocationExpansion interface{}
@@ -28,4 +30,14 @@ type GameServerExpansion interface{}
 
 type GameServerAllocationExpansion interface{}
 
+type GameServerDefaultsExpansion interface{}
+
+type GameServerSessionExpansion interface{}
+
 type GameServerSetExpansion interface{}
+
+type GameServerTemplateExpansion interface{}
+
+type PortAllocationSnapshotExpansion interface{}
+
+type ScaleEventExpansion interface{}
@@ -27,12 +27,18 @@ import (
 
 type StableV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AllocatorPoliciesGetter
 	FleetsGetter
 	FleetAllocationsGetter
 	FleetAutoscalersGetter
 	GameServersGetter
 	GameServerAllocationsGetter
+	GameServerDefaultsGetter
+	GameServerSessionsGetter
 	GameServerSetsGetter
+	GameServerTemplatesGetter
+	PortAllocationSnapshotsGetter
+	ScaleEventsGetter
 }
 
 // StableV1alpha1Client is used to interact with features provided by the stable.agones.dev group.
@@ -40,6 +46,10 @@ type StableV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *StableV1alpha1Client) AllocatorPolicies(namespace string) AllocatorPolicyInterface {
+	return newAllocatorPolicies(c, namespace)
+}
+
 func (c *StableV1alpha1Client) Fleets(namespace string) FleetInterface {
 	return newFleets(c, namespace)
 }
@@ -60,10 +70,30 @@ func (c *StableV1alpha1Client) GameServerAllocations(namespace string) GameServe
 	return newGameServerAllocations(c, namespace)
 }
 
+func (c *StableV1alpha1Client) GameServerDefaults(namespace string) GameServerDefaultsInterface {
+	return newGameServerDefaults(c, namespace)
+}
+
+func (c *StableV1alpha1Client) GameServerSessions(namespace string) GameServerSessionInterface {
+	return newGameServerSessions(c, namespace)
+}
+
 func (c *StableV1alpha1Client) GameServerSets(namespace string) GameServerSetInterface {
 	return newGameServerSets(c, namespace)
 }
 
+func (c *StableV1alpha1Client) GameServerTemplates(namespace string) GameServerTemplateInterface {
+	return newGameServerTemplates(c, namespace)
+}
+
+func (c *StableV1alpha1Client) PortAllocationSnapshots(namespace string) PortAllocationSnapshotInterface {
+	return newPortAllocationSnapshots(c, namespace)
+}
+
+func (c *StableV1alpha1Client) ScaleEvents(namespace string) ScaleEventInterface {
+	return newScaleEvents(c, namespace)
+}
+
 // NewForConfig creates a new StableV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*StableV1alpha1Client, error) {
 	config := *c
@@ -53,6 +53,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=stable.agones.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("allocatorpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().AllocatorPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("fleets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().Fleets().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("fleetallocations"):
@@ -63,8 +65,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().GameServers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("gameserverallocations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().GameServerAllocations().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("gameserverdefaults"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().GameServerDefaults().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("gameserversessions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().GameServerSessions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("gameserversets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().GameServerSets().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("gameservertemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().GameServerTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("portallocationsnapshots"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().PortAllocationSnapshots().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scaleevents"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Stable().V1alpha1().ScaleEvents().Informer()}, nil
 
 	}
 
@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AllocatorPolicies returns a AllocatorPolicyInformer.
+	AllocatorPolicies() AllocatorPolicyInformer
 	// Fleets returns a FleetInformer.
 	Fleets() FleetInformer
 	// FleetAllocations returns a FleetAllocationInformer.
@@ -34,8 +36,18 @@ type Interface interface {
 	GameServers() GameServerInformer
 	// GameServerAllocations returns a GameServerAllocationInformer.
 	GameServerAllocations() GameServerAllocationInformer
+	// GameServerDefaults returns a GameServerDefaultsInformer.
+	GameServerDefaults() GameServerDefaultsInformer
+	// GameServerSessions returns a GameServerSessionInformer.
+	GameServerSessions() GameServerSessionInformer
 	// GameServerSets returns a GameServerSetInformer.
 	GameServerSets() GameServerSetInformer
+	// GameServerTemplates returns a GameServerTemplateInformer.
+	GameServerTemplates() GameServerTemplateInformer
+	// PortAllocationSnapshots returns a PortAllocationSnapshotInformer.
+	PortAllocationSnapshots() PortAllocationSnapshotInformer
+	// ScaleEvents returns a ScaleEventInformer.
+	ScaleEvents() ScaleEventInformer
 }
 
 type version struct {
@@ -49,6 +61,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AllocatorPolicies returns a AllocatorPolicyInformer.
+func (v *version) AllocatorPolicies() AllocatorPolicyInformer {
+	return &allocatorPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Fleets returns a FleetInformer.
 func (v *version) Fleets() FleetInformer {
 	return &fleetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -74,7 +91,32 @@ func (v *version) GameServerAllocations() GameServerAllocationInformer {
 	return &gameServerAllocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// GameServerDefaults returns a GameServerDefaultsInformer.
+func (v *version) GameServerDefaults() GameServerDefaultsInformer {
+	return &gameServerDefaultsInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// GameServerSessions returns a GameServerSessionInformer.
+func (v *version) GameServerSessions() GameServerSessionInformer {
+	return &gameServerSessionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // GameServerSets returns a GameServerSetInformer.
 func (v *version) GameServerSets() GameServerSetInformer {
 	return &gameServerSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// GameServerTemplates returns a GameServerTemplateInformer.
+func (v *version) GameServerTemplates() GameServerTemplateInformer {
+	return &gameServerTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PortAllocationSnapshots returns a PortAllocationSnapshotInformer.
+func (v *version) PortAllocationSnapshots() PortAllocationSnapshotInformer {
+	return &portAllocationSnapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ScaleEvents returns a ScaleEventInformer.
+func (v *version) ScaleEvents() ScaleEventInformer {
+	return &scaleEventInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
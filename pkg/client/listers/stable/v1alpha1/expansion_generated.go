@@ -18,6 +18,14 @@
 
 package v1alpha1
 
+// AllocatorPolicyListerExpansion allows custom methods to be added to
+// AllocatorPolicyLister.
+type AllocatorPolicyListerExpansion interface{}
+
+// AllocatorPolicyNamespaceListerExpansion allows custom methods to be added to
+// AllocatorPolicyNamespaceLister.
+type AllocatorPolicyNamespaceListerExpansion interface{}
+
 // FleetListerExpansion allows custom methods to be added to
 // FleetLister.
 type FleetListerExpansion interface{}
@@ -58,6 +66,22 @@ type GameServerAllocationListerExpansion interface{}
 // GameServerAllocationNamespaceLister.
 type GameServerAllocationNamespaceListerExpansion interface{}
 
+// GameServerDefaultsListerExpansion allows custom methods to be added to
+// GameServerDefaultsLister.
+type GameServerDefaultsListerExpansion interface{}
+
+// GameServerDefaultsNamespaceListerExpansion allows custom methods to be added to
+// GameServerDefaultsNamespaceLister.
+type GameServerDefaultsNamespaceListerExpansion interface{}
+
+// GameServerSessionListerExpansion allows custom methods to be added to
+// GameServerSessionLister.
+type GameServerSessionListerExpansion interface{}
+
+// GameServerSessionNamespaceListerExpansion allows custom methods to be added to
+// GameServerSessionNamespaceLister.
+type GameServerSessionNamespaceListerExpansion interface{}
+
 // GameServerSetListerExpansion allows custom methods to be added to
 // GameServerSetLister.
 type GameServerSetListerExpansion interface{}
@@ -65,3 +89,27 @@ type GameServerSetListerExpansion interface{}
 // GameServerSetNamespaceListerExpansion allows custom methods to be added to
 // GameServerSetNamespaceLister.
 type GameServerSetNamespaceListerExpansion interface{}
+
+// GameServerTemplateListerExpansion allows custom methods to be added to
+// GameServerTemplateLister.
+type GameServerTemplateListerExpansion interface{}
+
+// GameServerTemplateNamespaceListerExpansion allows custom methods to be added to
+// GameServerTemplateNamespaceLister.
+type GameServerTemplateNamespaceListerExpansion interface{}
+
+// PortAllocationSnapshotListerExpansion allows custom methods to be added to
+// PortAllocationSnapshotLister.
+type PortAllocationSnapshotListerExpansion interface{}
+
+// PortAllocationSnapshotNamespaceListerExpansion allows custom methods to be added to
+// PortAllocationSnapshotNamespaceLister.
+type PortAllocationSnapshotNamespaceListerExpansion interface{}
+
+// ScaleEventListerExpansion allows custom methods to be added to
+// ScaleEventLister.
+type ScaleEventListerExpansion interface{}
+
+// ScaleEventNamespaceListerExpansion allows custom methods to be added to
+// ScaleEventNamespaceLister.
+type ScaleEventNamespaceListerExpansion interface{}
@@ -17,7 +17,6 @@ package fleetallocation
 import (
 	"encoding/json"
 	"fmt"
-	"sync"
 
 	"agones.dev/agones/pkg/apis/stable"
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
@@ -27,6 +26,7 @@ import (
 	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
 	"agones.dev/agones/pkg/fleets"
 	"agones.dev/agones/pkg/util/crd"
+	"agones.dev/agones/pkg/util/locks"
 	"agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/webhooks"
 	"github.com/mattbaird/jsonpatch"
@@ -63,14 +63,14 @@ type Controller struct {
 	fleetAllocationGetter getterv1alpha1.FleetAllocationsGetter
 	fleetAllocationLister listerv1alpha1.FleetAllocationLister
 	stop                  <-chan struct{}
-	allocationMutex       *sync.Mutex
+	allocationMutex       *locks.KeyedMutex
 	recorder              record.EventRecorder
 }
 
 // NewController returns a controller for a FleetAllocation
 func NewController(
 	wh *webhooks.WebHook,
-	allocationMutex *sync.Mutex,
+	allocationMutex *locks.KeyedMutex,
 	kubeClient kubernetes.Interface,
 	extClient extclientset.Interface,
 	agonesClient versioned.Interface,
@@ -259,10 +259,10 @@ func (c *Controller) mutationValidationHandler(review admv1beta1.AdmissionReview
 // allocate allocated a GameServer from a given Fleet
 func (c *Controller) allocate(f *v1alpha1.Fleet, fam *v1alpha1.MetaPatch) (*v1alpha1.GameServer, error) {
 	var allocation *v1alpha1.GameServer
-	// can only allocate one at a time, as we don't want two separate processes
-	// trying to allocate the same GameServer to different clients
-	c.allocationMutex.Lock()
-	defer c.allocationMutex.Unlock()
+	// can only allocate one at a time within a namespace, as we don't want two
+	// separate processes trying to allocate the same GameServer to different clients
+	c.allocationMutex.Lock(f.ObjectMeta.Namespace)
+	defer c.allocationMutex.Unlock(f.ObjectMeta.Namespace)
 
 	// make sure we have the most up to date view of the world
 	if !cache.WaitForCacheSync(c.stop, c.gameServerSynced) {
@@ -286,6 +286,7 @@ func (c *Controller) allocate(f *v1alpha1.Fleet, fam *v1alpha1.MetaPatch) (*v1al
 
 	gsCopy := allocation.DeepCopy()
 	gsCopy.Status.State = v1alpha1.GameServerStateAllocated
+	gsCopy.Status.RecordStateTransition()
 
 	if fam != nil {
 		c.patchMetadata(gsCopy, fam)
@@ -119,6 +119,12 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 	return nil
 }
 
+// WaitUntilRunning blocks until this controller has synced its caches and
+// its workers are processing events, or stop is closed
+func (c *Controller) WaitUntilRunning(stop <-chan struct{}) bool {
+	return c.workerqueue.WaitUntilRunning(stop)
+}
+
 // validationHandler will intercept when a FleetAutoscaler is created, and
 // validate its settings.
 func (c *Controller) validationHandler(review admv1beta1.AdmissionReview) (admv1beta1.AdmissionReview, error) {
@@ -213,11 +219,21 @@ func (c *Controller) syncFleetAutoscaler(key string) error {
 	return c.updateStatus(fas, currentReplicas, desiredReplicas, desiredReplicas != fleet.Spec.Replicas, scalingLimited)
 }
 
-// scaleFleet scales the fleet of the autoscaler to a new number of replicas
+// scaleFleet scales the fleet of the autoscaler to a new number of replicas.
+// Scale downs are held back by the fleet's scaleDownDelaySeconds, so Ready
+// GameServers are only removed if demand does not return within the delay
 func (c *Controller) scaleFleet(fas *stablev1alpha1.FleetAutoscaler, f *stablev1alpha1.Fleet, replicas int32) error {
+	if replicas < f.Spec.Replicas && f.Spec.ScaleDownDelaySeconds > 0 {
+		apply, err := c.delayScaleDown(fas, f, replicas)
+		if err != nil || !apply {
+			return err
+		}
+	}
+
 	if replicas != f.Spec.Replicas {
 		fCopy := f.DeepCopy()
 		fCopy.Spec.Replicas = replicas
+		delete(fCopy.ObjectMeta.Annotations, stablev1alpha1.ScaleDownRequestedAnnotation)
 		fCopy, err := c.fleetGetter.Fleets(f.ObjectMeta.Namespace).Update(fCopy)
 		if err != nil {
 			return errors.Wrapf(err, "error updating replicas for fleet %s", f.ObjectMeta.Name)
@@ -225,11 +241,49 @@ func (c *Controller) scaleFleet(fas *stablev1alpha1.FleetAutoscaler, f *stablev1
 
 		c.recorder.Eventf(fas, corev1.EventTypeNormal, "AutoScalingFleet",
 			"Scaling fleet %s from %d to %d", fCopy.ObjectMeta.Name, f.Spec.Replicas, fCopy.Spec.Replicas)
+	} else if _, ok := f.ObjectMeta.Annotations[stablev1alpha1.ScaleDownRequestedAnnotation]; ok {
+		// demand returned before the delay elapsed, so drop the pending scale down
+		fCopy := f.DeepCopy()
+		delete(fCopy.ObjectMeta.Annotations, stablev1alpha1.ScaleDownRequestedAnnotation)
+		if _, err := c.fleetGetter.Fleets(f.ObjectMeta.Namespace).Update(fCopy); err != nil {
+			return errors.Wrapf(err, "error clearing pending scale down for fleet %s", f.ObjectMeta.Name)
+		}
 	}
 
 	return nil
 }
 
+// delayScaleDown tracks when a scale down was first requested via the
+// ScaleDownRequestedAnnotation on the Fleet, and returns whether the
+// fleet's scaleDownDelaySeconds have elapsed and the scale down can be applied
+func (c *Controller) delayScaleDown(fas *stablev1alpha1.FleetAutoscaler, f *stablev1alpha1.Fleet, replicas int32) (bool, error) {
+	requested, ok := f.ObjectMeta.Annotations[stablev1alpha1.ScaleDownRequestedAnnotation]
+	if !ok {
+		fCopy := f.DeepCopy()
+		if fCopy.ObjectMeta.Annotations == nil {
+			fCopy.ObjectMeta.Annotations = map[string]string{}
+		}
+		fCopy.ObjectMeta.Annotations[stablev1alpha1.ScaleDownRequestedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if _, err := c.fleetGetter.Fleets(f.ObjectMeta.Namespace).Update(fCopy); err != nil {
+			return false, errors.Wrapf(err, "error recording pending scale down for fleet %s", f.ObjectMeta.Name)
+		}
+
+		c.recorder.Eventf(fas, corev1.EventTypeNormal, "ScaleDownDelayed",
+			"Scaling fleet %s down to %d delayed for %d seconds", f.ObjectMeta.Name, replicas, f.Spec.ScaleDownDelaySeconds)
+		return false, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, requested)
+	if err != nil {
+		// an unreadable timestamp can never elapse, so treat it as expired
+		c.logger.WithError(err).WithField("fleet", f.ObjectMeta.Name).
+			Warn("could not parse scale down requested annotation, scaling down")
+		return true, nil
+	}
+
+	return time.Since(t) >= time.Duration(f.Spec.ScaleDownDelaySeconds)*time.Second, nil
+}
+
 // updateStatus updates the status of the given FleetAutoscaler
 func (c *Controller) updateStatus(fas *stablev1alpha1.FleetAutoscaler, currentReplicas int32, desiredReplicas int32, scaled bool, scalingLimited bool) error {
 	fasCopy := fas.DeepCopy()
@@ -237,6 +291,7 @@ func (c *Controller) updateStatus(fas *stablev1alpha1.FleetAutoscaler, currentRe
 	fasCopy.Status.ScalingLimited = scalingLimited
 	fasCopy.Status.CurrentReplicas = currentReplicas
 	fasCopy.Status.DesiredReplicas = desiredReplicas
+	fasCopy.Status.ObservedGeneration = fasCopy.ObjectMeta.Generation
 	if scaled {
 		now := metav1.NewTime(time.Now())
 		fasCopy.Status.LastScaleTime = &now
@@ -263,6 +318,7 @@ func (c *Controller) updateStatusUnableToScale(fas *stablev1alpha1.FleetAutoscal
 	fasCopy.Status.ScalingLimited = false
 	fasCopy.Status.CurrentReplicas = 0
 	fasCopy.Status.DesiredReplicas = 0
+	fasCopy.Status.ObservedGeneration = fasCopy.ObjectMeta.Generation
 
 	if !apiequality.Semantic.DeepEqual(fas.Status, fasCopy.Status) {
 		_, err := c.fleetAutoscalerGetter.FleetAutoscalers(fas.ObjectMeta.Namespace).Update(fasCopy)
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
 	agtesting "agones.dev/agones/pkg/testing"
@@ -331,12 +332,111 @@ func TestControllerScaleFleet(t *testing.T) {
 	})
 }
 
+func TestControllerScaleFleetScaleDownDelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scale down is recorded, not applied", func(t *testing.T) {
+		c, m := newFakeController()
+		fas, f := defaultFixtures()
+		f.Spec.ScaleDownDelaySeconds = 60
+
+		update := false
+
+		m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			update = true
+			ca := action.(k8stesting.UpdateAction)
+			fleet := ca.GetObject().(*v1alpha1.Fleet)
+			assert.Equal(t, f.Spec.Replicas, fleet.Spec.Replicas)
+			assert.Contains(t, fleet.ObjectMeta.Annotations, v1alpha1.ScaleDownRequestedAnnotation)
+
+			return true, fleet, nil
+		})
+
+		err := c.scaleFleet(fas, f, f.Spec.Replicas-3)
+		assert.Nil(t, err)
+		assert.True(t, update, "Fleet should be updated")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScaleDownDelayed")
+	})
+
+	t.Run("scale down waits for the delay", func(t *testing.T) {
+		c, m := newFakeController()
+		fas, f := defaultFixtures()
+		f.Spec.ScaleDownDelaySeconds = 60
+		f.ObjectMeta.Annotations = map[string]string{
+			v1alpha1.ScaleDownRequestedAnnotation: time.Now().UTC().Format(time.RFC3339),
+		}
+
+		m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "fleet should not update")
+			return false, nil, nil
+		})
+
+		err := c.scaleFleet(fas, f, f.Spec.Replicas-3)
+		assert.Nil(t, err)
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+
+	t.Run("scale down applies once the delay has elapsed", func(t *testing.T) {
+		c, m := newFakeController()
+		fas, f := defaultFixtures()
+		f.Spec.ScaleDownDelaySeconds = 60
+		f.ObjectMeta.Annotations = map[string]string{
+			v1alpha1.ScaleDownRequestedAnnotation: time.Now().UTC().Add(-2 * time.Minute).Format(time.RFC3339),
+		}
+		replicas := f.Spec.Replicas - 3
+
+		update := false
+
+		m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			update = true
+			ca := action.(k8stesting.UpdateAction)
+			fleet := ca.GetObject().(*v1alpha1.Fleet)
+			assert.Equal(t, replicas, fleet.Spec.Replicas)
+			assert.NotContains(t, fleet.ObjectMeta.Annotations, v1alpha1.ScaleDownRequestedAnnotation)
+
+			return true, fleet, nil
+		})
+
+		err := c.scaleFleet(fas, f, replicas)
+		assert.Nil(t, err)
+		assert.True(t, update, "Fleet should be updated")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "AutoScalingFleet")
+	})
+
+	t.Run("returning demand clears the pending scale down", func(t *testing.T) {
+		c, m := newFakeController()
+		fas, f := defaultFixtures()
+		f.Spec.ScaleDownDelaySeconds = 60
+		f.ObjectMeta.Annotations = map[string]string{
+			v1alpha1.ScaleDownRequestedAnnotation: time.Now().UTC().Format(time.RFC3339),
+		}
+
+		update := false
+
+		m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			update = true
+			ca := action.(k8stesting.UpdateAction)
+			fleet := ca.GetObject().(*v1alpha1.Fleet)
+			assert.Equal(t, f.Spec.Replicas, fleet.Spec.Replicas)
+			assert.NotContains(t, fleet.ObjectMeta.Annotations, v1alpha1.ScaleDownRequestedAnnotation)
+
+			return true, fleet, nil
+		})
+
+		err := c.scaleFleet(fas, f, f.Spec.Replicas)
+		assert.Nil(t, err)
+		assert.True(t, update, "Fleet should be updated")
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+}
+
 func TestControllerUpdateStatus(t *testing.T) {
 	t.Parallel()
 
 	t.Run("must update", func(t *testing.T) {
 		c, m := newFakeController()
 		fas, _ := defaultFixtures()
+		fas.ObjectMeta.Generation = 3
 
 		fasUpdated := false
 
@@ -349,6 +449,7 @@ func TestControllerUpdateStatus(t *testing.T) {
 			assert.Equal(t, fas.Status.CurrentReplicas, int32(10))
 			assert.Equal(t, fas.Status.DesiredReplicas, int32(20))
 			assert.NotNil(t, fas.Status.LastScaleTime)
+			assert.Equal(t, int64(3), fas.Status.ObservedGeneration)
 			return true, fas, nil
 		})
 
@@ -47,6 +47,10 @@ func computeDesiredFleetSize(fas *stablev1alpha1.FleetAutoscaler, f *stablev1alp
 		return applyBufferPolicy(fas.Spec.Policy.Buffer, f)
 	case stablev1alpha1.WebhookPolicyType:
 		return applyWebhookPolicy(fas.Spec.Policy.Webhook, f)
+	case stablev1alpha1.CounterPolicyType:
+		return applyCounterPolicy(fas.Spec.Policy.Counter, f)
+	case stablev1alpha1.ListPolicyType:
+		return applyListPolicy(fas.Spec.Policy.List, f)
 	}
 
 	return f.Status.Replicas, false, errors.New("wrong policy type")
@@ -169,3 +173,54 @@ func applyBufferPolicy(b *stablev1alpha1.BufferPolicy, f *stablev1alpha1.Fleet)
 
 	return replicas, limited, nil
 }
+
+func applyCounterPolicy(c *stablev1alpha1.CounterPolicy, f *stablev1alpha1.Fleet) (int32, bool, error) {
+	agg, ok := f.Status.Counters[c.Key]
+	if !ok {
+		return f.Status.Replicas, false, errors.Errorf("fleet %s has no aggregated counter %s to scale on", f.ObjectMeta.Name, c.Key)
+	}
+	return applyCapacityPolicy(f, agg.Count, agg.Capacity, c.MinCapacity, c.MaxCapacity, c.BufferSize)
+}
+
+func applyListPolicy(l *stablev1alpha1.ListPolicy, f *stablev1alpha1.Fleet) (int32, bool, error) {
+	agg, ok := f.Status.Lists[l.Key]
+	if !ok {
+		return f.Status.Replicas, false, errors.Errorf("fleet %s has no aggregated list %s to scale on", f.ObjectMeta.Name, l.Key)
+	}
+	return applyCapacityPolicy(f, agg.Count, agg.Capacity, l.MinCapacity, l.MaxCapacity, l.BufferSize)
+}
+
+// applyCapacityPolicy resizes the fleet so that the given buffer of aggregated
+// capacity stays free, translating capacity into replicas through the average
+// capacity a single replica currently provides
+func applyCapacityPolicy(f *stablev1alpha1.Fleet, count, capacity, minCapacity, maxCapacity int64, bufferSize intstr.IntOrString) (int32, bool, error) {
+	if f.Status.Replicas <= 0 || capacity <= 0 {
+		return f.Status.Replicas, false, errors.Errorf("fleet %s has no per replica capacity to scale on", f.ObjectMeta.Name)
+	}
+	capacityPerReplica := float64(capacity) / float64(f.Status.Replicas)
+
+	var desiredCapacity float64
+	if bufferSize.Type == intstr.Int {
+		desiredCapacity = float64(count + int64(bufferSize.IntValue()))
+	} else {
+		// as with the Buffer policy, the percentage applies to the future
+		// capacity, not the current one
+		bufferPercent, err := intstr.GetValueFromIntOrPercent(&bufferSize, 100, true)
+		if err != nil {
+			return f.Status.Replicas, false, err
+		}
+		desiredCapacity = math.Ceil(float64(count*100) / float64(100-bufferPercent))
+	}
+
+	limited := false
+	if minCapacity != 0 && desiredCapacity < float64(minCapacity) {
+		desiredCapacity = float64(minCapacity)
+		limited = true
+	}
+	if desiredCapacity > float64(maxCapacity) {
+		desiredCapacity = float64(maxCapacity)
+		limited = true
+	}
+
+	return int32(math.Ceil(desiredCapacity / capacityPerReplica)), limited, nil
+}
@@ -123,6 +123,95 @@ func TestApplyBufferPolicy(t *testing.T) {
 	assert.Equal(t, limited, false)
 }
 
+func TestApplyCounterPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, f := defaultFixtures()
+	c := &v1alpha1.CounterPolicy{
+		Key:         "rooms",
+		BufferSize:  intstr.FromInt(300),
+		MaxCapacity: 2000,
+	}
+
+	// 10 replicas provide 100 rooms each; 800 in use plus a 300 buffer
+	// needs 1100 capacity, so an 11th replica
+	f.Status.Replicas = 10
+	f.Status.Counters = map[string]v1alpha1.AggregatedCounterStatus{
+		"rooms": {Count: 800, Capacity: 1000},
+	}
+	replicas, limited, err := applyCounterPolicy(c, f)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(11), replicas)
+	assert.Equal(t, false, limited)
+
+	// capped by maxCapacity
+	c.MaxCapacity = 1000
+	replicas, limited, err = applyCounterPolicy(c, f)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(10), replicas)
+	assert.Equal(t, true, limited)
+
+	// held up by minCapacity
+	c.MaxCapacity = 2000
+	c.MinCapacity = 500
+	c.BufferSize = intstr.FromInt(50)
+	f.Status.Counters["rooms"] = v1alpha1.AggregatedCounterStatus{Count: 100, Capacity: 1000}
+	replicas, limited, err = applyCounterPolicy(c, f)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(5), replicas)
+	assert.Equal(t, true, limited)
+
+	// percentage buffer applies to the future capacity
+	c.MinCapacity = 0
+	c.BufferSize = intstr.FromString("20%")
+	f.Status.Counters["rooms"] = v1alpha1.AggregatedCounterStatus{Count: 800, Capacity: 1000}
+	replicas, limited, err = applyCounterPolicy(c, f)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(10), replicas)
+	assert.Equal(t, false, limited)
+
+	// a counter the fleet doesn't aggregate is an error
+	c.Key = "missing"
+	replicas, _, err = applyCounterPolicy(c, f)
+	assert.NotNil(t, err)
+	assert.Equal(t, f.Status.Replicas, replicas)
+
+	// no capacity to derive a per replica value from is an error
+	c.Key = "rooms"
+	f.Status.Counters["rooms"] = v1alpha1.AggregatedCounterStatus{}
+	replicas, _, err = applyCounterPolicy(c, f)
+	assert.NotNil(t, err)
+	assert.Equal(t, f.Status.Replicas, replicas)
+}
+
+func TestApplyListPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, f := defaultFixtures()
+	l := &v1alpha1.ListPolicy{
+		Key:         "players",
+		BufferSize:  intstr.FromInt(100),
+		MaxCapacity: 1000,
+	}
+
+	// 5 replicas provide 100 entries each; 450 in use plus a 100 buffer
+	// needs 550 capacity, so a 6th replica
+	f.Status.Replicas = 5
+	f.Status.Lists = map[string]v1alpha1.AggregatedListStatus{
+		"players": {Count: 450, Capacity: 500},
+	}
+	replicas, limited, err := applyListPolicy(l, f)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(6), replicas)
+	assert.Equal(t, false, limited)
+
+	// a list the fleet doesn't aggregate is an error
+	l.Key = "missing"
+	replicas, _, err = applyListPolicy(l, f)
+	assert.NotNil(t, err)
+	assert.Equal(t, f.Status.Replicas, replicas)
+}
+
 type testServer struct{}
 
 func (t testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -25,6 +25,7 @@ import (
 	"agones.dev/agones/pkg/client/informers/externalversions"
 	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
 	"agones.dev/agones/pkg/util/crd"
+	"agones.dev/agones/pkg/util/images"
 	"agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/webhooks"
 	"agones.dev/agones/pkg/util/workerqueue"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -49,16 +51,24 @@ import (
 
 // Controller is a the GameServerSet controller
 type Controller struct {
-	logger              *logrus.Entry
-	crdGetter           v1beta1.CustomResourceDefinitionInterface
-	gameServerSetGetter getterv1alpha1.GameServerSetsGetter
-	gameServerSetLister listerv1alpha1.GameServerSetLister
-	gameServerSetSynced cache.InformerSynced
-	fleetGetter         getterv1alpha1.FleetsGetter
-	fleetLister         listerv1alpha1.FleetLister
-	fleetSynced         cache.InformerSynced
-	workerqueue         *workerqueue.WorkerQueue
-	recorder            record.EventRecorder
+	logger                   *logrus.Entry
+	crdGetter                v1beta1.CustomResourceDefinitionInterface
+	gameServerSetGetter      getterv1alpha1.GameServerSetsGetter
+	gameServerSetLister      listerv1alpha1.GameServerSetLister
+	gameServerSetSynced      cache.InformerSynced
+	fleetGetter              getterv1alpha1.FleetsGetter
+	fleetLister              listerv1alpha1.FleetLister
+	fleetSynced              cache.InformerSynced
+	gameServerTemplateLister listerv1alpha1.GameServerTemplateLister
+	gameServerTemplateSynced cache.InformerSynced
+	workerqueue              *workerqueue.WorkerQueue
+	recorder                 record.EventRecorder
+	imageResolver            imageResolver
+}
+
+// imageResolver resolves image tags to digest pinned references
+type imageResolver interface {
+	Resolve(image string) (string, error)
 }
 
 // NewController returns a new fleets crd controller
@@ -76,14 +86,20 @@ func NewController(
 	fleets := agonesInformerFactory.Stable().V1alpha1().Fleets()
 	fInformer := fleets.Informer()
 
+	gameServerTemplates := agonesInformerFactory.Stable().V1alpha1().GameServerTemplates()
+	gsTemplateInformer := gameServerTemplates.Informer()
+
 	c := &Controller{
-		crdGetter:           extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
-		gameServerSetGetter: agonesClient.StableV1alpha1(),
-		gameServerSetLister: gameServerSets.Lister(),
-		gameServerSetSynced: gsSetInformer.HasSynced,
-		fleetGetter:         agonesClient.StableV1alpha1(),
-		fleetLister:         fleets.Lister(),
-		fleetSynced:         fInformer.HasSynced,
+		crdGetter:                extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
+		gameServerSetGetter:      agonesClient.StableV1alpha1(),
+		gameServerSetLister:      gameServerSets.Lister(),
+		gameServerSetSynced:      gsSetInformer.HasSynced,
+		fleetGetter:              agonesClient.StableV1alpha1(),
+		fleetLister:              fleets.Lister(),
+		fleetSynced:              fInformer.HasSynced,
+		gameServerTemplateLister: gameServerTemplates.Lister(),
+		gameServerTemplateSynced: gsTemplateInformer.HasSynced,
+		imageResolver:            images.NewResolver(),
 	}
 
 	c.logger = runtime.NewLoggerWithType(c)
@@ -115,6 +131,13 @@ func NewController(
 		},
 	})
 
+	gsTemplateInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.gameServerTemplateEventHandler,
+		UpdateFunc: func(_, newObj interface{}) {
+			c.gameServerTemplateEventHandler(newObj)
+		},
+	})
+
 	return c
 }
 
@@ -168,8 +191,13 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 		return err
 	}
 
+	err = crd.WaitForEstablishedCRD(c.crdGetter, "gameservertemplates."+stable.GroupName, c.logger)
+	if err != nil {
+		return err
+	}
+
 	c.logger.Info("Wait for cache sync")
-	if !cache.WaitForCacheSync(stop, c.gameServerSetSynced, c.fleetSynced) {
+	if !cache.WaitForCacheSync(stop, c.gameServerSetSynced, c.fleetSynced, c.gameServerTemplateSynced) {
 		return errors.New("failed to wait for caches to sync")
 	}
 
@@ -177,6 +205,12 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 	return nil
 }
 
+// WaitUntilRunning blocks until this controller has synced its caches and
+// its workers are processing events, or stop is closed
+func (c *Controller) WaitUntilRunning(stop <-chan struct{}) bool {
+	return c.workerqueue.WaitUntilRunning(stop)
+}
+
 // gameServerSetEventHandler enqueues the owning Fleet for this GameServerSet,
 // assuming that it has one
 func (c *Controller) gameServerSetEventHandler(obj interface{}) {
@@ -199,6 +233,84 @@ func (c *Controller) gameServerSetEventHandler(obj interface{}) {
 	c.workerqueue.Enqueue(fleet)
 }
 
+// gameServerTemplateEventHandler enqueues the Fleets that reference the
+// changed GameServerTemplate preset, so the new template is rolled out
+func (c *Controller) gameServerTemplateEventHandler(obj interface{}) {
+	template := obj.(*stablev1alpha1.GameServerTemplate)
+	fleets, err := c.fleetLister.Fleets(template.ObjectMeta.Namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(c.logger.WithField("gameservertemplate", template.ObjectMeta.Name),
+			errors.Wrap(err, "error listing fleets"))
+		return
+	}
+
+	for _, fleet := range fleets {
+		if fleet.Spec.TemplateRef == template.ObjectMeta.Name {
+			c.workerqueue.Enqueue(fleet)
+		}
+	}
+}
+
+// resolveTemplate inlines the GameServerTemplate preset referenced by the
+// fleet's templateRef, returning a copy of the Fleet with the resolved
+// template. Fleets without a templateRef are returned as is
+func (c *Controller) resolveTemplate(fleet *stablev1alpha1.Fleet) (*stablev1alpha1.Fleet, error) {
+	if fleet.Spec.TemplateRef == "" {
+		return fleet, nil
+	}
+
+	template, err := c.gameServerTemplateLister.GameServerTemplates(fleet.ObjectMeta.Namespace).Get(fleet.Spec.TemplateRef)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			c.recorder.Eventf(fleet, corev1.EventTypeWarning, "FailedTemplateRef",
+				"could not find GameServerTemplate %s", fleet.Spec.TemplateRef)
+		}
+		return nil, errors.Wrapf(err, "error retrieving GameServerTemplate %s for fleet %s", fleet.Spec.TemplateRef, fleet.ObjectMeta.Name)
+	}
+
+	fCopy := fleet.DeepCopy()
+	fCopy.Spec.Template = fleet.ResolveTemplate(template)
+	return fCopy, nil
+}
+
+// pinImageDigests returns a copy of the Fleet with every container image tag
+// in its GameServer templates resolved to a digest pinned reference. The
+// resolver caches resolutions, so a tag that is re-pushed mid rollout keeps
+// the digest it had when the rollout started
+func (c *Controller) pinImageDigests(fleet *stablev1alpha1.Fleet) (*stablev1alpha1.Fleet, error) {
+	fCopy := fleet.DeepCopy()
+
+	pin := func(template *stablev1alpha1.GameServerTemplateSpec) error {
+		for i := range template.Spec.Template.Spec.Containers {
+			container := &template.Spec.Template.Spec.Containers[i]
+			resolved, err := c.imageResolver.Resolve(container.Image)
+			if err != nil {
+				c.recorder.Eventf(fleet, corev1.EventTypeWarning, "FailedImagePinning",
+					"could not resolve a digest for image %s", container.Image)
+				return errors.Wrapf(err, "error pinning image digests for fleet %s", fleet.ObjectMeta.Name)
+			}
+			container.Image = resolved
+		}
+		return nil
+	}
+
+	if err := pin(&fCopy.Spec.Template); err != nil {
+		return nil, err
+	}
+	for i := range fCopy.Spec.Flavors {
+		if err := pin(&fCopy.Spec.Flavors[i].Template); err != nil {
+			return nil, err
+		}
+	}
+	if fCopy.Spec.Canary != nil {
+		if err := pin(&fCopy.Spec.Canary.Template); err != nil {
+			return nil, err
+		}
+	}
+
+	return fCopy, nil
+}
+
 // syncFleet synchronised the fleet CRDs and configures/updates
 // backing GameServerSets
 func (c *Controller) syncFleet(key string) error {
@@ -221,27 +333,59 @@ func (c *Controller) syncFleet(key string) error {
 		return errors.Wrapf(err, "error retrieving fleet %s from namespace %s", name, namespace)
 	}
 
+	// inline any referenced GameServerTemplate preset, so the rest of the
+	// sync - including active GameServerSet detection - sees the full
+	// template. The resolved copy drives reconciliation only, and is never
+	// written back to the Fleet, so the spec keeps the reference rather
+	// than the preset's values
+	resolved, err := c.resolveTemplate(fleet)
+	if err != nil {
+		return err
+	}
+
+	// pin template images to digests before active GameServerSet detection,
+	// so the pinned template compares stable across syncs. As above, the pin
+	// stays on the in-memory copy - persisted into the spec, it would
+	// outlive the rollout it was taken for
+	if resolved.Spec.PinImageDigests {
+		resolved, err = c.pinImageDigests(resolved)
+		if err != nil {
+			return err
+		}
+	}
+
 	list, err := ListGameServerSetsByFleetOwner(c.gameServerSetLister, fleet)
 	if err != nil {
 		return err
 	}
 
-	active, rest := c.filterGameServerSetByActive(fleet, list)
+	// a paused Fleet stops replica and deployment reconciliation, so manual
+	// intervention isn't fought by the controller, but the pause state still
+	// propagates to the owned GameServerSets and status stays up to date
+	if err := c.syncPaused(fleet, list); err != nil {
+		return err
+	}
+	if fleet.Spec.Paused {
+		return c.updateFleetStatus(fleet)
+	}
+
+	active, rest := c.filterGameServerSetByActive(resolved, list)
 	// if there isn't an active gameServerSet, create one (but don't persist yet)
 	if active == nil {
 		c.logger.WithField("fleet", fleet.ObjectMeta.Name).Info("could not find active GameServerSet, creating")
-		active = fleet.GameServerSet()
+		active = resolved.GameServerSet()
 	}
 
-	replicas, err := c.applyDeploymentStrategy(fleet, active, rest)
+	replicas, err := c.applyDeploymentStrategy(resolved, active, rest)
 	if err != nil {
 		return err
 	}
+	replicas = c.applyDisruptionBudget(resolved, active, rest, replicas)
 	if err := c.deleteEmptyGameServerSets(fleet, rest); err != nil {
 		return err
 	}
 
-	if err := c.upsertGameServerSet(fleet, active, replicas); err != nil {
+	if err := c.upsertGameServerSet(resolved, active, replicas); err != nil {
 		return err
 	}
 	return c.updateFleetStatus(fleet)
@@ -263,20 +407,103 @@ func (c *Controller) upsertGameServerSet(fleet *stablev1alpha1.Fleet, active *st
 		return nil
 	}
 
-	if replicas != active.Spec.Replicas {
+	// flavor changes - including a canary template or percentage change -
+	// and label or annotation only template changes roll through the active
+	// GameServerSet in place, rather than through a new GameServerSet, as
+	// the set's own template spec hasn't changed
+	flavors := fleet.DesiredFlavors()
+	if replicas != active.Spec.Replicas || !reflect.DeepEqual(flavors, active.Spec.Flavors) ||
+		!reflect.DeepEqual(fleet.Spec.Template, active.Spec.Template) {
 		gsSetCopy := active.DeepCopy()
 		gsSetCopy.Spec.Replicas = replicas
+		gsSetCopy.Spec.Flavors = flavors
+		gsSetCopy.Spec.Template = *fleet.Spec.Template.DeepCopy()
+		// the lister's view of the paused state may be stale when the fleet
+		// has just been resumed, so carry the fleet's own value
+		gsSetCopy.Spec.Paused = fleet.Spec.Paused
 		gsSetCopy, err := c.gameServerSetGetter.GameServerSets(fleet.ObjectMeta.Namespace).Update(gsSetCopy)
 		if err != nil {
 			return errors.Wrapf(err, "error updating replicas for gameserverset for fleet %s", fleet.ObjectMeta.Name)
 		}
-		c.recorder.Eventf(fleet, corev1.EventTypeNormal, "ScalingGameServerSet",
-			"Scaling active GameServerSet %s from %d to %d", gsSetCopy.ObjectMeta.Name, active.Spec.Replicas, gsSetCopy.Spec.Replicas)
+		switch {
+		case replicas != active.Spec.Replicas:
+			c.recorder.Eventf(fleet, corev1.EventTypeNormal, "ScalingGameServerSet",
+				"Scaling active GameServerSet %s from %d to %d", gsSetCopy.ObjectMeta.Name, active.Spec.Replicas, gsSetCopy.Spec.Replicas)
+		case !reflect.DeepEqual(flavors, active.Spec.Flavors):
+			c.recorder.Eventf(fleet, corev1.EventTypeNormal, "UpdatingGameServerSet",
+				"Updating active GameServerSet %s template flavors", gsSetCopy.ObjectMeta.Name)
+		default:
+			c.recorder.Eventf(fleet, corev1.EventTypeNormal, "UpdatingGameServerSet",
+				"Updating active GameServerSet %s template metadata", gsSetCopy.ObjectMeta.Name)
+		}
+	}
+
+	return nil
+}
+
+// syncPaused propagates the Fleet's Paused field to all of its
+// GameServerSets, so their own replica reconciliation pauses and resumes
+// along with the Fleet's
+func (c *Controller) syncPaused(fleet *stablev1alpha1.Fleet, list []*stablev1alpha1.GameServerSet) error {
+	for _, gsSet := range list {
+		if gsSet.Spec.Paused == fleet.Spec.Paused {
+			continue
+		}
+
+		gsSetCopy := gsSet.DeepCopy()
+		gsSetCopy.Spec.Paused = fleet.Spec.Paused
+		if _, err := c.gameServerSetGetter.GameServerSets(fleet.ObjectMeta.Namespace).Update(gsSetCopy); err != nil {
+			return errors.Wrapf(err, "error updating paused state of gameserverset %s for fleet %s",
+				gsSet.ObjectMeta.Name, fleet.ObjectMeta.Name)
+		}
+
+		if fleet.Spec.Paused {
+			c.recorder.Eventf(fleet, corev1.EventTypeNormal, "PausedGameServerSet",
+				"Paused GameServerSet %s", gsSet.ObjectMeta.Name)
+		} else {
+			c.recorder.Eventf(fleet, corev1.EventTypeNormal, "ResumedGameServerSet",
+				"Resumed GameServerSet %s", gsSet.ObjectMeta.Name)
+		}
 	}
 
 	return nil
 }
 
+// applyDisruptionBudget clamps a scale down of the active GameServerSet so
+// that, combined with any in flight rolling update churn, the number of
+// simultaneously disrupted GameServers stays within the Fleet's disruption
+// budget. Scaling continues on subsequent syncs as earlier disruption clears
+func (c *Controller) applyDisruptionBudget(fleet *stablev1alpha1.Fleet, active *stablev1alpha1.GameServerSet, rest []*stablev1alpha1.GameServerSet, replicas int32) int32 {
+	budget, ok := fleet.MaxDisruptedReplicas()
+	if !ok || active.ObjectMeta.UID == "" || replicas >= active.Spec.Replicas {
+		return replicas
+	}
+
+	headroom := fleet.LowerBoundReplicas(budget - countDisruptedReplicas(active, rest))
+	if step := active.Spec.Replicas - replicas; step > headroom {
+		replicas = active.Spec.Replicas - headroom
+		c.logger.WithField("fleet", fleet.ObjectMeta.Name).WithField("replicas", replicas).
+			Info("clamping scale down of active gameserverset to fleet disruption budget")
+	}
+
+	return replicas
+}
+
+// countDisruptedReplicas returns the number of GameServers across the active
+// and inactive GameServerSets that are currently being disrupted - either
+// shutting down, or pending removal because their GameServerSet has been
+// scaled below its current replica count
+func countDisruptedReplicas(active *stablev1alpha1.GameServerSet, rest []*stablev1alpha1.GameServerSet) int32 {
+	var count int32
+	for _, gsSet := range append([]*stablev1alpha1.GameServerSet{active}, rest...) {
+		count += gsSet.Status.ShuttingDownReplicas
+		if diff := gsSet.Status.Replicas - gsSet.Spec.Replicas; diff > 0 {
+			count += diff
+		}
+	}
+	return count
+}
+
 // applyDeploymentStrategy applies the Fleet > Spec > Deployment strategy to all the non-active
 // GameServerSets that are passed in
 func (c *Controller) applyDeploymentStrategy(fleet *stablev1alpha1.Fleet, active *stablev1alpha1.GameServerSet, rest []*stablev1alpha1.GameServerSet) (int32, error) {
@@ -341,7 +568,7 @@ func (c *Controller) rollingUpdateDeployment(fleet *stablev1alpha1.Fleet, active
 	if err != nil {
 		return replicas, err
 	}
-	if err := c.rollingUpdateRest(fleet, rest); err != nil {
+	if err := c.rollingUpdateRest(fleet, active, rest); err != nil {
 		return replicas, err
 	}
 	return replicas, nil
@@ -366,22 +593,18 @@ func (c *Controller) rollingUpdateActive(fleet *stablev1alpha1.Fleet, active *st
 	}
 	surge := int32(r)
 
-	// make sure we don't end up with more than the configured max surge
+	// make sure we don't end up with more than the configured max surge.
+	// Allocated GameServers on the old GameServerSets are excluded from the
+	// surge calculation - they can't be shut down without dropping players,
+	// so the new GameServerSet comes up to full strength alongside them, and
+	// they drain out of the old sets as their sessions end
 	maxSurge := surge + fleet.Spec.Replicas
 	replicas = fleet.UpperBoundReplicas(replicas + surge)
-	total := stablev1alpha1.SumStatusReplicas(rest) + replicas
+	total := stablev1alpha1.SumStatusReplicas(rest) - stablev1alpha1.SumStatusAllocatedReplicas(rest) + replicas
 	if total > maxSurge {
 		replicas = fleet.LowerBoundReplicas(replicas - (total - maxSurge))
 	}
 
-	// always leave room for Allocated GameServers
-	sumAllocated := stablev1alpha1.SumStatusAllocatedReplicas(rest)
-
-	// make room for allocated game servers, but not over the fleet replica count
-	if replicas+sumAllocated > fleet.Spec.Replicas {
-		replicas = fleet.LowerBoundReplicas(replicas - sumAllocated)
-	}
-
 	c.logger.WithField("gameserverset", active.ObjectMeta.Name).WithField("replicas", replicas).
 		Info("applying rolling update to active gameserverset")
 
@@ -389,7 +612,7 @@ func (c *Controller) rollingUpdateActive(fleet *stablev1alpha1.Fleet, active *st
 }
 
 // rollingUpdateRest applies the rolling update to the inactive GameServerSets
-func (c *Controller) rollingUpdateRest(fleet *stablev1alpha1.Fleet, rest []*stablev1alpha1.GameServerSet) error {
+func (c *Controller) rollingUpdateRest(fleet *stablev1alpha1.Fleet, active *stablev1alpha1.GameServerSet, rest []*stablev1alpha1.GameServerSet) error {
 	if len(rest) == 0 {
 		return nil
 	}
@@ -400,10 +623,30 @@ func (c *Controller) rollingUpdateRest(fleet *stablev1alpha1.Fleet, rest []*stab
 	}
 	unavailable := int32(r)
 
+	// the fleet's disruption budget is shared with any concurrent scale down
+	// of the active GameServerSet, so only take what headroom is left
+	if budget, ok := fleet.MaxDisruptedReplicas(); ok {
+		headroom := budget - countDisruptedReplicas(active, rest)
+		if headroom <= 0 {
+			c.logger.WithField("fleet", fleet.ObjectMeta.Name).
+				Info("fleet disruption budget exhausted, deferring rolling update scale down")
+			return nil
+		}
+		if headroom < unavailable {
+			unavailable = headroom
+		}
+	}
+
 	for _, gsSet := range rest {
-		// if the status.Replicas are less than or equal to 0, then that means we are done
+		// Allocated GameServers can't be shut down without dropping players, so
+		// they don't count against MaxUnavailable, and the GameServerSet is never
+		// scaled below their count - those replicas drain out as sessions end,
+		// at which point the set empties and is deleted
+		floor := gsSet.Status.AllocatedReplicas
+
+		// if the status.Replicas are down to the Allocated floor, then that means we are done
 		// scaling this GameServerSet down, and can therefore exit/move to the next one.
-		if gsSet.Status.Replicas <= 0 {
+		if gsSet.Status.Replicas <= floor {
 			continue
 		}
 		// If the Spec.Replicas does not equal the Status.Replicas for this GameServerSet, this means
@@ -414,6 +657,9 @@ func (c *Controller) rollingUpdateRest(fleet *stablev1alpha1.Fleet, rest []*stab
 
 		gsSetCopy := gsSet.DeepCopy()
 		gsSetCopy.Spec.Replicas = fleet.LowerBoundReplicas(gsSetCopy.Spec.Replicas - unavailable)
+		if gsSetCopy.Spec.Replicas < floor {
+			gsSetCopy.Spec.Replicas = floor
+		}
 
 		c.logger.WithField("gameserverset", gsSet.ObjectMeta.Name).WithField("replicas", gsSetCopy.Spec.Replicas).
 			Info("applying rolling update to inactive gameserverset")
@@ -443,11 +689,36 @@ func (c *Controller) updateFleetStatus(fleet *stablev1alpha1.Fleet) error {
 	fCopy.Status.Replicas = 0
 	fCopy.Status.ReadyReplicas = 0
 	fCopy.Status.AllocatedReplicas = 0
+	fCopy.Status.ShuttingDownReplicas = 0
+	fCopy.Status.Counters = nil
+	fCopy.Status.Lists = nil
+	fCopy.Status.LabelSelector = stablev1alpha1.FleetNameLabel + "=" + fleet.ObjectMeta.Name
+	fCopy.Status.ObservedGeneration = fCopy.ObjectMeta.Generation
 
 	for _, gsSet := range list {
 		fCopy.Status.Replicas += gsSet.Status.Replicas
 		fCopy.Status.ReadyReplicas += gsSet.Status.ReadyReplicas
 		fCopy.Status.AllocatedReplicas += gsSet.Status.AllocatedReplicas
+		fCopy.Status.ShuttingDownReplicas += gsSet.Status.ShuttingDownReplicas
+
+		for name, counter := range gsSet.Status.Counters {
+			if fCopy.Status.Counters == nil {
+				fCopy.Status.Counters = map[string]stablev1alpha1.AggregatedCounterStatus{}
+			}
+			agg := fCopy.Status.Counters[name]
+			agg.Count += counter.Count
+			agg.Capacity += counter.Capacity
+			fCopy.Status.Counters[name] = agg
+		}
+		for name, l := range gsSet.Status.Lists {
+			if fCopy.Status.Lists == nil {
+				fCopy.Status.Lists = map[string]stablev1alpha1.AggregatedListStatus{}
+			}
+			agg := fCopy.Status.Lists[name]
+			agg.Count += l.Count
+			agg.Capacity += l.Capacity
+			fCopy.Status.Lists[name] = agg
+		}
 	}
 
 	_, err = c.fleetGetter.Fleets(fCopy.Namespace).Update(fCopy)
@@ -456,13 +727,15 @@ func (c *Controller) updateFleetStatus(fleet *stablev1alpha1.Fleet) error {
 
 // filterGameServerSetByActive returns the active GameServerSet (or nil if it
 // doesn't exist) and then the rest of the GameServerSets that are controlled
-// by this Fleet
+// by this Fleet. Only the template's spec is compared - a GameServerSet whose
+// template differs solely in labels or annotations stays active, and the
+// metadata change is rolled through it in place rather than by replacement
 func (c *Controller) filterGameServerSetByActive(fleet *stablev1alpha1.Fleet, list []*stablev1alpha1.GameServerSet) (*stablev1alpha1.GameServerSet, []*stablev1alpha1.GameServerSet) {
 	var active *stablev1alpha1.GameServerSet
 	var rest []*stablev1alpha1.GameServerSet
 
 	for _, gsSet := range list {
-		if reflect.DeepEqual(gsSet.Spec.Template, fleet.Spec.Template) {
+		if reflect.DeepEqual(gsSet.Spec.Template.Spec, fleet.Spec.Template.Spec) {
 			active = gsSet
 		} else {
 			rest = append(rest, gsSet)
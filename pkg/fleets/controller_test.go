@@ -16,7 +16,6 @@
 package fleets
 
 import (
-	"encoding/json"
 	"testing"
 	"time"
 
@@ -29,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	admv1beta1 "k8s.io/api/admission/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -143,6 +143,84 @@ func TestControllerSyncFleet(t *testing.T) {
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScalingGameServerSet")
 	})
 
+	t.Run("paused fleet leaves replicas alone", func(t *testing.T) {
+		f := defaultFixture()
+		f.Spec.Paused = true
+		c, m := newFakeController()
+		gsSet := f.GameServerSet()
+		gsSet.ObjectMeta.Name = "gsSet1"
+		gsSet.ObjectMeta.UID = "1234"
+		gsSet.Spec.Replicas = f.Spec.Replicas + 10
+		updated := false
+
+		m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+		})
+
+		m.AgonesClient.AddReactor("list", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerSetList{Items: []v1alpha1.GameServerSet{*gsSet}}, nil
+		})
+
+		m.AgonesClient.AddReactor("update", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+
+			ua := action.(k8stesting.UpdateAction)
+			gsSet := ua.GetObject().(*v1alpha1.GameServerSet)
+			// the pause propagates, but the out of sync replica count is
+			// left alone
+			assert.True(t, gsSet.Spec.Paused)
+			assert.Equal(t, f.Spec.Replicas+10, gsSet.Spec.Replicas)
+
+			return true, gsSet, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerSetSynced)
+		defer cancel()
+
+		err := c.syncFleet("default/fleet-1")
+		assert.Nil(t, err)
+		assert.True(t, updated, "gameserverset should have been paused")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "PausedGameServerSet")
+	})
+
+	t.Run("resumed fleet reconciles again", func(t *testing.T) {
+		f := defaultFixture()
+		f.Spec.Strategy.Type = appsv1.RecreateDeploymentStrategyType
+		c, m := newFakeController()
+		gsSet := f.GameServerSet()
+		gsSet.ObjectMeta.Name = "gsSet1"
+		gsSet.ObjectMeta.UID = "1234"
+		gsSet.Spec.Paused = true
+		gsSet.Spec.Replicas = f.Spec.Replicas + 10
+
+		m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+		})
+
+		m.AgonesClient.AddReactor("list", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerSetList{Items: []v1alpha1.GameServerSet{*gsSet}}, nil
+		})
+
+		var pausedStates []bool
+		m.AgonesClient.AddReactor("update", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			gsSet := ua.GetObject().(*v1alpha1.GameServerSet)
+			pausedStates = append(pausedStates, gsSet.Spec.Paused)
+
+			return true, gsSet, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerSetSynced)
+		defer cancel()
+
+		err := c.syncFleet("default/fleet-1")
+		assert.Nil(t, err)
+		// the resume lands first, then the usual replica reconciliation
+		assert.Equal(t, []bool{false, false}, pausedStates)
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ResumedGameServerSet")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScalingGameServerSet")
+	})
+
 	t.Run("gameserverset with different image details", func(t *testing.T) {
 		f := defaultFixture()
 		f.Spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
@@ -195,50 +273,258 @@ func TestControllerSyncFleet(t *testing.T) {
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScalingGameServerSet")
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "CreatingGameServerSet")
 	})
+
+	t.Run("fleet with a templateRef inlines the preset", func(t *testing.T) {
+		f := defaultFixture()
+		f.Spec.TemplateRef = "base"
+		c, m := newFakeController()
+
+		template := &v1alpha1.GameServerTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+			Spec: v1alpha1.GameServerTemplateSpec{
+				Spec: v1alpha1.GameServerSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "logger", Image: "example/logger"}},
+						},
+					},
+				},
+			},
+		}
+
+		created := false
+		m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+		})
+
+		m.AgonesClient.AddReactor("list", "gameservertemplates", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerTemplateList{Items: []v1alpha1.GameServerTemplate{*template}}, nil
+		})
+
+		m.AgonesClient.AddReactor("create", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ca := action.(k8stesting.CreateAction)
+			gsSet := ca.GetObject().(*v1alpha1.GameServerSet)
+
+			created = true
+			assert.Len(t, gsSet.Spec.Template.Spec.Template.Spec.Containers, 1)
+			assert.Equal(t, "example/logger", gsSet.Spec.Template.Spec.Template.Spec.Containers[0].Image)
+
+			return true, gsSet, nil
+		})
+
+		statusUpdated := false
+		m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			updatedFleet := ua.GetObject().(*v1alpha1.Fleet)
+
+			// the inlined preset must not be persisted into the Fleet's spec -
+			// only the reference stays
+			statusUpdated = true
+			assert.Equal(t, "base", updatedFleet.Spec.TemplateRef)
+			assert.Empty(t, updatedFleet.Spec.Template.Spec.Template.Spec.Containers)
+			return true, updatedFleet, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerTemplateSynced)
+		defer cancel()
+
+		err := c.syncFleet("default/fleet-1")
+		assert.Nil(t, err)
+		assert.True(t, created, "gameserverset should have been created")
+		assert.True(t, statusUpdated, "fleet status should have been updated")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "CreatingGameServerSet")
+	})
+
+	t.Run("fleet with a missing templateRef", func(t *testing.T) {
+		f := defaultFixture()
+		f.Spec.TemplateRef = "missing"
+		c, m := newFakeController()
+
+		m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+		})
+
+		m.AgonesClient.AddReactor("create", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "gameserverset should not be created")
+			return true, nil, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerTemplateSynced)
+		defer cancel()
+
+		err := c.syncFleet("default/fleet-1")
+		assert.NotNil(t, err)
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "FailedTemplateRef")
+	})
 }
 
-func TestControllerCreationMutationHandler(t *testing.T) {
+// fakeImageResolver pins images to a fixed fake digest, counting resolutions
+type fakeImageResolver struct {
+	resolved int
+}
+
+func (r *fakeImageResolver) Resolve(image string) (string, error) {
+	r.resolved++
+	return image + "@sha256:24a0c4b4a4c0eb97a1aabb8e29f18e917d05abfe1b7a7c07857230879ce7d3d3", nil
+}
+
+func TestControllerPinImageDigests(t *testing.T) {
 	t.Parallel()
 
-	c, _ := newFakeController()
-	gvk := metav1.GroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("Fleet"))
+	newFixture := func() *v1alpha1.Fleet {
+		f := defaultFixture()
+		f.Spec.PinImageDigests = true
+		f.Spec.Template.Spec.Template = corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "game", Image: "game:1.0"}}},
+		}
+		return f
+	}
 
-	fixture := v1alpha1.Fleet{}
+	t.Run("create gameserverset with pinned images", func(t *testing.T) {
+		f := newFixture()
+		c, m := newFakeController()
+		resolver := &fakeImageResolver{}
+		c.imageResolver = resolver
 
-	raw, err := json.Marshal(fixture)
-	assert.Nil(t, err)
-	review := admv1beta1.AdmissionReview{
-		Request: &admv1beta1.AdmissionRequest{
-			Kind:      gvk,
-			Operation: admv1beta1.Create,
-			Object: runtime.RawExtension{
-				Raw: raw,
-			},
+		created := false
+		m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+		})
+		m.AgonesClient.AddReactor("create", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ca := action.(k8stesting.CreateAction)
+			gsSet := ca.GetObject().(*v1alpha1.GameServerSet)
+
+			created = true
+			image := gsSet.Spec.Template.Spec.Template.Spec.Containers[0].Image
+			assert.Contains(t, image, "game:1.0@sha256:")
+			return true, gsSet, nil
+		})
+
+		statusUpdated := false
+		m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			updatedFleet := ua.GetObject().(*v1alpha1.Fleet)
+
+			// the digest pin must not be persisted into the Fleet's spec
+			statusUpdated = true
+			assert.Equal(t, "game:1.0", updatedFleet.Spec.Template.Spec.Template.Spec.Containers[0].Image)
+			return true, updatedFleet, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.fleetSynced)
+		defer cancel()
+
+		err := c.syncFleet("default/fleet-1")
+		assert.Nil(t, err)
+		assert.True(t, created, "gameserverset should have been created")
+		assert.True(t, statusUpdated, "fleet status should have been updated")
+		assert.Equal(t, 1, resolver.resolved)
+	})
+
+	t.Run("pinned gameserverset stays active", func(t *testing.T) {
+		f := newFixture()
+		c, m := newFakeController()
+		c.imageResolver = &fakeImageResolver{}
+
+		pinned, err := c.pinImageDigests(f)
+		assert.Nil(t, err)
+		gsSet := pinned.GameServerSet()
+		gsSet.ObjectMeta.Name = "gsSet1"
+		gsSet.ObjectMeta.UID = "4321"
+		gsSet.Spec.Replicas = f.Spec.Replicas
+
+		m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+		})
+		m.AgonesClient.AddReactor("list", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerSetList{Items: []v1alpha1.GameServerSet{*gsSet}}, nil
+		})
+		m.AgonesClient.AddReactor("create", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "gameserverset should not be created")
+			return true, nil, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerSetSynced)
+		defer cancel()
+
+		err = c.syncFleet("default/fleet-1")
+		assert.Nil(t, err)
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+}
+
+func TestControllerCanary(t *testing.T) {
+	t.Parallel()
+
+	f := defaultFixture()
+	f.Spec.Template.Spec.Container = "stable-container"
+	f.Spec.Canary = &v1alpha1.CanarySpec{
+		Percent: 20,
+		Template: v1alpha1.GameServerTemplateSpec{
+			Spec: v1alpha1.GameServerSpec{Container: "canary-container"},
 		},
-		Response: &admv1beta1.AdmissionResponse{Allowed: true},
 	}
 
-	result, err := c.creationMutationHandler(review)
-	assert.Nil(t, err)
-	assert.True(t, result.Response.Allowed)
-	assert.Equal(t, admv1beta1.PatchTypeJSONPatch, *result.Response.PatchType)
+	// the active GameServerSet predates the canary, so its flavors should
+	// be brought up to date in place rather than through a new set
+	gsSet := f.GameServerSet()
+	gsSet.ObjectMeta.Name = "gsSet1"
+	gsSet.ObjectMeta.UID = "4321"
+	gsSet.Spec.Replicas = f.Spec.Replicas
+	gsSet.Spec.Flavors = nil
 
-	patch := &jsonpatch.ByPath{}
-	err = json.Unmarshal(result.Response.Patch, patch)
-	assert.Nil(t, err)
+	c, m := newFakeController()
 
-	assertContains := func(patch *jsonpatch.ByPath, op jsonpatch.JsonPatchOperation) {
-		found := false
-		for _, p := range *patch {
-			if assert.ObjectsAreEqualValues(p, op) {
-				found = true
-			}
+	updated := false
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{*f}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerSetList{Items: []v1alpha1.GameServerSet{*gsSet}}, nil
+	})
+	m.AgonesClient.AddReactor("create", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.FailNow(t, "gameserverset should not be created")
+		return true, nil, nil
+	})
+	m.AgonesClient.AddReactor("update", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		updatedSet := ua.GetObject().(*v1alpha1.GameServerSet)
+
+		updated = true
+		if assert.Len(t, updatedSet.Spec.Flavors, 2) {
+			assert.Equal(t, v1alpha1.StableFlavorName, updatedSet.Spec.Flavors[0].Name)
+			assert.Equal(t, int32(80), updatedSet.Spec.Flavors[0].Weight)
+			assert.Equal(t, "stable-container", updatedSet.Spec.Flavors[0].Template.Spec.Container)
+			assert.Equal(t, v1alpha1.CanaryFlavorName, updatedSet.Spec.Flavors[1].Name)
+			assert.Equal(t, int32(20), updatedSet.Spec.Flavors[1].Weight)
+			assert.Equal(t, "canary-container", updatedSet.Spec.Flavors[1].Template.Spec.Container)
 		}
+		return true, updatedSet, nil
+	})
 
-		assert.True(t, found, "Could not find operation %#v in patch %v", op, *patch)
-	}
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerSetSynced)
+	defer cancel()
 
-	assertContains(patch, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/strategy/type", Value: "RollingUpdate"})
+	err := c.syncFleet("default/fleet-1")
+	assert.Nil(t, err)
+	assert.True(t, updated, "gameserverset should have been updated")
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "UpdatingGameServerSet")
+}
+
+func TestControllerCreationMutationHandler(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newFakeController()
+	gvk := metav1.GroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("Fleet"))
+
+	review := agtesting.NewAdmissionReview(t).Kind(gvk).Object(v1alpha1.Fleet{}).Review()
+
+	result, err := c.creationMutationHandler(review)
+	assert.Nil(t, err)
+	agtesting.AssertReviewAllowed(t, result)
+	assert.Equal(t, admv1beta1.PatchTypeJSONPatch, *result.Response.PatchType)
+
+	agtesting.AssertPatchContains(t, result, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/strategy/type", Value: "RollingUpdate"})
 }
 
 func TestControllerRun(t *testing.T) {
@@ -311,6 +597,7 @@ func TestControllerUpdateFleetStatus(t *testing.T) {
 	t.Parallel()
 
 	fleet := defaultFixture()
+	fleet.ObjectMeta.Generation = 2
 	c, m := newFakeController()
 
 	gsSet1 := fleet.GameServerSet()
@@ -318,6 +605,9 @@ func TestControllerUpdateFleetStatus(t *testing.T) {
 	gsSet1.Status.Replicas = 3
 	gsSet1.Status.ReadyReplicas = 2
 	gsSet1.Status.AllocatedReplicas = 1
+	gsSet1.Status.ShuttingDownReplicas = 1
+	gsSet1.Status.Counters = map[string]v1alpha1.AggregatedCounterStatus{"rooms": {Count: 2, Capacity: 10}}
+	gsSet1.Status.Lists = map[string]v1alpha1.AggregatedListStatus{"players": {Count: 3, Capacity: 20}}
 
 	gsSet2 := fleet.GameServerSet()
 	// nolint:goconst
@@ -325,6 +615,8 @@ func TestControllerUpdateFleetStatus(t *testing.T) {
 	gsSet2.Status.Replicas = 5
 	gsSet2.Status.ReadyReplicas = 5
 	gsSet2.Status.AllocatedReplicas = 2
+	gsSet2.Status.Counters = map[string]v1alpha1.AggregatedCounterStatus{"rooms": {Count: 4, Capacity: 15}}
+	gsSet2.Status.Lists = map[string]v1alpha1.AggregatedListStatus{"players": {Count: 1, Capacity: 5}}
 
 	m.AgonesClient.AddReactor("list", "gameserversets",
 		func(action k8stesting.Action) (bool, runtime.Object, error) {
@@ -341,6 +633,11 @@ func TestControllerUpdateFleetStatus(t *testing.T) {
 			assert.Equal(t, gsSet1.Status.Replicas+gsSet2.Status.Replicas, fleet.Status.Replicas)
 			assert.Equal(t, gsSet1.Status.ReadyReplicas+gsSet2.Status.ReadyReplicas, fleet.Status.ReadyReplicas)
 			assert.Equal(t, gsSet1.Status.AllocatedReplicas+gsSet2.Status.AllocatedReplicas, fleet.Status.AllocatedReplicas)
+			assert.Equal(t, gsSet1.Status.ShuttingDownReplicas+gsSet2.Status.ShuttingDownReplicas, fleet.Status.ShuttingDownReplicas)
+			assert.Equal(t, v1alpha1.FleetNameLabel+"="+fleet.ObjectMeta.Name, fleet.Status.LabelSelector)
+			assert.Equal(t, map[string]v1alpha1.AggregatedCounterStatus{"rooms": {Count: 6, Capacity: 25}}, fleet.Status.Counters)
+			assert.Equal(t, map[string]v1alpha1.AggregatedListStatus{"players": {Count: 4, Capacity: 25}}, fleet.Status.Lists)
+			assert.Equal(t, int64(2), fleet.Status.ObservedGeneration)
 			return true, fleet, nil
 		})
 
@@ -370,6 +667,12 @@ func TestControllerFilterGameServerSetByActive(t *testing.T) {
 	assert.Equal(t, gsSet1, active)
 	assert.Equal(t, []*v1alpha1.GameServerSet{gsSet2}, rest)
 
+	// a label only template difference is still active
+	gsSet1.Spec.Template.ObjectMeta.Labels = map[string]string{"version": "1.1"}
+	active, rest = c.filterGameServerSetByActive(f, []*v1alpha1.GameServerSet{gsSet1, gsSet2})
+	assert.Equal(t, gsSet1, active)
+	assert.Equal(t, []*v1alpha1.GameServerSet{gsSet2}, rest)
+
 	// none active
 	gsSet1.Spec.Template.Spec.Ports = []v1alpha1.GameServerPort{{HostPort: 9999}}
 	active, rest = c.filterGameServerSetByActive(f, []*v1alpha1.GameServerSet{gsSet1, gsSet2})
@@ -496,6 +799,47 @@ func TestControllerApplyDeploymentStrategy(t *testing.T) {
 	})
 }
 
+func TestControllerApplyDisruptionBudget(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		disruption       *v1alpha1.DisruptionSpec
+		restPendingScale int32
+		replicas         int32
+		expected         int32
+	}{
+		"no budget":                  {disruption: nil, replicas: 50, expected: 50},
+		"within budget":              {disruption: &v1alpha1.DisruptionSpec{MaxDisruptedPercent: 20}, replicas: 50, expected: 80},
+		"shared with rolling update": {disruption: &v1alpha1.DisruptionSpec{MaxDisruptedPercent: 20}, restPendingScale: 15, replicas: 50, expected: 95},
+		"budget exhausted":           {disruption: &v1alpha1.DisruptionSpec{MaxDisruptedPercent: 20}, restPendingScale: 25, replicas: 50, expected: 100},
+		"scale up is not disruption": {disruption: &v1alpha1.DisruptionSpec{MaxDisruptedPercent: 20}, replicas: 150, expected: 150},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			f := defaultFixture()
+			f.Spec.Replicas = 100
+			f.Spec.Disruption = v.disruption
+
+			active := f.GameServerSet()
+			active.ObjectMeta.Name = "active"
+			active.ObjectMeta.UID = "1234"
+			active.Spec.Replicas = 100
+			active.Status.Replicas = 100
+
+			// an inactive set mid scale down eats into the shared budget
+			rest := f.GameServerSet()
+			rest.ObjectMeta.Name = "rest"
+			rest.Spec.Replicas = 0
+			rest.Status.Replicas = v.restPendingScale
+
+			c, _ := newFakeController()
+			replicas := c.applyDisruptionBudget(f, active, []*v1alpha1.GameServerSet{rest}, v.replicas)
+			assert.Equal(t, v.expected, replicas)
+		})
+	}
+}
+
 func TestControllerUpsertGameServerSet(t *testing.T) {
 	t.Parallel()
 	f := defaultFixture()
@@ -544,6 +888,32 @@ func TestControllerUpsertGameServerSet(t *testing.T) {
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScalingGameServerSet")
 	})
 
+	t.Run("metadata only template update", func(t *testing.T) {
+		c, m := newFakeController()
+		gsSet := f.GameServerSet()
+		gsSet.ObjectMeta.UID = "1234"
+		gsSet.Spec.Replicas = replicas
+
+		fClone := f.DeepCopy()
+		fClone.Spec.Template.ObjectMeta.Labels = map[string]string{"version": "1.1"}
+
+		update := false
+		m.AgonesClient.AddReactor("update", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			update = true
+			ca := action.(k8stesting.UpdateAction)
+			gsSet := ca.GetObject().(*v1alpha1.GameServerSet)
+			assert.Equal(t, "1.1", gsSet.Spec.Template.ObjectMeta.Labels["version"])
+
+			return true, gsSet, nil
+		})
+
+		err := c.upsertGameServerSet(fClone, gsSet, replicas)
+		assert.Nil(t, err)
+
+		assert.True(t, update, "Should be update")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "template metadata")
+	})
+
 	t.Run("noop", func(t *testing.T) {
 		t.Parallel()
 
@@ -634,11 +1004,24 @@ func TestControllerRollingUpdateDeployment(t *testing.T) {
 			inactiveStatusAllocationReplicas: 5,
 
 			expected: expected{
-				inactiveSpecReplicas: 0,
-				replicas:             95,
+				inactiveSpecReplicas: 5,
+				replicas:             100,
 				updated:              true,
 			},
 		},
+		"fully allocated inactive doesn't block the update": {
+			fleetSpecReplicas:                100,
+			activeSpecReplicas:               0,
+			activeStatusReplicas:             0,
+			inactiveSpecReplicas:             100,
+			inactiveStatusReplicas:           100,
+			inactiveStatusAllocationReplicas: 100,
+			expected: expected{
+				inactiveSpecReplicas: 100,
+				replicas:             25,
+				updated:              false,
+			},
+		},
 		"attempt to drive replicas over the max surge": {
 			fleetSpecReplicas:      100,
 			activeSpecReplicas:     25,
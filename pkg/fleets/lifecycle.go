@@ -0,0 +1,222 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleets
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable"
+	stablev1alpha1 "agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"agones.dev/agones/pkg/client/informers/externalversions"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"agones.dev/agones/pkg/util/runtime"
+	"agones.dev/agones/pkg/util/workerqueue"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// LifecycleSignatureHeader is the header the hex encoded HMAC-SHA256
+// signature of the payload is sent in, when the Fleet has a signing key
+const LifecycleSignatureHeader = "X-Agones-Signature"
+
+var lifecycleClient = http.Client{
+	Timeout: 15 * time.Second,
+}
+
+// LifecyclePayload is the body POSTed to a Fleet's lifecycle webhook
+// on each key GameServer state transition
+type LifecyclePayload struct {
+	// Fleet is the name of the Fleet the GameServer belongs to
+	Fleet string `json:"fleet"`
+	// Namespace the GameServer and Fleet are in
+	Namespace string `json:"namespace"`
+	// GameServer is the name of the GameServer that transitioned
+	GameServer string `json:"gameServer"`
+	// State is the state the GameServer transitioned to
+	State stablev1alpha1.GameServerState `json:"state"`
+	// Address is the GameServer's address, if it has one yet
+	Address string `json:"address,omitempty"`
+	// Ports are the GameServer's allocated ports, if it has any yet
+	Ports []stablev1alpha1.GameServerStatusPort `json:"ports,omitempty"`
+	// Time is when the transition was observed
+	Time metav1.Time `json:"time"`
+}
+
+// LifecycleController watches GameServers, and invokes the owning Fleet's
+// lifecycle webhook when one transitions to the Ready, Allocated or
+// Shutdown state
+type LifecycleController struct {
+	logger           *logrus.Entry
+	gameServerSynced cache.InformerSynced
+	gameServerLister listerv1alpha1.GameServerLister
+	fleetSynced      cache.InformerSynced
+	fleetLister      listerv1alpha1.FleetLister
+	workerqueue      *workerqueue.WorkerQueue
+}
+
+// NewLifecycleController returns a new LifecycleController
+func NewLifecycleController(health healthcheck.Handler,
+	agonesInformerFactory externalversions.SharedInformerFactory) *LifecycleController {
+
+	gameServers := agonesInformerFactory.Stable().V1alpha1().GameServers()
+	gsInformer := gameServers.Informer()
+	fleets := agonesInformerFactory.Stable().V1alpha1().Fleets()
+
+	lc := &LifecycleController{
+		gameServerSynced: gsInformer.HasSynced,
+		gameServerLister: gameServers.Lister(),
+		fleetSynced:      fleets.Informer().HasSynced,
+		fleetLister:      fleets.Lister(),
+	}
+
+	lc.logger = runtime.NewLoggerWithType(lc)
+	lc.workerqueue = workerqueue.NewWorkerQueue(lc.syncGameServer, lc.logger, stable.GroupName+".LifecycleController")
+	health.AddLivenessCheck("fleet-lifecycle-workerqueue", healthcheck.Check(lc.workerqueue.Healthy))
+
+	gsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldGS := oldObj.(*stablev1alpha1.GameServer)
+			newGS := newObj.(*stablev1alpha1.GameServer)
+			if oldGS.Status.State == newGS.Status.State || !isLifecycleState(newGS.Status.State) {
+				return
+			}
+			// only GameServers that belong to a Fleet can have a webhook configured
+			if newGS.ObjectMeta.Labels[stablev1alpha1.FleetNameLabel] == "" {
+				return
+			}
+			key := newGS.ObjectMeta.Namespace + "/" + newGS.ObjectMeta.Name + "/" + string(newGS.Status.State)
+			lc.workerqueue.Enqueue(cache.ExplicitKey(key))
+		},
+	})
+
+	return lc
+}
+
+// isLifecycleState returns true if the state is one that the lifecycle
+// webhook is invoked for
+func isLifecycleState(state stablev1alpha1.GameServerState) bool {
+	return state == stablev1alpha1.GameServerStateReady ||
+		state == stablev1alpha1.GameServerStateAllocated ||
+		state == stablev1alpha1.GameServerStateShutdown
+}
+
+// Run the LifecycleController. Will block until stop is closed
+func (lc *LifecycleController) Run(workers int, stop <-chan struct{}) error {
+	lc.logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, lc.gameServerSynced, lc.fleetSynced) {
+		return errors.New("failed to wait for caches to sync")
+	}
+
+	lc.workerqueue.Run(workers, stop)
+	return nil
+}
+
+// WaitUntilRunning blocks until the LifecycleController is processing
+// events, or stop is closed
+func (lc *LifecycleController) WaitUntilRunning(stop <-chan struct{}) bool {
+	return lc.workerqueue.WaitUntilRunning(stop)
+}
+
+// syncGameServer sends the transition encoded in the key to the owning
+// Fleet's lifecycle webhook, if one is configured
+func (lc *LifecycleController) syncGameServer(key string) error {
+	lc.logger.WithField("key", key).Info("Synchronising")
+
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		// don't return an error, as we don't want this retried
+		runtime.HandleError(lc.logger.WithField("key", key), errors.New("invalid transition key"))
+		return nil
+	}
+	namespace, name, state := parts[0], parts[1], stablev1alpha1.GameServerState(parts[2])
+
+	gs, err := lc.gameServerLister.GameServers(namespace).Get(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			lc.logger.WithField("key", key).Info("GameServer is no longer available for syncing")
+			return nil
+		}
+		return errors.Wrapf(err, "error retrieving GameServer %s from namespace %s", name, namespace)
+	}
+
+	fleetName := gs.ObjectMeta.Labels[stablev1alpha1.FleetNameLabel]
+	fleet, err := lc.fleetLister.Fleets(namespace).Get(fleetName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			lc.logger.WithField("key", key).Info("Fleet is no longer available for syncing")
+			return nil
+		}
+		return errors.Wrapf(err, "error retrieving Fleet %s from namespace %s", fleetName, namespace)
+	}
+
+	if fleet.Spec.Lifecycle == nil || fleet.Spec.Lifecycle.WebhookURL == "" {
+		return nil
+	}
+
+	payload := LifecyclePayload{
+		Fleet:      fleet.ObjectMeta.Name,
+		Namespace:  namespace,
+		GameServer: name,
+		State:      state,
+		Address:    gs.Status.Address,
+		Ports:      gs.Status.Ports,
+		Time:       metav1.Now(),
+	}
+
+	return lc.send(fleet.Spec.Lifecycle, &payload)
+}
+
+// send POSTs the payload to the webhook, signing it if the Fleet has a
+// signing key. Returning an error will cause the delivery to be retried
+func (lc *LifecycleController) send(lifecycle *stablev1alpha1.LifecycleSpec, payload *LifecyclePayload) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling lifecycle payload")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, lifecycle.WebhookURL, strings.NewReader(string(b)))
+	if err != nil {
+		return errors.Wrapf(err, "error creating request for lifecycle webhook %s", lifecycle.WebhookURL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if lifecycle.SigningKey != "" {
+		mac := hmac.New(sha256.New, []byte(lifecycle.SigningKey))
+		mac.Write(b) // nolint: errcheck
+		req.Header.Set(LifecycleSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	res, err := lifecycleClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error posting to lifecycle webhook %s", lifecycle.WebhookURL)
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("bad status code %d from lifecycle webhook %s", res.StatusCode, lifecycle.WebhookURL)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleets
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLifecycleControllerSyncGameServer(t *testing.T) {
+	t.Parallel()
+
+	received := 0
+	var body []byte
+	var signature string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		signature = r.Header.Get(LifecycleSignatureHeader)
+		body, _ = ioutil.ReadAll(r.Body) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	m := agtesting.NewMocks()
+	lc := NewLifecycleController(healthcheck.NewHandler(), m.AgonesInformerFactory)
+
+	fleet := v1alpha1.Fleet{ObjectMeta: metav1.ObjectMeta{Name: "fleet-1", Namespace: "default"},
+		Spec: v1alpha1.FleetSpec{Lifecycle: &v1alpha1.LifecycleSpec{WebhookURL: ts.URL, SigningKey: "green"}}}
+	gs := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default",
+		Labels: map[string]string{v1alpha1.FleetNameLabel: fleet.ObjectMeta.Name}},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady, Address: "10.0.0.1",
+			Ports: []v1alpha1.GameServerStatusPort{{Name: "default", Port: 7777}}}}
+
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{fleet}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, lc.gameServerSynced, lc.fleetSynced)
+	defer cancel()
+
+	err := lc.syncGameServer("default/test/Ready")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, received)
+
+	var payload LifecyclePayload
+	err = json.Unmarshal(body, &payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "fleet-1", payload.Fleet)
+	assert.Equal(t, "default", payload.Namespace)
+	assert.Equal(t, "test", payload.GameServer)
+	assert.Equal(t, v1alpha1.GameServerStateReady, payload.State)
+	assert.Equal(t, "10.0.0.1", payload.Address)
+	if assert.Len(t, payload.Ports, 1) {
+		assert.Equal(t, int32(7777), payload.Ports[0].Port)
+	}
+
+	mac := hmac.New(sha256.New, []byte("green"))
+	mac.Write(body) // nolint: errcheck
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestLifecycleControllerSyncGameServerNoWebhook(t *testing.T) {
+	t.Parallel()
+
+	received := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer ts.Close()
+
+	m := agtesting.NewMocks()
+	lc := NewLifecycleController(healthcheck.NewHandler(), m.AgonesInformerFactory)
+
+	fleet := v1alpha1.Fleet{ObjectMeta: metav1.ObjectMeta{Name: "fleet-1", Namespace: "default"}}
+	gs := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default",
+		Labels: map[string]string{v1alpha1.FleetNameLabel: fleet.ObjectMeta.Name}},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady}}
+
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.FleetList{Items: []v1alpha1.Fleet{fleet}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, lc.gameServerSynced, lc.fleetSynced)
+	defer cancel()
+
+	err := lc.syncGameServer("default/test/Ready")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, received)
+}
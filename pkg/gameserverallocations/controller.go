@@ -16,7 +16,7 @@ package gameserverallocations
 
 import (
 	"encoding/json"
-	"sync"
+	"time"
 
 	"agones.dev/agones/pkg/apis/stable"
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
@@ -24,7 +24,9 @@ import (
 	getterv1alpha1 "agones.dev/agones/pkg/client/clientset/versioned/typed/stable/v1alpha1"
 	"agones.dev/agones/pkg/client/informers/externalversions"
 	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"agones.dev/agones/pkg/metrics"
 	"agones.dev/agones/pkg/util/crd"
+	"agones.dev/agones/pkg/util/locks"
 	"agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/webhooks"
 	"agones.dev/agones/pkg/util/workerqueue"
@@ -36,6 +38,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,6 +46,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 )
@@ -51,6 +55,9 @@ var (
 	// ErrNoGameServerReady is returned when there are no Ready GameServers
 	// available
 	ErrNoGameServerReady = errors.New("Could not find a Ready GameServer")
+	// ErrNoGameServerBackfill is returned when there are no Allocated
+	// GameServers with enough spare player capacity available
+	ErrNoGameServerBackfill = errors.New("Could not find an Allocated GameServer with spare player capacity")
 )
 
 // Controller is a the GameServerAllocation controller
@@ -60,11 +67,17 @@ type Controller struct {
 	crdGetter                  v1beta1.CustomResourceDefinitionInterface
 	gameServerSynced           cache.InformerSynced
 	gameServerGetter           getterv1alpha1.GameServersGetter
-	gameServerLister           listerv1alpha1.GameServerLister
+	gsCache                    *gameServerCache
 	gameServerAllocationSynced cache.InformerSynced
 	gameServerAllocationGetter getterv1alpha1.GameServerAllocationsGetter
+	nodeSynced                 cache.InformerSynced
+	nodeLister                 corelisterv1.NodeLister
+	maxGameServersPerNode      int64
+	secondaryClusterName       string
+	secondaryGameServerLister  listerv1alpha1.GameServerLister
+	secondaryGameServerSynced  cache.InformerSynced
 	stop                       <-chan struct{}
-	allocationMutex            *sync.Mutex
+	allocationMutex            *locks.KeyedMutex
 	workerqueue                *workerqueue.WorkerQueue
 	recorder                   record.EventRecorder
 }
@@ -77,7 +90,8 @@ type findComparator func(bestCount, currentCount NodeCount) bool
 // NewController returns a controller for a GameServerAllocation
 func NewController(wh *webhooks.WebHook,
 	health healthcheck.Handler,
-	allocationMutex *sync.Mutex,
+	maxGameServersPerNode int64,
+	allocationMutex *locks.KeyedMutex,
 	kubeClient kubernetes.Interface,
 	kubeInformerFactory informers.SharedInformerFactory,
 	extClient extclientset.Interface,
@@ -85,14 +99,18 @@ func NewController(wh *webhooks.WebHook,
 	agonesInformerFactory externalversions.SharedInformerFactory) *Controller {
 
 	agonesInformer := agonesInformerFactory.Stable().V1alpha1()
+	nodes := kubeInformerFactory.Core().V1().Nodes()
 	c := &Controller{
 		counter:                    NewAllocationCounter(kubeInformerFactory, agonesInformerFactory),
 		crdGetter:                  extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
 		gameServerSynced:           agonesInformer.GameServers().Informer().HasSynced,
 		gameServerGetter:           agonesClient.StableV1alpha1(),
-		gameServerLister:           agonesInformer.GameServers().Lister(),
+		gsCache:                    newGameServerCache(agonesInformer.GameServers().Informer()),
 		gameServerAllocationSynced: agonesInformer.GameServerAllocations().Informer().HasSynced,
 		gameServerAllocationGetter: agonesClient.StableV1alpha1(),
+		nodeSynced:                 nodes.Informer().HasSynced,
+		nodeLister:                 nodes.Lister(),
+		maxGameServersPerNode:      maxGameServersPerNode,
 		allocationMutex:            allocationMutex,
 	}
 	c.logger = runtime.NewLoggerWithType(c)
@@ -120,6 +138,22 @@ func NewController(wh *webhooks.WebHook,
 	return c
 }
 
+// RegisterSecondaryCluster registers a read only secondary cluster whose Ready
+// GameServers are included in the allocation candidate pool when the local
+// cluster has none available. Allocations served from the secondary cluster are
+// tagged with the given cluster name in their Status, and the GameServer state
+// is not updated, as the secondary cluster is read only.
+//
+// This is experimental, and a stepping stone towards full multicluster
+// allocation. It must be called before Run, and the informer factory must be
+// started by the caller.
+func (c *Controller) RegisterSecondaryCluster(name string, agonesInformerFactory externalversions.SharedInformerFactory) {
+	gameServers := agonesInformerFactory.Stable().V1alpha1().GameServers()
+	c.secondaryClusterName = name
+	c.secondaryGameServerLister = gameServers.Lister()
+	c.secondaryGameServerSynced = gameServers.Informer().HasSynced
+}
+
 // Run runs this controller. Will block until stop is closed.
 // Runs threadiness number workers to process the rate limited queue
 // Probably only needs 1 worker, as its just deleting unallocated GameServerAllocations
@@ -145,10 +179,17 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 	return nil
 }
 
+// WaitUntilRunning blocks until this controller has synced its caches and
+// its workers are processing events, or stop is closed
+func (c *Controller) WaitUntilRunning(stop <-chan struct{}) bool {
+	return c.workerqueue.WaitUntilRunning(stop)
+}
+
 // creationMutationHandler will intercept when a GameServerAllocation is created, and allocate it a GameServer
 // assuming that one is available. If not, it will reject the AdmissionReview.
 func (c *Controller) creationMutationHandler(review admv1beta1.AdmissionReview) (admv1beta1.AdmissionReview, error) {
 	c.logger.WithField("review", review).Info("creationMutationHandler")
+	start := time.Now()
 	obj := review.Request.Object
 	gsa := &v1alpha1.GameServerAllocation{}
 
@@ -158,23 +199,35 @@ func (c *Controller) creationMutationHandler(review admv1beta1.AdmissionReview)
 		return review, errors.Wrapf(err, "error unmarshalling original GameServerAllocation json: %s", obj.Raw)
 	}
 
+	// the admission payload doesn't always carry the namespace in the object metadata
+	if gsa.ObjectMeta.Namespace == "" {
+		gsa.ObjectMeta.Namespace = review.Request.Namespace
+	}
+
 	gsa.ApplyDefaults()
+	fleetName := gsa.Spec.Required.MatchLabels[v1alpha1.FleetNameLabel]
 	gs, err := c.allocate(gsa)
-	if err != nil && err != ErrNoGameServerReady {
+	if err != nil && err != ErrNoGameServerReady && err != ErrNoGameServerBackfill {
+		metrics.RecordAllocationLatency(fleetName, metrics.ResultError, time.Since(start))
 		return review, err
 	}
 
-	if err == ErrNoGameServerReady {
+	if err == ErrNoGameServerReady || err == ErrNoGameServerBackfill {
 		gsa.Status.State = v1alpha1.GameServerAllocationUnAllocated
+		metrics.RecordAllocationLatency(fleetName, metrics.ResultUnAllocated, time.Since(start))
 	} else {
-		// When a GameServer is deleted, the GameServerAllocation should go with it
-		ref := metav1.NewControllerRef(gs, v1alpha1.SchemeGroupVersion.WithKind("GameServer"))
-		gsa.ObjectMeta.OwnerReferences = append(gsa.ObjectMeta.OwnerReferences, *ref)
+		// When a GameServer is deleted, the GameServerAllocation should go with it.
+		// A GameServer in another cluster can't be an owner here
+		if gsa.Status.Cluster == "" {
+			ref := metav1.NewControllerRef(gs, v1alpha1.SchemeGroupVersion.WithKind("GameServer"))
+			gsa.ObjectMeta.OwnerReferences = append(gsa.ObjectMeta.OwnerReferences, *ref)
+		}
 		gsa.Status.State = v1alpha1.GameServerAllocationAllocated
 		gsa.Status.GameServerName = gs.ObjectMeta.Name
 		gsa.Status.Ports = gs.Status.Ports
 		gsa.Status.Address = gs.Status.Address
 		gsa.Status.NodeName = gs.Status.NodeName
+		metrics.RecordAllocationLatency(fleetName, metrics.ResultAllocated, time.Since(start))
 	}
 
 	newFA, err := json.Marshal(gsa)
@@ -242,32 +295,56 @@ func (c *Controller) mutationValidationHandler(review admv1beta1.AdmissionReview
 // allocate allocated a GameServer from a given Fleet
 func (c *Controller) allocate(gsa *v1alpha1.GameServerAllocation) (*v1alpha1.GameServer, error) {
 	var allocation *v1alpha1.GameServer
-	// can only allocate one at a time, as we don't want two separate processes
-	// trying to allocate the same GameServer to different clients
-	c.allocationMutex.Lock()
-	defer c.allocationMutex.Unlock()
+	// can only allocate one at a time within a namespace, as we don't want two
+	// separate processes trying to allocate the same GameServer to different clients
+	c.allocationMutex.Lock(gsa.ObjectMeta.Namespace)
+	defer c.allocationMutex.Unlock(gsa.ObjectMeta.Namespace)
 
 	// make sure we have the most up to date view of the world
-	if !cache.WaitForCacheSync(c.stop, c.gameServerSynced) {
+	synced := []cache.InformerSynced{c.gameServerSynced, c.nodeSynced}
+	if c.secondaryGameServerSynced != nil {
+		synced = append(synced, c.secondaryGameServerSynced)
+	}
+	if !cache.WaitForCacheSync(c.stop, synced...) {
 		return allocation, errors.New("error syncing GameServer cache")
 	}
 
-	var comparator findComparator
-
-	switch gsa.Spec.Scheduling {
-	case v1alpha1.Packed:
-		comparator = packedComparator
-	case v1alpha1.Distributed:
-		comparator = distributedComparator
+	var err error
+	if gsa.Spec.Backfill != nil {
+		allocation, err = c.findAllocatedGameServerForBackfill(gsa)
+	} else {
+		switch gsa.Spec.Scheduling {
+		case v1alpha1.LeastLoaded:
+			allocation, err = c.findLeastLoadedGameServerForAllocation(gsa)
+		default:
+			allocation, err = c.findReadyGameServerForAllocation(gsa, comparatorFor(gsa.Spec.Scheduling))
+		}
+	}
+	if err == ErrNoGameServerReady && gsa.Spec.Backfill == nil && c.secondaryGameServerLister != nil {
+		allocation, err = c.findReadyGameServerInSecondaryCluster(gsa)
+		if err == nil {
+			// the secondary cluster is read only, so the GameServer state is left
+			// untouched - its own controllers retain ownership of it
+			gsa.Status.Cluster = c.secondaryClusterName
+			return allocation, nil
+		}
 	}
-
-	allocation, err := c.findReadyGameServerForAllocation(gsa, comparator)
 	if err != nil {
 		return allocation, err
 	}
 
 	gsCopy := allocation.DeepCopy()
 	gsCopy.Status.State = v1alpha1.GameServerStateAllocated
+	gsCopy.Status.RecordStateTransition()
+
+	message := "Allocated"
+	if gsa.Spec.Backfill != nil {
+		if gsCopy.Status.Players == nil {
+			gsCopy.Status.Players = &v1alpha1.PlayerStatus{}
+		}
+		gsCopy.Status.Players.Count += gsa.Spec.Backfill.Players
+		message = "Backfilled"
+	}
 
 	c.patchMetadata(gsCopy, gsa.Spec.MetaPatch)
 
@@ -281,7 +358,10 @@ func (c *Controller) allocate(gsa *v1alpha1.GameServerAllocation) (*v1alpha1.Gam
 	if err != nil {
 		return gs, errors.Wrapf(err, "error updating GameServer %s", gsCopy.ObjectMeta.Name)
 	}
-	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State), "Allocated")
+	// record our own write ahead of the informer, so the next allocation
+	// doesn't hand this GameServer out again off the back of a stale read
+	c.gsCache.allocated(gs)
+	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State), message)
 
 	return gs, nil
 }
@@ -335,10 +415,7 @@ func (c *Controller) findReadyGameServerForAllocation(gsa *v1alpha1.GameServerAl
 		return bestGS, errors.Wrapf(err, "could not convert GameServer %s GameServerAllocation selector", gsa.ObjectMeta.Name)
 	}
 
-	gsList, err := c.gameServerLister.List(selector)
-	if err != nil {
-		return bestGS, errors.Wrapf(err, "could not list GameServers for GameServerAllocation %s", gsa.ObjectMeta.Name)
-	}
+	gsList := c.gsCache.list(selector)
 
 	preferred, err := gsa.Spec.PreferredSelectors()
 	if err != nil {
@@ -354,6 +431,12 @@ func (c *Controller) findReadyGameServerForAllocation(gsa *v1alpha1.GameServerAl
 	// build the index of possible allocatable GameServers
 	for _, gs := range gsList {
 		if gs.DeletionTimestamp.IsZero() && gs.Status.State == v1alpha1.GameServerStateReady {
+			// skip nodes that have hit the Allocated GameServer cap, so that
+			// packing doesn't pile every allocation onto a single hotspot node
+			if c.maxGameServersPerNode > 0 && counts[gs.Status.NodeName].allocated >= c.maxGameServersPerNode {
+				continue
+			}
+
 			allocatableRequired[gs.Status.NodeName] = gs
 
 			for i, p := range preferred {
@@ -377,6 +460,18 @@ func (c *Controller) findReadyGameServerForAllocation(gsa *v1alpha1.GameServerAl
 		}
 	}
 
+	// apply the topology hint ahead of the scheduling strategy, but only
+	// if it leaves us with at least one allocatable GameServer
+	if len(gsa.Spec.Topology) > 0 {
+		hinted, err := c.filterByTopology(gsa.Spec.Topology, allocationSet)
+		if err != nil {
+			return bestGS, err
+		}
+		if len(hinted) > 0 {
+			allocationSet = hinted
+		}
+	}
+
 	for nodeName, gs := range allocationSet {
 		count := counts[nodeName]
 		// bestGS == nil: if there is no best GameServer, then this node & GameServer is the always the best
@@ -392,3 +487,182 @@ func (c *Controller) findReadyGameServerForAllocation(gsa *v1alpha1.GameServerAl
 
 	return bestGS, err
 }
+
+// findReadyGameServerInSecondaryCluster returns a Ready GameServer from the
+// secondary cluster that matches the allocation's selectors. Node counts are
+// not tracked for the secondary cluster, so the first match on the most
+// preferred selector wins
+func (c *Controller) findReadyGameServerInSecondaryCluster(gsa *v1alpha1.GameServerAllocation) (*v1alpha1.GameServer, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&gsa.Spec.Required)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not convert GameServer %s GameServerAllocation selector", gsa.ObjectMeta.Name)
+	}
+
+	gsList, err := c.secondaryGameServerLister.List(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list GameServers in cluster %s for GameServerAllocation %s", c.secondaryClusterName, gsa.ObjectMeta.Name)
+	}
+
+	preferred, err := gsa.Spec.PreferredSelectors()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create preferred selectors for GameServerAllocation %s", gsa.ObjectMeta.Name)
+	}
+
+	var ready []*v1alpha1.GameServer
+	for _, gs := range gsList {
+		if gs.DeletionTimestamp.IsZero() && gs.Status.State == v1alpha1.GameServerStateReady {
+			ready = append(ready, gs)
+		}
+	}
+
+	for _, p := range preferred {
+		for _, gs := range ready {
+			if p.Matches(labels.Set(gs.Labels)) {
+				return gs, nil
+			}
+		}
+	}
+
+	if len(ready) == 0 {
+		return nil, ErrNoGameServerReady
+	}
+	return ready[0], nil
+}
+
+// filterByTopology returns the subset of the given GameServers whose Node
+// matches all the labels in the topology hint, such as zone or region labels.
+// Nodes that no longer exist are skipped
+func (c *Controller) filterByTopology(topology map[string]string, set map[string]*v1alpha1.GameServer) (map[string]*v1alpha1.GameServer, error) {
+	selector := labels.SelectorFromSet(topology)
+	result := map[string]*v1alpha1.GameServer{}
+
+	for nodeName, gs := range set {
+		node, err := c.nodeLister.Get(nodeName)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return result, errors.Wrapf(err, "could not retrieve node %s", nodeName)
+		}
+
+		if selector.Matches(labels.Set(node.ObjectMeta.Labels)) {
+			result[nodeName] = gs
+		}
+	}
+
+	return result, nil
+}
+
+// findLeastLoadedGameServerForAllocation returns the Ready GameServer with the
+// lowest Status.Load value, taking into account preferred selectors. This
+// ignores node packing, and is aimed at game servers that host multiple
+// matches per process, and report their load through the SDK
+func (c *Controller) findLeastLoadedGameServerForAllocation(gsa *v1alpha1.GameServerAllocation) (*v1alpha1.GameServer, error) {
+	var bestGS *v1alpha1.GameServer
+
+	selector, err := metav1.LabelSelectorAsSelector(&gsa.Spec.Required)
+	if err != nil {
+		return bestGS, errors.Wrapf(err, "could not convert GameServer %s GameServerAllocation selector", gsa.ObjectMeta.Name)
+	}
+
+	gsList := c.gsCache.list(selector)
+
+	preferred, err := gsa.Spec.PreferredSelectors()
+	if err != nil {
+		return bestGS, errors.Wrapf(err, "could not create preferred selectors for GameServerAllocation %s", gsa.ObjectMeta.Name)
+	}
+
+	var required []*v1alpha1.GameServer
+	preferredSets := make([][]*v1alpha1.GameServer, len(preferred))
+
+	for _, gs := range gsList {
+		if gs.DeletionTimestamp.IsZero() && gs.Status.State == v1alpha1.GameServerStateReady {
+			required = append(required, gs)
+
+			for i, p := range preferred {
+				if p.Matches(labels.Set(gs.Labels)) {
+					preferredSets[i] = append(preferredSets[i], gs)
+				}
+			}
+		}
+	}
+
+	candidates := required
+	for _, set := range preferredSets {
+		if len(set) > 0 {
+			candidates = set
+			break
+		}
+	}
+
+	for _, gs := range candidates {
+		if bestGS == nil || gs.Status.Load < bestGS.Status.Load {
+			bestGS = gs
+		}
+	}
+
+	if bestGS == nil {
+		err = ErrNoGameServerReady
+	}
+
+	return bestGS, err
+}
+
+// findAllocatedGameServerForBackfill returns an already Allocated GameServer
+// with enough spare player capacity for this backfill allocation, taking
+// into account preferred selectors. The fullest GameServer that still fits
+// the players is chosen, so that servers fill up and empty out in turn
+func (c *Controller) findAllocatedGameServerForBackfill(gsa *v1alpha1.GameServerAllocation) (*v1alpha1.GameServer, error) {
+	var bestGS *v1alpha1.GameServer
+
+	selector, err := metav1.LabelSelectorAsSelector(&gsa.Spec.Required)
+	if err != nil {
+		return bestGS, errors.Wrapf(err, "could not convert GameServer %s GameServerAllocation selector", gsa.ObjectMeta.Name)
+	}
+
+	gsList := c.gsCache.list(selector)
+
+	preferred, err := gsa.Spec.PreferredSelectors()
+	if err != nil {
+		return bestGS, errors.Wrapf(err, "could not create preferred selectors for GameServerAllocation %s", gsa.ObjectMeta.Name)
+	}
+
+	players := gsa.Spec.Backfill.Players
+
+	var required []*v1alpha1.GameServer
+	preferredSets := make([][]*v1alpha1.GameServer, len(preferred))
+
+	for _, gs := range gsList {
+		if gs.DeletionTimestamp.IsZero() && gs.Status.State == v1alpha1.GameServerStateAllocated &&
+			gs.Status.Players != nil && gs.Status.Players.Capacity > 0 &&
+			gs.Status.Players.Count+players <= gs.Status.Players.Capacity {
+			required = append(required, gs)
+
+			for i, p := range preferred {
+				if p.Matches(labels.Set(gs.Labels)) {
+					preferredSets[i] = append(preferredSets[i], gs)
+				}
+			}
+		}
+	}
+
+	candidates := required
+	for _, set := range preferredSets {
+		if len(set) > 0 {
+			candidates = set
+			break
+		}
+	}
+
+	for _, gs := range candidates {
+		if bestGS == nil || gs.Status.Players.Count > bestGS.Status.Players.Count {
+			bestGS = gs
+		}
+	}
+
+	if bestGS == nil {
+		err = ErrNoGameServerBackfill
+	}
+
+	return bestGS, err
+}
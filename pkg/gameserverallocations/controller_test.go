@@ -24,12 +24,14 @@ import (
 
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
 	agtesting "agones.dev/agones/pkg/testing"
+	"agones.dev/agones/pkg/util/locks"
 	"agones.dev/agones/pkg/util/webhooks"
 	applypatch "github.com/evanphx/json-patch"
 	"github.com/heptiolabs/healthcheck"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	admv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -215,6 +217,87 @@ func TestControllerAllocate(t *testing.T) {
 	assert.False(t, updated)
 }
 
+func TestControllerAllocateInformerLag(t *testing.T) {
+	t.Parallel()
+
+	f, _, gsList := defaultFixtures(2)
+	c, m := newFakeController()
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gsList}, nil
+	})
+	// the patch succeeds, but no watch event follows, as if the informer
+	// is lagging behind this controller's own writes
+	m.AgonesClient.AddReactor("patch", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := applyGameServerPatch(t, m, action)
+		gs.ObjectMeta.ResourceVersion = "99"
+		return true, gs, nil
+	})
+
+	stop, cancel := agtesting.StartInformers(m, m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Informer().HasSynced)
+	defer cancel()
+
+	err := c.counter.Run(stop)
+	assert.Nil(t, err)
+
+	gsa := v1alpha1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa-1"},
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{MatchLabels: map[string]string{v1alpha1.FleetNameLabel: f.ObjectMeta.Name}},
+		}}
+	gsa.ApplyDefaults()
+
+	// each allocation must hand out a different GameServer, even though the
+	// informer still reports them all as Ready
+	allocated := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		gs, err := c.allocate(&gsa)
+		assert.Nil(t, err)
+		assert.False(t, allocated[gs.ObjectMeta.Name], "GameServer %s was allocated twice", gs.ObjectMeta.Name)
+		allocated[gs.ObjectMeta.Name] = true
+	}
+
+	_, err = c.allocate(&gsa)
+	assert.Equal(t, ErrNoGameServerReady, err)
+}
+
+func TestControllerAllocateFromSecondaryCluster(t *testing.T) {
+	t.Parallel()
+
+	f, _, gsList := defaultFixtures(2)
+	c, m := newFakeController()
+
+	secondary := agtesting.NewMocks()
+	c.RegisterSecondaryCluster("standby", secondary.AgonesInformerFactory)
+
+	// the local cluster has no Ready GameServers, the secondary cluster does
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{}, nil
+	})
+	secondary.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gsList}, nil
+	})
+
+	stop, cancel := agtesting.StartInformers(m, m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Informer().HasSynced)
+	defer cancel()
+	_, cancelSecondary := agtesting.StartInformers(secondary, c.secondaryGameServerSynced)
+	defer cancelSecondary()
+
+	err := c.counter.Run(stop)
+	assert.Nil(t, err)
+
+	gsa := &v1alpha1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa-1"},
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{MatchLabels: map[string]string{v1alpha1.FleetNameLabel: f.ObjectMeta.Name}},
+		}}
+	gsa.ApplyDefaults()
+
+	gs, err := c.allocate(gsa)
+	assert.Nil(t, err)
+	// the secondary cluster is read only, so the GameServer state is untouched
+	assert.Equal(t, v1alpha1.GameServerStateReady, gs.Status.State)
+	assert.Equal(t, "standby", gsa.Status.Cluster)
+}
+
 func TestControllerAllocatePriority(t *testing.T) {
 	t.Parallel()
 
@@ -624,6 +707,234 @@ func TestControllerFindDistributedReadyGameServer(t *testing.T) {
 	assert.Nil(t, gs)
 }
 
+func TestControllerFindLeastLoadedGameServer(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+	prefLabels := map[string]string{"role": "gameserver", "preferred": "true"}
+
+	gsa := &v1alpha1.GameServerAllocation{
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Scheduling: v1alpha1.LeastLoaded,
+		},
+	}
+
+	c, m := newFakeController()
+	hasSync := m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Informer().HasSynced
+
+	n := metav1.Now()
+	gsList := []v1alpha1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateReady, Load: 10}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs2", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n2, State: v1alpha1.GameServerStateReady, Load: 2}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs3", Namespace: defaultNs, Labels: labels, DeletionTimestamp: &n}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateReady, Load: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs4", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateAllocated, Load: 0}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs5", Namespace: defaultNs, Labels: prefLabels}, Status: v1alpha1.GameServerStatus{NodeName: n2, State: v1alpha1.GameServerStateReady, Load: 5}},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gsList}, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, hasSync)
+	defer cancel()
+
+	// lowest load Ready server, ignoring deleting and allocated ones
+	gs, err := c.findLeastLoadedGameServerForAllocation(gsa)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs2", gs.ObjectMeta.Name)
+
+	// preferred selector takes precedence over load ordering
+	prefGSA := gsa.DeepCopy()
+	prefGSA.Spec.Preferred = []metav1.LabelSelector{{MatchLabels: map[string]string{"preferred": "true"}}}
+	gs, err = c.findLeastLoadedGameServerForAllocation(prefGSA)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs5", gs.ObjectMeta.Name)
+
+	// no Ready GameServers
+	noneGSA := gsa.DeepCopy()
+	noneGSA.Spec.Required = metav1.LabelSelector{MatchLabels: map[string]string{"role": "none"}}
+	gs, err = c.findLeastLoadedGameServerForAllocation(noneGSA)
+	assert.Equal(t, ErrNoGameServerReady, err)
+	assert.Nil(t, gs)
+}
+
+func TestControllerFindReadyGameServerMaxPerNode(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+
+	gsa := &v1alpha1.GameServerAllocation{
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+		},
+	}
+
+	c, m := newFakeController()
+	c.maxGameServersPerNode = 2
+	hasSync := m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Informer().HasSynced
+
+	gsList := []v1alpha1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateAllocated}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs2", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateAllocated}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs3", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateReady}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs4", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n2, State: v1alpha1.GameServerStateReady}},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gsList}, nil
+	})
+
+	stop, cancel := agtesting.StartInformers(m, hasSync)
+	defer cancel()
+
+	err := c.counter.Run(stop)
+	assert.Nil(t, err)
+
+	// node1 has hit the cap of 2 Allocated GameServers, so even though
+	// packing prefers it, the allocation should land on node2
+	gs, err := c.findReadyGameServerForAllocation(gsa, packedComparator)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs4", gs.ObjectMeta.Name)
+	assert.Equal(t, n2, gs.Status.NodeName)
+
+	// without the cap, node1 wins as usual
+	c.maxGameServersPerNode = 0
+	gs, err = c.findReadyGameServerForAllocation(gsa, packedComparator)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs3", gs.ObjectMeta.Name)
+}
+
+func TestControllerFindReadyGameServerTopologyHint(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+	zoneLabel := "failure-domain.beta.kubernetes.io/zone"
+
+	gsa := &v1alpha1.GameServerAllocation{
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Topology: map[string]string{zoneLabel: "zone-a"},
+		},
+	}
+
+	c, m := newFakeController()
+	hasSync := m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Informer().HasSynced
+
+	gsList := []v1alpha1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateReady}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs2", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n2, State: v1alpha1.GameServerStateReady}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs3", Namespace: defaultNs, Labels: labels}, Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateAllocated}},
+	}
+
+	nodeList := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: n1, Labels: map[string]string{zoneLabel: "zone-b"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: n2, Labels: map[string]string{zoneLabel: "zone-a"}}},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gsList}, nil
+	})
+	m.KubeClient.AddReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &corev1.NodeList{Items: nodeList}, nil
+	})
+
+	stop, cancel := agtesting.StartInformers(m, hasSync, c.nodeSynced)
+	defer cancel()
+
+	err := c.counter.Run(stop)
+	assert.Nil(t, err)
+
+	// packing would choose node1, as it already has an Allocated GameServer,
+	// but the zone hint should win
+	gs, err := c.findReadyGameServerForAllocation(gsa, packedComparator)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs2", gs.ObjectMeta.Name)
+	assert.Equal(t, n2, gs.Status.NodeName)
+
+	// a hint that matches no Nodes is ignored, and packing applies as usual
+	noneGSA := gsa.DeepCopy()
+	noneGSA.Spec.Topology = map[string]string{zoneLabel: "zone-z"}
+	gs, err = c.findReadyGameServerForAllocation(noneGSA, packedComparator)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs1", gs.ObjectMeta.Name)
+}
+
+func TestControllerAllocateBackfill(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+
+	gsa := &v1alpha1.GameServerAllocation{
+		Spec: v1alpha1.GameServerAllocationSpec{
+			Required: metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Backfill: &v1alpha1.BackfillSpec{Players: 2},
+		},
+	}
+	gsa.ApplyDefaults()
+
+	c, m := newFakeController()
+	hasSync := m.AgonesInformerFactory.Stable().V1alpha1().GameServers().Informer().HasSynced
+
+	gsList := []v1alpha1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: defaultNs, Labels: labels},
+			Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateReady,
+				Players: &v1alpha1.PlayerStatus{Capacity: 10}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs2", Namespace: defaultNs, Labels: labels},
+			Status: v1alpha1.GameServerStatus{NodeName: n1, State: v1alpha1.GameServerStateAllocated,
+				Players: &v1alpha1.PlayerStatus{Capacity: 10, Count: 4}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs3", Namespace: defaultNs, Labels: labels},
+			Status: v1alpha1.GameServerStatus{NodeName: n2, State: v1alpha1.GameServerStateAllocated,
+				Players: &v1alpha1.PlayerStatus{Capacity: 10, Count: 9}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs4", Namespace: defaultNs, Labels: labels},
+			Status: v1alpha1.GameServerStatus{NodeName: n2, State: v1alpha1.GameServerStateAllocated}},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gsList}, nil
+	})
+
+	updated := false
+	m.AgonesClient.AddReactor("patch", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := applyGameServerPatch(t, m, action)
+
+		updated = true
+		assert.Equal(t, v1alpha1.GameServerStateAllocated, gs.Status.State)
+		if assert.NotNil(t, gs.Status.Players) {
+			assert.Equal(t, int64(6), gs.Status.Players.Count)
+		}
+
+		return true, gs, nil
+	})
+
+	stop, cancel := agtesting.StartInformers(m, hasSync)
+	defer cancel()
+
+	err := c.counter.Run(stop)
+	assert.Nil(t, err)
+
+	// gs3 does not fit 2 more players, gs4 does not track players,
+	// so the fullest server that fits is gs2
+	gs, err := c.allocate(gsa)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs2", gs.ObjectMeta.Name)
+	assert.True(t, updated)
+
+	// no Allocated GameServer has room for this many players
+	bigGSA := gsa.DeepCopy()
+	bigGSA.Spec.Backfill.Players = 7
+	_, err = c.allocate(bigGSA)
+	assert.Equal(t, ErrNoGameServerBackfill, err)
+}
+
 func TestControllerRunSync(t *testing.T) {
 	c, m := newFakeController()
 	watch := watch.NewFake()
@@ -729,7 +1040,7 @@ func defaultFixtures(gsLen int) (*v1alpha1.Fleet, *v1alpha1.GameServerSet, []v1a
 func newFakeController() (*Controller, agtesting.Mocks) {
 	m := agtesting.NewMocks()
 	wh := webhooks.NewWebHook("", "")
-	c := NewController(wh, healthcheck.NewHandler(), &sync.Mutex{}, m.KubeClient, m.KubeInformerFactory, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
+	c := NewController(wh, healthcheck.NewHandler(), 0, locks.NewKeyedMutex("allocation"), m.KubeClient, m.KubeInformerFactory, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
 	c.recorder = m.FakeRecorder
 	return c, m
 }
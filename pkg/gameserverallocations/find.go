@@ -14,20 +14,30 @@
 
 package gameserverallocations
 
+import "agones.dev/agones/pkg/apis/stable/v1alpha1"
+
 // packedComparator prioritises Nodes with GameServers that are allocated, and then Nodes with the most
 // Ready GameServers -- this will bin pack allocated game servers together.
 func packedComparator(bestCount, currentCount NodeCount) bool {
-	if currentCount.allocated == bestCount.allocated && currentCount.ready > bestCount.ready {
-		return true
-	} else if currentCount.allocated > bestCount.allocated {
-		return true
-	}
-
-	return false
+	return comparatorFor(v1alpha1.Packed)(bestCount, currentCount)
 }
 
 // distributedComparator is the inverse of the packed comparator,
 // looking to distribute allocated gameservers on as many nodes as possible.
 func distributedComparator(bestCount, currentCount NodeCount) bool {
-	return !packedComparator(bestCount, currentCount)
+	return comparatorFor(v1alpha1.Distributed)(bestCount, currentCount)
+}
+
+// comparatorFor returns a findComparator backed by the node comparator of the
+// registered scheduling Strategy for the given strategy name. Unknown
+// strategies, and strategies without a node comparator, fall back to Packed
+func comparatorFor(strategy v1alpha1.SchedulingStrategy) findComparator {
+	s, ok := v1alpha1.GetSchedulingStrategy(strategy)
+	if !ok || s.NodeComparator == nil {
+		s, _ = v1alpha1.GetSchedulingStrategy(v1alpha1.Packed)
+	}
+
+	return func(bestCount, currentCount NodeCount) bool {
+		return s.NodeComparator(bestCount.ready, bestCount.allocated, currentCount.ready, currentCount.allocated)
+	}
 }
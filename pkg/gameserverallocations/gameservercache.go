@@ -0,0 +1,140 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"strconv"
+	"sync"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// gameServerCache is a continuously warmed view of the cluster's GameServers,
+// built from informer events, that the allocation candidate pool is drawn from.
+//
+// On top of the informer's view it tracks this controller's own allocation
+// writes until the informer catches up on them, so that a GameServer that has
+// just been allocated is never handed out a second time off the back of a
+// stale read
+type gameServerCache struct {
+	mu sync.RWMutex
+	// servers is the informer's view of the world, keyed by namespace/name
+	servers map[string]*v1alpha1.GameServer
+	// ownAllocations overlays servers with this controller's own recent
+	// allocation writes, keyed by namespace/name, until the informer
+	// reports a resource version at least as new as the write
+	ownAllocations map[string]*v1alpha1.GameServer
+}
+
+// newGameServerCache returns a gameServerCache warmed by the given
+// GameServer informer
+func newGameServerCache(informer cache.SharedIndexInformer) *gameServerCache {
+	c := &gameServerCache{
+		servers:        map[string]*v1alpha1.GameServer{},
+		ownAllocations: map[string]*v1alpha1.GameServer{},
+	}
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.update(obj.(*v1alpha1.GameServer))
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			c.update(newObj.(*v1alpha1.GameServer))
+		},
+		DeleteFunc: func(obj interface{}) {
+			gs, ok := obj.(*v1alpha1.GameServer)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					return
+				}
+				if gs, ok = tombstone.Obj.(*v1alpha1.GameServer); !ok {
+					return
+				}
+			}
+			c.delete(gs)
+		},
+	})
+
+	return c
+}
+
+// update records the informer's latest view of the given GameServer, and
+// drops the own allocation overlay once the informer has caught up to the
+// write it covers
+func (c *gameServerCache) update(gs *v1alpha1.GameServer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := gs.ObjectMeta.Namespace + "/" + gs.ObjectMeta.Name
+	c.servers[key] = gs
+	if own, ok := c.ownAllocations[key]; ok &&
+		!resourceVersionIsOlder(gs.ObjectMeta.ResourceVersion, own.ObjectMeta.ResourceVersion) {
+		delete(c.ownAllocations, key)
+	}
+}
+
+// delete removes the given GameServer from the cache
+func (c *gameServerCache) delete(gs *v1alpha1.GameServer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := gs.ObjectMeta.Namespace + "/" + gs.ObjectMeta.Name
+	delete(c.servers, key)
+	delete(c.ownAllocations, key)
+}
+
+// allocated records an allocation write this controller has just made, as
+// returned by the api server, so that reads reflect it ahead of the informer
+func (c *gameServerCache) allocated(gs *v1alpha1.GameServer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ownAllocations[gs.ObjectMeta.Namespace+"/"+gs.ObjectMeta.Name] = gs
+}
+
+// list returns a snapshot of the cached GameServers whose labels match the
+// given selector, with this controller's own recent allocation writes
+// overlaid over anything the informer is yet to catch up on
+func (c *gameServerCache) list(selector labels.Selector) []*v1alpha1.GameServer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var list []*v1alpha1.GameServer
+	for key, gs := range c.servers {
+		if own, ok := c.ownAllocations[key]; ok {
+			gs = own
+		}
+		if selector.Matches(labels.Set(gs.ObjectMeta.Labels)) {
+			list = append(list, gs)
+		}
+	}
+	return list
+}
+
+// resourceVersionIsOlder returns true if a is an older resource version
+// than b. Resource versions are opaque strings, but compare numerically on
+// etcd backed clusters; if either doesn't parse, anything other than an
+// exact match is treated as older, so the overlay stays in place
+func resourceVersionIsOlder(a, b string) bool {
+	av, aerr := strconv.ParseUint(a, 10, 64)
+	bv, berr := strconv.ParseUint(b, 10, 64)
+	if aerr != nil || berr != nil {
+		return a != b
+	}
+	return av < bv
+}
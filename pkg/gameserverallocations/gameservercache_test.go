@@ -0,0 +1,96 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func cacheFixture(name, resourceVersion string, state v1alpha1.GameServerState) *v1alpha1.GameServer {
+	return &v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNs,
+			ResourceVersion: resourceVersion, Labels: map[string]string{"fleet": "test"}},
+		Status: v1alpha1.GameServerStatus{State: state},
+	}
+}
+
+func TestGameServerCacheUpdateDelete(t *testing.T) {
+	t.Parallel()
+
+	c := &gameServerCache{
+		servers:        map[string]*v1alpha1.GameServer{},
+		ownAllocations: map[string]*v1alpha1.GameServer{},
+	}
+
+	gs1 := cacheFixture("gs1", "1", v1alpha1.GameServerStateReady)
+	gs2 := cacheFixture("gs2", "1", v1alpha1.GameServerStateReady)
+	gs2.ObjectMeta.Labels = map[string]string{"fleet": "other"}
+	c.update(gs1)
+	c.update(gs2)
+
+	list := c.list(labels.SelectorFromSet(labels.Set{"fleet": "test"}))
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, "gs1", list[0].ObjectMeta.Name)
+	}
+	assert.Len(t, c.list(labels.Everything()), 2)
+
+	c.delete(gs1)
+	assert.Len(t, c.list(labels.Everything()), 1)
+}
+
+func TestGameServerCacheOwnAllocations(t *testing.T) {
+	t.Parallel()
+
+	c := &gameServerCache{
+		servers:        map[string]*v1alpha1.GameServer{},
+		ownAllocations: map[string]*v1alpha1.GameServer{},
+	}
+
+	c.update(cacheFixture("gs1", "1", v1alpha1.GameServerStateReady))
+	c.allocated(cacheFixture("gs1", "2", v1alpha1.GameServerStateAllocated))
+
+	// our own write wins over the informer's stale view
+	list := c.list(labels.Everything())
+	if assert.Len(t, list, 1) {
+		assert.Equal(t, v1alpha1.GameServerStateAllocated, list[0].Status.State)
+	}
+
+	// a stale informer event doesn't clear the overlay
+	c.update(cacheFixture("gs1", "1", v1alpha1.GameServerStateReady))
+	assert.Equal(t, v1alpha1.GameServerStateAllocated, c.list(labels.Everything())[0].Status.State)
+
+	// once the informer catches up the overlay is dropped, and newer
+	// events - such as the server recycling back to Ready - flow through
+	c.update(cacheFixture("gs1", "2", v1alpha1.GameServerStateAllocated))
+	c.update(cacheFixture("gs1", "3", v1alpha1.GameServerStateReady))
+	assert.Equal(t, v1alpha1.GameServerStateReady, c.list(labels.Everything())[0].Status.State)
+}
+
+func TestResourceVersionIsOlder(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, resourceVersionIsOlder("1", "2"))
+	assert.False(t, resourceVersionIsOlder("2", "2"))
+	assert.False(t, resourceVersionIsOlder("10", "9"))
+
+	// opaque resource versions only count as caught up on an exact match
+	assert.True(t, resourceVersionIsOlder("abc", "def"))
+	assert.False(t, resourceVersionIsOlder("abc", "abc"))
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameservers
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AddressProvider resolves the externally reachable address for the Node a
+// GameServer's Pod has been scheduled to, for populating
+// GameServerStatus.Address. Implementations are registered by name, and
+// selected with the `address-provider` controller flag
+type AddressProvider interface {
+	// Address returns the external address for the given Node
+	Address(node *corev1.Node) (string, error)
+}
+
+var (
+	addressProviderMutex sync.RWMutex
+	addressProviders     = map[string]AddressProvider{}
+)
+
+func init() {
+	// the default provider prefers the ExternalIP, falling back to the
+	// InternalIP, since minikube only has an InternalIP on a Node
+	RegisterAddressProvider("", nodeAddressProvider{
+		preference: []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP}})
+
+	// GCE and Azure populate the ExternalIP on public Nodes
+	RegisterAddressProvider("gce", nodeAddressProvider{
+		preference: []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP}})
+	RegisterAddressProvider("azure", nodeAddressProvider{
+		preference: []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP}})
+
+	// EC2 metadata publishes a stable public hostname for an instance,
+	// while its public IP can change across stop/start, so prefer the
+	// ExternalDNS record when there is one
+	RegisterAddressProvider("ec2", nodeAddressProvider{
+		preference: []corev1.NodeAddressType{corev1.NodeExternalDNS, corev1.NodeExternalIP, corev1.NodeInternalIP}})
+}
+
+// RegisterAddressProvider registers the AddressProvider behind the given
+// name, replacing any existing registration, so cloud specific address
+// resolution can be plugged in
+func RegisterAddressProvider(name string, provider AddressProvider) {
+	addressProviderMutex.Lock()
+	defer addressProviderMutex.Unlock()
+	addressProviders[name] = provider
+}
+
+// AddressProviderFor returns the AddressProvider registered under the given
+// name, falling back to the default provider if the name is not registered
+func AddressProviderFor(name string) AddressProvider {
+	addressProviderMutex.RLock()
+	defer addressProviderMutex.RUnlock()
+	if provider, ok := addressProviders[name]; ok {
+		return provider
+	}
+	return addressProviders[""]
+}
+
+// nodeAddressProvider resolves the address from the Node's Status.Addresses
+// in the given order of preference
+type nodeAddressProvider struct {
+	preference []corev1.NodeAddressType
+}
+
+// Address returns the first of the Node's addresses that matches the
+// provider's type preference order
+func (p nodeAddressProvider) Address(node *corev1.Node) (string, error) {
+	for _, t := range p.preference {
+		for _, a := range node.Status.Addresses {
+			if a.Type == t {
+				return a.Address, nil
+			}
+		}
+	}
+
+	return "", errors.Errorf("Could not find an address for Node: %s", node.ObjectMeta.Name)
+}
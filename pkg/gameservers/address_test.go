@@ -0,0 +1,68 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameservers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddressProviderFor(t *testing.T) {
+	t.Parallel()
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			{Type: corev1.NodeExternalIP, Address: "12.13.14.15"},
+			{Type: corev1.NodeExternalDNS, Address: "ec2.example.com"},
+		}}}
+
+	// the default provider prefers the external IP
+	addr, err := AddressProviderFor("").Address(node)
+	assert.Nil(t, err)
+	assert.Equal(t, "12.13.14.15", addr)
+
+	// unknown names fall back to the default provider
+	addr, err = AddressProviderFor("not-a-provider").Address(node)
+	assert.Nil(t, err)
+	assert.Equal(t, "12.13.14.15", addr)
+
+	// ec2 prefers the stable public hostname
+	addr, err = AddressProviderFor("ec2").Address(node)
+	assert.Nil(t, err)
+	assert.Equal(t, "ec2.example.com", addr)
+
+	// fall through the preference order when addresses are missing
+	node.Status.Addresses = node.Status.Addresses[:1]
+	addr, err = AddressProviderFor("ec2").Address(node)
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", addr)
+
+	// no usable address at all is an error
+	node.Status.Addresses = nil
+	_, err = AddressProviderFor("gce").Address(node)
+	assert.NotNil(t, err)
+}
+
+func TestRegisterAddressProvider(t *testing.T) {
+	t.Parallel()
+
+	custom := nodeAddressProvider{preference: []corev1.NodeAddressType{corev1.NodeInternalIP}}
+	RegisterAddressProvider("test-internal", custom)
+	assert.Equal(t, custom, AddressProviderFor("test-internal"))
+}
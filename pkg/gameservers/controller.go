@@ -17,9 +17,11 @@ package gameservers
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
+	"agones.dev/agones/pkg"
 	"agones.dev/agones/pkg/apis/stable"
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
 	"agones.dev/agones/pkg/client/clientset/versioned"
@@ -53,31 +55,45 @@ import (
 	"k8s.io/client-go/util/workqueue"
 )
 
+// maxPodCreationRetries is how many times Pod creation is retried with
+// backoff before the GameServer is moved to Error
+const maxPodCreationRetries = 5
+
 var (
 	errPodNotFound = errors.New("A Pod for this GameServer Was Not Found")
 )
 
 // Controller is a the main GameServer crd controller
 type Controller struct {
-	logger                 *logrus.Entry
-	sidecarImage           string
-	alwaysPullSidecarImage bool
-	sidecarCPURequest      resource.Quantity
-	sidecarCPULimit        resource.Quantity
-	crdGetter              v1beta1.CustomResourceDefinitionInterface
-	podGetter              typedcorev1.PodsGetter
-	podLister              corelisterv1.PodLister
-	podSynced              cache.InformerSynced
-	gameServerGetter       getterv1alpha1.GameServersGetter
-	gameServerLister       listerv1alpha1.GameServerLister
-	gameServerSynced       cache.InformerSynced
-	nodeLister             corelisterv1.NodeLister
-	portAllocator          *PortAllocator
-	healthController       *HealthController
-	workerqueue            *workerqueue.WorkerQueue
-	creationWorkerQueue    *workerqueue.WorkerQueue // handles creation only
-	deletionWorkerQueue    *workerqueue.WorkerQueue // handles deletion only
-	stop                   <-chan struct {
+	logger                   *logrus.Entry
+	sidecarImage             string
+	queryResponderImage      string
+	alwaysPullSidecarImage   bool
+	sidecarCPURequest        resource.Quantity
+	sidecarCPULimit          resource.Quantity
+	simulate                 bool
+	simulateLatency          time.Duration
+	maxPerNamespace          int64
+	maxPerFleet              int64
+	crdGetter                v1beta1.CustomResourceDefinitionInterface
+	podGetter                typedcorev1.PodsGetter
+	serviceGetter            typedcorev1.ServicesGetter
+	podLister                corelisterv1.PodLister
+	podSynced                cache.InformerSynced
+	gameServerGetter         getterv1alpha1.GameServersGetter
+	gameServerLister         listerv1alpha1.GameServerLister
+	gameServerSynced         cache.InformerSynced
+	gameServerDefaultsLister listerv1alpha1.GameServerDefaultsLister
+	gameServerDefaultsSynced cache.InformerSynced
+	nodeLister               corelisterv1.NodeLister
+	addressProvider          AddressProvider
+	portAllocator            *PortAllocator
+	healthController         *HealthController
+	sidecarRBAC              *sidecarRBAC
+	workerqueue              *workerqueue.WorkerQueue
+	creationWorkerQueue      *workerqueue.WorkerQueue // handles creation only
+	deletionWorkerQueue      *workerqueue.WorkerQueue // handles deletion only
+	stop                     <-chan struct {
 	}
 	recorder record.EventRecorder
 }
@@ -87,6 +103,7 @@ func NewController(
 	wh *webhooks.WebHook,
 	health healthcheck.Handler,
 	minPort, maxPort int32,
+	addressProvider string,
 	sidecarImage string,
 	alwaysPullSidecarImage bool,
 	sidecarCPURequest resource.Quantity,
@@ -100,22 +117,28 @@ func NewController(
 	pods := kubeInformerFactory.Core().V1().Pods()
 	gameServers := agonesInformerFactory.Stable().V1alpha1().GameServers()
 	gsInformer := gameServers.Informer()
+	gameServerDefaults := agonesInformerFactory.Stable().V1alpha1().GameServerDefaults()
 
 	c := &Controller{
-		sidecarImage:           sidecarImage,
-		sidecarCPULimit:        sidecarCPULimit,
-		sidecarCPURequest:      sidecarCPURequest,
-		alwaysPullSidecarImage: alwaysPullSidecarImage,
-		crdGetter:              extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
-		podGetter:              kubeClient.CoreV1(),
-		podLister:              pods.Lister(),
-		podSynced:              pods.Informer().HasSynced,
-		gameServerGetter:       agonesClient.StableV1alpha1(),
-		gameServerLister:       gameServers.Lister(),
-		gameServerSynced:       gsInformer.HasSynced,
-		nodeLister:             kubeInformerFactory.Core().V1().Nodes().Lister(),
-		portAllocator:          NewPortAllocator(minPort, maxPort, kubeInformerFactory, agonesInformerFactory),
-		healthController:       NewHealthController(kubeClient, agonesClient, kubeInformerFactory, agonesInformerFactory),
+		sidecarImage:             sidecarImage,
+		sidecarCPULimit:          sidecarCPULimit,
+		sidecarCPURequest:        sidecarCPURequest,
+		alwaysPullSidecarImage:   alwaysPullSidecarImage,
+		crdGetter:                extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
+		podGetter:                kubeClient.CoreV1(),
+		serviceGetter:            kubeClient.CoreV1(),
+		podLister:                pods.Lister(),
+		podSynced:                pods.Informer().HasSynced,
+		gameServerGetter:         agonesClient.StableV1alpha1(),
+		gameServerLister:         gameServers.Lister(),
+		gameServerSynced:         gsInformer.HasSynced,
+		gameServerDefaultsLister: gameServerDefaults.Lister(),
+		gameServerDefaultsSynced: gameServerDefaults.Informer().HasSynced,
+		nodeLister:               kubeInformerFactory.Core().V1().Nodes().Lister(),
+		addressProvider:          AddressProviderFor(addressProvider),
+		portAllocator:            NewPortAllocator(minPort, maxPort, kubeInformerFactory, agonesInformerFactory),
+		healthController:         NewHealthController(kubeClient, agonesClient, kubeInformerFactory, agonesInformerFactory),
+		sidecarRBAC:              newSidecarRBAC(kubeClient),
 	}
 
 	c.logger = runtime.NewLoggerWithType(c)
@@ -173,6 +196,47 @@ func NewController(
 	return c
 }
 
+// RegisterUnhealthyCollector configures the image that the health controller
+// runs as a Job on a GameServer's node before its Pod is replaced, to collect
+// crash artifacts such as core dumps and logs.
+// This is an experimental feature, and may change without notice
+func (c *Controller) RegisterUnhealthyCollector(image string) {
+	c.healthController.collectorImage = image
+}
+
+// RegisterPortSnapshotPersistence enables the port allocator to persist its
+// state to a PortAllocationSnapshot in the given namespace, and restore from
+// it on startup. Must be called before Run
+func (c *Controller) RegisterPortSnapshotPersistence(getter getterv1alpha1.PortAllocationSnapshotsGetter, namespace string) {
+	c.portAllocator.RegisterSnapshotPersistence(getter, namespace)
+}
+
+// RegisterQueryResponderImage sets the image for the query responder sidecar,
+// injected next to the game server container when a GameServer declares a
+// QueryResponder on its spec. Must be called before Run
+func (c *Controller) RegisterQueryResponderImage(image string) {
+	c.queryResponderImage = image
+}
+
+// RegisterGameServerLimits caps how many GameServers may exist per namespace
+// and per fleet. New GameServers over a limit are rejected by the validating
+// webhook with a quota error, so a runaway autoscaler in a shared cluster
+// can't starve everyone else. Zero disables a limit. Must be called before Run
+func (c *Controller) RegisterGameServerLimits(perNamespace, perFleet int64) {
+	c.maxPerNamespace = perNamespace
+	c.maxPerFleet = perFleet
+}
+
+// EnableSimulation puts the controller into simulation mode - GameServers
+// skip Pod creation entirely and are walked through their lifecycle states
+// with the given latency between transitions. This is for scale testing the
+// control plane on small clusters and in CI, and must never be enabled on a
+// cluster serving real game servers
+func (c *Controller) EnableSimulation(latency time.Duration) {
+	c.simulate = true
+	c.simulateLatency = latency
+}
+
 func (c *Controller) enqueueGameServerBasedOnState(item interface{}) {
 	gs := item.(*v1alpha1.GameServer)
 
@@ -214,7 +278,16 @@ func (c *Controller) creationMutationHandler(review admv1beta1.AdmissionReview)
 
 	// This is the main logic of this function
 	// the rest is really just json plumbing
+	if defaults, dErr := c.gameServerDefaultsLister.GameServerDefaults(review.Request.Namespace).Get(v1alpha1.GameServerDefaultsName); dErr == nil {
+		defaults.Apply(gs)
+	} else if !k8serrors.IsNotFound(dErr) {
+		return review, errors.Wrapf(dErr, "error retrieving GameServerDefaults for namespace %s", review.Request.Namespace)
+	}
 	gs.ApplyDefaults()
+	// record which controller version created this GameServer. GameServers
+	// created by a previous version keep their original annotation, so upgrades
+	// don't reclaim GameServers that are already running
+	gs.SetControllerVersion(pkg.Version)
 
 	newGS, err := json.Marshal(gs)
 	if err != nil {
@@ -272,9 +345,64 @@ func (c *Controller) creationValidationHandler(review admv1beta1.AdmissionReview
 		return review, nil
 	}
 
+	if gs.ObjectMeta.Namespace == "" {
+		gs.ObjectMeta.Namespace = review.Request.Namespace
+	}
+	if message, ok := c.checkGameServerLimits(gs); !ok {
+		review.Response.Allowed = false
+		review.Response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: message,
+			Reason:  metav1.StatusReasonForbidden,
+		}
+
+		c.logger.WithField("review", review).Info("GameServer over limit")
+		return review, nil
+	}
+
 	return review, nil
 }
 
+// checkGameServerLimits returns whether creating the given GameServer would
+// exceed the configured per namespace or per fleet GameServer limits, with a
+// quota error message when it would. Counting errors allow the creation, as
+// the scale up batching applies the same limits
+func (c *Controller) checkGameServerLimits(gs *v1alpha1.GameServer) (string, bool) {
+	if c.maxPerNamespace <= 0 && c.maxPerFleet <= 0 {
+		return "", true
+	}
+
+	list, err := c.gameServerLister.GameServers(gs.ObjectMeta.Namespace).List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(err).Warning("could not list GameServers to apply limits")
+		return "", true
+	}
+
+	count := int64(0)
+	fleetCount := int64(0)
+	fleetName := gs.ObjectMeta.Labels[v1alpha1.FleetNameLabel]
+	for _, existing := range list {
+		if !existing.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		count++
+		if fleetName != "" && existing.ObjectMeta.Labels[v1alpha1.FleetNameLabel] == fleetName {
+			fleetCount++
+		}
+	}
+
+	if c.maxPerNamespace > 0 && count >= c.maxPerNamespace {
+		return fmt.Sprintf("namespace %s has reached its quota of %d GameServers",
+			gs.ObjectMeta.Namespace, c.maxPerNamespace), false
+	}
+	if c.maxPerFleet > 0 && fleetName != "" && fleetCount >= c.maxPerFleet {
+		return fmt.Sprintf("fleet %s has reached its quota of %d GameServers",
+			fleetName, c.maxPerFleet), false
+	}
+
+	return "", true
+}
+
 // Run the GameServer controller. Will block until stop is closed.
 // Runs threadiness number workers to process the rate limited queue
 func (c *Controller) Run(workers int, stop <-chan struct{}) error {
@@ -285,8 +413,13 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 		return err
 	}
 
+	err = crd.WaitForEstablishedCRD(c.crdGetter, "gameserverdefaults.stable.agones.dev", c.logger)
+	if err != nil {
+		return err
+	}
+
 	c.logger.Info("Wait for cache sync")
-	if !cache.WaitForCacheSync(stop, c.gameServerSynced) {
+	if !cache.WaitForCacheSync(stop, c.gameServerSynced, c.gameServerDefaultsSynced) {
 		return errors.New("failed to wait for caches to sync")
 	}
 
@@ -316,6 +449,12 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 	return nil
 }
 
+// WaitUntilRunning blocks until this controller has finished its startup
+// and its workers are processing events, or stop is closed
+func (c *Controller) WaitUntilRunning(stop <-chan struct{}) bool {
+	return c.workerqueue.WaitUntilRunning(stop)
+}
+
 // syncGameServer synchronises the Pods for the GameServers.
 // and reacts to status changes that can occur through the client SDK
 func (c *Controller) syncGameServer(key string) error {
@@ -344,16 +483,25 @@ func (c *Controller) syncGameServer(key string) error {
 	if gs, err = c.syncGameServerPortAllocationState(gs); err != nil {
 		return err
 	}
-	if gs, err = c.syncGameServerCreatingState(gs); err != nil {
-		return err
-	}
-	if gs, err = c.syncGameServerStartingState(gs); err != nil {
-		return err
+	if c.simulate {
+		if gs, err = c.syncSimulatedGameServer(gs); err != nil {
+			return err
+		}
+	} else {
+		if gs, err = c.syncGameServerCreatingState(gs); err != nil {
+			return err
+		}
+		if gs, err = c.syncGameServerStartingState(gs); err != nil {
+			return err
+		}
+		if gs, err = c.syncGameServerRequestReadyState(gs); err != nil {
+			return err
+		}
 	}
-	if gs, err = c.syncGameServerRequestReadyState(gs); err != nil {
+	if gs, err = c.syncDevelopmentGameServer(gs); err != nil {
 		return err
 	}
-	if gs, err = c.syncDevelopmentGameServer(gs); err != nil {
+	if gs, err = c.syncGameServerReservedState(gs); err != nil {
 		return err
 	}
 	if err = c.syncGameServerShutdownState(gs); err != nil {
@@ -413,6 +561,7 @@ func (c *Controller) syncGameServerPortAllocationState(gs *v1alpha1.GameServer)
 	gsCopy := c.portAllocator.Allocate(gs.DeepCopy())
 
 	gsCopy.Status.State = v1alpha1.GameServerStateCreating
+	gsCopy.Status.RecordStateTransition()
 	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State), "Port allocated")
 
 	c.logger.WithField("gs", gsCopy).Info("Syncing Port Allocation GameServerState")
@@ -459,6 +608,7 @@ func (c *Controller) syncGameServerCreatingState(gs *v1alpha1.GameServer) (*v1al
 
 	gsCopy := gs.DeepCopy()
 	gsCopy.Status.State = v1alpha1.GameServerStateStarting
+	gsCopy.Status.RecordStateTransition()
 	gs, err = c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
 	if err != nil {
 		return gs, errors.Wrapf(err, "error updating GameServer %s to Starting state", gs.Name)
@@ -489,6 +639,7 @@ func (c *Controller) syncDevelopmentGameServer(gs *v1alpha1.GameServer) (*v1alph
 	}
 	// TODO: Use UpdateStatus() when it's available.
 	gsCopy.Status.State = v1alpha1.GameServerStateReady
+	gsCopy.Status.RecordStateTransition()
 	gsCopy.Status.Ports = ports
 	gsCopy.Status.Address = devIPAddress
 	gsCopy.Status.NodeName = devIPAddress
@@ -499,11 +650,88 @@ func (c *Controller) syncDevelopmentGameServer(gs *v1alpha1.GameServer) (*v1alph
 	return gs, nil
 }
 
+// syncSimulatedGameServer advances a GameServer through Starting, Scheduled
+// and Ready without ever creating a Pod, waiting simulateLatency between each
+// transition, so the control plane can be scale tested without consuming nodes
+func (c *Controller) syncSimulatedGameServer(gs *v1alpha1.GameServer) (*v1alpha1.GameServer, error) {
+	if !gs.ObjectMeta.DeletionTimestamp.IsZero() {
+		return gs, nil
+	}
+	if _, isDev := gs.GetDevAddress(); isDev {
+		return gs, nil
+	}
+
+	var next v1alpha1.GameServerState
+	switch gs.Status.State {
+	case v1alpha1.GameServerStateCreating:
+		next = v1alpha1.GameServerStateStarting
+	case v1alpha1.GameServerStateStarting:
+		next = v1alpha1.GameServerStateScheduled
+	case v1alpha1.GameServerStateScheduled, v1alpha1.GameServerStateRequestReady:
+		next = v1alpha1.GameServerStateReady
+	default:
+		return gs, nil
+	}
+
+	// wait out the configured latency since the last transition, and come
+	// back to this GameServer once it has passed
+	if remaining := c.simulatedTransitionDelay(gs); remaining > 0 {
+		key, err := cache.MetaNamespaceKeyFunc(gs)
+		if err != nil {
+			return gs, errors.Wrapf(err, "error getting key for GameServer %s", gs.ObjectMeta.Name)
+		}
+		time.AfterFunc(remaining, func() {
+			c.workerqueue.Enqueue(cache.ExplicitKey(key))
+		})
+		return gs, nil
+	}
+
+	c.logger.WithField("gs", gs.ObjectMeta.Name).WithField("state", next).Info("Syncing simulated GameServer")
+
+	gsCopy := gs.DeepCopy()
+	if gsCopy.Status.Address == "" {
+		ports := []v1alpha1.GameServerStatusPort{}
+		for _, p := range gs.Spec.Ports {
+			ports = append(ports, p.Status())
+		}
+		gsCopy.Status.Ports = ports
+		gsCopy.Status.Address = "127.0.0.1"
+		gsCopy.Status.NodeName = "simulated"
+	}
+	gsCopy.Status.State = next
+	gsCopy.Status.RecordStateTransition()
+	gs, err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
+	if err != nil {
+		return gs, errors.Wrapf(err, "error updating simulated GameServer %s to %s state", gsCopy.ObjectMeta.Name, next)
+	}
+	return gs, nil
+}
+
+// simulatedTransitionDelay returns how long a simulated GameServer still has
+// to wait before its next lifecycle transition
+func (c *Controller) simulatedTransitionDelay(gs *v1alpha1.GameServer) time.Duration {
+	if c.simulateLatency <= 0 || len(gs.Status.Timeline) == 0 {
+		return 0
+	}
+	last := gs.Status.Timeline[len(gs.Status.Timeline)-1]
+	return c.simulateLatency - time.Since(last.Time.Time)
+}
+
 // createGameServerPod creates the backing Pod for a given GameServer
 func (c *Controller) createGameServerPod(gs *v1alpha1.GameServer) (*v1alpha1.GameServer, error) {
-	sidecar := c.sidecar(gs)
+	// fail soft, as the install may already manage the sidecar's RBAC in
+	// this namespace
+	if err := c.sidecarRBAC.reconcile(gs.ObjectMeta.Namespace); err != nil {
+		c.logger.WithField("namespace", gs.ObjectMeta.Namespace).WithError(err).
+			Warn("error reconciling sidecar RBAC")
+	}
+
+	sidecars := []corev1.Container{c.sidecar(gs)}
+	if gs.Spec.QueryResponder != nil && c.queryResponderImage != "" {
+		sidecars = append(sidecars, c.queryResponder(gs))
+	}
 	var pod *corev1.Pod
-	pod, err := gs.Pod(sidecar)
+	pod, err := gs.Pod(sidecars...)
 
 	// this shouldn't happen, but if it does.
 	if err != nil {
@@ -526,14 +754,67 @@ func (c *Controller) createGameServerPod(gs *v1alpha1.GameServer) (*v1alpha1.Gam
 			gs, err = c.moveToErrorState(gs, err.Error())
 			return gs, err
 		}
-		return gs, errors.Wrapf(err, "error creating Pod for GameServer %s", gs.Name)
+		return c.retryPodCreation(gs, err)
 	}
 	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State),
 		fmt.Sprintf("Pod %s created", pod.ObjectMeta.Name))
 
+	if err := c.createGameServerService(gs); err != nil {
+		return gs, err
+	}
+
 	return gs, nil
 }
 
+// retryPodCreation records a failed Pod creation on the GameServer status, and
+// requeues it for a rate limited retry, so transient failures such as exceeded
+// quota or an admission denial don't immediately replace the GameServer. Once
+// maxPodCreationRetries is reached, the GameServer is moved to Error
+func (c *Controller) retryPodCreation(gs *v1alpha1.GameServer, podErr error) (*v1alpha1.GameServer, error) {
+	if gs.Status.PodCreationAttempts >= maxPodCreationRetries {
+		c.logger.WithField("gameserver", gs.ObjectMeta.Name).WithError(podErr).
+			Error("Pod creation retries exhausted")
+		return c.moveToErrorState(gs, podErr.Error())
+	}
+
+	gsCopy := gs.DeepCopy()
+	gsCopy.Status.PodCreationAttempts++
+	gsCopy.Status.PodCreationFailure = podErr.Error()
+
+	gs, err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
+	if err != nil {
+		return gs, errors.Wrapf(err, "error recording Pod creation failure on GameServer %s", gsCopy.ObjectMeta.Name)
+	}
+
+	c.recorder.Event(gs, corev1.EventTypeWarning, string(gs.Status.State),
+		fmt.Sprintf("Pod creation failed (attempt %d of %d): %s", gs.Status.PodCreationAttempts, maxPodCreationRetries, podErr.Error()))
+	// return the original error, so the workerqueue requeues this GameServer with backoff
+	return gs, errors.Wrapf(podErr, "error creating Pod for GameServer %s", gs.ObjectMeta.Name)
+}
+
+// createGameServerService creates the Service fronting a GameServer's ports,
+// when the GameServer declares a ServiceType. A noop if the Service
+// already exists
+func (c *Controller) createGameServerService(gs *v1alpha1.GameServer) error {
+	svc := gs.Service()
+	if svc == nil {
+		return nil
+	}
+
+	c.logger.WithField("service", svc).Info("creating Service for GameServer")
+	svc, err := c.serviceGetter.Services(gs.ObjectMeta.Namespace).Create(svc)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error creating Service for GameServer %s", gs.ObjectMeta.Name)
+	}
+	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State),
+		fmt.Sprintf("Service %s created", svc.ObjectMeta.Name))
+
+	return nil
+}
+
 // sidecar creates the sidecar container for a given GameServer
 func (c *Controller) sidecar(gs *v1alpha1.GameServer) corev1.Container {
 	sidecar := corev1.Container{
@@ -574,12 +855,83 @@ func (c *Controller) sidecar(gs *v1alpha1.GameServer) corev1.Container {
 		sidecar.Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: c.sidecarCPULimit}
 	}
 
+	// namespace wide defaults override the install wide sidecar resources
+	if defaults, err := c.gameServerDefaultsLister.GameServerDefaults(gs.ObjectMeta.Namespace).Get(v1alpha1.GameServerDefaultsName); err == nil {
+		if len(defaults.Spec.SidecarResources.Requests) > 0 {
+			sidecar.Resources.Requests = defaults.Spec.SidecarResources.Requests
+		}
+		if len(defaults.Spec.SidecarResources.Limits) > 0 {
+			sidecar.Resources.Limits = defaults.Spec.SidecarResources.Limits
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		c.logger.WithError(err).WithField("gs", gs.ObjectMeta.Name).Warn("error retrieving GameServerDefaults. Using install wide sidecar resources")
+	}
+
+	// the GameServer's own SdkServer configuration takes precedence over
+	// both the namespace and install wide settings
+	if len(gs.Spec.SdkServer.Resources.Requests) > 0 {
+		sidecar.Resources.Requests = gs.Spec.SdkServer.Resources.Requests
+	}
+	if len(gs.Spec.SdkServer.Resources.Limits) > 0 {
+		sidecar.Resources.Limits = gs.Spec.SdkServer.Resources.Limits
+	}
+	if gs.Spec.SdkServer.LogLevel != "" {
+		sidecar.Env = append(sidecar.Env, corev1.EnvVar{Name: "LOG_LEVEL", Value: string(gs.Spec.SdkServer.LogLevel)})
+	}
+	if gs.Spec.SdkServer.GRPCPort != 0 {
+		sidecar.Env = append(sidecar.Env, corev1.EnvVar{Name: "GRPC_PORT", Value: strconv.Itoa(int(gs.Spec.SdkServer.GRPCPort))})
+	}
+	if gs.Spec.SdkServer.HTTPPort != 0 {
+		sidecar.Env = append(sidecar.Env, corev1.EnvVar{Name: "HTTP_PORT", Value: strconv.Itoa(int(gs.Spec.SdkServer.HTTPPort))})
+	}
+
 	if c.alwaysPullSidecarImage {
 		sidecar.ImagePullPolicy = corev1.PullAlways
 	}
 	return sidecar
 }
 
+// queryResponder creates the query responder sidecar container for a given
+// GameServer, serving the configured server-query protocol from SDK state
+func (c *Controller) queryResponder(gs *v1alpha1.GameServer) corev1.Container {
+	qr := gs.Spec.QueryResponder
+	container := corev1.Container{
+		Name:  "agones-query-responder",
+		Image: c.queryResponderImage,
+		Ports: []corev1.ContainerPort{{
+			ContainerPort: qr.Port,
+			Protocol:      corev1.ProtocolUDP,
+		}},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "GAMESERVER_NAME",
+				Value: gs.ObjectMeta.Name,
+			},
+			{
+				Name: "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.namespace",
+					},
+				},
+			},
+			{
+				Name:  "QUERY_PROTOCOL",
+				Value: string(qr.Protocol),
+			},
+			{
+				Name:  "QUERY_PORT",
+				Value: strconv.Itoa(int(qr.Port)),
+			},
+		},
+	}
+
+	if c.alwaysPullSidecarImage {
+		container.ImagePullPolicy = corev1.PullAlways
+	}
+	return container
+}
+
 // addGameServerHealthCheck adds the http health check to the GameServer container
 func (c *Controller) addGameServerHealthCheck(gs *v1alpha1.GameServer, pod *corev1.Pod) {
 	if !gs.Spec.Health.Disabled {
@@ -632,6 +984,11 @@ func (c *Controller) syncGameServerStartingState(gs *v1alpha1.GameServer) (*v1al
 	}
 
 	gsCopy.Status.State = v1alpha1.GameServerStateScheduled
+	gsCopy.Status.RecordStateTransition()
+	setCondition(gsCopy, v1alpha1.GameServerPodScheduled, corev1.ConditionTrue, "PodScheduled",
+		fmt.Sprintf("Pod scheduled to node %s", pod.Spec.NodeName))
+	setCondition(gsCopy, v1alpha1.GameServerAddressable, corev1.ConditionTrue, "AddressPopulated",
+		"Address and ports populated")
 	gs, err = c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
 	if err != nil {
 		return gs, errors.Wrapf(err, "error updating GameServer %s to Scheduled state", gs.Name)
@@ -644,6 +1001,10 @@ func (c *Controller) syncGameServerStartingState(gs *v1alpha1.GameServer) (*v1al
 // applyGameServerAddressAndPort gets the backing Pod for the GamesServer,
 // and sets the allocated Address and Port values to it and returns it.
 func (c *Controller) applyGameServerAddressAndPort(gs *v1alpha1.GameServer, pod *corev1.Pod) (*v1alpha1.GameServer, error) {
+	if gs.Spec.ServiceType != "" {
+		return c.applyGameServerServiceAddressAndPort(gs, pod)
+	}
+
 	addr, err := c.address(pod)
 	if err != nil {
 		return gs, errors.Wrapf(err, "error getting external address for GameServer %s", gs.ObjectMeta.Name)
@@ -651,6 +1012,7 @@ func (c *Controller) applyGameServerAddressAndPort(gs *v1alpha1.GameServer, pod
 
 	gs.Status.Address = addr
 	gs.Status.NodeName = pod.Spec.NodeName
+	gs.Status.Network = gs.Spec.Network
 	// HostPort is always going to be populated, even when dynamic
 	// This will be a double up of information, but it will be easier to read
 	gs.Status.Ports = make([]v1alpha1.GameServerStatusPort, len(gs.Spec.Ports))
@@ -661,6 +1023,54 @@ func (c *Controller) applyGameServerAddressAndPort(gs *v1alpha1.GameServer, pod
 	return gs, nil
 }
 
+// applyGameServerServiceAddressAndPort sets the Status Address and Ports
+// from the Service fronting the GameServer, rather than the node. For a
+// LoadBalancer Service this returns an error until the load balancer
+// address has been provisioned, so that the sync is retried
+func (c *Controller) applyGameServerServiceAddressAndPort(gs *v1alpha1.GameServer, pod *corev1.Pod) (*v1alpha1.GameServer, error) {
+	svc, err := c.serviceGetter.Services(gs.ObjectMeta.Namespace).Get(gs.ObjectMeta.Name, metav1.GetOptions{})
+	if err != nil {
+		return gs, errors.Wrapf(err, "error retrieving Service for GameServer %s", gs.ObjectMeta.Name)
+	}
+
+	gs.Status.NodeName = pod.Spec.NodeName
+	gs.Status.Network = gs.Spec.Network
+	gs.Status.Ports = make([]v1alpha1.GameServerStatusPort, len(svc.Spec.Ports))
+
+	// the Service's ports are created from the spec's ports by name, so the
+	// role can be carried over to the status the same way
+	roles := map[string]v1alpha1.PortRole{}
+	for _, p := range gs.Spec.Ports {
+		roles[p.Name] = p.Role
+	}
+
+	switch gs.Spec.ServiceType {
+	case corev1.ServiceTypeLoadBalancer:
+		if len(svc.Status.LoadBalancer.Ingress) == 0 {
+			return gs, errors.Errorf("LoadBalancer address for GameServer %s is not yet available", gs.ObjectMeta.Name)
+		}
+		ingress := svc.Status.LoadBalancer.Ingress[0]
+		gs.Status.Address = ingress.IP
+		if gs.Status.Address == "" {
+			gs.Status.Address = ingress.Hostname
+		}
+		for i, p := range svc.Spec.Ports {
+			gs.Status.Ports[i] = v1alpha1.GameServerStatusPort{Name: p.Name, Port: p.Port, Role: roles[p.Name]}
+		}
+	default:
+		addr, err := c.address(pod)
+		if err != nil {
+			return gs, errors.Wrapf(err, "error getting external address for GameServer %s", gs.ObjectMeta.Name)
+		}
+		gs.Status.Address = addr
+		for i, p := range svc.Spec.Ports {
+			gs.Status.Ports[i] = v1alpha1.GameServerStatusPort{Name: p.Name, Port: p.NodePort, Role: roles[p.Name]}
+		}
+	}
+
+	return gs, nil
+}
+
 // syncGameServerRequestReadyState checks if the Game Server is Requesting to be ready,
 // and then adds the IP and Port information to the Status and marks the GameServer
 // as Ready
@@ -693,9 +1103,33 @@ func (c *Controller) syncGameServerRequestReadyState(gs *v1alpha1.GameServer) (*
 		if err != nil {
 			return gs, err
 		}
+		setCondition(gsCopy, v1alpha1.GameServerPodScheduled, corev1.ConditionTrue, "PodScheduled",
+			fmt.Sprintf("Pod scheduled to node %s", pod.Spec.NodeName))
+		setCondition(gsCopy, v1alpha1.GameServerAddressable, corev1.ConditionTrue, "AddressPopulated",
+			"Address and ports populated")
+	}
+
+	if gs.Spec.Players != nil && gsCopy.Status.Players == nil {
+		gsCopy.Status.Players = &v1alpha1.PlayerStatus{Capacity: gs.Spec.Players.InitialCapacity}
+	}
+	if len(gs.Spec.Counters) > 0 && gsCopy.Status.Counters == nil {
+		gsCopy.Status.Counters = map[string]v1alpha1.CounterStatus{}
+		for name, counter := range gs.Spec.Counters {
+			gsCopy.Status.Counters[name] = counter
+		}
+	}
+	if len(gs.Spec.Lists) > 0 && gsCopy.Status.Lists == nil {
+		gsCopy.Status.Lists = map[string]v1alpha1.ListStatus{}
+		for name, list := range gs.Spec.Lists {
+			gsCopy.Status.Lists[name] = *list.DeepCopy()
+		}
 	}
 
 	gsCopy.Status.State = v1alpha1.GameServerStateReady
+	gsCopy.Status.RecordStateTransition()
+	setCondition(gsCopy, v1alpha1.GameServerSDKConnected, corev1.ConditionTrue, "SDKReady",
+		"Game process connected to the SDK sidecar and called Ready")
+	setCondition(gsCopy, v1alpha1.GameServerHealthy, corev1.ConditionTrue, "Ready", "GameServer is Ready")
 	gs, err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
 	if err != nil {
 		return gs, errors.Wrapf(err, "error setting Ready, Port and address on GameServer %s Status", gs.ObjectMeta.Name)
@@ -708,13 +1142,47 @@ func (c *Controller) syncGameServerRequestReadyState(gs *v1alpha1.GameServer) (*
 	return gs, nil
 }
 
-// syncGameServerShutdownState deletes the GameServer (and therefore the backing Pod) if it is in shutdown state
+// syncGameServerReservedState moves a Reserved GameServer back to Ready once
+// its reservation has expired. If it has not expired yet, the GameServer is
+// re-enqueued for the remaining duration
+func (c *Controller) syncGameServerReservedState(gs *v1alpha1.GameServer) (*v1alpha1.GameServer, error) {
+	if !(gs.Status.State == v1alpha1.GameServerStateReserved && gs.ObjectMeta.DeletionTimestamp.IsZero()) ||
+		gs.Status.ReservedUntil == nil {
+		return gs, nil
+	}
+
+	now := time.Now().UTC()
+	if remaining := gs.Status.ReservedUntil.Time.Sub(now); remaining > 0 {
+		c.workerqueue.EnqueueAfter(gs, remaining)
+		return gs, nil
+	}
+
+	c.logger.WithField("gs", gs.ObjectMeta.Name).Info("Syncing Reserved State, moving to Ready")
+
+	gsCopy := gs.DeepCopy()
+	gsCopy.Status.State = v1alpha1.GameServerStateReady
+	gsCopy.Status.ReservedUntil = nil
+	gsCopy.Status.RecordStateTransition()
+	gs, err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
+	if err != nil {
+		return gs, errors.Wrapf(err, "error moving GameServer %s out of Reserved", gs.ObjectMeta.Name)
+	}
+	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State), "Reservation expired")
+	return gs, nil
+}
+
+// syncGameServerShutdownState deletes the GameServer (and therefore the backing Pod) if it is in shutdown state,
+// or moves it to the Completed state if its shutdown policy is Retain
 func (c *Controller) syncGameServerShutdownState(gs *v1alpha1.GameServer) error {
 	if !(gs.Status.State == v1alpha1.GameServerStateShutdown && gs.ObjectMeta.DeletionTimestamp.IsZero()) {
 		return nil
 	}
 
 	c.logger.WithField("gs", gs).Info("Syncing Shutdown State")
+	if gs.Spec.Shutdown.Policy == v1alpha1.ShutdownPolicyRetain {
+		return c.retainGameServer(gs)
+	}
+
 	// be explicit about where to delete.
 	p := metav1.DeletePropagationBackground
 	err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Delete(gs.ObjectMeta.Name, &metav1.DeleteOptions{PropagationPolicy: &p})
@@ -725,10 +1193,38 @@ func (c *Controller) syncGameServerShutdownState(gs *v1alpha1.GameServer) error
 	return nil
 }
 
+// retainGameServer deletes the backing Pod of a Shutdown GameServer, but moves
+// the GameServer itself into the Completed state, so it can be inspected until
+// the garbage collector removes it after the retain period
+func (c *Controller) retainGameServer(gs *v1alpha1.GameServer) error {
+	pods, err := c.listGameServerPods(gs)
+	if err != nil {
+		return err
+	}
+	for _, p := range pods {
+		err = c.podGetter.Pods(p.ObjectMeta.Namespace).Delete(p.ObjectMeta.Name, nil)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting pod for GameServer %s, %s", gs.ObjectMeta.Name, p.ObjectMeta.Name)
+		}
+	}
+
+	gsCopy := gs.DeepCopy()
+	gsCopy.Status.State = v1alpha1.GameServerStateCompleted
+	gsCopy.Status.RecordStateTransition()
+	gs, err = c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
+	if err != nil {
+		return errors.Wrapf(err, "error retaining GameServer %s", gsCopy.ObjectMeta.Name)
+	}
+	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State),
+		fmt.Sprintf("Pod deleted, GameServer retained for %d seconds", gs.Spec.Shutdown.RetainSeconds))
+	return nil
+}
+
 // moveToErrorState moves the GameServer to the error state
 func (c *Controller) moveToErrorState(gs *v1alpha1.GameServer, msg string) (*v1alpha1.GameServer, error) {
 	copy := gs.DeepCopy()
 	copy.Status.State = v1alpha1.GameServerStateError
+	copy.Status.RecordStateTransition()
 
 	gs, err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(copy)
 	if err != nil {
@@ -780,31 +1276,28 @@ func (c *Controller) listGameServerPods(gs *v1alpha1.GameServer) ([]*corev1.Pod,
 	return result, nil
 }
 
-// address returns the IP that the given Pod is being run on
-// This should be the externalIP, but if the externalIP is
-// not set, it will fall back to the internalIP with a warning.
-// (basically because minikube only has an internalIP)
+// address returns the externally reachable address that the given Pod's
+// GameServer should be connected to through, as resolved by the
+// configured AddressProvider
 func (c *Controller) address(pod *corev1.Pod) (string, error) {
 	node, err := c.nodeLister.Get(pod.Spec.NodeName)
 	if err != nil {
 		return "", errors.Wrapf(err, "error retrieving node %s for Pod %s", pod.Spec.NodeName, pod.ObjectMeta.Name)
 	}
 
-	for _, a := range node.Status.Addresses {
-		if a.Type == corev1.NodeExternalIP {
-			return a.Address, nil
-		}
-	}
-
-	// minikube only has an InternalIP on a Node, so we'll fall back to that.
-	c.logger.WithField("node", node.ObjectMeta.Name).Warn("Could not find ExternalIP. Falling back to Internal")
-	for _, a := range node.Status.Addresses {
-		if a.Type == corev1.NodeInternalIP {
-			return a.Address, nil
-		}
-	}
+	return c.addressProvider.Address(node)
+}
 
-	return "", errors.Errorf("Could not find an address for Node: %s", node.ObjectMeta.Name)
+// setCondition records a condition observed by the controller on the given
+// GameServer, stamped with the current time
+func setCondition(gs *v1alpha1.GameServer, t v1alpha1.GameServerConditionType, status corev1.ConditionStatus, reason string, message string) {
+	gs.Status.SetCondition(v1alpha1.GameServerCondition{
+		Type:          t,
+		Status:        status,
+		LastProbeTime: metav1.Now(),
+		Reason:        reason,
+		Message:       message,
+	})
 }
 
 // isGameServerPod returns if this Pod is a Pod that comes from a GameServer
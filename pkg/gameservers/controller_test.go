@@ -18,14 +18,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
+	"agones.dev/agones/pkg"
 	"agones.dev/agones/pkg/apis/stable"
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
 	agtesting "agones.dev/agones/pkg/testing"
 	"agones.dev/agones/pkg/util/webhooks"
 	"github.com/heptiolabs/healthcheck"
 	"github.com/mattbaird/jsonpatch"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	admv1beta1 "k8s.io/api/admission/v1beta1"
@@ -373,6 +377,102 @@ func TestControllerCreationMutationHandler(t *testing.T) {
 
 	assertContains(patch, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/metadata/finalizers", Value: []interface{}{"stable.agones.dev"}})
 	assertContains(patch, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/ports/0/protocol", Value: "UDP"})
+	assertContains(patch, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/metadata/annotations",
+		Value: map[string]interface{}{v1alpha1.ControllerVersionAnnotation: pkg.Version}})
+}
+
+func TestControllerCreationMutationHandlerExistingControllerVersion(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newFakeController()
+
+	fixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default",
+		Annotations: map[string]string{v1alpha1.ControllerVersionAnnotation: "0.7.0"}},
+		Spec: newSingleContainerSpec()}
+
+	raw, err := json.Marshal(fixture)
+	assert.Nil(t, err)
+	review := admv1beta1.AdmissionReview{
+		Request: &admv1beta1.AdmissionRequest{
+			Kind:      GameServerKind,
+			Operation: admv1beta1.Create,
+			Object: runtime.RawExtension{
+				Raw: raw,
+			},
+		},
+		Response: &admv1beta1.AdmissionResponse{Allowed: true},
+	}
+
+	result, err := c.creationMutationHandler(review)
+	assert.Nil(t, err)
+
+	patch := &jsonpatch.ByPath{}
+	err = json.Unmarshal(result.Response.Patch, patch)
+	assert.Nil(t, err)
+
+	for _, p := range *patch {
+		assert.NotEqual(t, "/metadata/annotations", p.Path,
+			"annotation from the previous controller version should be left untouched, got %#v", p)
+	}
+}
+
+func TestControllerCreationMutationHandlerNamespaceDefaults(t *testing.T) {
+	t.Parallel()
+
+	c, m := newFakeController()
+
+	defaults := &v1alpha1.GameServerDefaults{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.GameServerDefaultsName, Namespace: "default"},
+		Spec: v1alpha1.GameServerDefaultsSpec{
+			Scheduling: v1alpha1.Distributed,
+			Health:     v1alpha1.Health{FailureThreshold: 9},
+		},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameserverdefaults", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerDefaultsList{Items: []v1alpha1.GameServerDefaults{*defaults}}, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.gameServerDefaultsSynced)
+	defer cancel()
+
+	fixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: newSingleContainerSpec()}
+
+	raw, err := json.Marshal(fixture)
+	assert.Nil(t, err)
+	review := admv1beta1.AdmissionReview{
+		Request: &admv1beta1.AdmissionRequest{
+			Kind:      GameServerKind,
+			Namespace: "default",
+			Operation: admv1beta1.Create,
+			Object: runtime.RawExtension{
+				Raw: raw,
+			},
+		},
+		Response: &admv1beta1.AdmissionResponse{Allowed: true},
+	}
+
+	result, err := c.creationMutationHandler(review)
+	assert.Nil(t, err)
+
+	patch := &jsonpatch.ByPath{}
+	err = json.Unmarshal(result.Response.Patch, patch)
+	assert.Nil(t, err)
+
+	assertContains := func(patch *jsonpatch.ByPath, op jsonpatch.JsonPatchOperation) {
+		found := false
+		for _, p := range *patch {
+			if assert.ObjectsAreEqualValues(p, op) {
+				found = true
+			}
+		}
+
+		assert.True(t, found, "Could not find operation %#v in patch %v", op, *patch)
+	}
+
+	assertContains(patch, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/scheduling", Value: "Distributed"})
+	assertContains(patch, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/health/failureThreshold", Value: float64(9)})
 }
 
 func TestControllerCreationValidationHandler(t *testing.T) {
@@ -442,6 +542,97 @@ func TestControllerCreationValidationHandler(t *testing.T) {
 	})
 }
 
+func TestControllerCreationValidationHandlerLimits(t *testing.T) {
+	t.Parallel()
+
+	newReview := func(gs *v1alpha1.GameServer) admv1beta1.AdmissionReview {
+		raw, err := json.Marshal(gs)
+		assert.Nil(t, err)
+		return admv1beta1.AdmissionReview{
+			Request: &admv1beta1.AdmissionRequest{
+				Kind:      GameServerKind,
+				Operation: admv1beta1.Create,
+				Object: runtime.RawExtension{
+					Raw: raw,
+				},
+			},
+			Response: &admv1beta1.AdmissionResponse{Allowed: true},
+		}
+	}
+
+	existing := func(count int, fleet string) *v1alpha1.GameServerList {
+		list := &v1alpha1.GameServerList{}
+		for i := 0; i < count; i++ {
+			gs := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "gs-" + strconv.Itoa(i), Namespace: "default"}}
+			if fleet != "" {
+				gs.ObjectMeta.Labels = map[string]string{v1alpha1.FleetNameLabel: fleet}
+			}
+			list.Items = append(list.Items, gs)
+		}
+		return list
+	}
+
+	t.Run("namespace quota reached", func(t *testing.T) {
+		c, mocks := newFakeController()
+		c.RegisterGameServerLimits(2, 0)
+		mocks.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, existing(2, ""), nil
+		})
+		_, cancel := agtesting.StartInformers(mocks, c.gameServerSynced)
+		defer cancel()
+
+		fixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec()}
+		fixture.ApplyDefaults()
+
+		result, err := c.creationValidationHandler(newReview(fixture))
+		assert.Nil(t, err)
+		assert.False(t, result.Response.Allowed)
+		assert.Equal(t, metav1.StatusFailure, result.Response.Result.Status)
+		assert.Equal(t, metav1.StatusReasonForbidden, result.Response.Result.Reason)
+		assert.Contains(t, result.Response.Result.Message, "quota")
+	})
+
+	t.Run("fleet quota reached", func(t *testing.T) {
+		c, mocks := newFakeController()
+		c.RegisterGameServerLimits(0, 3)
+		mocks.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, existing(3, "fleet-1"), nil
+		})
+		_, cancel := agtesting.StartInformers(mocks, c.gameServerSynced)
+		defer cancel()
+
+		fixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default",
+			Labels: map[string]string{v1alpha1.FleetNameLabel: "fleet-1"}},
+			Spec: newSingleContainerSpec()}
+		fixture.ApplyDefaults()
+
+		result, err := c.creationValidationHandler(newReview(fixture))
+		assert.Nil(t, err)
+		assert.False(t, result.Response.Allowed)
+		assert.Equal(t, metav1.StatusReasonForbidden, result.Response.Result.Reason)
+		assert.Contains(t, result.Response.Result.Message, "fleet fleet-1")
+	})
+
+	t.Run("below the limits", func(t *testing.T) {
+		c, mocks := newFakeController()
+		c.RegisterGameServerLimits(5, 5)
+		mocks.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, existing(2, ""), nil
+		})
+		_, cancel := agtesting.StartInformers(mocks, c.gameServerSynced)
+		defer cancel()
+
+		fixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec()}
+		fixture.ApplyDefaults()
+
+		result, err := c.creationValidationHandler(newReview(fixture))
+		assert.Nil(t, err)
+		assert.True(t, result.Response.Allowed)
+	})
+}
+
 func TestControllerSyncGameServerDeletionTimestamp(t *testing.T) {
 	t.Parallel()
 
@@ -570,6 +761,64 @@ func TestControllerSyncGameServerPortAllocationState(t *testing.T) {
 	})
 }
 
+func TestControllerSyncSimulatedGameServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("walks through the lifecycle without a pod", func(t *testing.T) {
+		c, m := newFakeController()
+		c.EnableSimulation(0)
+
+		gs := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec:   newSingleContainerSpec(),
+			Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateCreating}}
+
+		m.KubeClient.AddReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "pod should not be created")
+			return true, nil, nil
+		})
+
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			return true, ua.GetObject().(*v1alpha1.GameServer), nil
+		})
+
+		states := []v1alpha1.GameServerState{}
+		current := gs
+		for i := 0; i < 3; i++ {
+			updated, err := c.syncSimulatedGameServer(current)
+			assert.Nil(t, err)
+			states = append(states, updated.Status.State)
+			current = updated
+		}
+
+		expected := []v1alpha1.GameServerState{v1alpha1.GameServerStateStarting,
+			v1alpha1.GameServerStateScheduled, v1alpha1.GameServerStateReady}
+		assert.Equal(t, expected, states)
+		assert.Equal(t, "127.0.0.1", current.Status.Address)
+		assert.Equal(t, "simulated", current.Status.NodeName)
+		assert.Len(t, current.Status.Ports, 1)
+	})
+
+	t.Run("waits out the configured latency", func(t *testing.T) {
+		c, m := newFakeController()
+		c.EnableSimulation(time.Hour)
+
+		gs := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec(),
+			Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateCreating,
+				Timeline: []v1alpha1.GameServerStateTransition{{State: v1alpha1.GameServerStateCreating, Time: metav1.Now()}}}}
+
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "gameserver should not be updated")
+			return true, nil, nil
+		})
+
+		updated, err := c.syncSimulatedGameServer(gs)
+		assert.Nil(t, err)
+		assert.Equal(t, v1alpha1.GameServerStateCreating, updated.Status.State)
+	})
+}
+
 func TestControllerSyncGameServerCreatingState(t *testing.T) {
 	t.Parallel()
 
@@ -678,6 +927,59 @@ func TestControllerSyncGameServerCreatingState(t *testing.T) {
 		assert.Equal(t, v1alpha1.GameServerStateError, gs.Status.State)
 	})
 
+	t.Run("pod creation is forbidden by quota", func(t *testing.T) {
+		c, mocks := newFakeController()
+		fixture := newFixture()
+		gsUpdated := false
+
+		mocks.KubeClient.AddReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "test", errors.New("exceeded quota"))
+		})
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gsUpdated = true
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+			assert.Equal(t, int32(1), gs.Status.PodCreationAttempts)
+			assert.Contains(t, gs.Status.PodCreationFailure, "exceeded quota")
+			assert.NotEqual(t, v1alpha1.GameServerStateError, gs.Status.State)
+			return true, gs, nil
+		})
+
+		_, cancel := agtesting.StartInformers(mocks, c.gameServerSynced)
+		defer cancel()
+
+		_, err := c.syncGameServerCreatingState(fixture)
+		assert.NotNil(t, err, "should return an error, so the workerqueue retries")
+		assert.True(t, gsUpdated, "failure should be recorded on the GameServer")
+		agtesting.AssertEventContains(t, mocks.FakeRecorder.Events, "Pod creation failed")
+	})
+
+	t.Run("pod creation retries exhausted", func(t *testing.T) {
+		c, mocks := newFakeController()
+		fixture := newFixture()
+		fixture.Status.PodCreationAttempts = maxPodCreationRetries
+		gsUpdated := false
+
+		mocks.KubeClient.AddReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "test", errors.New("exceeded quota"))
+		})
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gsUpdated = true
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+			assert.Equal(t, v1alpha1.GameServerStateError, gs.Status.State)
+			return true, gs, nil
+		})
+
+		_, cancel := agtesting.StartInformers(mocks, c.gameServerSynced)
+		defer cancel()
+
+		gs, err := c.syncGameServerCreatingState(fixture)
+		assert.Nil(t, err)
+		assert.True(t, gsUpdated, "GameServer should be updated")
+		assert.Equal(t, v1alpha1.GameServerStateError, gs.Status.State)
+	})
+
 	t.Run("GameServer with unknown state", func(t *testing.T) {
 		testNoChange(t, "Unknown", func(c *Controller, fixture *v1alpha1.GameServer) (*v1alpha1.GameServer, error) {
 			return c.syncGameServerCreatingState(fixture)
@@ -738,6 +1040,13 @@ func TestControllerSyncGameServerStartingState(t *testing.T) {
 
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "Address and port populated")
 		assert.NotEmpty(t, gs.Status.Ports)
+
+		if cond := gs.Status.Condition(v1alpha1.GameServerPodScheduled); assert.NotNil(t, cond) {
+			assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		}
+		if cond := gs.Status.Condition(v1alpha1.GameServerAddressable); assert.NotNil(t, cond) {
+			assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		}
 	})
 
 	t.Run("GameServer with unknown state", func(t *testing.T) {
@@ -793,10 +1102,16 @@ func TestControllerCreateGameServerPod(t *testing.T) {
 			assert.Equal(t, pod.Spec.Containers[1].Image, c.sidecarImage)
 			assert.Equal(t, pod.Spec.Containers[1].Resources.Limits.Cpu(), &c.sidecarCPULimit)
 			assert.Equal(t, pod.Spec.Containers[1].Resources.Requests.Cpu(), &c.sidecarCPURequest)
-			assert.Len(t, pod.Spec.Containers[1].Env, 2, "2 env vars")
+			assert.Len(t, pod.Spec.Containers[1].Env, 5, "5 env vars")
 			assert.Equal(t, "GAMESERVER_NAME", pod.Spec.Containers[1].Env[0].Name)
 			assert.Equal(t, fixture.ObjectMeta.Name, pod.Spec.Containers[1].Env[0].Value)
 			assert.Equal(t, "POD_NAMESPACE", pod.Spec.Containers[1].Env[1].Name)
+			assert.Equal(t, "LOG_LEVEL", pod.Spec.Containers[1].Env[2].Name)
+			assert.Equal(t, "Info", pod.Spec.Containers[1].Env[2].Value)
+			assert.Equal(t, "GRPC_PORT", pod.Spec.Containers[1].Env[3].Name)
+			assert.Equal(t, "59357", pod.Spec.Containers[1].Env[3].Value)
+			assert.Equal(t, "HTTP_PORT", pod.Spec.Containers[1].Env[4].Name)
+			assert.Equal(t, "59358", pod.Spec.Containers[1].Env[4].Value)
 			return true, pod, nil
 		})
 
@@ -808,6 +1123,68 @@ func TestControllerCreateGameServerPod(t *testing.T) {
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "Pod")
 	})
 
+	t.Run("sdkServer overrides", func(t *testing.T) {
+		c, _ := newFakeController()
+		fixture := newFixture()
+		fixture.Spec.SdkServer = v1alpha1.SdkServerSpec{
+			LogLevel: v1alpha1.SdkServerLogLevelDebug,
+			GRPCPort: 9357,
+			HTTPPort: 9358,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			},
+		}
+
+		sidecar := c.sidecar(fixture)
+
+		assert.Equal(t, resource.MustParse("50m"), sidecar.Resources.Requests[corev1.ResourceCPU])
+		assert.Equal(t, resource.MustParse("64Mi"), sidecar.Resources.Limits[corev1.ResourceMemory])
+
+		env := map[string]string{}
+		for _, e := range sidecar.Env {
+			env[e.Name] = e.Value
+		}
+		assert.Equal(t, "Debug", env["LOG_LEVEL"])
+		assert.Equal(t, "9357", env["GRPC_PORT"])
+		assert.Equal(t, "9358", env["HTTP_PORT"])
+	})
+
+	t.Run("query responder sidecar", func(t *testing.T) {
+		c, m := newFakeController()
+		c.RegisterQueryResponderImage("myimage:0.1")
+		fixture := newFixture()
+		fixture.Spec.QueryResponder = &v1alpha1.QueryResponderSpec{}
+		fixture.ApplyDefaults()
+		created := false
+
+		m.KubeClient.AddReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			created = true
+			ca := action.(k8stesting.CreateAction)
+			pod := ca.GetObject().(*corev1.Pod)
+
+			assert.Len(t, pod.Spec.Containers, 3, "Should have sdk and query responder sidecars")
+			qr := pod.Spec.Containers[2]
+			assert.Equal(t, "agones-query-responder", qr.Name)
+			assert.Equal(t, "myimage:0.1", qr.Image)
+			assert.Equal(t, int32(27015), qr.Ports[0].ContainerPort)
+			assert.Equal(t, corev1.ProtocolUDP, qr.Ports[0].Protocol)
+
+			env := map[string]string{}
+			for _, e := range qr.Env {
+				env[e.Name] = e.Value
+			}
+			assert.Equal(t, fixture.ObjectMeta.Name, env["GAMESERVER_NAME"])
+			assert.Equal(t, "A2S", env["QUERY_PROTOCOL"])
+			assert.Equal(t, "27015", env["QUERY_PORT"])
+			return true, pod, nil
+		})
+
+		_, err := c.createGameServerPod(fixture)
+		assert.Nil(t, err)
+		assert.True(t, created)
+	})
+
 	t.Run("invalid podspec", func(t *testing.T) {
 		c, mocks := newFakeController()
 		fixture := newFixture()
@@ -861,6 +1238,41 @@ func TestControllerApplyGameServerAddressAndPort(t *testing.T) {
 	assert.Equal(t, node.ObjectMeta.Name, gs.Status.NodeName)
 }
 
+func TestControllerApplyGameServerServiceAddressAndPort(t *testing.T) {
+	t.Parallel()
+	c, m := newFakeController()
+
+	gsFixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: newSingleContainerSpec(), Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateRequestReady}}
+	gsFixture.Spec.ServiceType = corev1.ServiceTypeLoadBalancer
+	gsFixture.ApplyDefaults()
+	pod, err := gsFixture.Pod()
+	assert.Nil(t, err)
+	pod.Spec.NodeName = nodeFixtureName
+
+	svc := gsFixture.Service()
+	assert.NotNil(t, svc)
+
+	m.KubeClient.AddReactor("get", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, svc, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.gameServerSynced)
+	defer cancel()
+
+	// no load balancer address yet, so the sync should be retried
+	_, err = c.applyGameServerAddressAndPort(gsFixture.DeepCopy(), pod)
+	assert.NotNil(t, err)
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: ipFixture}}
+
+	gs, err := c.applyGameServerAddressAndPort(gsFixture, pod)
+	assert.Nil(t, err)
+	assert.Equal(t, ipFixture, gs.Status.Address)
+	assert.Equal(t, nodeFixtureName, gs.Status.NodeName)
+	assert.Equal(t, gs.Spec.Ports[0].ContainerPort, gs.Status.Ports[0].Port)
+}
+
 func TestControllerSyncGameServerRequestReadyState(t *testing.T) {
 	t.Parallel()
 
@@ -894,6 +1306,13 @@ func TestControllerSyncGameServerRequestReadyState(t *testing.T) {
 		assert.True(t, gsUpdated, "GameServer wasn't updated")
 		assert.Equal(t, v1alpha1.GameServerStateReady, gs.Status.State)
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "SDK.Ready() executed")
+
+		if cond := gs.Status.Condition(v1alpha1.GameServerSDKConnected); assert.NotNil(t, cond) {
+			assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		}
+		if cond := gs.Status.Condition(v1alpha1.GameServerHealthy); assert.NotNil(t, cond) {
+			assert.Equal(t, corev1.ConditionTrue, cond.Status)
+		}
 	})
 
 	t.Run("GameServer without an Address, but RequestReady State", func(t *testing.T) {
@@ -956,6 +1375,74 @@ func TestControllerSyncGameServerRequestReadyState(t *testing.T) {
 	})
 }
 
+func TestControllerSyncGameServerReservedState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("expired reservation", func(t *testing.T) {
+		c, m := newFakeController()
+
+		expired := metav1.NewTime(time.Now().UTC().Add(-time.Minute))
+		gsFixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec(), Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReserved, ReservedUntil: &expired}}
+		gsFixture.ApplyDefaults()
+		gsUpdated := false
+
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gsUpdated = true
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+			assert.Equal(t, v1alpha1.GameServerStateReady, gs.Status.State)
+			assert.Nil(t, gs.Status.ReservedUntil)
+			return true, gs, nil
+		})
+
+		gs, err := c.syncGameServerReservedState(gsFixture)
+		assert.Nil(t, err, "should not error")
+		assert.True(t, gsUpdated, "GameServer wasn't updated")
+		assert.Equal(t, v1alpha1.GameServerStateReady, gs.Status.State)
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "Reservation expired")
+	})
+
+	t.Run("reservation still active", func(t *testing.T) {
+		c, m := newFakeController()
+
+		until := metav1.NewTime(time.Now().UTC().Add(time.Hour))
+		gsFixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec(), Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReserved, ReservedUntil: &until}}
+		gsFixture.ApplyDefaults()
+		gsUpdated := false
+
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gsUpdated = true
+			return true, nil, nil
+		})
+
+		gs, err := c.syncGameServerReservedState(gsFixture)
+		assert.Nil(t, err, "should not error")
+		assert.False(t, gsUpdated, "GameServer should not have been updated")
+		assert.Equal(t, v1alpha1.GameServerStateReserved, gs.Status.State)
+	})
+
+	t.Run("not in Reserved state", func(t *testing.T) {
+		c, m := newFakeController()
+
+		gsFixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec(), Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady}}
+		gsFixture.ApplyDefaults()
+		gsUpdated := false
+
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			gsUpdated = true
+			return true, nil, nil
+		})
+
+		gs, err := c.syncGameServerReservedState(gsFixture)
+		assert.Nil(t, err, "should not error")
+		assert.False(t, gsUpdated, "GameServer should not have been updated")
+		assert.Equal(t, v1alpha1.GameServerStateReady, gs.Status.State)
+	})
+}
+
 func TestControllerSyncGameServerShutdownState(t *testing.T) {
 	t.Parallel()
 
@@ -984,6 +1471,49 @@ func TestControllerSyncGameServerShutdownState(t *testing.T) {
 		assert.Contains(t, <-mocks.FakeRecorder.Events, "Deletion started")
 	})
 
+	t.Run("GameServer with a Retain shutdown policy", func(t *testing.T) {
+		c, mocks := newFakeController()
+		gsFixture := &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec(), Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateShutdown}}
+		gsFixture.Spec.Shutdown.Policy = v1alpha1.ShutdownPolicyRetain
+		gsFixture.ApplyDefaults()
+		pod, err := gsFixture.Pod()
+		assert.Nil(t, err)
+		pod.ObjectMeta.Name = pod.ObjectMeta.GenerateName
+
+		podDeleted := false
+		mocks.KubeClient.AddReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &corev1.PodList{Items: []corev1.Pod{*pod}}, nil
+		})
+		mocks.KubeClient.AddReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			podDeleted = true
+			da := action.(k8stesting.DeleteAction)
+			assert.Equal(t, pod.ObjectMeta.Name, da.GetName())
+			return true, nil, nil
+		})
+		mocks.AgonesClient.AddReactor("delete", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "a retained GameServer should not be deleted")
+			return true, nil, nil
+		})
+		updated := false
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+			assert.Equal(t, v1alpha1.GameServerStateCompleted, gs.Status.State)
+			return true, gs, nil
+		})
+
+		_, cancel := agtesting.StartInformers(mocks, c.gameServerSynced)
+		defer cancel()
+
+		err = c.syncGameServerShutdownState(gsFixture)
+		assert.Nil(t, err)
+		assert.True(t, podDeleted, "pod should be deleted")
+		assert.True(t, updated, "GameServer should be moved to Completed")
+		assert.Contains(t, <-mocks.FakeRecorder.Events, "GameServer retained")
+	})
+
 	t.Run("GameServer with unknown state", func(t *testing.T) {
 		testNoChange(t, "Unknown", func(c *Controller, fixture *v1alpha1.GameServer) (*v1alpha1.GameServer, error) {
 			return fixture, c.syncGameServerShutdownState(fixture)
@@ -1184,7 +1714,7 @@ func newFakeController() (*Controller, agtesting.Mocks) {
 	m := agtesting.NewMocks()
 	wh := webhooks.NewWebHook("", "")
 	c := NewController(wh, healthcheck.NewHandler(),
-		10, 20, "sidecar:dev", false,
+		10, 20, "", "sidecar:dev", false,
 		resource.MustParse("0.05"), resource.MustParse("0.1"), m.KubeClient, m.KubeInformerFactory, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
 	c.recorder = m.FakeRecorder
 	return c, m
@@ -0,0 +1,180 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameservers
+
+import (
+	"sort"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"agones.dev/agones/pkg/client/clientset/versioned"
+	getterv1alpha1 "agones.dev/agones/pkg/client/clientset/versioned/typed/stable/v1alpha1"
+	"agones.dev/agones/pkg/client/informers/externalversions"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// gcPeriod is how often the garbage collector sweeps for
+// terminal GameServers that have outlived their TTL
+const gcPeriod = time.Minute
+
+// GarbageCollector deletes GameServers that have terminated in the Error
+// state once they are older than a TTL, keeping the most recent ones
+// around for debugging, so a bad rollout doesn't leave thousands of dead
+// GameServers behind. It also deletes Completed GameServers once their
+// retain period has expired
+type GarbageCollector struct {
+	logger           *logrus.Entry
+	ttl              time.Duration
+	keep             int
+	clock            clock.Clock
+	gameServerSynced cache.InformerSynced
+	gameServerLister listerv1alpha1.GameServerLister
+	gameServerGetter getterv1alpha1.GameServersGetter
+	eventGetter      typedcorev1.EventsGetter
+}
+
+// NewGarbageCollector returns a new GarbageCollector for Error GameServers.
+// A ttl of 0 disables collection
+func NewGarbageCollector(ttl time.Duration, keep int,
+	kubeClient kubernetes.Interface,
+	agonesClient versioned.Interface,
+	agonesInformerFactory externalversions.SharedInformerFactory) *GarbageCollector {
+
+	gameServers := agonesInformerFactory.Stable().V1alpha1().GameServers()
+
+	gc := &GarbageCollector{
+		ttl:              ttl,
+		keep:             keep,
+		clock:            clock.RealClock{},
+		gameServerSynced: gameServers.Informer().HasSynced,
+		gameServerLister: gameServers.Lister(),
+		gameServerGetter: agonesClient.StableV1alpha1(),
+		eventGetter:      kubeClient.CoreV1(),
+	}
+	gc.logger = runtime.NewLoggerWithType(gc)
+
+	return gc
+}
+
+// Run periodically collects terminal GameServers that are older than their TTL.
+// Will block until stop is closed
+func (gc *GarbageCollector) Run(_ int, stop <-chan struct{}) error {
+	if gc.ttl <= 0 {
+		gc.logger.Info("Error GameServer garbage collection is disabled")
+	}
+
+	gc.logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, gc.gameServerSynced) {
+		return errors.New("failed to wait for caches to sync")
+	}
+
+	wait.Until(gc.collect, gcPeriod, stop)
+	return nil
+}
+
+// collect deletes Error GameServers beyond the keep count whose
+// TTL has expired, and Completed GameServers past their retain period,
+// along with their events
+func (gc *GarbageCollector) collect() {
+	list, err := gc.gameServerLister.List(labels.Everything())
+	if err != nil {
+		gc.logger.WithError(err).Error("could not list GameServers for garbage collection")
+		return
+	}
+
+	gc.collectCompleted(list)
+	if gc.ttl <= 0 {
+		return
+	}
+
+	var dead []*v1alpha1.GameServer
+	for _, gs := range list {
+		if gs.Status.State == v1alpha1.GameServerStateError && gs.ObjectMeta.DeletionTimestamp.IsZero() {
+			dead = append(dead, gs)
+		}
+	}
+
+	// keep the most recent ones around for debugging
+	sort.Slice(dead, func(i, j int) bool {
+		return dead[j].ObjectMeta.CreationTimestamp.Before(&dead[i].ObjectMeta.CreationTimestamp)
+	})
+	if len(dead) <= gc.keep {
+		return
+	}
+
+	now := gc.clock.Now()
+	for _, gs := range dead[gc.keep:] {
+		if now.Sub(gs.ObjectMeta.CreationTimestamp.Time) < gc.ttl {
+			continue
+		}
+		gc.logger.WithField("gs", gs.ObjectMeta.Name).Info("Deleting Error GameServer past TTL")
+
+		err := gc.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Delete(gs.ObjectMeta.Name, nil)
+		if err != nil {
+			gc.logger.WithError(err).WithField("gs", gs.ObjectMeta.Name).Error("could not delete GameServer")
+			continue
+		}
+		gc.deleteEvents(gs)
+	}
+}
+
+// collectCompleted deletes Completed GameServers whose retain period has expired
+func (gc *GarbageCollector) collectCompleted(list []*v1alpha1.GameServer) {
+	now := gc.clock.Now()
+	for _, gs := range list {
+		if gs.Status.State != v1alpha1.GameServerStateCompleted || !gs.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		completedAt := gs.Status.TransitionTime(v1alpha1.GameServerStateCompleted)
+		if completedAt.IsZero() {
+			completedAt = gs.ObjectMeta.CreationTimestamp
+		}
+		if now.Sub(completedAt.Time) < time.Duration(gs.Spec.Shutdown.RetainSeconds)*time.Second {
+			continue
+		}
+		gc.logger.WithField("gs", gs.ObjectMeta.Name).Info("Deleting Completed GameServer past retain period")
+
+		err := gc.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Delete(gs.ObjectMeta.Name, nil)
+		if err != nil {
+			gc.logger.WithError(err).WithField("gs", gs.ObjectMeta.Name).Error("could not delete GameServer")
+			continue
+		}
+		gc.deleteEvents(gs)
+	}
+}
+
+// deleteEvents deletes the events that belong to this GameServer, best effort
+func (gc *GarbageCollector) deleteEvents(gs *v1alpha1.GameServer) {
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.kind", "GameServer"),
+		fields.OneTermEqualSelector("involvedObject.name", gs.ObjectMeta.Name)).String()
+
+	err := gc.eventGetter.Events(gs.ObjectMeta.Namespace).
+		DeleteCollection(nil, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		gc.logger.WithError(err).WithField("gs", gs.ObjectMeta.Name).Warn("could not delete GameServer events")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameservers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGarbageCollectorCollect(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	gc := NewGarbageCollector(time.Hour, 2, m.KubeClient, m.AgonesClient, m.AgonesInformerFactory)
+	fakeClock := clock.NewFakeClock(time.Now())
+	gc.clock = fakeClock
+
+	// 5 Error GameServers, oldest first, a minute apart, and one Ready one
+	var gameServers []v1alpha1.GameServer
+	for i := 0; i < 5; i++ {
+		gameServers = append(gameServers, v1alpha1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("error-%d", i), Namespace: "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-2*time.Hour + time.Duration(i)*time.Minute)),
+			},
+			Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateError},
+		})
+	}
+	gameServers = append(gameServers, v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-2 * time.Hour))},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady},
+	})
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gameServers}, nil
+	})
+
+	var deleted []string
+	m.AgonesClient.AddReactor("delete", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		da := action.(k8stesting.DeleteAction)
+		deleted = append(deleted, da.GetName())
+		return true, nil, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, gc.gameServerSynced)
+	defer cancel()
+
+	gc.collect()
+
+	// the two most recent Error GameServers are kept, the rest are past the TTL
+	assert.Len(t, deleted, 3)
+	assert.Contains(t, deleted, "error-0")
+	assert.Contains(t, deleted, "error-1")
+	assert.Contains(t, deleted, "error-2")
+}
+
+func TestGarbageCollectorCollectCompleted(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	gc := NewGarbageCollector(0, 0, m.KubeClient, m.AgonesClient, m.AgonesInformerFactory)
+	fakeClock := clock.NewFakeClock(time.Now())
+	gc.clock = fakeClock
+
+	expired := v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "default"},
+		Spec: v1alpha1.GameServerSpec{
+			Shutdown: v1alpha1.ShutdownSpec{Policy: v1alpha1.ShutdownPolicyRetain, RetainSeconds: 600},
+		},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateCompleted,
+			Timeline: []v1alpha1.GameServerStateTransition{
+				{State: v1alpha1.GameServerStateCompleted, Time: metav1.NewTime(fakeClock.Now().Add(-time.Hour))},
+			}},
+	}
+	retained := v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "retained", Namespace: "default"},
+		Spec: v1alpha1.GameServerSpec{
+			Shutdown: v1alpha1.ShutdownSpec{Policy: v1alpha1.ShutdownPolicyRetain, RetainSeconds: 600},
+		},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateCompleted,
+			Timeline: []v1alpha1.GameServerStateTransition{
+				{State: v1alpha1.GameServerStateCompleted, Time: metav1.NewTime(fakeClock.Now().Add(-time.Minute))},
+			}},
+	}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{expired, retained}}, nil
+	})
+
+	var deleted []string
+	m.AgonesClient.AddReactor("delete", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		da := action.(k8stesting.DeleteAction)
+		deleted = append(deleted, da.GetName())
+		return true, nil, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, gc.gameServerSynced)
+	defer cancel()
+
+	gc.collect()
+
+	// only the GameServer past its retain period is deleted, even
+	// though the Error GameServer TTL is disabled
+	assert.Equal(t, []string{"expired"}, deleted)
+}
+
+func TestGarbageCollectorRespectsTTL(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	gc := NewGarbageCollector(time.Hour, 0, m.KubeClient, m.AgonesClient, m.AgonesInformerFactory)
+	fakeClock := clock.NewFakeClock(time.Now())
+	gc.clock = fakeClock
+
+	gs := v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "error-young", Namespace: "default",
+			CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-time.Minute))},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateError},
+	}
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+	})
+	m.AgonesClient.AddReactor("delete", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.FailNow(t, "should not delete a GameServer within its TTL")
+		return true, nil, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, gc.gameServerSynced)
+	defer cancel()
+
+	gc.collect()
+}
@@ -15,6 +15,7 @@
 package gameservers
 
 import (
+	"fmt"
 	"strings"
 
 	"agones.dev/agones/pkg/apis/stable"
@@ -27,6 +28,7 @@ import (
 	"agones.dev/agones/pkg/util/workerqueue"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,21 +36,27 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	typedbatchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 )
 
+// collectorLabelRole is the RoleLabel value for crash collector Jobs
+const collectorLabelRole = "collector"
+
 // HealthController watches Pods, and applies
 // an Unhealthy state if certain pods crash, or can't be assigned a port, and other
 // similar type conditions.
 type HealthController struct {
 	logger           *logrus.Entry
+	collectorImage   string
 	podSynced        cache.InformerSynced
 	podLister        corelisterv1.PodLister
 	gameServerGetter getterv1alpha1.GameServersGetter
 	gameServerLister listerv1alpha1.GameServerLister
+	jobGetter        typedbatchv1.JobsGetter
 	workerqueue      *workerqueue.WorkerQueue
 	recorder         record.EventRecorder
 }
@@ -63,6 +71,7 @@ func NewHealthController(kubeClient kubernetes.Interface, agonesClient versioned
 		podLister:        kubeInformerFactory.Core().V1().Pods().Lister(),
 		gameServerGetter: agonesClient.StableV1alpha1(),
 		gameServerLister: agonesInformerFactory.Stable().V1alpha1().GameServers().Lister(),
+		jobGetter:        kubeClient.BatchV1(),
 	}
 
 	hc.logger = runtime.NewLoggerWithType(hc)
@@ -145,6 +154,16 @@ func (hc *HealthController) syncGameServer(key string) error {
 		return errors.Wrapf(err, "error retrieving GameServer %s from namespace %s", name, namespace)
 	}
 
+	// a Pod deleted out from under a GameServer that tolerates eviction -
+	// such as a node drain during a cluster upgrade - is a graceful
+	// shutdown, not a health failure
+	if gs.TolerateEviction() {
+		if pod, err := hc.podLister.Pods(namespace).Get(name); err == nil && pod.ObjectMeta.DeletionTimestamp != nil {
+			hc.logger.WithField("key", key).Info("Pod is being evicted, and this GameServer tolerates eviction. Skipping")
+			return nil
+		}
+	}
+
 	var reason string
 	unhealthy := false
 
@@ -163,8 +182,11 @@ func (hc *HealthController) syncGameServer(key string) error {
 
 	if unhealthy {
 		hc.logger.WithField("gs", gs).Infof("Marking GameServer as GameServerStateUnhealthy")
+		hc.collectCrashArtifacts(gs)
 		gsCopy := gs.DeepCopy()
 		gsCopy.Status.State = v1alpha1.GameServerStateUnhealthy
+		gsCopy.Status.RecordStateTransition()
+		setCondition(gsCopy, v1alpha1.GameServerHealthy, corev1.ConditionFalse, "Unhealthy", reason)
 
 		if _, err := hc.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy); err != nil {
 			return errors.Wrapf(err, "error updating GameServer %s to unhealthy", gs.ObjectMeta.Name)
@@ -175,3 +197,48 @@ func (hc *HealthController) syncGameServer(key string) error {
 
 	return nil
 }
+
+// collectCrashArtifacts runs the configured collector image as a Job on the
+// GameServer's node, so core dumps and logs can be shipped off before the
+// replacement GameServer's Pod takes its place. Best effort - a collection
+// failure should never stop the GameServer being marked Unhealthy
+func (hc *HealthController) collectCrashArtifacts(gs *v1alpha1.GameServer) {
+	if hc.collectorImage == "" {
+		return
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: gs.ObjectMeta.Name + "-collector-",
+			Namespace:    gs.ObjectMeta.Namespace,
+			Labels: map[string]string{
+				v1alpha1.RoleLabel:          collectorLabelRole,
+				v1alpha1.GameServerPodLabel: gs.ObjectMeta.Name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeName:      gs.Status.NodeName,
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:  "collector",
+						Image: hc.collectorImage,
+						Env: []corev1.EnvVar{
+							{Name: "GAMESERVER_NAME", Value: gs.ObjectMeta.Name},
+							{Name: "GAMESERVER_NAMESPACE", Value: gs.ObjectMeta.Namespace},
+							{Name: "NODE_NAME", Value: gs.Status.NodeName},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	if _, err := hc.jobGetter.Jobs(gs.ObjectMeta.Namespace).Create(job); err != nil {
+		hc.logger.WithError(err).WithField("gs", gs.ObjectMeta.Name).Error("could not create crash collector Job")
+		return
+	}
+	hc.recorder.Event(gs, corev1.EventTypeNormal, "CrashCollection",
+		fmt.Sprintf("Started crash artifact collection on node %s", gs.Status.NodeName))
+}
@@ -21,6 +21,7 @@ import (
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
 	agtesting "agones.dev/agones/pkg/testing"
 	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -129,6 +130,9 @@ func TestHealthControllerSyncGameServer(t *testing.T) {
 				ua := action.(k8stesting.UpdateAction)
 				gsObj := ua.GetObject().(*v1alpha1.GameServer)
 				assert.Equal(t, test.expected.state, gsObj.Status.State)
+				if cond := gsObj.Status.Condition(v1alpha1.GameServerHealthy); assert.NotNil(t, cond) {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				}
 				return true, gsObj, nil
 			})
 
@@ -144,6 +148,101 @@ func TestHealthControllerSyncGameServer(t *testing.T) {
 	}
 }
 
+func TestHealthControllerSyncGameServerTolerateEviction(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		eviction       v1alpha1.EvictionPolicy
+		podDeleting    bool
+		expectedUpdate bool
+	}{
+		"on upgrade, pod being evicted": {eviction: v1alpha1.EvictionOnUpgrade, podDeleting: true, expectedUpdate: false},
+		"always, pod being evicted":     {eviction: v1alpha1.EvictionAlways, podDeleting: true, expectedUpdate: false},
+		"never, pod being evicted":      {eviction: v1alpha1.EvictionNever, podDeleting: true, expectedUpdate: true},
+		"on upgrade, container crashed": {eviction: v1alpha1.EvictionOnUpgrade, podDeleting: false, expectedUpdate: true},
+	}
+
+	for name, test := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			m := agtesting.NewMocks()
+			hc := NewHealthController(m.KubeClient, m.AgonesClient, m.KubeInformerFactory, m.AgonesInformerFactory)
+			hc.recorder = m.FakeRecorder
+
+			gs := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}, Spec: newSingleContainerSpec(),
+				Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady}}
+			gs.ApplyDefaults()
+			gs.Spec.Eviction = test.eviction
+
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+			if test.podDeleting {
+				now := metav1.Now()
+				pod.ObjectMeta.DeletionTimestamp = &now
+			}
+
+			m.KubeClient.AddReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PodList{Items: []corev1.Pod{pod}}, nil
+			})
+			m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+			})
+			updated := false
+			m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				updated = true
+				ua := action.(k8stesting.UpdateAction)
+				gsObj := ua.GetObject().(*v1alpha1.GameServer)
+				assert.Equal(t, v1alpha1.GameServerStateUnhealthy, gsObj.Status.State)
+				return true, gsObj, nil
+			})
+
+			_, cancel := agtesting.StartInformers(m)
+			defer cancel()
+
+			err := hc.syncGameServer("default/test")
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedUpdate, updated, "updated test")
+		})
+	}
+}
+
+func TestHealthControllerCollectCrashArtifacts(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	hc := NewHealthController(m.KubeClient, m.AgonesClient, m.KubeInformerFactory, m.AgonesInformerFactory)
+	hc.recorder = m.FakeRecorder
+	hc.collectorImage = "collector/image:0.1"
+
+	gs := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}, Spec: newSingleContainerSpec(),
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady, NodeName: "node1"}}
+	gs.ApplyDefaults()
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		return true, ua.GetObject(), nil
+	})
+
+	jobCreated := false
+	m.KubeClient.AddReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		jobCreated = true
+		ca := action.(k8stesting.CreateAction)
+		job := ca.GetObject().(*batchv1.Job)
+		assert.Equal(t, "collector/image:0.1", job.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, "node1", job.Spec.Template.Spec.NodeName)
+		assert.Equal(t, gs.ObjectMeta.Name, job.ObjectMeta.Labels[v1alpha1.GameServerPodLabel])
+		return true, job, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m)
+	defer cancel()
+
+	err := hc.syncGameServer("default/test")
+	assert.Nil(t, err)
+	assert.True(t, jobCreated, "collector job should be created")
+}
+
 func TestHealthControllerRun(t *testing.T) {
 	m := agtesting.NewMocks()
 	hc := NewHealthController(m.KubeClient, m.AgonesClient, m.KubeInformerFactory, m.AgonesInformerFactory)
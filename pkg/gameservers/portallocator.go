@@ -17,21 +17,30 @@ package gameservers
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	getterv1alpha1 "agones.dev/agones/pkg/client/clientset/versioned/typed/stable/v1alpha1"
 	"agones.dev/agones/pkg/client/informers/externalversions"
 	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
 	"agones.dev/agones/pkg/util/runtime"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	corelisterv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
+// snapshotSaveInterval is how often the port allocation
+// snapshot is written, when persistence is registered
+const snapshotSaveInterval = 30 * time.Second
+
 // A set of port allocations for a node
 type portAllocation map[int32]bool
 
@@ -53,6 +62,8 @@ type PortAllocator struct {
 	nodeSynced         cache.InformerSynced
 	nodeLister         corelisterv1.NodeLister
 	nodeInformer       cache.SharedIndexInformer
+	snapshotGetter     getterv1alpha1.PortAllocationSnapshotsGetter
+	snapshotNamespace  string
 }
 
 // NewPortAllocator returns a new dynamic port
@@ -88,6 +99,15 @@ func NewPortAllocator(minPort, maxPort int32,
 	return pa
 }
 
+// RegisterSnapshotPersistence enables persisting this allocator's state to a
+// PortAllocationSnapshot in the given namespace, so that a restart can restore
+// its state from the snapshot rather than a full sync of every GameServer.
+// Must be called before Run
+func (pa *PortAllocator) RegisterSnapshotPersistence(getter getterv1alpha1.PortAllocationSnapshotsGetter, namespace string) {
+	pa.snapshotGetter = getter
+	pa.snapshotNamespace = namespace
+}
+
 // Run sets up the current state of port allocations and
 // starts tracking Pod and Node changes
 func (pa *PortAllocator) Run(stop <-chan struct{}) error {
@@ -97,11 +117,24 @@ func (pa *PortAllocator) Run(stop <-chan struct{}) error {
 		return errors.New("failed to wait for caches to sync")
 	}
 
+	if pa.snapshotGetter != nil {
+		err := pa.restoreFromSnapshot()
+		if err == nil {
+			go wait.Until(pa.saveSnapshot, snapshotSaveInterval, stop)
+			return nil
+		}
+		pa.logger.WithError(err).Info("Could not restore from snapshot. Performing full sync")
+	}
+
 	// on run, let's make sure we start with a perfect slate straight away
 	if err := pa.syncAll(); err != nil {
 		return errors.Wrap(err, "error performing initial sync")
 	}
 
+	if pa.snapshotGetter != nil {
+		go wait.Until(pa.saveSnapshot, snapshotSaveInterval, stop)
+	}
+
 	return nil
 }
 
@@ -116,47 +149,90 @@ func (pa *PortAllocator) Allocate(gs *v1alpha1.GameServer) *v1alpha1.GameServer
 		port int32
 	}
 
-	// we only want this to be called inside the mutex lock
-	// so let's define the function here so it can never be called elsewhere.
-	// Also the return gives an escape from the double loop
-	findOpenPorts := func(amount int) []pn {
-		var ports []pn
+	// we only want these to be called inside the mutex lock
+	// so let's define the functions here so they can never be called elsewhere
+	findOpenPort := func() (portAllocation, int32, bool) {
 		for _, n := range pa.portAllocations {
 			for p, taken := range n {
 				if !taken {
-					ports = append(ports, pn{pa: n, port: p})
-					// only allocate as many ports as are asked for by the GameServer
-					if len(ports) == amount {
-						return ports
+					return n, p, true
+				}
+			}
+		}
+		return nil, 0, false
+	}
+
+	// findContiguousBlock finds size contiguous open ports on a single node,
+	// since a port block must all land on whatever node the Pod is scheduled to
+	findContiguousBlock := func(size int32) (portAllocation, int32, bool) {
+		for _, n := range pa.portAllocations {
+			for start := pa.minPort; start+size-1 <= pa.maxPort; start++ {
+				open := true
+				for p := start; p < start+size; p++ {
+					if n[p] {
+						open = false
+						break
 					}
 				}
+				if open {
+					return n, start, true
+				}
 			}
 		}
-		return ports
+		return nil, 0, false
 	}
 
 	// this allows us to do recursion, within the mutex lock
 	var allocate func(gs *v1alpha1.GameServer) *v1alpha1.GameServer
 	allocate = func(gs *v1alpha1.GameServer) *v1alpha1.GameServer {
-		amount := gs.CountPorts(v1alpha1.Dynamic)
-		allocations := findOpenPorts(amount)
+		// ports are marked as we go, and rolled back as a whole if any
+		// of the entries cannot be satisfied
+		var marked []pn
+		mark := func(a portAllocation, port int32) {
+			a[port] = true
+			marked = append(marked, pn{pa: a, port: port})
+		}
 
-		if len(allocations) == amount {
-			pa.gameServerRegistry[gs.ObjectMeta.UID] = true
+		satisfied := true
+		for i, p := range gs.Spec.Ports {
+			if p.PortPolicy != v1alpha1.Dynamic && p.PortPolicy != v1alpha1.Passthrough {
+				continue
+			}
 
-			for i, p := range gs.Spec.Ports {
-				if p.PortPolicy == v1alpha1.Dynamic {
-					// pop off allocation
-					var a pn
-					a, allocations = allocations[0], allocations[1:]
-					a.pa[a.port] = true
-					gs.Spec.Ports[i].HostPort = a.port
+			if size := p.BlockSize(); size > 1 {
+				n, start, ok := findContiguousBlock(size)
+				if !ok {
+					satisfied = false
+					break
+				}
+				for port := start; port < start+size; port++ {
+					mark(n, port)
+				}
+				gs.Spec.Ports[i].HostPort = start
+			} else {
+				n, port, ok := findOpenPort()
+				if !ok {
+					satisfied = false
+					break
 				}
+				mark(n, port)
+				gs.Spec.Ports[i].HostPort = port
 			}
 
+			if p.PortPolicy == v1alpha1.Passthrough {
+				gs.Spec.Ports[i].ContainerPort = gs.Spec.Ports[i].HostPort
+			}
+		}
+
+		if satisfied {
+			pa.gameServerRegistry[gs.ObjectMeta.UID] = true
 			return gs
 		}
 
+		for _, m := range marked {
+			m.pa[m.port] = false
+		}
+
 		// if we get here, we ran out of ports. Add a node, and try again.
 		// this is important, because to autoscale scale up, we create GameServers that
 		// can't be scheduled on the current set of nodes, so we need to be sure
@@ -194,7 +270,9 @@ func (pa *PortAllocator) DeAllocate(gs *v1alpha1.GameServer) {
 		if p.HostPort < pa.minPort || p.HostPort > pa.maxPort {
 			continue
 		}
-		pa.portAllocations = setPortAllocation(p.HostPort, pa.portAllocations, false)
+		for port := p.HostPort; port < p.HostPort+p.BlockSize(); port++ {
+			pa.portAllocations = setPortAllocation(port, pa.portAllocations, false)
+		}
 	}
 
 	delete(pa.gameServerRegistry, gs.ObjectMeta.UID)
@@ -250,6 +328,159 @@ func (pa *PortAllocator) syncAll() error {
 	return nil
 }
 
+// restoreFromSnapshot rebuilds the port allocation state from the persisted
+// PortAllocationSnapshot, rather than traversing every GameServer. The
+// snapshot is only used if it covers exactly the set of GameServers with
+// dynamic ports that still exist - anything else means allocations happened
+// that it doesn't know about, and only a full sync can be trusted
+func (pa *PortAllocator) restoreFromSnapshot() error {
+	snapshot, err := pa.snapshotGetter.PortAllocationSnapshots(pa.snapshotNamespace).
+		Get(v1alpha1.PortAllocationSnapshotName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "error retrieving port allocation snapshot")
+	}
+
+	spec := snapshot.Spec
+	if spec.MinPort != pa.minPort || spec.MaxPort != pa.maxPort {
+		return errors.Errorf("snapshot port range %d-%d does not match the configured range %d-%d",
+			spec.MinPort, spec.MaxPort, pa.minPort, pa.maxPort)
+	}
+
+	gameservers, err := pa.gameServerLister.List(labels.Everything())
+	if err != nil {
+		return errors.Wrap(err, "error listing all GameServers")
+	}
+
+	dynamic := 0
+	byUID := map[string]*v1alpha1.GameServer{}
+	for _, gs := range gameservers {
+		if gs.CountPorts(v1alpha1.Dynamic)+gs.CountPorts(v1alpha1.Passthrough) > 0 {
+			dynamic++
+			byUID[string(gs.ObjectMeta.UID)] = gs
+		}
+	}
+	if len(spec.GameServers) != dynamic {
+		return errors.Errorf("snapshot records %d GameServers, but %d have dynamic ports", len(spec.GameServers), dynamic)
+	}
+	for _, record := range spec.GameServers {
+		if _, ok := byUID[record.UID]; !ok {
+			return errors.Errorf("snapshot records GameServer %s/%s that no longer exists", record.Namespace, record.Name)
+		}
+	}
+
+	nodes, err := pa.nodeLister.List(labels.Everything())
+	if err != nil {
+		return errors.Wrap(err, "error listing all nodes")
+	}
+
+	pa.mutex.Lock()
+	defer pa.mutex.Unlock()
+
+	nodePorts := pa.nodePortAllocation(nodes)
+	gsRegistry := map[types.UID]bool{}
+	var nonReadyNodesPorts []int32
+
+	for _, record := range spec.GameServers {
+		gsRegistry[types.UID(record.UID)] = true
+		for _, port := range record.Ports {
+			if alloc, ok := nodePorts[record.Node]; record.Node != "" && ok {
+				alloc[port] = true
+			} else if port != 0 {
+				nonReadyNodesPorts = append(nonReadyNodesPorts, port)
+			}
+		}
+	}
+
+	allocations := make([]portAllocation, 0, len(nodePorts))
+	for _, a := range nodePorts {
+		allocations = append(allocations, a)
+	}
+	for _, p := range nonReadyNodesPorts {
+		allocations = setPortAllocation(p, allocations, true)
+	}
+
+	pa.portAllocations = allocations
+	pa.gameServerRegistry = gsRegistry
+
+	pa.logger.WithField("gameServers", len(spec.GameServers)).Info("Restored port allocations from snapshot")
+	return nil
+}
+
+// saveSnapshot persists the current port allocation state as a
+// PortAllocationSnapshot, creating it if it does not exist yet
+func (pa *PortAllocator) saveSnapshot() {
+	snapshot, err := pa.buildSnapshot()
+	if err != nil {
+		pa.logger.WithError(err).Warn("error building port allocation snapshot")
+		return
+	}
+
+	snapshots := pa.snapshotGetter.PortAllocationSnapshots(pa.snapshotNamespace)
+	existing, err := snapshots.Get(v1alpha1.PortAllocationSnapshotName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			if _, err := snapshots.Create(snapshot); err != nil {
+				pa.logger.WithError(err).Warn("error creating port allocation snapshot")
+			}
+			return
+		}
+		pa.logger.WithError(err).Warn("error retrieving port allocation snapshot")
+		return
+	}
+
+	existing.Spec = snapshot.Spec
+	if _, err := snapshots.Update(existing); err != nil {
+		pa.logger.WithError(err).Warn("error updating port allocation snapshot")
+	}
+}
+
+// buildSnapshot records the ports held by each GameServer
+// this allocator knows about
+func (pa *PortAllocator) buildSnapshot() (*v1alpha1.PortAllocationSnapshot, error) {
+	gameservers, err := pa.gameServerLister.List(labels.Everything())
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing all GameServers")
+	}
+
+	pa.mutex.RLock()
+	defer pa.mutex.RUnlock()
+
+	var records []v1alpha1.GameServerPortRecord
+	for _, gs := range gameservers {
+		if _, ok := pa.gameServerRegistry[gs.ObjectMeta.UID]; !ok {
+			continue
+		}
+		var ports []int32
+		for _, p := range gs.Spec.Ports {
+			if p.PortPolicy == v1alpha1.Dynamic || p.PortPolicy == v1alpha1.Passthrough {
+				for port := p.HostPort; port < p.HostPort+p.BlockSize(); port++ {
+					ports = append(ports, port)
+				}
+			}
+		}
+		records = append(records, v1alpha1.GameServerPortRecord{
+			Name:      gs.ObjectMeta.Name,
+			Namespace: gs.ObjectMeta.Namespace,
+			UID:       string(gs.ObjectMeta.UID),
+			Node:      gs.Status.NodeName,
+			Ports:     ports,
+		})
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Namespace+"/"+records[i].Name < records[j].Namespace+"/"+records[j].Name
+	})
+
+	return &v1alpha1.PortAllocationSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.PortAllocationSnapshotName, Namespace: pa.snapshotNamespace},
+		Spec: v1alpha1.PortAllocationSnapshotSpec{
+			MinPort:     pa.minPort,
+			MaxPort:     pa.maxPort,
+			GameServers: records,
+		},
+	}, nil
+}
+
 // registerExistingGameServerPorts registers the gameservers against gsRegistry and the ports against nodePorts.
 // and returns an ordered list of portAllocations per cluster nodes, and an array of
 // any GameServers allocated a port, but not yet assigned a Node will returned as an array of port values.
@@ -265,16 +496,20 @@ func (pa *PortAllocator) registerExistingGameServerPorts(gameservers []*v1alpha1
 
 	for _, gs := range gameservers {
 		for _, p := range gs.Spec.Ports {
-			if p.PortPolicy == v1alpha1.Dynamic {
+			if p.PortPolicy == v1alpha1.Dynamic || p.PortPolicy == v1alpha1.Passthrough {
 				gsRegistry[gs.ObjectMeta.UID] = true
 
 				// if the node doesn't exist, it's likely unscheduled
 				_, ok := nodePortAllocation[gs.Status.NodeName]
 				if gs.Status.NodeName != "" && ok {
-					nodePortAllocation[gs.Status.NodeName][p.HostPort] = true
-					nodePortCount[gs.Status.NodeName]++
+					for port := p.HostPort; port < p.HostPort+p.BlockSize(); port++ {
+						nodePortAllocation[gs.Status.NodeName][port] = true
+						nodePortCount[gs.Status.NodeName]++
+					}
 				} else if p.HostPort != 0 {
-					nonReadyNodesPorts = append(nonReadyNodesPorts, p.HostPort)
+					for port := p.HostPort; port < p.HostPort+p.BlockSize(); port++ {
+						nonReadyNodesPorts = append(nonReadyNodesPorts, port)
+					}
 				}
 			}
 		}
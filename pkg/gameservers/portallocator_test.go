@@ -25,6 +25,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -98,6 +99,102 @@ func TestPortAllocatorAllocate(t *testing.T) {
 		assert.Equal(t, expected, copy.Spec.Ports[3].HostPort)
 	})
 
+	t.Run("passthrough ports", func(t *testing.T) {
+		m := agtesting.NewMocks()
+		pa := NewPortAllocator(10, 50, m.KubeInformerFactory, m.AgonesInformerFactory)
+		nodeWatch := watch.NewFake()
+		m.KubeClient.AddWatchReactor("nodes", k8stesting.DefaultWatchReactor(nodeWatch, nil))
+
+		stop, cancel := agtesting.StartInformers(m, pa.nodeSynced)
+		defer cancel()
+
+		nodeWatch.Add(&n1)
+		assert.True(t, cache.WaitForCacheSync(stop, pa.nodeSynced))
+
+		err := pa.syncAll()
+		assert.Nil(t, err)
+
+		copy := fixture.DeepCopy()
+		copy.Spec.Ports = []v1alpha1.GameServerPort{{Name: "passthrough", PortPolicy: v1alpha1.Passthrough}}
+		gs := pa.Allocate(copy)
+		assert.Equal(t, 1, countTotalAllocatedPorts(pa))
+		assert.NotEmpty(t, gs.Spec.Ports[0].HostPort)
+		assert.Equal(t, gs.Spec.Ports[0].HostPort, gs.Spec.Ports[0].ContainerPort)
+
+		// mixed dynamic and passthrough
+		copy = fixture.DeepCopy()
+		copy.Spec.Ports = append(copy.Spec.Ports, v1alpha1.GameServerPort{Name: "passthrough", PortPolicy: v1alpha1.Passthrough})
+		gs = pa.Allocate(copy)
+		assert.Equal(t, 3, countTotalAllocatedPorts(pa))
+		assert.NotEmpty(t, gs.Spec.Ports[0].HostPort)
+		assert.Equal(t, int32(7777), gs.Spec.Ports[0].ContainerPort)
+		assert.NotEmpty(t, gs.Spec.Ports[1].HostPort)
+		assert.Equal(t, gs.Spec.Ports[1].HostPort, gs.Spec.Ports[1].ContainerPort)
+	})
+
+	t.Run("contiguous port ranges", func(t *testing.T) {
+		m := agtesting.NewMocks()
+		pa := NewPortAllocator(10, 50, m.KubeInformerFactory, m.AgonesInformerFactory)
+		nodeWatch := watch.NewFake()
+		m.KubeClient.AddWatchReactor("nodes", k8stesting.DefaultWatchReactor(nodeWatch, nil))
+
+		stop, cancel := agtesting.StartInformers(m, pa.nodeSynced)
+		defer cancel()
+
+		nodeWatch.Add(&n1)
+		nodeWatch.Add(&n2)
+		assert.True(t, cache.WaitForCacheSync(stop, pa.nodeSynced))
+
+		err := pa.syncAll()
+		assert.Nil(t, err)
+
+		copy := fixture.DeepCopy()
+		copy.Spec.Ports[0].Range = 3
+		gs := pa.Allocate(copy)
+		assert.Equal(t, 3, countTotalAllocatedPorts(pa))
+
+		// the whole block is allocated on a single node, starting at HostPort
+		start := gs.Spec.Ports[0].HostPort
+		assert.NotEmpty(t, start)
+		sameNode := false
+		for _, node := range pa.portAllocations {
+			if node[start] {
+				sameNode = true
+				for port := start; port < start+3; port++ {
+					assert.True(t, node[port], "port %d should be taken on the same node", port)
+				}
+			}
+		}
+		assert.True(t, sameNode, "could not find the node the block was allocated on")
+
+		// deallocation frees the whole block
+		pa.DeAllocate(gs)
+		assert.Equal(t, 0, countTotalAllocatedPorts(pa))
+	})
+
+	t.Run("tcpudp ports are counted once", func(t *testing.T) {
+		m := agtesting.NewMocks()
+		pa := NewPortAllocator(10, 50, m.KubeInformerFactory, m.AgonesInformerFactory)
+		nodeWatch := watch.NewFake()
+		m.KubeClient.AddWatchReactor("nodes", k8stesting.DefaultWatchReactor(nodeWatch, nil))
+
+		stop, cancel := agtesting.StartInformers(m, pa.nodeSynced)
+		defer cancel()
+
+		nodeWatch.Add(&n1)
+		assert.True(t, cache.WaitForCacheSync(stop, pa.nodeSynced))
+
+		err := pa.syncAll()
+		assert.Nil(t, err)
+
+		// the same host port serves both protocols, so only one allocation
+		copy := fixture.DeepCopy()
+		copy.Spec.Ports[0].Protocol = v1alpha1.ProtocolTCPUDP
+		gs := pa.Allocate(copy)
+		assert.Equal(t, 1, countTotalAllocatedPorts(pa))
+		assert.NotEmpty(t, gs.Spec.Ports[0].HostPort)
+	})
+
 	t.Run("ports are all allocated", func(t *testing.T) {
 		m := agtesting.NewMocks()
 		pa := NewPortAllocator(10, 20, m.KubeInformerFactory, m.AgonesInformerFactory)
@@ -345,6 +442,112 @@ func TestPortAllocatorSyncPortAllocations(t *testing.T) {
 	assert.Equal(t, 5, count)
 }
 
+func TestPortAllocatorSnapshot(t *testing.T) {
+	t.Parallel()
+
+	gs1 := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: "default", UID: "1"},
+		Spec: v1alpha1.GameServerSpec{
+			Ports: []v1alpha1.GameServerPort{{PortPolicy: v1alpha1.Dynamic, HostPort: 10}},
+		},
+		Status: v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady, NodeName: n1.ObjectMeta.Name}}
+
+	setup := func(m agtesting.Mocks) {
+		m.KubeClient.AddReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &corev1.NodeList{Items: []corev1.Node{n1, n2}}, nil
+		})
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs1}}, nil
+		})
+	}
+
+	t.Run("restore from a valid snapshot", func(t *testing.T) {
+		m := agtesting.NewMocks()
+		setup(m)
+		pa := NewPortAllocator(10, 20, m.KubeInformerFactory, m.AgonesInformerFactory)
+		pa.RegisterSnapshotPersistence(m.AgonesClient.StableV1alpha1(), "agones-system")
+
+		m.AgonesClient.AddReactor("get", "portallocationsnapshots", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			snapshot := &v1alpha1.PortAllocationSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.PortAllocationSnapshotName, Namespace: "agones-system"},
+				Spec: v1alpha1.PortAllocationSnapshotSpec{
+					MinPort: 10, MaxPort: 20,
+					GameServers: []v1alpha1.GameServerPortRecord{
+						{Name: "gs1", Namespace: "default", UID: "1", Node: n1.ObjectMeta.Name, Ports: []int32{10}},
+					},
+				},
+			}
+			return true, snapshot, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, pa.gameServerSynced, pa.nodeSynced)
+		defer cancel()
+
+		err := pa.restoreFromSnapshot()
+		assert.Nil(t, err)
+		assert.Len(t, pa.portAllocations, 2)
+		assert.Len(t, pa.gameServerRegistry, 1)
+		assert.Equal(t, 1, countAllocatedPorts(pa, 10))
+	})
+
+	t.Run("restore from a stale snapshot", func(t *testing.T) {
+		m := agtesting.NewMocks()
+		setup(m)
+		pa := NewPortAllocator(10, 20, m.KubeInformerFactory, m.AgonesInformerFactory)
+		pa.RegisterSnapshotPersistence(m.AgonesClient.StableV1alpha1(), "agones-system")
+
+		m.AgonesClient.AddReactor("get", "portallocationsnapshots", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			snapshot := &v1alpha1.PortAllocationSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.PortAllocationSnapshotName, Namespace: "agones-system"},
+				Spec:       v1alpha1.PortAllocationSnapshotSpec{MinPort: 10, MaxPort: 20},
+			}
+			return true, snapshot, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, pa.gameServerSynced, pa.nodeSynced)
+		defer cancel()
+
+		// records no GameServers, but one has dynamic ports
+		err := pa.restoreFromSnapshot()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("save the current state", func(t *testing.T) {
+		m := agtesting.NewMocks()
+		setup(m)
+		pa := NewPortAllocator(10, 20, m.KubeInformerFactory, m.AgonesInformerFactory)
+		pa.RegisterSnapshotPersistence(m.AgonesClient.StableV1alpha1(), "agones-system")
+
+		m.AgonesClient.AddReactor("get", "portallocationsnapshots", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, k8serrors.NewNotFound(v1alpha1.Resource("portallocationsnapshots"), v1alpha1.PortAllocationSnapshotName)
+		})
+		created := make(chan *v1alpha1.PortAllocationSnapshot, 1)
+		m.AgonesClient.AddReactor("create", "portallocationsnapshots", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ca := action.(k8stesting.CreateAction)
+			snapshot := ca.GetObject().(*v1alpha1.PortAllocationSnapshot)
+			created <- snapshot
+			return true, snapshot, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, pa.gameServerSynced, pa.nodeSynced)
+		defer cancel()
+
+		err := pa.syncAll()
+		assert.Nil(t, err)
+
+		pa.saveSnapshot()
+		select {
+		case snapshot := <-created:
+			assert.Equal(t, int32(10), snapshot.Spec.MinPort)
+			assert.Equal(t, int32(20), snapshot.Spec.MaxPort)
+			assert.Len(t, snapshot.Spec.GameServers, 1)
+			assert.Equal(t, "gs1", snapshot.Spec.GameServers[0].Name)
+			assert.Equal(t, []int32{10}, snapshot.Spec.GameServers[0].Ports)
+		default:
+			assert.FailNow(t, "snapshot should have been created")
+		}
+	})
+}
+
 func TestPortAllocatorSyncDeleteGameServer(t *testing.T) {
 	t.Parallel()
 
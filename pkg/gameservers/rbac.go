@@ -0,0 +1,123 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameservers
+
+import (
+	"sync"
+
+	"agones.dev/agones/pkg/apis/stable"
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	typedrbacv1 "k8s.io/client-go/kubernetes/typed/rbac/v1"
+)
+
+// sidecarRoleRules are the permissions the SDK sidecar needs against the
+// backing GameServer, and mirror the agones-sdk ClusterRole in the install
+var sidecarRoleRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"events"},
+		Verbs:     []string{"create"},
+	},
+	{
+		APIGroups: []string{stable.GroupName},
+		Resources: []string{"gameservers"},
+		Verbs:     []string{"list", "update", "watch"},
+	},
+}
+
+// sidecarRBAC reconciles the ServiceAccount, Role and RoleBinding the SDK
+// sidecar runs under, in each namespace GameServers appear in, so a new
+// namespace works without the install's static list having to be updated
+type sidecarRBAC struct {
+	logger               *logrus.Entry
+	serviceAccountGetter typedcorev1.ServiceAccountsGetter
+	roleGetter           typedrbacv1.RolesGetter
+	roleBindingGetter    typedrbacv1.RoleBindingsGetter
+	mutex                sync.Mutex
+	reconciled           map[string]bool
+}
+
+// newSidecarRBAC returns a sidecarRBAC reconciler
+func newSidecarRBAC(kubeClient kubernetes.Interface) *sidecarRBAC {
+	s := &sidecarRBAC{
+		serviceAccountGetter: kubeClient.CoreV1(),
+		roleGetter:           kubeClient.RbacV1(),
+		roleBindingGetter:    kubeClient.RbacV1(),
+		reconciled:           map[string]bool{},
+	}
+	s.logger = runtime.NewLoggerWithType(s)
+	return s
+}
+
+// reconcile ensures the sidecar ServiceAccount, Role and RoleBinding exist in
+// the given namespace, converging the Role's rules if it has drifted. Once a
+// namespace has been reconciled it is not visited again for the lifetime of
+// the controller
+func (s *sidecarRBAC) reconcile(namespace string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.reconciled[namespace] {
+		return nil
+	}
+	s.logger.WithField("namespace", namespace).Info("Reconciling sidecar RBAC")
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.SidecarServiceAccountName, Namespace: namespace},
+	}
+	if _, err := s.serviceAccountGetter.ServiceAccounts(namespace).Create(sa); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "error creating ServiceAccount %s in namespace %s", sa.ObjectMeta.Name, namespace)
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.SidecarServiceAccountName, Namespace: namespace},
+		Rules:      sidecarRoleRules,
+	}
+	if _, err := s.roleGetter.Roles(namespace).Create(role); err != nil {
+		if !k8serrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "error creating Role %s in namespace %s", role.ObjectMeta.Name, namespace)
+		}
+		if _, err := s.roleGetter.Roles(namespace).Update(role); err != nil {
+			return errors.Wrapf(err, "error updating Role %s in namespace %s", role.ObjectMeta.Name, namespace)
+		}
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.SidecarServiceAccountName + "-access", Namespace: namespace},
+		Subjects: []rbacv1.Subject{
+			{Kind: rbacv1.ServiceAccountKind, Name: v1alpha1.SidecarServiceAccountName, Namespace: namespace},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     v1alpha1.SidecarServiceAccountName,
+		},
+	}
+	if _, err := s.roleBindingGetter.RoleBindings(namespace).Create(binding); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "error creating RoleBinding %s in namespace %s", binding.ObjectMeta.Name, namespace)
+	}
+
+	s.reconciled[namespace] = true
+	return nil
+}
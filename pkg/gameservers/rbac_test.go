@@ -0,0 +1,121 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameservers
+
+import (
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSidecarRBACReconcile(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	s := newSidecarRBAC(m.KubeClient)
+
+	var saNamespaces []string
+	m.KubeClient.AddReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ca := action.(k8stesting.CreateAction)
+		sa := ca.GetObject().(*corev1.ServiceAccount)
+		assert.Equal(t, v1alpha1.SidecarServiceAccountName, sa.ObjectMeta.Name)
+		saNamespaces = append(saNamespaces, sa.ObjectMeta.Namespace)
+		return true, sa, nil
+	})
+
+	roleCreated := 0
+	m.KubeClient.AddReactor("create", "roles", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		roleCreated++
+		ca := action.(k8stesting.CreateAction)
+		role := ca.GetObject().(*rbacv1.Role)
+		assert.Equal(t, v1alpha1.SidecarServiceAccountName, role.ObjectMeta.Name)
+		assert.Equal(t, sidecarRoleRules, role.Rules)
+		return true, role, nil
+	})
+
+	bindingCreated := 0
+	m.KubeClient.AddReactor("create", "rolebindings", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		bindingCreated++
+		ca := action.(k8stesting.CreateAction)
+		binding := ca.GetObject().(*rbacv1.RoleBinding)
+		assert.Equal(t, v1alpha1.SidecarServiceAccountName+"-access", binding.ObjectMeta.Name)
+		assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind,
+			Name: v1alpha1.SidecarServiceAccountName, Namespace: binding.ObjectMeta.Namespace}}, binding.Subjects)
+		assert.Equal(t, "Role", binding.RoleRef.Kind)
+		assert.Equal(t, v1alpha1.SidecarServiceAccountName, binding.RoleRef.Name)
+		return true, binding, nil
+	})
+
+	err := s.reconcile("default")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"default"}, saNamespaces)
+	assert.Equal(t, 1, roleCreated)
+	assert.Equal(t, 1, bindingCreated)
+
+	// a reconciled namespace is not visited again
+	err = s.reconcile("default")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"default"}, saNamespaces)
+	assert.Equal(t, 1, roleCreated)
+	assert.Equal(t, 1, bindingCreated)
+
+	// but a new namespace is
+	err = s.reconcile("other")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"default", "other"}, saNamespaces)
+}
+
+func TestSidecarRBACReconcileExisting(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	s := newSidecarRBAC(m.KubeClient)
+
+	alreadyExists := func(resource, name string) error {
+		return k8serrors.NewAlreadyExists(schema.GroupResource{Resource: resource}, name)
+	}
+
+	m.KubeClient.AddReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, alreadyExists("serviceaccounts", v1alpha1.SidecarServiceAccountName)
+	})
+	m.KubeClient.AddReactor("create", "roles", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, alreadyExists("roles", v1alpha1.SidecarServiceAccountName)
+	})
+	m.KubeClient.AddReactor("create", "rolebindings", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, alreadyExists("rolebindings", v1alpha1.SidecarServiceAccountName+"-access")
+	})
+
+	// an existing Role has its rules converged
+	roleUpdated := false
+	m.KubeClient.AddReactor("update", "roles", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		roleUpdated = true
+		ua := action.(k8stesting.UpdateAction)
+		role := ua.GetObject().(*rbacv1.Role)
+		assert.Equal(t, sidecarRoleRules, role.Rules)
+		return true, role, nil
+	})
+
+	err := s.reconcile("default")
+	assert.Nil(t, err)
+	assert.True(t, roleUpdated)
+}
@@ -0,0 +1,257 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gameserversessions records the allocation history of GameServers
+// as GameServerSession resources
+package gameserversessions
+
+import (
+	"agones.dev/agones/pkg/apis/stable"
+	stablev1alpha1 "agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"agones.dev/agones/pkg/client/clientset/versioned"
+	getterv1alpha1 "agones.dev/agones/pkg/client/clientset/versioned/typed/stable/v1alpha1"
+	"agones.dev/agones/pkg/client/informers/externalversions"
+	listerv1alpha1 "agones.dev/agones/pkg/client/listers/stable/v1alpha1"
+	"agones.dev/agones/pkg/util/crd"
+	"agones.dev/agones/pkg/util/runtime"
+	"agones.dev/agones/pkg/util/workerqueue"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	extclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiextclientv1beta1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+// Controller watches GameServers, opening a GameServerSession when a
+// GameServer becomes Allocated, and closing it again when the GameServer
+// leaves the Allocated state or is deleted
+type Controller struct {
+	logger           *logrus.Entry
+	crdGetter        apiextclientv1beta1.CustomResourceDefinitionInterface
+	gameServerSynced cache.InformerSynced
+	gameServerLister listerv1alpha1.GameServerLister
+	sessionGetter    getterv1alpha1.GameServerSessionsGetter
+	sessionLister    listerv1alpha1.GameServerSessionLister
+	sessionSynced    cache.InformerSynced
+	workerqueue      *workerqueue.WorkerQueue
+	recorder         record.EventRecorder
+}
+
+// NewController returns a new GameServerSession controller
+func NewController(
+	health healthcheck.Handler,
+	kubeClient kubernetes.Interface,
+	extClient extclientset.Interface,
+	agonesClient versioned.Interface,
+	agonesInformerFactory externalversions.SharedInformerFactory) *Controller {
+
+	gameServers := agonesInformerFactory.Stable().V1alpha1().GameServers()
+	gsInformer := gameServers.Informer()
+
+	sessions := agonesInformerFactory.Stable().V1alpha1().GameServerSessions()
+
+	c := &Controller{
+		crdGetter:        extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
+		gameServerSynced: gsInformer.HasSynced,
+		gameServerLister: gameServers.Lister(),
+		sessionGetter:    agonesClient.StableV1alpha1(),
+		sessionLister:    sessions.Lister(),
+		sessionSynced:    sessions.Informer().HasSynced,
+	}
+
+	c.logger = runtime.NewLoggerWithType(c)
+	c.workerqueue = workerqueue.NewWorkerQueue(c.syncGameServer, c.logger, stable.GroupName+".GameServerSessionController")
+	health.AddLivenessCheck("gameserversession-workerqueue", healthcheck.Check(c.workerqueue.Healthy))
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(c.logger.Infof)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	c.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "gameserversession-controller"})
+
+	gsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldGs := oldObj.(*stablev1alpha1.GameServer)
+			newGs := newObj.(*stablev1alpha1.GameServer)
+			if oldGs.Status.State != newGs.Status.State {
+				c.workerqueue.Enqueue(newGs)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.workerqueue.Enqueue(obj)
+		},
+	})
+
+	return c
+}
+
+// Run the GameServerSession controller. Will block until stop is closed.
+// Runs threadiness number workers to process the rate limited queue
+func (c *Controller) Run(workers int, stop <-chan struct{}) error {
+	err := crd.WaitForEstablishedCRD(c.crdGetter, "gameserversessions.stable.agones.dev", c.logger)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, c.gameServerSynced, c.sessionSynced) {
+		return errors.New("failed to wait for caches to sync")
+	}
+
+	c.workerqueue.Run(workers, stop)
+	return nil
+}
+
+// WaitUntilRunning blocks until this controller has synced its caches and
+// its workers are processing events, or stop is closed
+func (c *Controller) WaitUntilRunning(stop <-chan struct{}) bool {
+	return c.workerqueue.WaitUntilRunning(stop)
+}
+
+// syncGameServer reconciles the GameServerSessions for the GameServer
+// behind this key, opening a session if it is Allocated, and closing
+// any active sessions if it is not
+func (c *Controller) syncGameServer(key string) error {
+	c.logger.WithField("key", key).Info("Synchronising")
+
+	// Convert the namespace/name string into a distinct namespace and name
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		// don't return an error, as we don't want this retried
+		runtime.HandleError(c.logger.WithField("key", key), errors.Wrapf(err, "invalid resource key"))
+		return nil
+	}
+
+	gs, err := c.gameServerLister.GameServers(namespace).Get(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return c.closeSessions(namespace, name, "")
+		}
+		return errors.Wrapf(err, "error retrieving GameServer %s from namespace %s", name, namespace)
+	}
+
+	if gs.Status.State == stablev1alpha1.GameServerStateAllocated {
+		return c.openSession(gs)
+	}
+
+	return c.closeSessions(namespace, name, gs.Status.State)
+}
+
+// openSession creates an Active GameServerSession for this Allocated
+// GameServer, if one does not already exist
+func (c *Controller) openSession(gs *stablev1alpha1.GameServer) error {
+	active, err := c.activeSessions(gs.ObjectMeta.Namespace, gs.ObjectMeta.Name)
+	if err != nil {
+		return err
+	}
+	if len(active) > 0 {
+		return nil
+	}
+
+	session := &stablev1alpha1.GameServerSession{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: gs.ObjectMeta.Name + "-",
+			Namespace:    gs.ObjectMeta.Namespace,
+			Labels: map[string]string{
+				stablev1alpha1.GameServerSessionGameServerLabel: gs.ObjectMeta.Name,
+			},
+		},
+		Spec: stablev1alpha1.GameServerSessionSpec{
+			GameServerName: gs.ObjectMeta.Name,
+			FleetName:      gs.ObjectMeta.Labels[stablev1alpha1.FleetNameLabel],
+			Version:        gameServerVersion(gs),
+		},
+		Status: stablev1alpha1.GameServerSessionStatus{
+			State:   stablev1alpha1.GameServerSessionActive,
+			Started: metav1.Now(),
+		},
+	}
+	if session.Spec.FleetName != "" {
+		session.ObjectMeta.Labels[stablev1alpha1.FleetNameLabel] = session.Spec.FleetName
+	}
+
+	session, err = c.sessionGetter.GameServerSessions(gs.ObjectMeta.Namespace).Create(session)
+	if err != nil {
+		return errors.Wrapf(err, "error creating GameServerSession for GameServer %s", gs.ObjectMeta.Name)
+	}
+
+	c.recorder.Eventf(gs, corev1.EventTypeNormal, "SessionOpened",
+		"GameServerSession %s opened", session.ObjectMeta.Name)
+	return nil
+}
+
+// closeSessions closes any Active GameServerSessions for this GameServer,
+// recording the state the GameServer moved to, if it is known
+func (c *Controller) closeSessions(namespace, name string, state stablev1alpha1.GameServerState) error {
+	active, err := c.activeSessions(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	for _, session := range active {
+		sCopy := session.DeepCopy()
+		sCopy.Status.State = stablev1alpha1.GameServerSessionClosed
+		sCopy.Status.Finished = &now
+		sCopy.Status.GameServerState = state
+
+		sCopy, err = c.sessionGetter.GameServerSessions(namespace).Update(sCopy)
+		if err != nil {
+			return errors.Wrapf(err, "error closing GameServerSession %s", session.ObjectMeta.Name)
+		}
+
+		c.recorder.Eventf(sCopy, corev1.EventTypeNormal, "SessionClosed",
+			"GameServerSession for GameServer %s closed", name)
+	}
+
+	return nil
+}
+
+// activeSessions returns the Active GameServerSessions for the
+// named GameServer
+func (c *Controller) activeSessions(namespace, name string) ([]*stablev1alpha1.GameServerSession, error) {
+	selector := labels.SelectorFromSet(labels.Set{stablev1alpha1.GameServerSessionGameServerLabel: name})
+	list, err := c.sessionLister.GameServerSessions(namespace).List(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing GameServerSessions for GameServer %s", name)
+	}
+
+	var active []*stablev1alpha1.GameServerSession
+	for _, session := range list {
+		if session.Status.State == stablev1alpha1.GameServerSessionActive {
+			active = append(active, session)
+		}
+	}
+
+	return active, nil
+}
+
+// gameServerVersion returns the image of the game server container,
+// to record which release hosted the session
+func gameServerVersion(gs *stablev1alpha1.GameServer) string {
+	for _, container := range gs.Spec.Template.Spec.Containers {
+		if container.Name == gs.Spec.Container {
+			return container.Image
+		}
+	}
+	return ""
+}
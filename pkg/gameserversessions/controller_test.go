@@ -0,0 +1,183 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserversessions
+
+import (
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestControllerSyncGameServerOpensSession(t *testing.T) {
+	t.Parallel()
+
+	c, m := newFakeController()
+	gs := fixtureGameServer(v1alpha1.GameServerStateAllocated)
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*gs}}, nil
+	})
+
+	created := false
+	m.AgonesClient.AddReactor("create", "gameserversessions", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		created = true
+		ca := action.(k8stesting.CreateAction)
+		session := ca.GetObject().(*v1alpha1.GameServerSession)
+
+		assert.Equal(t, gs.ObjectMeta.Name, session.Spec.GameServerName)
+		assert.Equal(t, "fleet-1", session.Spec.FleetName)
+		assert.Equal(t, "gameserver/image:0.1", session.Spec.Version)
+		assert.Equal(t, v1alpha1.GameServerSessionActive, session.Status.State)
+		assert.False(t, session.Status.Started.IsZero())
+		assert.Equal(t, gs.ObjectMeta.Name, session.ObjectMeta.Labels[v1alpha1.GameServerSessionGameServerLabel])
+
+		return true, session, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.gameServerSynced, c.sessionSynced)
+	defer cancel()
+
+	err := c.syncGameServer("default/test")
+	assert.Nil(t, err)
+	assert.True(t, created, "session should have been created")
+
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "SessionOpened")
+}
+
+func TestControllerSyncGameServerClosesSession(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		list     *v1alpha1.GameServerList
+		expected v1alpha1.GameServerState
+	}{
+		"shutdown": {
+			list:     &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*fixtureGameServer(v1alpha1.GameServerStateShutdown)}},
+			expected: v1alpha1.GameServerStateShutdown,
+		},
+		"deleted": {
+			list:     &v1alpha1.GameServerList{},
+			expected: "",
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			c, m := newFakeController()
+
+			m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, v.list, nil
+			})
+
+			session := v1alpha1.GameServerSession{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-abcd", Namespace: "default",
+					Labels: map[string]string{v1alpha1.GameServerSessionGameServerLabel: "test"},
+				},
+				Spec:   v1alpha1.GameServerSessionSpec{GameServerName: "test"},
+				Status: v1alpha1.GameServerSessionStatus{State: v1alpha1.GameServerSessionActive, Started: metav1.Now()},
+			}
+			m.AgonesClient.AddReactor("list", "gameserversessions", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, &v1alpha1.GameServerSessionList{Items: []v1alpha1.GameServerSession{session}}, nil
+			})
+
+			updated := false
+			m.AgonesClient.AddReactor("update", "gameserversessions", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				updated = true
+				ua := action.(k8stesting.UpdateAction)
+				s := ua.GetObject().(*v1alpha1.GameServerSession)
+
+				assert.Equal(t, v1alpha1.GameServerSessionClosed, s.Status.State)
+				assert.NotNil(t, s.Status.Finished)
+				assert.Equal(t, v.expected, s.Status.GameServerState)
+
+				return true, s, nil
+			})
+
+			_, cancel := agtesting.StartInformers(m, c.gameServerSynced, c.sessionSynced)
+			defer cancel()
+
+			err := c.syncGameServer("default/test")
+			assert.Nil(t, err)
+			assert.True(t, updated, "session should have been closed")
+
+			agtesting.AssertEventContains(t, m.FakeRecorder.Events, "SessionClosed")
+		})
+	}
+}
+
+func TestControllerSyncGameServerAlreadyActive(t *testing.T) {
+	t.Parallel()
+
+	c, m := newFakeController()
+	gs := fixtureGameServer(v1alpha1.GameServerStateAllocated)
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*gs}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "gameserversessions", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		session := v1alpha1.GameServerSession{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-abcd", Namespace: "default",
+				Labels: map[string]string{v1alpha1.GameServerSessionGameServerLabel: "test"},
+			},
+			Status: v1alpha1.GameServerSessionStatus{State: v1alpha1.GameServerSessionActive},
+		}
+		return true, &v1alpha1.GameServerSessionList{Items: []v1alpha1.GameServerSession{session}}, nil
+	})
+	m.AgonesClient.AddReactor("create", "gameserversessions", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.FailNow(t, "no session should be created")
+		return true, nil, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.gameServerSynced, c.sessionSynced)
+	defer cancel()
+
+	err := c.syncGameServer("default/test")
+	assert.Nil(t, err)
+}
+
+// fixtureGameServer returns a fleet owned GameServer in the given state
+func fixtureGameServer(state v1alpha1.GameServerState) *v1alpha1.GameServer {
+	return &v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test", Namespace: "default",
+			Labels: map[string]string{v1alpha1.FleetNameLabel: "fleet-1"},
+		},
+		Spec: v1alpha1.GameServerSpec{
+			Container: "gameserver",
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "gameserver", Image: "gameserver/image:0.1"}},
+				},
+			},
+		},
+		Status: v1alpha1.GameServerStatus{State: state},
+	}
+}
+
+func newFakeController() (*Controller, agtesting.Mocks) {
+	m := agtesting.NewMocks()
+	c := NewController(healthcheck.NewHandler(), m.KubeClient, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
+	c.recorder = m.FakeRecorder
+	return c, m
+}
@@ -16,8 +16,10 @@ package gameserversets
 
 import (
 	"encoding/json"
+	"reflect"
 	"sort"
 	"sync"
+	"time"
 
 	"agones.dev/agones/pkg/apis/stable"
 	"agones.dev/agones/pkg/apis/stable/v1alpha1"
@@ -30,6 +32,7 @@ import (
 	"agones.dev/agones/pkg/util/webhooks"
 	"agones.dev/agones/pkg/util/workerqueue"
 	"github.com/heptiolabs/healthcheck"
+	"github.com/mattbaird/jsonpatch"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	admv1beta1 "k8s.io/api/admission/v1beta1"
@@ -38,6 +41,7 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -52,14 +56,20 @@ var (
 )
 
 const (
-	maxCreationParalellism         = 16
-	maxGameServerCreationsPerBatch = 64
+	maxCreationParalellism = 16
+	maxDeletionParallelism = 64
 
-	maxDeletionParallelism         = 64
-	maxGameServerDeletionsPerBatch = 64
+	// defaultMaxGameServerCreationsPerBatch is the default upper bound on GameServer
+	// creations per reconcile, applied when no value is passed to NewController
+	defaultMaxGameServerCreationsPerBatch = 64
 
-	// maxPodPendingCount is the maximum number of pending pods per game server set
-	maxPodPendingCount = 5000
+	// defaultMaxGameServerDeletionsPerBatch is the default upper bound on GameServer
+	// deletions per reconcile, applied when no value is passed to NewController
+	defaultMaxGameServerDeletionsPerBatch = 64
+
+	// defaultMaxPodPendingCount is the default maximum number of pending pods per game
+	// server set. It can be overridden per set with the MaxPodPendingCountAnnotation
+	defaultMaxPodPendingCount = 5000
 )
 
 // Controller is a the GameServerSet controller
@@ -72,25 +82,52 @@ type Controller struct {
 	gameServerSetGetter getterv1alpha1.GameServerSetsGetter
 	gameServerSetLister listerv1alpha1.GameServerSetLister
 	gameServerSetSynced cache.InformerSynced
+	quotaGetter         typedcorev1.ResourceQuotasGetter
+	scaleEventGetter    getterv1alpha1.ScaleEventsGetter
+	scaleEventLister    listerv1alpha1.ScaleEventLister
+	scaleEventSynced    cache.InformerSynced
 	workerqueue         *workerqueue.WorkerQueue
 	stop                <-chan struct{}
 	recorder            record.EventRecorder
 	stateCache          *gameServerStateCache
+	expectations        *expectations
+
+	maxGameServerCreationsPerBatch int
+	maxGameServerDeletionsPerBatch int
+	maxPodPendingCount             int
+	maxGameServersPerNamespace     int64
+	maxGameServersPerFleet         int64
 }
 
-// NewController returns a new gameserverset crd controller
+// NewController returns a new gameserverset crd controller.
+// maxCreationsPerBatch, maxDeletionsPerBatch and maxPodPendingCount bound how much
+// work a single reconcile pushes at the API server - values of 0 or less use the defaults
 func NewController(
 	wh *webhooks.WebHook,
 	health healthcheck.Handler,
+	maxCreationsPerBatch int,
+	maxDeletionsPerBatch int,
+	maxPodPendingCount int,
 	kubeClient kubernetes.Interface,
 	extClient extclientset.Interface,
 	agonesClient versioned.Interface,
 	agonesInformerFactory externalversions.SharedInformerFactory) *Controller {
 
+	if maxCreationsPerBatch <= 0 {
+		maxCreationsPerBatch = defaultMaxGameServerCreationsPerBatch
+	}
+	if maxDeletionsPerBatch <= 0 {
+		maxDeletionsPerBatch = defaultMaxGameServerDeletionsPerBatch
+	}
+	if maxPodPendingCount <= 0 {
+		maxPodPendingCount = defaultMaxPodPendingCount
+	}
+
 	gameServers := agonesInformerFactory.Stable().V1alpha1().GameServers()
 	gsInformer := gameServers.Informer()
 	gameServerSets := agonesInformerFactory.Stable().V1alpha1().GameServerSets()
 	gsSetInformer := gameServerSets.Informer()
+	scaleEvents := agonesInformerFactory.Stable().V1alpha1().ScaleEvents()
 
 	c := &Controller{
 		crdGetter:           extClient.ApiextensionsV1beta1().CustomResourceDefinitions(),
@@ -100,7 +137,16 @@ func NewController(
 		gameServerSetGetter: agonesClient.StableV1alpha1(),
 		gameServerSetLister: gameServerSets.Lister(),
 		gameServerSetSynced: gsSetInformer.HasSynced,
+		quotaGetter:         kubeClient.CoreV1(),
+		scaleEventGetter:    agonesClient.StableV1alpha1(),
+		scaleEventLister:    scaleEvents.Lister(),
+		scaleEventSynced:    scaleEvents.Informer().HasSynced,
 		stateCache:          &gameServerStateCache{},
+		expectations:        &expectations{},
+
+		maxGameServerCreationsPerBatch: maxCreationsPerBatch,
+		maxGameServerDeletionsPerBatch: maxDeletionsPerBatch,
+		maxPodPendingCount:             maxPodPendingCount,
 	}
 
 	c.logger = runtime.NewLoggerWithType(c)
@@ -112,37 +158,76 @@ func NewController(
 	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
 	c.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "gameserverset-controller"})
 
+	wh.AddHandler("/validate", v1alpha1.Kind("GameServerSet"), admv1beta1.Create, c.creationValidationHandler)
 	wh.AddHandler("/validate", v1alpha1.Kind("GameServerSet"), admv1beta1.Update, c.updateValidationHandler)
+	wh.AddHandler("/mutate", v1alpha1.Kind("GameServerSet"), admv1beta1.Update, c.updateMutationHandler)
 
 	gsSetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.workerqueue.Enqueue,
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			oldGss := oldObj.(*v1alpha1.GameServerSet)
 			newGss := newObj.(*v1alpha1.GameServerSet)
-			if oldGss.Spec.Replicas != newGss.Spec.Replicas {
+			// Generation bumps on any spec change, and the spec comparison
+			// covers callers that don't maintain it, such as the fake
+			// clientset. Labels and annotations don't touch the generation,
+			// so compare them directly. Status only updates and informer
+			// resyncs don't need a reconcile
+			if oldGss.ObjectMeta.Generation != newGss.ObjectMeta.Generation ||
+				!reflect.DeepEqual(oldGss.Spec, newGss.Spec) ||
+				!reflect.DeepEqual(oldGss.ObjectMeta.Labels, newGss.ObjectMeta.Labels) ||
+				!reflect.DeepEqual(oldGss.ObjectMeta.Annotations, newGss.ObjectMeta.Annotations) {
 				c.workerqueue.Enqueue(newGss)
 			}
 		},
-		DeleteFunc: func(gsSet interface{}) {
-			c.stateCache.deleteGameServerSet(gsSet.(*v1alpha1.GameServerSet))
+		DeleteFunc: func(obj interface{}) {
+			gsSet := obj.(*v1alpha1.GameServerSet)
+			c.stateCache.deleteGameServerSet(gsSet)
+			c.expectations.delete(gsSet.ObjectMeta.Namespace + "/" + gsSet.ObjectMeta.Name)
 		},
 	})
 
 	gsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: c.gameServerEventHandler,
+		AddFunc: func(obj interface{}) {
+			if gs, ok := obj.(*v1alpha1.GameServer); ok {
+				if key, ok := ownerKey(gs); ok {
+					c.expectations.creationObserved(key)
+				}
+			}
+			c.gameServerEventHandler(obj)
+		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			gs := newObj.(*v1alpha1.GameServer)
+			if gs.Status.State == v1alpha1.GameServerStateShutdown || gs.ObjectMeta.DeletionTimestamp != nil {
+				if key, ok := ownerKey(gs); ok {
+					c.expectations.deletionObserved(key, gs.ObjectMeta.Name)
+				}
+			}
 			// ignore if already being deleted
 			if gs.ObjectMeta.DeletionTimestamp == nil {
 				c.gameServerEventHandler(gs)
 			}
 		},
-		DeleteFunc: c.gameServerEventHandler,
+		DeleteFunc: func(obj interface{}) {
+			if gs, ok := obj.(*v1alpha1.GameServer); ok {
+				if key, ok := ownerKey(gs); ok {
+					c.expectations.deletionObserved(key, gs.ObjectMeta.Name)
+				}
+			}
+			c.gameServerEventHandler(obj)
+		},
 	})
 
 	return c
 }
 
+// RegisterGameServerLimits caps how many GameServers scale ups may create per
+// namespace and per fleet, mirroring the limits the validating webhook applies
+// to direct creations. Zero disables a limit. Must be called before Run
+func (c *Controller) RegisterGameServerLimits(perNamespace, perFleet int64) {
+	c.maxGameServersPerNamespace = perNamespace
+	c.maxGameServersPerFleet = perFleet
+}
+
 // Run the GameServerSet controller. Will block until stop is closed.
 // Runs threadiness number workers to process the rate limited queue
 func (c *Controller) Run(workers int, stop <-chan struct{}) error {
@@ -153,8 +238,13 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 		return err
 	}
 
+	err = crd.WaitForEstablishedCRD(c.crdGetter, "scaleevents."+stable.GroupName, c.logger)
+	if err != nil {
+		return err
+	}
+
 	c.logger.Info("Wait for cache sync")
-	if !cache.WaitForCacheSync(stop, c.gameServerSynced, c.gameServerSetSynced) {
+	if !cache.WaitForCacheSync(stop, c.gameServerSynced, c.gameServerSetSynced, c.scaleEventSynced) {
 		return errors.New("failed to wait for caches to sync")
 	}
 
@@ -162,6 +252,12 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 	return nil
 }
 
+// WaitUntilRunning blocks until this controller has synced its caches and
+// its workers are processing events, or stop is closed
+func (c *Controller) WaitUntilRunning(stop <-chan struct{}) bool {
+	return c.workerqueue.WaitUntilRunning(stop)
+}
+
 // updateValidationHandler that validates a GameServerSet when is updated
 // Should only be called on gameserverset update operations.
 func (c *Controller) updateValidationHandler(review admv1beta1.AdmissionReview) (admv1beta1.AdmissionReview, error) {
@@ -203,6 +299,114 @@ func (c *Controller) updateValidationHandler(review admv1beta1.AdmissionReview)
 	return review, nil
 }
 
+// creationValidationHandler validates a GameServerSet when it is created,
+// rejecting sets whose GameServer template is invalid.
+// Should only be called on gameserverset create operations.
+func (c *Controller) creationValidationHandler(review admv1beta1.AdmissionReview) (admv1beta1.AdmissionReview, error) {
+	c.logger.WithField("review", review).Info("creationValidationHandler")
+
+	obj := review.Request.Object
+	gsSet := &v1alpha1.GameServerSet{}
+	if err := json.Unmarshal(obj.Raw, gsSet); err != nil {
+		return review, errors.Wrapf(err, "error unmarshalling original GameServerSet json: %s", obj.Raw)
+	}
+
+	ok, causes := gsSet.Validate()
+	if !ok {
+		review.Response.Allowed = false
+		details := metav1.StatusDetails{
+			Name:   review.Request.Name,
+			Group:  review.Request.Kind.Group,
+			Kind:   review.Request.Kind.Kind,
+			Causes: causes,
+		}
+		review.Response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: "GameServerSet configuration is invalid",
+			Reason:  metav1.StatusReasonInvalid,
+			Details: &details,
+		}
+
+		c.logger.WithField("review", review).Info("Invalid GameServerSet")
+		return review, nil
+	}
+
+	return review, nil
+}
+
+// scaleAudit is the record stored in the LastScaleAnnotation when the
+// replica count of a GameServerSet changes
+type scaleAudit struct {
+	User             string      `json:"user"`
+	Replicas         int32       `json:"replicas"`
+	PreviousReplicas int32       `json:"previousReplicas"`
+	Time             metav1.Time `json:"time"`
+}
+
+// updateMutationHandler annotates a GameServerSet with the source of replica
+// changes - the user or service account from the admission request - so scale
+// changes can be traced back to whoever made them.
+// Should only be called on gameserverset update operations.
+func (c *Controller) updateMutationHandler(review admv1beta1.AdmissionReview) (admv1beta1.AdmissionReview, error) {
+	c.logger.WithField("review", review).Info("updateMutationHandler")
+
+	newGss := &v1alpha1.GameServerSet{}
+	oldGss := &v1alpha1.GameServerSet{}
+
+	newObj := review.Request.Object
+	if err := json.Unmarshal(newObj.Raw, newGss); err != nil {
+		return review, errors.Wrapf(err, "error unmarshalling new GameServerSet json: %s", newObj.Raw)
+	}
+
+	oldObj := review.Request.OldObject
+	if err := json.Unmarshal(oldObj.Raw, oldGss); err != nil {
+		return review, errors.Wrapf(err, "error unmarshalling old GameServerSet json: %s", oldObj.Raw)
+	}
+
+	if oldGss.Spec.Replicas == newGss.Spec.Replicas {
+		return review, nil
+	}
+
+	audit := scaleAudit{
+		User:             review.Request.UserInfo.Username,
+		Replicas:         newGss.Spec.Replicas,
+		PreviousReplicas: oldGss.Spec.Replicas,
+		Time:             metav1.Now(),
+	}
+	b, err := json.Marshal(audit)
+	if err != nil {
+		return review, errors.Wrapf(err, "error marshalling scale audit for GameServerSet %s", newGss.ObjectMeta.Name)
+	}
+
+	if newGss.ObjectMeta.Annotations == nil {
+		newGss.ObjectMeta.Annotations = map[string]string{}
+	}
+	newGss.ObjectMeta.Annotations[v1alpha1.LastScaleAnnotation] = string(b)
+
+	patched, err := json.Marshal(newGss)
+	if err != nil {
+		return review, errors.Wrapf(err, "error marshalling annotated GameServerSet %s to json", newGss.ObjectMeta.Name)
+	}
+
+	patch, err := jsonpatch.CreatePatch(newObj.Raw, patched)
+	if err != nil {
+		return review, errors.Wrapf(err, "error creating patch for GameServerSet %s", newGss.ObjectMeta.Name)
+	}
+
+	jsn, err := json.Marshal(patch)
+	if err != nil {
+		return review, errors.Wrapf(err, "error creating json for patch for GameServerSet %s", newGss.ObjectMeta.Name)
+	}
+
+	c.logger.WithField("gsSet", newGss.ObjectMeta.Name).WithField("patch", string(jsn)).Infof("patch created!")
+
+	pt := admv1beta1.PatchTypeJSONPatch
+	review.Response.PatchType = &pt
+	review.Response.Patch = jsn
+
+	return review, nil
+}
+
 func (c *Controller) gameServerEventHandler(obj interface{}) {
 	gs, ok := obj.(*v1alpha1.GameServer)
 	if !ok {
@@ -226,6 +430,16 @@ func (c *Controller) gameServerEventHandler(obj interface{}) {
 	c.workerqueue.EnqueueImmediately(gsSet)
 }
 
+// ownerKey returns the expectations cache key of the GameServerSet that
+// controls the given GameServer, if it has one
+func ownerKey(gs *v1alpha1.GameServer) (string, bool) {
+	ref := metav1.GetControllerOf(gs)
+	if ref == nil || ref.Kind != "GameServerSet" {
+		return "", false
+	}
+	return gs.ObjectMeta.Namespace + "/" + ref.Name, true
+}
+
 // syncGameServer synchronises the GameServers for the Set,
 // making sure there are aways as many GameServers as requested
 func (c *Controller) syncGameServerSet(key string) error {
@@ -256,7 +470,55 @@ func (c *Controller) syncGameServerSet(key string) error {
 
 	list = c.stateCache.forGameServerSet(gsSet).reconcileWithUpdatedServerList(list)
 
-	numServersToAdd, toDelete, isPartial := computeReconciliationAction(list, int(gsSet.Spec.Replicas), maxGameServerCreationsPerBatch, maxGameServerDeletionsPerBatch, maxPodPendingCount)
+	if !c.expectations.satisfied(key) {
+		// a previous sync has creations or deletions in flight the informer
+		// hasn't caught up on - acting on this stale list would duplicate
+		// them, so just refresh status and come back shortly
+		c.logger.WithField("key", key).Info("Deferring sync, waiting on in flight creations or deletions")
+		defer c.workerqueue.EnqueueAfter(gsSet, time.Second)
+		return c.syncGameServerSetStatus(gsSet, list)
+	}
+
+	if gsSet.Spec.Paused {
+		// replica reconciliation is paused - typically by the owning Fleet
+		// during incident response - so only keep status up to date
+		return c.syncGameServerSetStatus(gsSet, list)
+	}
+
+	if gsSet.AllowsTemplateUpdate() {
+		if err := c.replaceStaleGameServers(gsSet, list); err != nil {
+			c.logger.WithError(err).Warning("error replacing stale game servers")
+		}
+	}
+
+	if err := c.reconcileGameServerMetadata(gsSet, list); err != nil {
+		c.logger.WithError(err).Warning("error reconciling game server metadata")
+	}
+
+	if gsSet.Spec.AllocationOverflow != nil {
+		if err := c.reconcileAllocationOverflow(gsSet, list); err != nil {
+			c.logger.WithError(err).Warning("error reconciling allocation overflow")
+		}
+	}
+
+	if len(gsSet.Spec.Flavors) > 0 {
+		if err := c.reconcileFlavors(gsSet, list); err != nil {
+			c.logger.WithError(err).Warning("error reconciling flavors")
+		}
+	}
+
+	var drainRequeue time.Duration
+	if gsSet.Spec.DrainStrategy != nil {
+		var err error
+		if drainRequeue, err = c.reconcileDrainStrategy(gsSet, list); err != nil {
+			c.logger.WithError(err).Warning("error reconciling drain strategy")
+		}
+	}
+
+	numServersToAdd, toDelete, isPartial, requeueAfter := computeReconciliationAction(gsSet.Spec.Scheduling, list, int(gsSet.Spec.Replicas), c.maxGameServerCreationsPerBatch, c.maxGameServerDeletionsPerBatch, gsSet.MaxPodPendingCount(c.maxPodPendingCount), gsSet.Spec.MinReadySeconds)
+	if drainRequeue > 0 && (requeueAfter == 0 || drainRequeue < requeueAfter) {
+		requeueAfter = drainRequeue
+	}
 	status := computeStatus(list)
 	fields := logrus.Fields{}
 
@@ -285,10 +547,26 @@ func (c *Controller) syncGameServerSet(key string) error {
 		// make sure we get a follow-up, by re-scheduling this GSS in the worker queue immediately before this
 		// function returns
 		defer c.workerqueue.EnqueueImmediately(gsSet)
+	} else if requeueAfter > 0 {
+		// deletions were deferred by minReadySeconds - reconcile again once
+		// the youngest protected GameServer has aged out
+		defer c.workerqueue.EnqueueAfter(gsSet, requeueAfter)
+	}
+
+	if err := c.recordScaleEvent(gsSet, status.Replicas); err != nil {
+		c.logger.WithError(err).Warning("error recording scale event")
+	}
+
+	if numServersToAdd > 0 {
+		numServersToAdd = c.capAddToQuotaHeadroom(gsSet, numServersToAdd)
 	}
 
 	if numServersToAdd > 0 {
-		if err := c.addMoreGameServers(gsSet, numServersToAdd); err != nil {
+		numServersToAdd = c.capAddToGameServerLimits(gsSet, numServersToAdd)
+	}
+
+	if numServersToAdd > 0 {
+		if err := c.addMoreGameServers(gsSet, list, numServersToAdd); err != nil {
 			c.logger.WithError(err).Warning("error adding game servers")
 		}
 	}
@@ -303,14 +581,64 @@ func (c *Controller) syncGameServerSet(key string) error {
 }
 
 // computeReconciliationAction computes the action to take to reconcile a game server set set given
-// the list of game servers that were found and target replica count.
-func computeReconciliationAction(list []*v1alpha1.GameServer, targetReplicaCount int, maxCreations int, maxDeletions int, maxPending int) (int, []*v1alpha1.GameServer, bool) {
+// the list of game servers that were found and target replica count. Also returns how long to wait
+// before reconciling again, when deletions were deferred by minReadySeconds.
+func computeReconciliationAction(strategy v1alpha1.SchedulingStrategy, list []*v1alpha1.GameServer, targetReplicaCount int, maxCreations int, maxDeletions int, maxPending int, minReadySeconds int32) (int, []*v1alpha1.GameServer, bool, time.Duration) {
 	var upCount int // up == Ready or will become ready
 
 	// track the number of pods that are being created at any given moment by the GameServerSet
 	// so we can limit it at a throughput that Kubernetes can handle
 	var podPendingCount int // podPending == "up" but don't have a Pod running yet
 	var toDelete []*v1alpha1.GameServer
+	var requeueAfter time.Duration
+
+	// by default, delete the GameServers that became Ready most recently -
+	// falling back to the newest by creation time when no transition was
+	// recorded - unless the scheduling strategy says otherwise
+	less := func(a, b *v1alpha1.GameServer) bool {
+		at, bt := a.Status.ReadyAt(), b.Status.ReadyAt()
+		if at.IsZero() || bt.IsZero() || at.Equal(&bt) {
+			return a.ObjectMeta.CreationTimestamp.After(b.ObjectMeta.CreationTimestamp.Time)
+		}
+		return bt.Before(&at)
+	}
+	presort := false
+	if s, ok := v1alpha1.GetSchedulingStrategy(strategy); ok {
+		if s.ScaleDownNodeComparator != nil {
+			counts := countGameServersPerNode(list)
+			less = func(a, b *v1alpha1.GameServer) bool {
+				return s.ScaleDownNodeComparator(a, b, counts)
+			}
+			presort = true
+		} else if s.ScaleDownComparator != nil {
+			less = s.ScaleDownComparator
+		}
+	}
+
+	// the deletion cost annotation trumps the strategy's ordering, so
+	// operators and matchmakers can protect GameServers that are about to be
+	// allocated
+	if hasDeletionCost(list) {
+		strategyLess := less
+		less = func(a, b *v1alpha1.GameServer) bool {
+			if ac, bc := a.DeletionCost(), b.DeletionCost(); ac != bc {
+				return ac < bc
+			}
+			return strategyLess(a, b)
+		}
+		presort = true
+	}
+
+	if presort {
+		// process the GameServers the strategy most wants to keep first, so
+		// the excess that gets scheduled for deletion is the cheapest to
+		// remove - for Packed, those on the least packed nodes, consolidating
+		// Pods so the cluster autoscaler can remove the emptied nodes
+		list = append([]*v1alpha1.GameServer{}, list...)
+		sort.Slice(list, func(i, j int) bool {
+			return less(list[j], list[i])
+		})
+	}
 
 	scheduleDeletion := func(gs *v1alpha1.GameServer) {
 		if gs.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -326,9 +654,9 @@ func computeReconciliationAction(list []*v1alpha1.GameServer, targetReplicaCount
 		}
 	}
 
-	// pass 1 - count allocated servers only, since those can't be touched
+	// pass 1 - count allocated and reserved servers only, since those can't be touched
 	for _, gs := range list {
-		if isAllocated(gs) {
+		if isAllocated(gs) || isReserved(gs) {
 			upCount++
 			continue
 		}
@@ -336,7 +664,7 @@ func computeReconciliationAction(list []*v1alpha1.GameServer, targetReplicaCount
 
 	// pass 2 - handle all other statuses
 	for _, gs := range list {
-		if isAllocated(gs) {
+		if isAllocated(gs) || isReserved(gs) {
 			// already handled above
 			continue
 		}
@@ -362,12 +690,26 @@ func computeReconciliationAction(list []*v1alpha1.GameServer, targetReplicaCount
 		case v1alpha1.GameServerStateRequestReady:
 			handleGameServerUp(gs)
 		case v1alpha1.GameServerStateReady:
+			// anti-flap: hold on to excess GameServers that only just became
+			// Ready, and reconcile again once they have aged past
+			// minReadySeconds, so a brief scale down doesn't waste boot work
+			if upCount >= targetReplicaCount {
+				if remaining := minReadyRemaining(gs, minReadySeconds); remaining > 0 {
+					if requeueAfter == 0 || remaining < requeueAfter {
+						requeueAfter = remaining
+					}
+					continue
+				}
+			}
 			handleGameServerUp(gs)
 		case v1alpha1.GameServerStateShutdown:
 			// will be deleted soon
 			handleGameServerUp(gs)
+		case v1alpha1.GameServerStateCompleted:
+			// retained for inspection, and will be removed by the garbage
+			// collector - don't count it, so a replacement gets created
 
-		// GameServerStateAllocated - already handled above
+		// GameServerStateAllocated, GameServerStateReserved - already handled above
 
 		case v1alpha1.GameServerStateError, v1alpha1.GameServerStateUnhealthy:
 			scheduleDeletion(gs)
@@ -401,29 +743,191 @@ func computeReconciliationAction(list []*v1alpha1.GameServer, targetReplicaCount
 	}
 
 	if len(toDelete) > maxDeletions {
-		// we have to pick which GS to delete, let's delete the newest ones first.
+		// we have to pick which GS to delete, so order them by the strategy's
+		// preference and take the front of the list
 		sort.Slice(toDelete, func(i, j int) bool {
-			return toDelete[i].ObjectMeta.CreationTimestamp.After(toDelete[j].ObjectMeta.CreationTimestamp.Time)
+			return less(toDelete[i], toDelete[j])
 		})
 
 		toDelete = toDelete[0:maxDeletions]
 		partialReconciliation = true
 	}
 
-	return numServersToAdd, toDelete, partialReconciliation
+	return numServersToAdd, toDelete, partialReconciliation, requeueAfter
 }
 
 func isAllocated(gs *v1alpha1.GameServer) bool {
 	return gs.ObjectMeta.DeletionTimestamp == nil && gs.Status.State == v1alpha1.GameServerStateAllocated
 }
 
+func isReserved(gs *v1alpha1.GameServer) bool {
+	return gs.ObjectMeta.DeletionTimestamp == nil && gs.Status.State == v1alpha1.GameServerStateReserved
+}
+
+// minReadyRemaining returns how much longer the given GameServer is protected
+// from scale down deletion by minReadySeconds, or 0 if it is already eligible
+func minReadyRemaining(gs *v1alpha1.GameServer, minReadySeconds int32) time.Duration {
+	if minReadySeconds <= 0 {
+		return 0
+	}
+	readyAt := gs.Status.ReadyAt()
+	if readyAt.IsZero() {
+		return 0
+	}
+	remaining := time.Duration(minReadySeconds)*time.Second - time.Since(readyAt.Time)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// recordScaleEvent creates a ScaleEvent when the desired replica count of this
+// GameServerSet changes, recording the desired and observed counts at that
+// point, so external autoscaling systems can consume scaling decisions without
+// needing access to this controller
+func (c *Controller) recordScaleEvent(gsSet *v1alpha1.GameServerSet, observed int32) error {
+	selector := labels.SelectorFromSet(labels.Set{v1alpha1.ScaleEventGameServerSetLabel: gsSet.ObjectMeta.Name})
+	list, err := c.scaleEventLister.ScaleEvents(gsSet.ObjectMeta.Namespace).List(selector)
+	if err != nil {
+		return errors.Wrapf(err, "error listing ScaleEvents for GameServerSet %s", gsSet.ObjectMeta.Name)
+	}
+
+	var latest *v1alpha1.ScaleEvent
+	for _, se := range list {
+		if latest == nil || latest.ObjectMeta.CreationTimestamp.Before(&se.ObjectMeta.CreationTimestamp) {
+			latest = se
+		}
+	}
+	if latest != nil && latest.Spec.DesiredReplicas == gsSet.Spec.Replicas {
+		return nil
+	}
+	if latest == nil && gsSet.Spec.Replicas == observed {
+		// steady state, there is no scaling decision to record
+		return nil
+	}
+
+	event := &v1alpha1.ScaleEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: gsSet.ObjectMeta.Name + "-",
+			Namespace:    gsSet.ObjectMeta.Namespace,
+			Labels: map[string]string{
+				v1alpha1.ScaleEventGameServerSetLabel: gsSet.ObjectMeta.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(gsSet, v1alpha1.SchemeGroupVersion.WithKind("GameServerSet")),
+			},
+		},
+		Spec: v1alpha1.ScaleEventSpec{
+			GameServerSetName: gsSet.ObjectMeta.Name,
+			FleetName:         gsSet.ObjectMeta.Labels[v1alpha1.FleetNameLabel],
+			DesiredReplicas:   gsSet.Spec.Replicas,
+			ObservedReplicas:  observed,
+		},
+	}
+	if event.Spec.FleetName != "" {
+		event.ObjectMeta.Labels[v1alpha1.FleetNameLabel] = event.Spec.FleetName
+	}
+
+	event, err = c.scaleEventGetter.ScaleEvents(gsSet.ObjectMeta.Namespace).Create(event)
+	if err != nil {
+		return errors.Wrapf(err, "error creating ScaleEvent for GameServerSet %s", gsSet.ObjectMeta.Name)
+	}
+
+	c.recorder.Eventf(gsSet, corev1.EventTypeNormal, "ScaleEventRecorded",
+		"ScaleEvent %s recorded scaling from %d to %d replicas", event.ObjectMeta.Name, observed, gsSet.Spec.Replicas)
+	return nil
+}
+
+// capAddToQuotaHeadroom caps the number of GameServers to create to the free
+// pod count in the namespace's ResourceQuotas, so we don't create GameServers
+// whose Pods will be rejected and cycle to Error
+func (c *Controller) capAddToQuotaHeadroom(gsSet *v1alpha1.GameServerSet, toAdd int) int {
+	quotas, err := c.quotaGetter.ResourceQuotas(gsSet.ObjectMeta.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		c.logger.WithError(err).Warning("could not list ResourceQuotas, scale up will not be capped")
+		return toAdd
+	}
+
+	headroom := toAdd
+	for _, rq := range quotas.Items {
+		hard, ok := rq.Status.Hard[corev1.ResourcePods]
+		if !ok {
+			continue
+		}
+		used := rq.Status.Used[corev1.ResourcePods]
+		free := int(hard.Value() - used.Value())
+		if free < headroom {
+			headroom = free
+		}
+	}
+	if headroom < 0 {
+		headroom = 0
+	}
+
+	if headroom < toAdd {
+		c.recorder.Eventf(gsSet, corev1.EventTypeWarning, "ScaleUpLimited",
+			"Creating %d of %d GameServers: namespace ResourceQuota only has headroom for %d more pods", headroom, toAdd, headroom)
+	}
+	return headroom
+}
+
+// capAddToGameServerLimits caps the number of GameServers to create to the
+// configured per namespace and per fleet GameServer limits, so a runaway
+// scale up in a shared cluster can't starve everyone else
+func (c *Controller) capAddToGameServerLimits(gsSet *v1alpha1.GameServerSet, toAdd int) int {
+	if c.maxGameServersPerNamespace <= 0 && c.maxGameServersPerFleet <= 0 {
+		return toAdd
+	}
+
+	list, err := c.gameServerLister.GameServers(gsSet.ObjectMeta.Namespace).List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(err).Warning("could not list GameServers, scale up will not be capped")
+		return toAdd
+	}
+
+	count := int64(0)
+	fleetCount := int64(0)
+	fleetName := gsSet.ObjectMeta.Labels[v1alpha1.FleetNameLabel]
+	for _, gs := range list {
+		if !gs.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		count++
+		if fleetName != "" && gs.ObjectMeta.Labels[v1alpha1.FleetNameLabel] == fleetName {
+			fleetCount++
+		}
+	}
+
+	headroom := toAdd
+	if c.maxGameServersPerNamespace > 0 && int(c.maxGameServersPerNamespace-count) < headroom {
+		headroom = int(c.maxGameServersPerNamespace - count)
+	}
+	if c.maxGameServersPerFleet > 0 && fleetName != "" && int(c.maxGameServersPerFleet-fleetCount) < headroom {
+		headroom = int(c.maxGameServersPerFleet - fleetCount)
+	}
+	if headroom < 0 {
+		headroom = 0
+	}
+
+	if headroom < toAdd {
+		c.recorder.Eventf(gsSet, corev1.EventTypeWarning, "ScaleUpLimited",
+			"Creating %d of %d GameServers: the configured GameServer quota only has room for %d more", headroom, toAdd, headroom)
+	}
+	return headroom
+}
+
 // addMoreGameServers adds diff more GameServers to the set
-func (c *Controller) addMoreGameServers(gsSet *v1alpha1.GameServerSet, count int) error {
+func (c *Controller) addMoreGameServers(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer, count int) error {
 	c.logger.WithField("count", count).WithField("gameserverset", gsSet.ObjectMeta.Name).Info("Adding more gameservers")
 
-	return parallelize(newGameServersChannel(count, gsSet), maxCreationParalellism, func(gs *v1alpha1.GameServer) error {
+	key := gsSet.ObjectMeta.Namespace + "/" + gsSet.ObjectMeta.Name
+	c.expectations.expectCreations(key, count)
+
+	return parallelize(newGameServersChannel(count, gsSet, list), maxCreationParalellism, func(gs *v1alpha1.GameServer) error {
 		gs, err := c.gameServerGetter.GameServers(gs.Namespace).Create(gs)
 		if err != nil {
+			// this creation will never arrive through the informer
+			c.expectations.creationObserved(key)
 			return errors.Wrapf(err, "error creating gameserver for gameserverset %s", gsSet.ObjectMeta.Name)
 		}
 
@@ -436,12 +940,22 @@ func (c *Controller) addMoreGameServers(gsSet *v1alpha1.GameServerSet, count int
 func (c *Controller) deleteGameServers(gsSet *v1alpha1.GameServerSet, toDelete []*v1alpha1.GameServer) error {
 	c.logger.WithField("diff", len(toDelete)).WithField("gameserverset", gsSet.ObjectMeta.Name).Info("Deleting gameservers")
 
+	key := gsSet.ObjectMeta.Namespace + "/" + gsSet.ObjectMeta.Name
+	names := make([]string, 0, len(toDelete))
+	for _, gs := range toDelete {
+		names = append(names, gs.ObjectMeta.Name)
+	}
+	c.expectations.expectDeletions(key, names)
+
 	return parallelize(gameServerListToChannel(toDelete), maxDeletionParallelism, func(gs *v1alpha1.GameServer) error {
 		// We should not delete the gameservers directly buy set their state to shutdown and let the gameserver controller to delete
 		gsCopy := gs.DeepCopy()
 		gsCopy.Status.State = v1alpha1.GameServerStateShutdown
+		gsCopy.Status.RecordStateTransition()
 		_, err := c.gameServerGetter.GameServers(gs.Namespace).Update(gsCopy)
 		if err != nil {
+			// this deletion will never arrive through the informer
+			c.expectations.deletionObserved(key, gs.ObjectMeta.Name)
 			return errors.Wrapf(err, "error updating gameserver %s from status %s to Shutdown status.", gs.ObjectMeta.Name, gs.Status.State)
 		}
 
@@ -451,13 +965,351 @@ func (c *Controller) deleteGameServers(gsSet *v1alpha1.GameServerSet, toDelete [
 	})
 }
 
-func newGameServersChannel(n int, gsSet *v1alpha1.GameServerSet) chan *v1alpha1.GameServer {
+// replaceStaleGameServers shuts down GameServers that were created from an older
+// template than the one currently on the GameServerSet. Allocated GameServers are
+// left alone, as are GameServers already on their way out, so replacement happens
+// as the reconciliation loop tops the set back up to its target replica count.
+func (c *Controller) replaceStaleGameServers(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) error {
+	// GameServers are compared against the hash of the template they were
+	// created from - the set's own, or their flavor's
+	hashes := map[string]string{"": gsSet.TemplateHash()}
+	for i := range gsSet.Spec.Flavors {
+		f := &gsSet.Spec.Flavors[i]
+		hashes[f.Name] = f.TemplateHash()
+	}
+
+	var stale []*v1alpha1.GameServer
+	for _, gs := range list {
+		if isAllocated(gs) || !gs.ObjectMeta.DeletionTimestamp.IsZero() ||
+			gs.Status.State == v1alpha1.GameServerStateShutdown ||
+			gs.Status.State == v1alpha1.GameServerStateCompleted {
+			continue
+		}
+		hash, ok := hashes[gs.ObjectMeta.Labels[v1alpha1.GameServerFlavorLabel]]
+		if !ok || gs.ObjectMeta.Annotations[v1alpha1.GameServerTemplateHashAnnotation] != hash {
+			stale = append(stale, gs)
+		}
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	c.logger.WithField("count", len(stale)).WithField("gameserverset", gsSet.ObjectMeta.Name).
+		Info("Replacing gameservers from an older template")
+	return c.deleteGameServers(gsSet, stale)
+}
+
+// reconcileGameServerMetadata patches the labels and annotations of the
+// GameServerSet's template - or the GameServer's flavor's template - onto
+// existing GameServers, so metadata only template changes propagate without
+// replacing the GameServers themselves. Labels and annotations removed from
+// the template are left in place on existing GameServers
+func (c *Controller) reconcileGameServerMetadata(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) error {
+	templates := map[string]v1alpha1.GameServerTemplateSpec{"": gsSet.Spec.Template}
+	for i := range gsSet.Spec.Flavors {
+		f := &gsSet.Spec.Flavors[i]
+		templates[f.Name] = f.Template
+	}
+
+	var outdated []*v1alpha1.GameServer
+	for _, gs := range list {
+		if !gs.ObjectMeta.DeletionTimestamp.IsZero() ||
+			gs.Status.State == v1alpha1.GameServerStateShutdown ||
+			gs.Status.State == v1alpha1.GameServerStateCompleted {
+			continue
+		}
+		template, ok := templates[gs.ObjectMeta.Labels[v1alpha1.GameServerFlavorLabel]]
+		if !ok {
+			continue
+		}
+		if !metadataApplied(&template.ObjectMeta, gs) {
+			outdated = append(outdated, gs)
+		}
+	}
+
+	if len(outdated) == 0 {
+		return nil
+	}
+
+	c.logger.WithField("count", len(outdated)).WithField("gameserverset", gsSet.ObjectMeta.Name).
+		Info("Patching template metadata onto existing gameservers")
+	return parallelize(gameServerListToChannel(outdated), maxCreationParalellism, func(gs *v1alpha1.GameServer) error {
+		template := templates[gs.ObjectMeta.Labels[v1alpha1.GameServerFlavorLabel]]
+		gsCopy := gs.DeepCopy()
+		applyMetadata(&template.ObjectMeta, gsCopy)
+		if _, err := c.gameServerGetter.GameServers(gsCopy.ObjectMeta.Namespace).Update(gsCopy); err != nil {
+			return errors.Wrapf(err, "error updating template metadata on gameserver %s", gs.ObjectMeta.Name)
+		}
+		return nil
+	})
+}
+
+// metadataApplied returns true if all the labels and annotations of the given
+// template metadata are already present on the GameServer
+func metadataApplied(meta *metav1.ObjectMeta, gs *v1alpha1.GameServer) bool {
+	for k, v := range meta.Labels {
+		if gs.ObjectMeta.Labels[k] != v {
+			return false
+		}
+	}
+	for k, v := range meta.Annotations {
+		if gs.ObjectMeta.Annotations[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// applyMetadata applies the labels and annotations of the given template
+// metadata to the GameServer
+func applyMetadata(meta *metav1.ObjectMeta, gs *v1alpha1.GameServer) {
+	if len(meta.Labels) > 0 && gs.ObjectMeta.Labels == nil {
+		gs.ObjectMeta.Labels = map[string]string{}
+	}
+	for k, v := range meta.Labels {
+		gs.ObjectMeta.Labels[k] = v
+	}
+	if len(meta.Annotations) > 0 && gs.ObjectMeta.Annotations == nil {
+		gs.ObjectMeta.Annotations = map[string]string{}
+	}
+	for k, v := range meta.Annotations {
+		gs.ObjectMeta.Annotations[k] = v
+	}
+}
+
+// reconcileAllocationOverflow applies the AllocationOverflow labels and
+// annotations to Allocated GameServers in excess of the desired replica count,
+// so external systems can find and drain them. The most recently allocated
+// GameServers are marked first, and markings are never removed, as the
+// GameServer is expected to move to Shutdown once drained
+func (c *Controller) reconcileAllocationOverflow(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) error {
+	var allocated []*v1alpha1.GameServer
+	for _, gs := range list {
+		if isAllocated(gs) {
+			allocated = append(allocated, gs)
+		}
+	}
+
+	overflow := int32(len(allocated)) - gsSet.Spec.Replicas
+	if overflow <= 0 {
+		return nil
+	}
+
+	marked, rest := gsSet.Spec.AllocationOverflow.CountMatches(allocated)
+	overflow -= marked
+	if overflow <= 0 {
+		return nil
+	}
+
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].ObjectMeta.CreationTimestamp.After(rest[j].ObjectMeta.CreationTimestamp.Time)
+	})
+	if int(overflow) < len(rest) {
+		rest = rest[:overflow]
+	}
+
+	c.logger.WithField("count", len(rest)).WithField("gameserverset", gsSet.ObjectMeta.Name).
+		Info("Marking overflowing Allocated gameservers")
+	return parallelize(gameServerListToChannel(rest), maxCreationParalellism, func(gs *v1alpha1.GameServer) error {
+		gsCopy := gs.DeepCopy()
+		gsSet.Spec.AllocationOverflow.Apply(gsCopy)
+		if _, err := c.gameServerGetter.GameServers(gsCopy.ObjectMeta.Namespace).Update(gsCopy); err != nil {
+			return errors.Wrapf(err, "error updating allocation overflow metadata on gameserver %s", gs.ObjectMeta.Name)
+		}
+
+		c.recorder.Eventf(gsSet, corev1.EventTypeNormal, "AllocationOverflow",
+			"Applied allocation overflow metadata to gameserver: %s", gs.ObjectMeta.Name)
+		return nil
+	})
+}
+
+// reconcileFlavors shuts down GameServers from flavors that exceed their
+// weighted share of the desired replica count, and GameServers whose flavor
+// is no longer declared, so the reconciliation loop replaces them with
+// GameServers from under-represented flavors. Allocated GameServers are left
+// alone
+func (c *Controller) reconcileFlavors(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) error {
+	desired := gsSet.FlavorDistribution(gsSet.Spec.Replicas)
+
+	byFlavor := map[string][]*v1alpha1.GameServer{}
+	counts := map[string]int32{}
+	var surplus []*v1alpha1.GameServer
+	for _, gs := range list {
+		if !gs.ObjectMeta.DeletionTimestamp.IsZero() ||
+			gs.Status.State == v1alpha1.GameServerStateShutdown ||
+			gs.Status.State == v1alpha1.GameServerStateCompleted {
+			continue
+		}
+
+		name := gs.ObjectMeta.Labels[v1alpha1.GameServerFlavorLabel]
+		if _, ok := desired[name]; !ok {
+			// created before flavors were declared, or from a flavor that
+			// has since been removed
+			if !isAllocated(gs) {
+				surplus = append(surplus, gs)
+			}
+			continue
+		}
+		counts[name]++
+		if !isAllocated(gs) {
+			byFlavor[name] = append(byFlavor[name], gs)
+		}
+	}
+
+	for _, f := range gsSet.Spec.Flavors {
+		excess := int(counts[f.Name] - desired[f.Name])
+		if excess <= 0 {
+			continue
+		}
+		candidates := byFlavor[f.Name]
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].ObjectMeta.CreationTimestamp.After(candidates[j].ObjectMeta.CreationTimestamp.Time)
+		})
+		if excess < len(candidates) {
+			candidates = candidates[:excess]
+		}
+		surplus = append(surplus, candidates...)
+	}
+
+	if len(surplus) == 0 {
+		return nil
+	}
+
+	c.logger.WithField("count", len(surplus)).WithField("gameserverset", gsSet.ObjectMeta.Name).
+		Info("Rebalancing gameserver flavors")
+	return c.deleteGameServers(gsSet, surplus)
+}
+
+// reconcileDrainStrategy marks Allocated GameServers in excess of the desired
+// replica count for shutdown, and deletes draining GameServers once they
+// return to Ready, or their drain timeout elapses. The draining mark is
+// removed again if a scale up brings the replica count back over the number
+// of Allocated GameServers. Returns how long to wait before reconciling
+// again, when a drain timeout is still pending
+func (c *Controller) reconcileDrainStrategy(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) (time.Duration, error) {
+	ds := gsSet.Spec.DrainStrategy
+	now := time.Now()
+
+	var rest []*v1alpha1.GameServer     // Allocated, not yet draining
+	var draining []*v1alpha1.GameServer // Allocated and draining
+	var toDelete []*v1alpha1.GameServer
+	var requeueAfter time.Duration
+
+	for _, gs := range list {
+		if !gs.ObjectMeta.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if ds.Draining(gs) && gs.Status.State == v1alpha1.GameServerStateReady {
+			// the session has ended, and the GameServer can go
+			toDelete = append(toDelete, gs)
+			continue
+		}
+		if !isAllocated(gs) {
+			continue
+		}
+		if !ds.Draining(gs) {
+			rest = append(rest, gs)
+			continue
+		}
+
+		draining = append(draining, gs)
+		if deadline, ok := ds.DrainDeadline(gs); ok {
+			if remaining := deadline.Sub(now); remaining <= 0 {
+				toDelete = append(toDelete, gs)
+			} else if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+		}
+	}
+
+	overflow := len(draining) + len(rest) - int(gsSet.Spec.Replicas)
+
+	if toMark := overflow - len(draining); toMark > 0 {
+		sort.Slice(rest, func(i, j int) bool {
+			return rest[i].ObjectMeta.CreationTimestamp.After(rest[j].ObjectMeta.CreationTimestamp.Time)
+		})
+		if toMark < len(rest) {
+			rest = rest[:toMark]
+		}
+
+		c.logger.WithField("count", len(rest)).WithField("gameserverset", gsSet.ObjectMeta.Name).
+			Info("Draining Allocated gameservers")
+		err := parallelize(gameServerListToChannel(rest), maxCreationParalellism, func(gs *v1alpha1.GameServer) error {
+			gsCopy := gs.DeepCopy()
+			ds.StartDraining(gsCopy, now)
+			if _, err := c.gameServerGetter.GameServers(gsCopy.ObjectMeta.Namespace).Update(gsCopy); err != nil {
+				return errors.Wrapf(err, "error marking gameserver %s as draining", gs.ObjectMeta.Name)
+			}
+
+			c.recorder.Eventf(gsSet, corev1.EventTypeNormal, "DrainingGameServer",
+				"Marked Allocated gameserver for shutdown: %s", gs.ObjectMeta.Name)
+			return nil
+		})
+		if err != nil {
+			return requeueAfter, err
+		}
+	} else if unmark := -overflow; unmark > 0 && len(draining) > 0 {
+		// the scale down was reversed - let the GameServers keep running
+		if unmark > len(draining) {
+			unmark = len(draining)
+		}
+		err := parallelize(gameServerListToChannel(draining[:unmark]), maxCreationParalellism, func(gs *v1alpha1.GameServer) error {
+			gsCopy := gs.DeepCopy()
+			ds.StopDraining(gsCopy)
+			if _, err := c.gameServerGetter.GameServers(gsCopy.ObjectMeta.Namespace).Update(gsCopy); err != nil {
+				return errors.Wrapf(err, "error unmarking draining gameserver %s", gs.ObjectMeta.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			return requeueAfter, err
+		}
+	}
+
+	if len(toDelete) > 0 {
+		return requeueAfter, c.deleteGameServers(gsSet, toDelete)
+	}
+	return requeueAfter, nil
+}
+
+func newGameServersChannel(n int, gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) chan *v1alpha1.GameServer {
 	gameServers := make(chan *v1alpha1.GameServer)
 	go func() {
 		defer close(gameServers)
 
+		var desired map[string]int32
+		var counts map[string]int32
+		if len(gsSet.Spec.Flavors) > 0 {
+			desired = gsSet.FlavorDistribution(gsSet.Spec.Replicas)
+			counts = countGameServersPerFlavor(list)
+		}
+
 		for i := 0; i < n; i++ {
-			gameServers <- gsSet.GameServer()
+			var gs *v1alpha1.GameServer
+			if len(gsSet.Spec.Flavors) > 0 {
+				// each new GameServer takes the flavor that is furthest
+				// below its weighted share, preferring earlier declaration
+				// on ties
+				best := &gsSet.Spec.Flavors[0]
+				for j := 1; j < len(gsSet.Spec.Flavors); j++ {
+					f := &gsSet.Spec.Flavors[j]
+					if desired[f.Name]-counts[f.Name] > desired[best.Name]-counts[best.Name] {
+						best = f
+					}
+				}
+				counts[best.Name]++
+				gs = gsSet.FlavorGameServer(best)
+			} else {
+				gs = gsSet.GameServer()
+			}
+
+			// spread multi-architecture GameServerSets across their declared
+			// architectures, unless the template already pins one
+			if archs := gs.Spec.Architectures; len(archs) > 0 && gs.Architecture() == "" {
+				gs.PinArchitecture(archs[i%len(archs)].Arch)
+			}
+			gameServers <- gs
 		}
 	}()
 
@@ -511,12 +1363,15 @@ func parallelize(gameServers chan *v1alpha1.GameServer, parallelism int, work fu
 
 // syncGameServerSetStatus synchronises the GameServerSet State with active GameServer counts
 func (c *Controller) syncGameServerSetStatus(gsSet *v1alpha1.GameServerSet, list []*v1alpha1.GameServer) error {
-	return c.updateStatusIfChanged(gsSet, computeStatus(list))
+	status := computeStatus(list)
+	status.LabelSelector = v1alpha1.GameServerSetGameServerLabel + "=" + gsSet.ObjectMeta.Name
+	status.ObservedGeneration = gsSet.ObjectMeta.Generation
+	return c.updateStatusIfChanged(gsSet, status)
 }
 
 // updateStatusIfChanged updates GameServerSet status if it's different than provided.
 func (c *Controller) updateStatusIfChanged(gsSet *v1alpha1.GameServerSet, status v1alpha1.GameServerSetStatus) error {
-	if gsSet.Status != status {
+	if !reflect.DeepEqual(gsSet.Status, status) {
 		gsSetCopy := gsSet.DeepCopy()
 		gsSetCopy.Status = status
 		_, err := c.gameServerSetGetter.GameServerSets(gsSet.ObjectMeta.Namespace).Update(gsSetCopy)
@@ -527,18 +1382,98 @@ func (c *Controller) updateStatusIfChanged(gsSet *v1alpha1.GameServerSet, status
 	return nil
 }
 
+// hasDeletionCost returns true if any of the GameServers carry the
+// GameServerDeletionCostAnnotation
+func hasDeletionCost(list []*v1alpha1.GameServer) bool {
+	for _, gs := range list {
+		if _, ok := gs.ObjectMeta.Annotations[v1alpha1.GameServerDeletionCostAnnotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// countGameServersPerFlavor returns how many GameServers that are still up,
+// or on their way up, each flavor currently has, keyed by flavor name
+func countGameServersPerFlavor(list []*v1alpha1.GameServer) map[string]int32 {
+	counts := map[string]int32{}
+	for _, gs := range list {
+		if !gs.ObjectMeta.DeletionTimestamp.IsZero() ||
+			gs.Status.State == v1alpha1.GameServerStateShutdown ||
+			gs.Status.State == v1alpha1.GameServerStateCompleted {
+			continue
+		}
+		counts[gs.ObjectMeta.Labels[v1alpha1.GameServerFlavorLabel]]++
+	}
+	return counts
+}
+
+// countGameServersPerNode returns how many GameServers are on each node,
+// keyed by node name. GameServers not yet scheduled to a node are not counted
+func countGameServersPerNode(list []*v1alpha1.GameServer) map[string]int {
+	counts := map[string]int{}
+	for _, gs := range list {
+		if gs.Status.NodeName != "" {
+			counts[gs.Status.NodeName]++
+		}
+	}
+	return counts
+}
+
 // computeStatus computes the status of the game server set.
 func computeStatus(list []*v1alpha1.GameServer) v1alpha1.GameServerSetStatus {
 	var status v1alpha1.GameServerSetStatus
 
 	status.Replicas = int32(len(list))
 	for _, gs := range list {
+		if !gs.ObjectMeta.DeletionTimestamp.IsZero() {
+			status.ShuttingDownReplicas++
+			continue
+		}
+
 		switch gs.Status.State {
 		case v1alpha1.GameServerStateReady:
 			status.ReadyReplicas++
 		case v1alpha1.GameServerStateAllocated:
 			status.AllocatedReplicas++
+		case v1alpha1.GameServerStateReserved:
+			status.ReservedReplicas++
+		case v1alpha1.GameServerStateShutdown:
+			status.ShutdownReplicas++
+		}
+
+		if gs.Status.Players != nil {
+			if status.Players == nil {
+				status.Players = &v1alpha1.PlayerStatus{}
+			}
+			status.Players.Count += gs.Status.Players.Count
+			status.Players.Capacity += gs.Status.Players.Capacity
 		}
+
+		for name, counter := range gs.Status.Counters {
+			if status.Counters == nil {
+				status.Counters = map[string]v1alpha1.AggregatedCounterStatus{}
+			}
+			agg := status.Counters[name]
+			agg.Count += counter.Count
+			agg.Capacity += counter.Capacity
+			status.Counters[name] = agg
+		}
+		for name, list := range gs.Status.Lists {
+			if status.Lists == nil {
+				status.Lists = map[string]v1alpha1.AggregatedListStatus{}
+			}
+			agg := status.Lists[name]
+			agg.Count += int64(len(list.Values))
+			agg.Capacity += list.Capacity
+			status.Lists[name] = agg
+		}
+	}
+
+	if status.Players != nil {
+		// keep the flat player fields in step for existing consumers
+		status.PlayerCount = status.Players.Count
+		status.PlayerCapacity = status.Players.Capacity
 	}
 
 	return status
@@ -16,6 +16,7 @@ package gameserversets
 
 import (
 	"encoding/json"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -27,6 +28,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	admv1beta1 "k8s.io/api/admission/v1beta1"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -38,6 +42,34 @@ func gsWithState(st v1alpha1.GameServerState) *v1alpha1.GameServer {
 	return &v1alpha1.GameServer{Status: v1alpha1.GameServerStatus{State: st}}
 }
 
+func gsWithPlayers(st v1alpha1.GameServerState, count, capacity int64) *v1alpha1.GameServer {
+	gs := gsWithState(st)
+	gs.Status.Players = &v1alpha1.PlayerStatus{Count: count, Capacity: capacity}
+	return gs
+}
+
+func gsWithRooms(st v1alpha1.GameServerState, count, capacity int64, players ...string) *v1alpha1.GameServer {
+	gs := gsWithState(st)
+	gs.Status.Counters = map[string]v1alpha1.CounterStatus{"rooms": {Count: count, Capacity: capacity}}
+	gs.Status.Lists = map[string]v1alpha1.ListStatus{"players": {Capacity: capacity, Values: players}}
+	return gs
+}
+
+func gsBeingDeleted(st v1alpha1.GameServerState) *v1alpha1.GameServer {
+	gs := gsWithState(st)
+	now := metav1.Now()
+	gs.ObjectMeta.DeletionTimestamp = &now
+	return gs
+}
+
+func gsReadyFor(age time.Duration) *v1alpha1.GameServer {
+	gs := gsWithState(v1alpha1.GameServerStateReady)
+	gs.Status.Timeline = []v1alpha1.GameServerStateTransition{
+		{State: v1alpha1.GameServerStateReady, Time: metav1.NewTime(time.Now().Add(-age))},
+	}
+	return gs
+}
+
 func gsPendingDeletionWithState(st v1alpha1.GameServerState) *v1alpha1.GameServer {
 	return &v1alpha1.GameServer{
 		ObjectMeta: metav1.ObjectMeta{
@@ -58,9 +90,11 @@ func TestComputeReconciliationAction(t *testing.T) {
 		desc                   string
 		list                   []*v1alpha1.GameServer
 		targetReplicaCount     int
+		minReadySeconds        int32
 		wantNumServersToAdd    int
 		wantNumServersToDelete int
 		wantIsPartial          bool
+		wantRequeue            bool
 	}{
 		{
 			desc: "Empty",
@@ -128,6 +162,39 @@ func TestComputeReconciliationAction(t *testing.T) {
 			targetReplicaCount:     0,
 			wantNumServersToDelete: 1,
 		},
+		{
+			desc: "DeleteIgnoresReservedServers",
+			list: []*v1alpha1.GameServer{
+				gsWithState(v1alpha1.GameServerStateReady),
+				gsWithState(v1alpha1.GameServerStateReserved),
+				gsWithState(v1alpha1.GameServerStateReserved),
+			},
+			targetReplicaCount:     0,
+			wantNumServersToDelete: 1,
+		},
+		{
+			desc: "MinReadySecondsDefersDeletion",
+			list: []*v1alpha1.GameServer{
+				gsReadyFor(time.Second),
+				gsReadyFor(time.Second),
+				gsReadyFor(time.Second),
+			},
+			targetReplicaCount:     1,
+			minReadySeconds:        60,
+			wantNumServersToDelete: 0,
+			wantRequeue:            true,
+		},
+		{
+			desc: "MinReadySecondsExpired",
+			list: []*v1alpha1.GameServer{
+				gsReadyFor(2 * time.Minute),
+				gsReadyFor(2 * time.Minute),
+				gsReadyFor(2 * time.Minute),
+			},
+			targetReplicaCount:     1,
+			minReadySeconds:        60,
+			wantNumServersToDelete: 2,
+		},
 		{
 			desc: "CreateWhileDeletionsPending",
 			list: []*v1alpha1.GameServer{
@@ -158,15 +225,67 @@ func TestComputeReconciliationAction(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			toAdd, toDelete, isPartial := computeReconciliationAction(tc.list, tc.targetReplicaCount, maxTestCreationsPerBatch, maxTestDeletionsPerBatch, maxTestPendingPerBatch)
+			toAdd, toDelete, isPartial, requeueAfter := computeReconciliationAction(v1alpha1.Packed, tc.list, tc.targetReplicaCount, maxTestCreationsPerBatch, maxTestDeletionsPerBatch, maxTestPendingPerBatch, tc.minReadySeconds)
 
 			assert.Equal(t, tc.wantNumServersToAdd, toAdd, "# of GameServers to add")
 			assert.Len(t, toDelete, tc.wantNumServersToDelete, "# of GameServers to delete")
 			assert.Equal(t, tc.wantIsPartial, isPartial, "is partial reconciliation")
+			assert.Equal(t, tc.wantRequeue, requeueAfter > 0, "requeue after minReadySeconds")
 		})
 	}
 }
 
+func TestComputeReconciliationActionPackedScaleDown(t *testing.T) {
+	gsOnNode := func(name, node string) *v1alpha1.GameServer {
+		gs := gsWithState(v1alpha1.GameServerStateReady)
+		gs.ObjectMeta.Name = name
+		gs.Status.NodeName = node
+		return gs
+	}
+
+	// node1 hosts three GameServers, and node2 only one, so scaling down by
+	// one should remove the GameServer on the least packed node
+	list := []*v1alpha1.GameServer{
+		gsOnNode("gs1", "node1"),
+		gsOnNode("gs2", "node2"),
+		gsOnNode("gs3", "node1"),
+		gsOnNode("gs4", "node1"),
+	}
+
+	_, toDelete, _, _ := computeReconciliationAction(v1alpha1.Packed, list, 3, maxTestCreationsPerBatch, maxTestDeletionsPerBatch, maxTestPendingPerBatch, 0)
+	if assert.Len(t, toDelete, 1) {
+		assert.Equal(t, "gs2", toDelete[0].ObjectMeta.Name)
+	}
+
+	// Distributed has no node aware comparator, so list order decides
+	_, toDelete, _, _ = computeReconciliationAction(v1alpha1.Distributed, list, 3, maxTestCreationsPerBatch, maxTestDeletionsPerBatch, maxTestPendingPerBatch, 0)
+	if assert.Len(t, toDelete, 1) {
+		assert.Equal(t, "gs4", toDelete[0].ObjectMeta.Name)
+	}
+}
+
+func TestComputeReconciliationActionDeletionCost(t *testing.T) {
+	gsWithCost := func(name, cost string) *v1alpha1.GameServer {
+		gs := gsWithState(v1alpha1.GameServerStateReady)
+		gs.ObjectMeta.Name = name
+		gs.ObjectMeta.Annotations = map[string]string{v1alpha1.GameServerDeletionCostAnnotation: cost}
+		return gs
+	}
+
+	// gs2 is about to be allocated, so the matchmaker raised its deletion
+	// cost - the cheaper GameServer should be removed instead
+	list := []*v1alpha1.GameServer{
+		gsWithCost("gs1", "100"),
+		gsWithCost("gs2", "1000"),
+		gsWithCost("gs3", "100"),
+	}
+
+	_, toDelete, _, _ := computeReconciliationAction(v1alpha1.Packed, list, 2, maxTestCreationsPerBatch, maxTestDeletionsPerBatch, maxTestPendingPerBatch, 0)
+	if assert.Len(t, toDelete, 1) {
+		assert.NotEqual(t, "gs2", toDelete[0].ObjectMeta.Name)
+	}
+}
+
 func TestComputeStatus(t *testing.T) {
 	cases := []struct {
 		list       []*v1alpha1.GameServer
@@ -183,6 +302,29 @@ func TestComputeStatus(t *testing.T) {
 			gsWithState(v1alpha1.GameServerStateCreating),
 			gsWithState(v1alpha1.GameServerStateReady),
 		}, v1alpha1.GameServerSetStatus{ReadyReplicas: 1, AllocatedReplicas: 2, Replicas: 4}},
+		{[]*v1alpha1.GameServer{
+			gsWithPlayers(v1alpha1.GameServerStateReady, 3, 10),
+			gsWithPlayers(v1alpha1.GameServerStateAllocated, 7, 10),
+			gsWithState(v1alpha1.GameServerStateCreating),
+		}, v1alpha1.GameServerSetStatus{ReadyReplicas: 1, AllocatedReplicas: 1, Replicas: 3,
+			PlayerCount: 10, PlayerCapacity: 20,
+			Players: &v1alpha1.PlayerStatus{Count: 10, Capacity: 20}}},
+		{[]*v1alpha1.GameServer{
+			gsWithRooms(v1alpha1.GameServerStateReady, 2, 10, "alice"),
+			gsWithRooms(v1alpha1.GameServerStateAllocated, 5, 10, "bob", "carol"),
+		}, v1alpha1.GameServerSetStatus{ReadyReplicas: 1, AllocatedReplicas: 1, Replicas: 2,
+			Counters: map[string]v1alpha1.AggregatedCounterStatus{"rooms": {Count: 7, Capacity: 20}},
+			Lists:    map[string]v1alpha1.AggregatedListStatus{"players": {Count: 3, Capacity: 20}}}},
+		{[]*v1alpha1.GameServer{
+			gsWithState(v1alpha1.GameServerStateReady),
+			gsBeingDeleted(v1alpha1.GameServerStateReady),
+			gsBeingDeleted(v1alpha1.GameServerStateShutdown),
+		}, v1alpha1.GameServerSetStatus{ReadyReplicas: 1, Replicas: 3, ShuttingDownReplicas: 2}},
+		{[]*v1alpha1.GameServer{
+			gsWithState(v1alpha1.GameServerStateReady),
+			gsWithState(v1alpha1.GameServerStateReserved),
+			gsWithState(v1alpha1.GameServerStateShutdown),
+		}, v1alpha1.GameServerSetStatus{ReadyReplicas: 1, ReservedReplicas: 1, ShutdownReplicas: 1, Replicas: 3}},
 	}
 
 	for _, tc := range cases {
@@ -244,6 +386,31 @@ func TestControllerWatchGameServers(t *testing.T) {
 	gsSetWatch.Modify(gsSetCopy)
 	assert.Equal(t, expected, f())
 
+	// gsSet update that doesn't touch replicas
+	logrus.Info("modify gsSet scheduling")
+	gsSetCopy = gsSetCopy.DeepCopy()
+	gsSetCopy.Spec.Scheduling = v1alpha1.Distributed
+	gsSetWatch.Modify(gsSetCopy)
+	assert.Equal(t, expected, f())
+
+	// gsSet metadata only update
+	logrus.Info("modify gsSet labels")
+	gsSetCopy = gsSetCopy.DeepCopy()
+	gsSetCopy.ObjectMeta.Labels = map[string]string{"new": "label"}
+	gsSetWatch.Modify(gsSetCopy)
+	assert.Equal(t, expected, f())
+
+	// gsSet status only update
+	logrus.Info("modify gsSet status - noop")
+	gsSetCopy = gsSetCopy.DeepCopy()
+	gsSetCopy.Status.ReadyReplicas = 3
+	gsSetWatch.Modify(gsSetCopy)
+	select {
+	case <-received:
+		assert.Fail(t, "Should be no value")
+	case <-time.After(time.Second):
+	}
+
 	gs := gsSet.GameServer()
 	gs.ObjectMeta.Name = "test-gs"
 	// gs add
@@ -322,6 +489,81 @@ func TestSyncGameServerSet(t *testing.T) {
 		assert.True(t, updated, "A game servers should have been updated")
 	})
 
+	t.Run("replacing gameservers from an older template", func(t *testing.T) {
+		gsSet := defaultFixture()
+		gsSet.ObjectMeta.Annotations = map[string]string{v1alpha1.AllowTemplateUpdateAnnotation: "true"}
+		gsSet.Spec.Replicas = 5
+		list := createGameServers(gsSet, 5)
+
+		// two are from an older template, but one of those is Allocated, so it stays
+		list[0].ObjectMeta.Annotations[v1alpha1.GameServerTemplateHashAnnotation] = "old"
+		list[1].ObjectMeta.Annotations[v1alpha1.GameServerTemplateHashAnnotation] = "old"
+		list[1].Status.State = v1alpha1.GameServerStateAllocated
+
+		var shutdown []string
+
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("list", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerSetList{Items: []v1alpha1.GameServerSet{*gsSet}}, nil
+		})
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerList{Items: list}, nil
+		})
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+			assert.Equal(t, v1alpha1.GameServerStateShutdown, gs.Status.State)
+			shutdown = append(shutdown, gs.GetName())
+			return true, nil, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.gameServerSetSynced, c.gameServerSynced)
+		defer cancel()
+
+		c.syncGameServerSet(gsSet.ObjectMeta.Namespace + "/" + gsSet.ObjectMeta.Name) // nolint: errcheck
+
+		assert.Equal(t, []string{"test-0"}, shutdown)
+	})
+
+	t.Run("paused gameserverset only updates status", func(t *testing.T) {
+		gsSet := defaultFixture()
+		gsSet.Spec.Paused = true
+		list := createGameServers(gsSet, 5)
+
+		statusUpdated := false
+
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("list", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerSetList{Items: []v1alpha1.GameServerSet{*gsSet}}, nil
+		})
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.GameServerList{Items: list}, nil
+		})
+		m.AgonesClient.AddReactor("create", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "gameservers should not be created while paused")
+			return true, nil, nil
+		})
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "gameservers should not be updated while paused")
+			return true, nil, nil
+		})
+		m.AgonesClient.AddReactor("update", "gameserversets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			gsSet := ua.GetObject().(*v1alpha1.GameServerSet)
+			assert.Equal(t, int32(5), gsSet.Status.ReadyReplicas)
+
+			statusUpdated = true
+			return true, gsSet, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.gameServerSetSynced, c.gameServerSynced)
+		defer cancel()
+
+		c.syncGameServerSet(gsSet.ObjectMeta.Namespace + "/" + gsSet.ObjectMeta.Name) // nolint: errcheck
+
+		assert.True(t, statusUpdated, "status should have been updated")
+	})
+
 	t.Run("removing gamservers", func(t *testing.T) {
 		gsSet := defaultFixture()
 		list := createGameServers(gsSet, 15)
@@ -387,6 +629,258 @@ func TestControllerSyncUnhealthyGameServers(t *testing.T) {
 	assert.Equal(t, 3, updatedCount, "Updates should have occured")
 }
 
+func TestControllerReconcileAllocationOverflow(t *testing.T) {
+	t.Parallel()
+
+	gsSet := defaultFixture()
+	gsSet.Spec.Replicas = 1
+	gsSet.Spec.AllocationOverflow = &v1alpha1.AllocationOverflow{
+		Labels: map[string]string{"drain": "true"},
+	}
+
+	var list []*v1alpha1.GameServer
+	for i, name := range []string{"test-1", "test-2", "test-3"} {
+		gs := gsSet.GameServer()
+		gs.ObjectMeta.Name = name
+		gs.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(i) * time.Minute))
+		gs.Status = v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateAllocated}
+		list = append(list, gs)
+	}
+	// test-1 has already been marked, so only the newest unmarked GameServer
+	// should be updated to cover the overflow of two
+	list[0].ObjectMeta.Labels["drain"] = "true"
+
+	var updated []string
+
+	c, m := newFakeController()
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*v1alpha1.GameServer)
+
+		assert.Equal(t, "true", gs.ObjectMeta.Labels["drain"])
+		updated = append(updated, gs.ObjectMeta.Name)
+		return true, gs, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m)
+	defer cancel()
+
+	err := c.reconcileAllocationOverflow(gsSet, list)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"test-3"}, updated)
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "AllocationOverflow")
+}
+
+func TestControllerReconcileGameServerMetadata(t *testing.T) {
+	t.Parallel()
+
+	gsSet := defaultFixture()
+	gsSet.Spec.Template.ObjectMeta.Labels = map[string]string{"version": "1.1"}
+	gsSet.Spec.Template.ObjectMeta.Annotations = map[string]string{"owner": "team-a"}
+
+	var list []*v1alpha1.GameServer
+	for i := 0; i < 3; i++ {
+		gs := gsSet.GameServer()
+		gs.ObjectMeta.Name = "test-" + strconv.Itoa(i)
+		gs.Status.State = v1alpha1.GameServerStateReady
+		list = append(list, gs)
+	}
+	// test-0 was created before the metadata change
+	list[0].ObjectMeta.Labels = map[string]string{v1alpha1.GameServerSetGameServerLabel: gsSet.ObjectMeta.Name}
+	list[0].ObjectMeta.Annotations = nil
+	// test-1 is on its way out, and should be left alone
+	list[1].ObjectMeta.Labels["version"] = "1.0"
+	list[1].Status.State = v1alpha1.GameServerStateShutdown
+
+	var updated []string
+
+	c, m := newFakeController()
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*v1alpha1.GameServer)
+
+		assert.Equal(t, "1.1", gs.ObjectMeta.Labels["version"])
+		assert.Equal(t, "team-a", gs.ObjectMeta.Annotations["owner"])
+		updated = append(updated, gs.ObjectMeta.Name)
+		return true, gs, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m)
+	defer cancel()
+
+	err := c.reconcileGameServerMetadata(gsSet, list)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"test-0"}, updated)
+}
+
+func TestControllerReconcileFlavors(t *testing.T) {
+	t.Parallel()
+
+	template := func(image string) v1alpha1.GameServerTemplateSpec {
+		return v1alpha1.GameServerTemplateSpec{
+			Spec: v1alpha1.GameServerSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "game", Image: image}}},
+				},
+			},
+		}
+	}
+
+	gsSet := defaultFixture()
+	gsSet.Spec.Replicas = 4
+	gsSet.Spec.Flavors = []v1alpha1.Flavor{
+		{Name: "small", Weight: 1, Template: template("game:small")},
+		{Name: "large", Weight: 1, Template: template("game:large")},
+	}
+
+	// four small and no large - two small should be shut down, newest first,
+	// so large replacements can be created
+	var list []*v1alpha1.GameServer
+	for i := 0; i < 4; i++ {
+		gs := gsSet.FlavorGameServer(&gsSet.Spec.Flavors[0])
+		gs.ObjectMeta.Name = "small-" + strconv.Itoa(i)
+		gs.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(i) * time.Minute))
+		gs.Status.State = v1alpha1.GameServerStateReady
+		list = append(list, gs)
+	}
+	// the oldest is Allocated, and must not be touched even when in excess
+	list[0].Status.State = v1alpha1.GameServerStateAllocated
+
+	var deleted []string
+
+	c, m := newFakeController()
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*v1alpha1.GameServer)
+
+		assert.Equal(t, v1alpha1.GameServerStateShutdown, gs.Status.State)
+		deleted = append(deleted, gs.ObjectMeta.Name)
+		return true, gs, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m)
+	defer cancel()
+
+	err := c.reconcileFlavors(gsSet, list)
+	assert.Nil(t, err)
+	sort.Strings(deleted)
+	assert.Equal(t, []string{"small-2", "small-3"}, deleted)
+
+	// new GameServers fill the flavors with the largest deficit first
+	var images []string
+	for gs := range newGameServersChannel(2, gsSet, list[:2]) {
+		assert.NotEmpty(t, gs.ObjectMeta.Labels[v1alpha1.GameServerFlavorLabel])
+		images = append(images, gs.Spec.Template.Spec.Containers[0].Image)
+	}
+	assert.Equal(t, []string{"game:large", "game:large"}, images)
+}
+
+func TestControllerReconcileDrainStrategy(t *testing.T) {
+	t.Parallel()
+
+	newFixtures := func() (*v1alpha1.GameServerSet, []*v1alpha1.GameServer) {
+		gsSet := defaultFixture()
+		gsSet.Spec.Replicas = 1
+		gsSet.Spec.DrainStrategy = &v1alpha1.DrainStrategy{TimeoutSeconds: 300}
+
+		var list []*v1alpha1.GameServer
+		for i, name := range []string{"test-1", "test-2", "test-3"} {
+			gs := gsSet.GameServer()
+			gs.ObjectMeta.Name = name
+			gs.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(i) * time.Minute))
+			gs.Status = v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateAllocated}
+			list = append(list, gs)
+		}
+		return gsSet, list
+	}
+
+	t.Run("mark excess Allocated gameservers", func(t *testing.T) {
+		gsSet, list := newFixtures()
+		// test-1 is already draining, so only the newest unmarked GameServer
+		// should be updated to cover the overflow of two
+		gsSet.Spec.DrainStrategy.StartDraining(list[0], time.Now())
+
+		var updated []string
+
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+
+			assert.Equal(t, "true", gs.ObjectMeta.Labels[v1alpha1.GameServerDrainingLabel])
+			assert.NotEmpty(t, gs.ObjectMeta.Annotations[v1alpha1.DrainStartAnnotation])
+			updated = append(updated, gs.ObjectMeta.Name)
+			return true, gs, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m)
+		defer cancel()
+
+		requeue, err := c.reconcileDrainStrategy(gsSet, list)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"test-3"}, updated)
+		assert.True(t, requeue > 0, "should requeue to enforce the timeout")
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "DrainingGameServer")
+	})
+
+	t.Run("delete on return to Ready or timeout", func(t *testing.T) {
+		gsSet, list := newFixtures()
+		gsSet.Spec.Replicas = 0
+		// test-1 returned to Ready, test-2 has been draining past the timeout,
+		// test-3 is still mid-session
+		gsSet.Spec.DrainStrategy.StartDraining(list[0], time.Now())
+		list[0].Status.State = v1alpha1.GameServerStateReady
+		gsSet.Spec.DrainStrategy.StartDraining(list[1], time.Now().Add(-10*time.Minute))
+		gsSet.Spec.DrainStrategy.StartDraining(list[2], time.Now())
+
+		var deleted []string
+
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+
+			assert.Equal(t, v1alpha1.GameServerStateShutdown, gs.Status.State)
+			deleted = append(deleted, gs.ObjectMeta.Name)
+			return true, gs, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m)
+		defer cancel()
+
+		requeue, err := c.reconcileDrainStrategy(gsSet, list)
+		assert.Nil(t, err)
+		sort.Strings(deleted)
+		assert.Equal(t, []string{"test-1", "test-2"}, deleted)
+		assert.True(t, requeue > 0, "test-3's timeout is still pending")
+	})
+
+	t.Run("unmark when the scale down is reversed", func(t *testing.T) {
+		gsSet, list := newFixtures()
+		gsSet.Spec.Replicas = 5
+		gsSet.Spec.DrainStrategy.StartDraining(list[1], time.Now())
+
+		var updated []string
+
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*v1alpha1.GameServer)
+
+			assert.NotContains(t, gs.ObjectMeta.Labels, v1alpha1.GameServerDrainingLabel)
+			updated = append(updated, gs.ObjectMeta.Name)
+			return true, gs, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m)
+		defer cancel()
+
+		_, err := c.reconcileDrainStrategy(gsSet, list)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"test-2"}, updated)
+	})
+}
+
 func TestSyncMoreGameServers(t *testing.T) {
 	gsSet := defaultFixture()
 
@@ -407,17 +901,227 @@ func TestSyncMoreGameServers(t *testing.T) {
 	_, cancel := agtesting.StartInformers(m)
 	defer cancel()
 
-	err := c.addMoreGameServers(gsSet, expected)
+	err := c.addMoreGameServers(gsSet, nil, expected)
 	assert.Nil(t, err)
 	assert.Equal(t, expected, count)
 	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "SuccessfulCreate")
 }
 
+func TestNewGameServersChannelSpreadsArchitectures(t *testing.T) {
+	t.Parallel()
+
+	gsSet := defaultFixture()
+	gsSet.Spec.Template.Spec = v1alpha1.GameServerSpec{
+		Container: "game",
+		Architectures: []v1alpha1.ArchitectureSpec{
+			{Arch: "amd64", Image: "game:amd64"},
+			{Arch: "arm64", Image: "game:arm64"},
+		},
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "game", Image: "game"}}}},
+	}
+
+	images := map[string]int{}
+	for gs := range newGameServersChannel(4, gsSet, nil) {
+		arch := gs.Architecture()
+		assert.NotEmpty(t, arch)
+		images[gs.Spec.Template.Spec.Containers[0].Image]++
+		assert.Equal(t, "game:"+arch, gs.Spec.Template.Spec.Containers[0].Image)
+	}
+
+	assert.Equal(t, map[string]int{"game:amd64": 2, "game:arm64": 2}, images)
+
+	// a template that pins an architecture is left alone
+	gsSet.Spec.Template.Spec.Template.Spec.NodeSelector = map[string]string{v1alpha1.NodeArchitectureLabel: "arm64"}
+	for gs := range newGameServersChannel(2, gsSet, nil) {
+		assert.Equal(t, "arm64", gs.Architecture())
+	}
+}
+
+func TestControllerCapAddToQuotaHeadroom(t *testing.T) {
+	t.Parallel()
+
+	quota := func(hard, used int64) corev1.ResourceQuota {
+		return corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(hard, resource.DecimalSI)},
+				Used: corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(used, resource.DecimalSI)},
+			},
+		}
+	}
+
+	t.Run("quota caps the batch", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+		m.KubeClient.AddReactor("list", "resourcequotas", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &corev1.ResourceQuotaList{Items: []corev1.ResourceQuota{quota(10, 7)}}, nil
+		})
+
+		assert.Equal(t, 3, c.capAddToQuotaHeadroom(gsSet, 5))
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScaleUpLimited")
+	})
+
+	t.Run("enough headroom", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+		m.KubeClient.AddReactor("list", "resourcequotas", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &corev1.ResourceQuotaList{Items: []corev1.ResourceQuota{quota(100, 7)}}, nil
+		})
+
+		assert.Equal(t, 5, c.capAddToQuotaHeadroom(gsSet, 5))
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+
+	t.Run("no pod quota in the namespace", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+
+		assert.Equal(t, 5, c.capAddToQuotaHeadroom(gsSet, 5))
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+}
+
+func TestControllerCapAddToGameServerLimits(t *testing.T) {
+	t.Parallel()
+
+	existing := func(count int, fleet string) *v1alpha1.GameServerList {
+		list := &v1alpha1.GameServerList{}
+		for i := 0; i < count; i++ {
+			gs := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "gs-" + strconv.Itoa(i), Namespace: "default"}}
+			if fleet != "" {
+				gs.ObjectMeta.Labels = map[string]string{v1alpha1.FleetNameLabel: fleet}
+			}
+			list.Items = append(list.Items, gs)
+		}
+		return list
+	}
+
+	t.Run("namespace limit caps the batch", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+		c.RegisterGameServerLimits(10, 0)
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, existing(7, ""), nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.gameServerSynced)
+		defer cancel()
+
+		assert.Equal(t, 3, c.capAddToGameServerLimits(gsSet, 5))
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScaleUpLimited")
+	})
+
+	t.Run("fleet limit caps the batch", func(t *testing.T) {
+		gsSet := defaultFixture()
+		gsSet.ObjectMeta.Labels = map[string]string{v1alpha1.FleetNameLabel: "fleet-1"}
+		c, m := newFakeController()
+		c.RegisterGameServerLimits(0, 8)
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, existing(7, "fleet-1"), nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.gameServerSynced)
+		defer cancel()
+
+		assert.Equal(t, 1, c.capAddToGameServerLimits(gsSet, 5))
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScaleUpLimited")
+	})
+
+	t.Run("no limits configured", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, existing(100, ""), nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.gameServerSynced)
+		defer cancel()
+
+		assert.Equal(t, 5, c.capAddToGameServerLimits(gsSet, 5))
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+}
+
+func TestControllerRecordScaleEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records a scaling decision", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+
+		var created *v1alpha1.ScaleEvent
+		m.AgonesClient.AddReactor("create", "scaleevents", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			created = action.(k8stesting.CreateAction).GetObject().(*v1alpha1.ScaleEvent)
+			return true, created, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.scaleEventSynced)
+		defer cancel()
+
+		err := c.recordScaleEvent(gsSet, 3)
+		assert.Nil(t, err)
+		if assert.NotNil(t, created) {
+			assert.Equal(t, gsSet.ObjectMeta.Name, created.Spec.GameServerSetName)
+			assert.Equal(t, int32(10), created.Spec.DesiredReplicas)
+			assert.Equal(t, int32(3), created.Spec.ObservedReplicas)
+			assert.Equal(t, gsSet.ObjectMeta.Name, created.ObjectMeta.Labels[v1alpha1.ScaleEventGameServerSetLabel])
+			assert.True(t, metav1.IsControlledBy(created, gsSet))
+		}
+		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScaleEventRecorded")
+	})
+
+	t.Run("does not repeat the same decision", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+
+		existing := &v1alpha1.ScaleEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-abcd", Namespace: "default",
+				Labels: map[string]string{v1alpha1.ScaleEventGameServerSetLabel: gsSet.ObjectMeta.Name},
+			},
+			Spec: v1alpha1.ScaleEventSpec{GameServerSetName: gsSet.ObjectMeta.Name, DesiredReplicas: 10, ObservedReplicas: 3},
+		}
+		m.AgonesClient.AddReactor("list", "scaleevents", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.ScaleEventList{Items: []v1alpha1.ScaleEvent{*existing}}, nil
+		})
+		m.AgonesClient.AddReactor("create", "scaleevents", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "ScaleEvent should not be created")
+			return true, nil, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.scaleEventSynced)
+		defer cancel()
+
+		err := c.recordScaleEvent(gsSet, 7)
+		assert.Nil(t, err)
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+
+	t.Run("steady state is not recorded", func(t *testing.T) {
+		gsSet := defaultFixture()
+		c, m := newFakeController()
+
+		m.AgonesClient.AddReactor("create", "scaleevents", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			assert.FailNow(t, "ScaleEvent should not be created")
+			return true, nil, nil
+		})
+
+		_, cancel := agtesting.StartInformers(m, c.scaleEventSynced)
+		defer cancel()
+
+		err := c.recordScaleEvent(gsSet, gsSet.Spec.Replicas)
+		assert.Nil(t, err)
+		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	})
+}
+
 func TestControllerSyncGameServerSetStatus(t *testing.T) {
 	t.Parallel()
 
 	t.Run("all ready list", func(t *testing.T) {
 		gsSet := defaultFixture()
+		gsSet.ObjectMeta.Generation = 2
 		c, m := newFakeController()
 
 		updated := false
@@ -429,6 +1133,8 @@ func TestControllerSyncGameServerSetStatus(t *testing.T) {
 			assert.Equal(t, int32(1), gsSet.Status.Replicas)
 			assert.Equal(t, int32(1), gsSet.Status.ReadyReplicas)
 			assert.Equal(t, int32(0), gsSet.Status.AllocatedReplicas)
+			assert.Equal(t, v1alpha1.GameServerSetGameServerLabel+"="+gsSet.ObjectMeta.Name, gsSet.Status.LabelSelector)
+			assert.Equal(t, int64(2), gsSet.Status.ObservedGeneration)
 
 			return true, nil, nil
 		})
@@ -543,6 +1249,137 @@ func TestControllerUpdateValidationHandler(t *testing.T) {
 	})
 }
 
+func TestControllerCreationValidationHandler(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newFakeController()
+	gvk := metav1.GroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("GameServerSet"))
+
+	newReview := func(gsSet *v1alpha1.GameServerSet) admv1beta1.AdmissionReview {
+		raw, err := json.Marshal(gsSet)
+		assert.Nil(t, err)
+
+		return admv1beta1.AdmissionReview{
+			Request: &admv1beta1.AdmissionRequest{
+				Kind:      gvk,
+				Operation: admv1beta1.Create,
+				Object: runtime.RawExtension{
+					Raw: raw,
+				},
+			},
+			Response: &admv1beta1.AdmissionResponse{Allowed: true},
+		}
+	}
+
+	t.Run("valid gameserverset create", func(t *testing.T) {
+		gsSet := defaultFixture()
+		gsSet.Spec.Template = v1alpha1.GameServerTemplateSpec{
+			Spec: v1alpha1.GameServerSpec{
+				Ports: []v1alpha1.GameServerPort{{Name: "main", PortPolicy: v1alpha1.Dynamic, ContainerPort: 7777}},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "container", Image: "container/image"}}},
+				},
+			},
+		}
+
+		result, err := c.creationValidationHandler(newReview(gsSet))
+		assert.Nil(t, err)
+		assert.True(t, result.Response.Allowed)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		gsSet := defaultFixture()
+		gsSet.Spec.Template = v1alpha1.GameServerTemplateSpec{
+			Spec: v1alpha1.GameServerSpec{
+				Container: "missing",
+				Ports:     []v1alpha1.GameServerPort{{Name: "main", PortPolicy: v1alpha1.Dynamic, ContainerPort: 7777}},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{
+						{Name: "container", Image: "container/image"},
+						{Name: "sidekick", Image: "sidekick/image"},
+					}},
+				},
+			},
+		}
+
+		result, err := c.creationValidationHandler(newReview(gsSet))
+		assert.Nil(t, err)
+		assert.False(t, result.Response.Allowed)
+		assert.Equal(t, metav1.StatusFailure, result.Response.Result.Status)
+		assert.Equal(t, metav1.StatusReasonInvalid, result.Response.Result.Reason)
+	})
+
+	t.Run("static ports with multiple replicas", func(t *testing.T) {
+		gsSet := defaultFixture()
+		gsSet.Spec.Template = v1alpha1.GameServerTemplateSpec{
+			Spec: v1alpha1.GameServerSpec{
+				Ports: []v1alpha1.GameServerPort{{Name: "main", PortPolicy: v1alpha1.Static, HostPort: 7777, ContainerPort: 7777}},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "container", Image: "container/image"}}},
+				},
+			},
+		}
+
+		result, err := c.creationValidationHandler(newReview(gsSet))
+		assert.Nil(t, err)
+		assert.False(t, result.Response.Allowed)
+	})
+}
+
+func TestControllerUpdateMutationHandler(t *testing.T) {
+	t.Parallel()
+
+	c, _ := newFakeController()
+	gvk := metav1.GroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("GameServerSet"))
+	fixture := &v1alpha1.GameServerSet{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1alpha1.GameServerSetSpec{Replicas: 5},
+	}
+	raw, err := json.Marshal(fixture)
+	assert.Nil(t, err)
+
+	newReview := func(new *v1alpha1.GameServerSet) admv1beta1.AdmissionReview {
+		newRaw, err := json.Marshal(new)
+		assert.Nil(t, err)
+
+		return admv1beta1.AdmissionReview{
+			Request: &admv1beta1.AdmissionRequest{
+				Kind:      gvk,
+				Operation: admv1beta1.Update,
+				UserInfo:  authv1.UserInfo{Username: "scaler@example.com"},
+				Object: runtime.RawExtension{
+					Raw: newRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: raw,
+				},
+			},
+			Response: &admv1beta1.AdmissionResponse{Allowed: true},
+		}
+	}
+
+	t.Run("scale change is recorded", func(t *testing.T) {
+		new := fixture.DeepCopy()
+		new.Spec.Replicas = 0
+
+		result, err := c.updateMutationHandler(newReview(new))
+		assert.Nil(t, err)
+		assert.True(t, result.Response.Allowed)
+		assert.NotNil(t, result.Response.PatchType)
+
+		patch := string(result.Response.Patch)
+		assert.Contains(t, patch, v1alpha1.LastScaleAnnotation)
+		assert.Contains(t, patch, "scaler@example.com")
+		assert.Contains(t, patch, `\"previousReplicas\":5`)
+	})
+
+	t.Run("no scale change, no patch", func(t *testing.T) {
+		result, err := c.updateMutationHandler(newReview(fixture.DeepCopy()))
+		assert.Nil(t, err)
+		assert.True(t, result.Response.Allowed)
+		assert.Nil(t, result.Response.Patch)
+	})
+}
+
 // defaultFixture creates the default GameServerSet fixture
 func defaultFixture() *v1alpha1.GameServerSet {
 	gsSet := &v1alpha1.GameServerSet{
@@ -572,7 +1409,7 @@ func createGameServers(gsSet *v1alpha1.GameServerSet, size int) []v1alpha1.GameS
 func newFakeController() (*Controller, agtesting.Mocks) {
 	m := agtesting.NewMocks()
 	wh := webhooks.NewWebHook("", "")
-	c := NewController(wh, healthcheck.NewHandler(), m.KubeClient, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
+	c := NewController(wh, healthcheck.NewHandler(), 0, 0, 0, m.KubeClient, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
 	c.recorder = m.FakeRecorder
 	return c, m
 }
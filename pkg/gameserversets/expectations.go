@@ -0,0 +1,117 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserversets
+
+import (
+	"sync"
+	"time"
+)
+
+// expectationsTimeout is how long unobserved expectations are honoured
+// before a sync is allowed to proceed anyway, in case a watch event was
+// dropped
+const expectationsTimeout = 3 * time.Minute
+
+// expectationsEntry records the creations and deletions a single
+// GameServerSet's syncs have issued but not yet seen back through the
+// informer. Creations are counted, since GenerateName means their names
+// aren't known until the API server answers; deletions are tracked by name
+type expectationsEntry struct {
+	mu        sync.Mutex
+	creations int
+	deletions map[string]bool
+	raised    time.Time
+}
+
+// expectations tracks in flight creations and deletions per GameServerSet,
+// in the manner of ReplicaSet's controller expectations, so a sync working
+// from a list the informer hasn't caught up on defers instead of issuing
+// duplicate creations or deletions
+type expectations struct {
+	cache sync.Map
+}
+
+func (e *expectations) forKey(key string) *expectationsEntry {
+	v, _ := e.cache.LoadOrStore(key, &expectationsEntry{})
+	return v.(*expectationsEntry)
+}
+
+// expectCreations records that count creations have been issued and are
+// awaited back through the informer
+func (e *expectations) expectCreations(key string, count int) {
+	entry := e.forKey(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.creations += count
+	entry.raised = time.Now()
+}
+
+// creationObserved records that one awaited creation has been seen, or that
+// an issued creation failed and will never arrive
+func (e *expectations) creationObserved(key string) {
+	entry := e.forKey(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.creations > 0 {
+		entry.creations--
+	}
+}
+
+// expectDeletions records that the named GameServers have been scheduled for
+// removal, awaited back through the informer as a Shutdown or delete event
+func (e *expectations) expectDeletions(key string, names []string) {
+	entry := e.forKey(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.deletions == nil {
+		entry.deletions = map[string]bool{}
+	}
+	for _, name := range names {
+		entry.deletions[name] = true
+	}
+	entry.raised = time.Now()
+}
+
+// deletionObserved records that the named GameServer's removal has been seen
+func (e *expectations) deletionObserved(key, name string) {
+	entry := e.forKey(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	delete(entry.deletions, name)
+}
+
+// satisfied returns true if there are no outstanding creations or deletions
+// for the given GameServerSet, or the outstanding ones have gone unobserved
+// for longer than expectationsTimeout
+func (e *expectations) satisfied(key string) bool {
+	entry := e.forKey(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.creations <= 0 && len(entry.deletions) == 0 {
+		return true
+	}
+	if time.Since(entry.raised) > expectationsTimeout {
+		entry.creations = 0
+		entry.deletions = nil
+		return true
+	}
+	return false
+}
+
+// delete drops the expectations for a GameServerSet that has been removed
+func (e *expectations) delete(key string) {
+	e.cache.Delete(key)
+}
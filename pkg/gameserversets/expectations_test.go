@@ -0,0 +1,65 @@
+package gameserversets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectations(t *testing.T) {
+	var e expectations
+	key := "ns1/set-1"
+
+	assert.True(t, e.satisfied(key), "a key with no expectations should be satisfied")
+
+	e.expectCreations(key, 2)
+	assert.False(t, e.satisfied(key))
+	e.creationObserved(key)
+	assert.False(t, e.satisfied(key))
+	e.creationObserved(key)
+	assert.True(t, e.satisfied(key))
+
+	// extra observations, such as from a failed create, never go negative
+	e.creationObserved(key)
+	e.expectCreations(key, 1)
+	assert.False(t, e.satisfied(key))
+	e.creationObserved(key)
+	assert.True(t, e.satisfied(key))
+
+	e.expectDeletions(key, []string{"gs-1", "gs-2"})
+	assert.False(t, e.satisfied(key))
+	e.deletionObserved(key, "gs-1")
+	assert.False(t, e.satisfied(key))
+	// unknown names are ignored
+	e.deletionObserved(key, "gs-3")
+	assert.False(t, e.satisfied(key))
+	e.deletionObserved(key, "gs-2")
+	assert.True(t, e.satisfied(key))
+
+	assert.True(t, e.satisfied("ns1/set-2"), "other keys are unaffected")
+}
+
+func TestExpectationsTimeout(t *testing.T) {
+	var e expectations
+	key := "ns1/set-1"
+
+	e.expectCreations(key, 1)
+	e.expectDeletions(key, []string{"gs-1"})
+	assert.False(t, e.satisfied(key))
+
+	// wind back the clock as if the watch events were dropped
+	e.forKey(key).raised = time.Now().Add(-expectationsTimeout - time.Second)
+	assert.True(t, e.satisfied(key), "expired expectations should be dropped")
+	assert.True(t, e.satisfied(key))
+}
+
+func TestExpectationsDelete(t *testing.T) {
+	var e expectations
+	key := "ns1/set-1"
+
+	e.expectCreations(key, 1)
+	assert.False(t, e.satisfied(key))
+	e.delete(key)
+	assert.True(t, e.satisfied(key))
+}
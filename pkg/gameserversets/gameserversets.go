@@ -81,17 +81,21 @@ func filterGameServersOnLeastFullNodes(gsList []*v1alpha1.GameServer, limit int3
 	return result
 }
 
-// ListGameServersByGameServerSetOwner lists the GameServers for a given GameServerSet
+// ListGameServersByGameServerSetOwner lists the GameServers for a given GameServerSet.
+// The list is scoped to the GameServerSet's namespace, so the cache's namespace
+// index is used rather than scanning every GameServer in the cluster.
+// GameServers marked as unmanaged are excluded, so scaling never touches them
 func ListGameServersByGameServerSetOwner(gameServerLister listerv1alpha1.GameServerLister,
 	gsSet *v1alpha1.GameServerSet) ([]*v1alpha1.GameServer, error) {
-	list, err := gameServerLister.List(labels.SelectorFromSet(labels.Set{v1alpha1.GameServerSetGameServerLabel: gsSet.ObjectMeta.Name}))
+	list, err := gameServerLister.GameServers(gsSet.ObjectMeta.Namespace).
+		List(labels.SelectorFromSet(labels.Set{v1alpha1.GameServerSetGameServerLabel: gsSet.ObjectMeta.Name}))
 	if err != nil {
 		return list, errors.Wrapf(err, "error listing gameservers for gameserverset %s", gsSet.ObjectMeta.Name)
 	}
 
 	var result []*v1alpha1.GameServer
 	for _, gs := range list {
-		if metav1.IsControlledBy(gs, gsSet) {
+		if metav1.IsControlledBy(gs, gsSet) && !gs.IsUnmanaged() {
 			result = append(result, gs)
 		}
 	}
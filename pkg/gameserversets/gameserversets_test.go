@@ -83,10 +83,16 @@ func TestListGameServersByGameServerSetOwner(t *testing.T) {
 	gs3 := v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "not-included"}}
 	gs4 := gsSet.GameServer()
 	gs4.ObjectMeta.OwnerReferences = nil
+	gs5 := gsSet.GameServer()
+	gs5.ObjectMeta.Name = "other-namespace"
+	gs5.ObjectMeta.Namespace = "other"
+	gs6 := gsSet.GameServer()
+	gs6.ObjectMeta.Name = "unmanaged"
+	gs6.ObjectMeta.Annotations = map[string]string{v1alpha1.UnmanagedAnnotation: "true"}
 
 	m := agtesting.NewMocks()
 	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
-		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*gs1, *gs2, gs3, *gs4}}, nil
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*gs1, *gs2, gs3, *gs4, *gs5, *gs6}}, nil
 	})
 
 	gameServers := m.AgonesInformerFactory.Stable().V1alpha1().GameServers()
@@ -0,0 +1,109 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// alertRule is a single recommended Prometheus alerting rule
+type alertRule struct {
+	alert    string
+	expr     string
+	duration string
+	severity string
+	summary  string
+}
+
+// alertRules are the recommended alerting rules for the golden signals of
+// the controller. They are defined here, next to the metric definitions, so
+// alerting stays aligned with metric names and label semantics as they
+// evolve, and are exported with the controller's --dump-alert-rules flag
+var alertRules = []alertRule{
+	{
+		alert:    "AgonesControllerMetricsAbsent",
+		expr:     `absent(agones_gameservers_count)`,
+		duration: "5m",
+		severity: "critical",
+		summary:  "The Agones controller is not reporting metrics - it has likely crashed or reconciliation has stalled",
+	},
+	{
+		alert:    "AgonesGameServersUnhealthy",
+		expr:     `sum(agones_gameservers_count{type="Unhealthy"}) > 0`,
+		duration: "5m",
+		severity: "warning",
+		summary:  "GameServers are failing their health checks",
+	},
+	{
+		alert:    "AgonesGameServersStuckPortAllocation",
+		expr:     `sum(agones_gameservers_count{type="PortAllocation"}) > 0`,
+		duration: "10m",
+		severity: "critical",
+		summary:  "GameServers cannot be assigned ports - the configured host port range is likely exhausted",
+	},
+	{
+		alert:    "AgonesAllocationErrors",
+		expr:     `sum(rate(agones_gameserver_allocations_latency_count{result="error"}[5m])) > 0`,
+		duration: "5m",
+		severity: "critical",
+		summary:  "GameServer allocations are failing with errors",
+	},
+	{
+		alert:    "AgonesAllocationStarved",
+		expr:     `sum(rate(agones_gameserver_allocations_latency_count{result="unallocated"}[5m])) > 0`,
+		duration: "10m",
+		severity: "warning",
+		summary:  "Allocation requests are finding no Ready GameServers - fleets may be scaled too low",
+	},
+	{
+		alert:    "AgonesAllocationLatencyHigh",
+		expr:     `histogram_quantile(0.99, sum(rate(agones_gameserver_allocations_latency_bucket[5m])) by (le)) > 1000`,
+		duration: "10m",
+		severity: "warning",
+		summary:  "The 99th percentile of GameServer allocation latency is over a second",
+	},
+	{
+		alert:    "AgonesFleetAutoscalerUnableToScale",
+		expr:     `min(agones_fleet_autoscalers_able_to_scale) == 0`,
+		duration: "5m",
+		severity: "critical",
+		summary:  "A FleetAutoscaler cannot scale its Fleet",
+	},
+}
+
+// AlertRulesYAML renders the recommended alerting rules as a Prometheus
+// rules file that can be loaded directly, or wrapped in a PrometheusRule
+// resource when using the prometheus-operator
+func AlertRulesYAML() string {
+	b := &bytes.Buffer{}
+	fmt.Fprintln(b, "# Recommended Prometheus alerting rules for the Agones controller,")
+	fmt.Fprintln(b, "# generated by the controller with --dump-alert-rules")
+	fmt.Fprintln(b, "groups:")
+	fmt.Fprintln(b, "- name: agones.rules")
+	fmt.Fprintln(b, "  rules:")
+
+	for _, r := range alertRules {
+		fmt.Fprintf(b, "  - alert: %s\n", r.alert)
+		fmt.Fprintf(b, "    expr: %s\n", r.expr)
+		fmt.Fprintf(b, "    for: %s\n", r.duration)
+		fmt.Fprintln(b, "    labels:")
+		fmt.Fprintf(b, "      severity: %s\n", r.severity)
+		fmt.Fprintln(b, "    annotations:")
+		fmt.Fprintf(b, "      summary: %s\n", r.summary)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertRulesYAML(t *testing.T) {
+	t.Parallel()
+
+	y := AlertRulesYAML()
+	assert.Contains(t, y, "groups:")
+	assert.Contains(t, y, "- name: agones.rules")
+	for _, r := range alertRules {
+		assert.Contains(t, y, "- alert: "+r.alert)
+		assert.Contains(t, y, "expr: "+r.expr)
+		assert.Contains(t, y, "severity: "+r.severity)
+	}
+}
+
+// every metric an alert rule refers to must be a registered view, so the
+// rules can't drift from the metrics as they evolve
+func TestAlertRulesReferenceRegisteredViews(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]bool{}
+	for _, v := range stateViews {
+		names[v.Name] = true
+	}
+
+	re := regexp.MustCompile(`agones_([a-z_]+)`)
+	for _, r := range alertRules {
+		for _, m := range re.FindAllStringSubmatch(r.expr, -1) {
+			// distributions are exposed with _count and _bucket suffixes
+			name := strings.TrimSuffix(strings.TrimSuffix(m[1], "_bucket"), "_count")
+			if !names[name] && !names[m[1]] {
+				assert.Failf(t, "unknown metric", "alert %s refers to metric %s, which is not a registered view", r.alert, m[0])
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/tag"
+)
+
+// allocation results, for the result label on the allocation latency metric
+const (
+	// ResultAllocated is recorded when an allocation found a GameServer
+	ResultAllocated = "allocated"
+	// ResultUnAllocated is recorded when there was no GameServer to allocate
+	ResultUnAllocated = "unallocated"
+	// ResultError is recorded when an allocation failed with an error
+	ResultError = "error"
+)
+
+// RecordAllocationLatency records how long a gameserver allocation took
+// from the request being received to the response being written, labelled
+// by the fleet allocated from and the result
+func RecordAllocationLatency(fleetName string, result string, duration time.Duration) {
+	if fleetName == "" {
+		fleetName = "none"
+	}
+	recordWithTags(context.Background(), []tag.Mutator{
+		tag.Upsert(keyFleetName, fleetName), tag.Upsert(keyResult, result)},
+		gsAllocationLatencyStats.M(float64(duration)/float64(time.Millisecond)))
+}
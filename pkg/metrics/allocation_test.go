@@ -0,0 +1,45 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+)
+
+func TestRecordAllocationLatency(t *testing.T) {
+	RecordAllocationLatency("test-fleet", ResultAllocated, 50*time.Millisecond)
+	RecordAllocationLatency("", ResultUnAllocated, 10*time.Millisecond)
+
+	rows, err := view.RetrieveData("gameserver_allocations_latency")
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+
+	fleets := map[string]string{}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tg := range row.Tags {
+			tags[tg.Key.Name()] = tg.Value
+		}
+		fleets[tags["result"]] = tags["fleet_name"]
+	}
+
+	// an allocation without a fleet selector is recorded against "none"
+	assert.Equal(t, "test-fleet", fleets[ResultAllocated])
+	assert.Equal(t, "none", fleets[ResultUnAllocated])
+}
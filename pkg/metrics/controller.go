@@ -55,6 +55,7 @@ type Controller struct {
 	logger           *logrus.Entry
 	gameServerLister listerv1alpha1.GameServerLister
 	faLister         listerv1alpha1.FleetAllocationLister
+	fleetLister      listerv1alpha1.FleetLister
 	nodeLister       v1.NodeLister
 	gameServerSynced cache.InformerSynced
 	fleetSynced      cache.InformerSynced
@@ -64,6 +65,9 @@ type Controller struct {
 	lock             sync.Mutex
 	gsCount          GameServerCount
 	faCount          map[string]int64
+
+	podMetricsGetter      func() ([]podMetrics, error)
+	podMetricsUnavailable bool
 }
 
 // NewController returns a new metrics controller
@@ -89,6 +93,7 @@ func NewController(
 		gameServerLister: gameServer.Lister(),
 		nodeLister:       node.Lister(),
 		faLister:         fa.Lister(),
+		fleetLister:      fleets.Lister(),
 		gameServerSynced: gsInformer.HasSynced,
 		fleetSynced:      fInformer.HasSynced,
 		fasSynced:        fasInformer.HasSynced,
@@ -96,6 +101,7 @@ func NewController(
 		nodeSynced:       nodeInformer.HasSynced,
 		gsCount:          GameServerCount{},
 		faCount:          map[string]int64{},
+		podMetricsGetter: newPodMetricsGetter(kubeClient),
 	}
 
 	c.logger = runtime.NewLoggerWithType(c)
@@ -311,8 +317,52 @@ func (c *Controller) collect() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	c.collectGameServerCounts()
+	c.collectFleetAggregates()
 	c.collectFleetAllocationCounts()
 	c.collectNodeCounts()
+	c.collectGameServerResourceUsage()
+}
+
+// fleetRolloutState determines where a fleet is in its rollout - stable when
+// its replica counts have converged on the spec, progressing while a scale
+// or a rolling update is still working towards it
+func fleetRolloutState(f *stablev1alpha1.Fleet) string {
+	if f.Status.Replicas == f.Spec.Replicas &&
+		f.Status.ReadyReplicas+f.Status.AllocatedReplicas == f.Status.Replicas {
+		return "stable"
+	}
+	return "progressing"
+}
+
+// collects replica counts and rollout states summed across all fleets in all
+// namespaces, so platform operators get a single pane view of the cluster
+// without querying every namespace
+func (c *Controller) collectFleetAggregates() {
+	fleets, err := c.fleetLister.List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(err).Warn("failed listing fleets")
+		return
+	}
+
+	var total, allocated, ready, desired int64
+	states := map[string]int64{"stable": 0, "progressing": 0}
+	for _, f := range fleets {
+		total += int64(f.Status.Replicas)
+		allocated += int64(f.Status.AllocatedReplicas)
+		ready += int64(f.Status.ReadyReplicas)
+		desired += int64(f.Spec.Replicas)
+		states[fleetRolloutState(f)]++
+	}
+
+	counts := map[string]int64{"total": total, "allocated": allocated, "ready": ready, "desired": desired}
+	for t, count := range counts {
+		recordWithTags(context.Background(), []tag.Mutator{tag.Upsert(keyType, t)},
+			fleetsAggregateStats.M(count))
+	}
+	for state, count := range states {
+		recordWithTags(context.Background(), []tag.Mutator{tag.Upsert(keyRolloutState, state)},
+			fleetsRolloutCountStats.M(count))
+	}
 }
 
 // collects fleet allocations count by going through our informer cache
@@ -21,19 +21,24 @@ import (
 )
 
 var (
-	fleetsReplicasCountStats  = stats.Int64("fleets/replicas_count", "The count of replicas per fleet", "1")
-	fasBufferLimitsCountStats = stats.Int64("fas/buffer_limits", "The buffer limits of autoscalers", "1")
-	fasBufferSizeStats        = stats.Int64("fas/buffer_size", "The buffer size value of autoscalers", "1")
-	fasCurrentReplicasStats   = stats.Int64("fas/current_replicas_count", "The current replicas cout as seen by autoscalers", "1")
-	fasDesiredReplicasStats   = stats.Int64("fas/desired_replicas_count", "The desired replicas cout as seen by autoscalers", "1")
-	fasAbleToScaleStats       = stats.Int64("fas/able_to_scale", "The fleet autoscaler can access the fleet to scale (0 indicates false, 1 indicates true)", "1")
-	fasLimitedStats           = stats.Int64("fas/limited", "The fleet autoscaler is capped (0 indicates false, 1 indicates true)", "1")
-	gameServerCountStats      = stats.Int64("gameservers/count", "The count of gameservers", "1")
-	fleetAllocationCountStats = stats.Int64("fleet_allocations/count", "The count of fleet allocations", "1")
-	gameServerTotalStats      = stats.Int64("gameservers/total", "The total of gameservers", "1")
-	fleetAllocationTotalStats = stats.Int64("fleet_allocations/total", "The total of fleet allocations", "1")
-	nodesCountStats           = stats.Int64("nodes/count", "The count of nodes in the cluster", "1")
-	gsPerNodesCountStats      = stats.Int64("gameservers_node/count", "The count of gameservers per node in the cluster", "1")
+	fleetsReplicasCountStats   = stats.Int64("fleets/replicas_count", "The count of replicas per fleet", "1")
+	fleetsAggregateStats       = stats.Int64("fleets/aggregate_replicas_count", "The count of replicas across all fleets and namespaces", "1")
+	fleetsRolloutCountStats    = stats.Int64("fleets/rollout_count", "The count of fleets per rollout state", "1")
+	fasBufferLimitsCountStats  = stats.Int64("fas/buffer_limits", "The buffer limits of autoscalers", "1")
+	fasBufferSizeStats         = stats.Int64("fas/buffer_size", "The buffer size value of autoscalers", "1")
+	fasCurrentReplicasStats    = stats.Int64("fas/current_replicas_count", "The current replicas cout as seen by autoscalers", "1")
+	fasDesiredReplicasStats    = stats.Int64("fas/desired_replicas_count", "The desired replicas cout as seen by autoscalers", "1")
+	fasAbleToScaleStats        = stats.Int64("fas/able_to_scale", "The fleet autoscaler can access the fleet to scale (0 indicates false, 1 indicates true)", "1")
+	fasLimitedStats            = stats.Int64("fas/limited", "The fleet autoscaler is capped (0 indicates false, 1 indicates true)", "1")
+	gameServerCountStats       = stats.Int64("gameservers/count", "The count of gameservers", "1")
+	fleetAllocationCountStats  = stats.Int64("fleet_allocations/count", "The count of fleet allocations", "1")
+	gameServerTotalStats       = stats.Int64("gameservers/total", "The total of gameservers", "1")
+	fleetAllocationTotalStats  = stats.Int64("fleet_allocations/total", "The total of fleet allocations", "1")
+	nodesCountStats            = stats.Int64("nodes/count", "The count of nodes in the cluster", "1")
+	gsPerNodesCountStats       = stats.Int64("gameservers_node/count", "The count of gameservers per node in the cluster", "1")
+	gameServerCPUUsageStats    = stats.Int64("gameservers/cpu_usage", "The cpu usage of gameservers", "m")
+	gameServerMemoryUsageStats = stats.Int64("gameservers/memory_usage", "The memory usage of gameservers", "By")
+	gsAllocationLatencyStats   = stats.Float64("gameserver_allocations/latency", "The latency of gameserver allocations", "ms")
 
 	stateViews = []*view.View{
 		&view.View{
@@ -43,6 +48,20 @@ var (
 			Aggregation: view.LastValue(),
 			TagKeys:     []tag.Key{keyName, keyType},
 		},
+		&view.View{
+			Name:        "fleets_aggregate_replicas_count",
+			Measure:     fleetsAggregateStats,
+			Description: "The number of replicas across all fleets and namespaces",
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyType},
+		},
+		&view.View{
+			Name:        "fleets_rollout_count",
+			Measure:     fleetsRolloutCountStats,
+			Description: "The number of fleets in each rollout state across all namespaces",
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyRolloutState},
+		},
 		&view.View{
 			Name:        "fleet_autoscalers_buffer_limits",
 			Measure:     fasBufferLimitsCountStats,
@@ -120,6 +139,27 @@ var (
 			Aggregation: view.LastValue(),
 			TagKeys:     []tag.Key{keyEmpty},
 		},
+		&view.View{
+			Name:        "gameservers_cpu_usage",
+			Measure:     gameServerCPUUsageStats,
+			Description: "The cpu usage of gameservers in millicores, as reported by the metrics-server",
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyName, keyFleetName},
+		},
+		&view.View{
+			Name:        "gameservers_memory_usage",
+			Measure:     gameServerMemoryUsageStats,
+			Description: "The memory usage of gameservers in bytes, as reported by the metrics-server",
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyName, keyFleetName},
+		},
+		&view.View{
+			Name:        "gameserver_allocations_latency",
+			Measure:     gsAllocationLatencyStats,
+			Description: "The latency of gameserver allocations in milliseconds",
+			Aggregation: view.Distribution(0, 10, 25, 50, 100, 200, 500, 1000, 2000, 5000),
+			TagKeys:     []tag.Key{keyFleetName, keyResult},
+		},
 		&view.View{
 			Name:        "gameservers_node_count",
 			Measure:     gsPerNodesCountStats,
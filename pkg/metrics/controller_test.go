@@ -184,6 +184,26 @@ func TestControllerFleetReplicasCount(t *testing.T) {
 	assert.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(fleetReplicasCountExpected), "agones_fleets_replicas_count"))
 }
 
+func TestControllerFleetAggregates(t *testing.T) {
+
+	registry := prometheus.NewRegistry()
+	_, err := RegisterPrometheusExporter(registry)
+	assert.Nil(t, err)
+
+	c := newFakeController()
+	defer c.close()
+
+	c.fleetWatch.Add(fleet("fleet-stable", 5, 2, 3, 5))
+	c.fleetWatch.Add(fleet("fleet-rolling", 8, 2, 5, 5))
+
+	c.sync()
+	c.collect()
+	report()
+
+	assert.Nil(t, testutil.GatherAndCompare(registry, strings.NewReader(fleetAggregatesExpected),
+		"agones_fleets_aggregate_replicas_count", "agones_fleets_rollout_count"))
+}
+
 func TestControllerFleetAutoScalerState(t *testing.T) {
 	registry := prometheus.NewRegistry()
 	_, err := RegisterPrometheusExporter(registry)
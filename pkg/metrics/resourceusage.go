@@ -0,0 +1,136 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+
+	stablev1alpha1 "agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"github.com/pkg/errors"
+	"go.opencensus.io/tag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podMetricsEndpoint is the metrics-server (metrics.k8s.io) endpoint
+// for pod resource usage across all namespaces
+const podMetricsEndpoint = "/apis/metrics.k8s.io/v1beta1/pods"
+
+// podMetricsList is a minimal decoding of the metrics.k8s.io
+// PodMetricsList, since the typed client is not vendored
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+// podMetrics is the resource usage of a single pod
+type podMetrics struct {
+	ObjectMeta metav1.ObjectMeta  `json:"metadata"`
+	Containers []containerMetrics `json:"containers"`
+}
+
+// containerMetrics is the resource usage of a single container
+type containerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// sum returns the total cpu (millicores) and memory (bytes) usage
+// across all of the pod's containers
+func (pm podMetrics) sum() (cpuMillis, memoryBytes int64) {
+	for _, c := range pm.Containers {
+		if cpu, ok := c.Usage[corev1.ResourceCPU]; ok {
+			cpuMillis += cpu.MilliValue()
+		}
+		if memory, ok := c.Usage[corev1.ResourceMemory]; ok {
+			memoryBytes += memory.Value()
+		}
+	}
+	return cpuMillis, memoryBytes
+}
+
+// newPodMetricsGetter returns a function that retrieves the resource
+// usage of all GameServer pods from the metrics.k8s.io API
+func newPodMetricsGetter(kubeClient kubernetes.Interface) func() ([]podMetrics, error) {
+	return func() ([]podMetrics, error) {
+		data, err := kubeClient.CoreV1().RESTClient().Get().
+			AbsPath(podMetricsEndpoint).
+			Param("labelSelector", stablev1alpha1.GameServerRolePodSelector.String()).
+			DoRaw()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve pod metrics")
+		}
+
+		list := &podMetricsList{}
+		if err := json.Unmarshal(data, list); err != nil {
+			return nil, errors.Wrap(err, "could not parse pod metrics")
+		}
+
+		return list.Items, nil
+	}
+}
+
+// collectGameServerResourceUsage records cpu and memory usage of each
+// GameServer pod, as reported by the metrics-server. If the metrics API
+// is not available in this cluster, this quietly becomes a noop
+func (c *Controller) collectGameServerResourceUsage() {
+	if c.podMetricsGetter == nil {
+		return
+	}
+
+	items, err := c.podMetricsGetter()
+	if err != nil {
+		// metrics-server is optional, so only warn the first time it
+		// cannot be reached, and then stay quiet
+		if !c.podMetricsUnavailable {
+			c.podMetricsUnavailable = true
+			c.logger.WithError(err).Warn("could not collect gameserver resource usage. Is metrics-server installed?")
+		}
+		return
+	}
+	c.podMetricsUnavailable = false
+
+	gameServers, err := c.gameServerLister.List(labels.Everything())
+	if err != nil {
+		c.logger.WithError(err).Warn("failed listing gameservers")
+		return
+	}
+
+	fleets := map[string]string{}
+	for _, gs := range gameServers {
+		fleetName := gs.Labels[stablev1alpha1.FleetNameLabel]
+		if fleetName == "" {
+			fleetName = "none"
+		}
+		// pods inherit the name of their gameserver
+		fleets[gs.ObjectMeta.Namespace+"/"+gs.ObjectMeta.Name] = fleetName
+	}
+
+	for _, pm := range items {
+		fleetName, ok := fleets[pm.ObjectMeta.Namespace+"/"+pm.ObjectMeta.Name]
+		if !ok {
+			continue
+		}
+
+		cpuMillis, memoryBytes := pm.sum()
+		recordWithTags(context.Background(), []tag.Mutator{
+			tag.Upsert(keyName, pm.ObjectMeta.Name),
+			tag.Upsert(keyFleetName, fleetName)},
+			gameServerCPUUsageStats.M(cpuMillis),
+			gameServerMemoryUsageStats.M(memoryBytes))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodMetricsSum(t *testing.T) {
+	t.Parallel()
+
+	pm := podMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: "gs-1", Namespace: "default"},
+		Containers: []containerMetrics{
+			{Name: "game", Usage: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			}},
+			{Name: "agones-sdk", Usage: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			}},
+		},
+	}
+
+	cpu, memory := pm.sum()
+	assert.Equal(t, int64(260), cpu)
+	assert.Equal(t, int64((64+16)*1024*1024), memory)
+}
+
+func TestCollectGameServerResourceUsageUnavailable(t *testing.T) {
+	c := newFakeController()
+	defer c.close()
+
+	c.podMetricsGetter = func() ([]podMetrics, error) {
+		return nil, errors.New("the server could not find the requested resource")
+	}
+
+	c.collectGameServerResourceUsage()
+	assert.True(t, c.podMetricsUnavailable)
+
+	c.podMetricsGetter = func() ([]podMetrics, error) {
+		return []podMetrics{}, nil
+	}
+	c.collectGameServerResourceUsage()
+	assert.False(t, c.podMetricsUnavailable)
+}
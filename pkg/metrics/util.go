@@ -25,13 +25,15 @@ import (
 var (
 	logger = runtime.NewLoggerWithSource("metrics")
 
-	keyName       = mustTagKey("name")
-	keyFleetName  = mustTagKey("fleet_name")
-	keyType       = mustTagKey("type")
-	keyStatusCode = mustTagKey("status_code")
-	keyVerb       = mustTagKey("verb")
-	keyEndpoint   = mustTagKey("endpoint")
-	keyEmpty      = mustTagKey("empty")
+	keyName         = mustTagKey("name")
+	keyFleetName    = mustTagKey("fleet_name")
+	keyType         = mustTagKey("type")
+	keyRolloutState = mustTagKey("rollout_state")
+	keyStatusCode   = mustTagKey("status_code")
+	keyResult       = mustTagKey("result")
+	keyVerb         = mustTagKey("verb")
+	keyEndpoint     = mustTagKey("endpoint")
+	keyEmpty        = mustTagKey("empty")
 )
 
 func recordWithTags(ctx context.Context, mutators []tag.Mutator, ms ...stats.Measurement) {
@@ -36,6 +36,8 @@ import (
 func newFakeController() *fakeController {
 	m := agtesting.NewMocks()
 	c := NewController(m.KubeClient, m.AgonesClient, m.KubeInformerFactory, m.AgonesInformerFactory)
+	// the fake clientset does not implement a usable RESTClient
+	c.podMetricsGetter = nil
 	gsWatch := watch.NewFake()
 	faWatch := watch.NewFake()
 	fasWatch := watch.NewFake()
@@ -253,6 +255,18 @@ agones_fleets_replicas_count{name="fleet-test",type="ready"} 1
 agones_fleets_replicas_count{name="fleet-test",type="total"} 8
 `
 
+var fleetAggregatesExpected = `# HELP agones_fleets_aggregate_replicas_count The number of replicas across all fleets and namespaces
+# TYPE agones_fleets_aggregate_replicas_count gauge
+agones_fleets_aggregate_replicas_count{type="allocated"} 4
+agones_fleets_aggregate_replicas_count{type="desired"} 10
+agones_fleets_aggregate_replicas_count{type="ready"} 8
+agones_fleets_aggregate_replicas_count{type="total"} 13
+# HELP agones_fleets_rollout_count The number of fleets in each rollout state across all namespaces
+# TYPE agones_fleets_rollout_count gauge
+agones_fleets_rollout_count{rollout_state="progressing"} 1
+agones_fleets_rollout_count{rollout_state="stable"} 1
+`
+
 var fasStateExpected = `# HELP agones_fleet_autoscalers_able_to_scale The fleet autoscaler can access the fleet to scale
 # TYPE agones_fleet_autoscalers_able_to_scale gauge
 agones_fleet_autoscalers_able_to_scale{fleet_name="first-fleet",name="name-switch"} 0
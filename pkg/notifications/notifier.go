@@ -0,0 +1,135 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notifications forwards significant controller events to
+// external sinks, such as a webhook endpoint, so that operational
+// conditions can be surfaced outside of the Kubernetes Event stream.
+package notifications
+
+import (
+	"strings"
+
+	"agones.dev/agones/pkg/apis/stable"
+	"agones.dev/agones/pkg/util/runtime"
+	"agones.dev/agones/pkg/util/workerqueue"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	listercorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Sink receives notifications of significant controller events.
+// Implementations must be safe to call from multiple goroutines.
+// Custom sinks, such as a PubSub publisher, can be implemented
+// against this interface and passed to NewNotifier.
+type Sink interface {
+	// Send delivers the event to the sink. Returning an error
+	// will cause the delivery to be retried.
+	Send(event *corev1.Event) error
+}
+
+// Notifier watches the Kubernetes Events emitted by the Agones controllers
+// and forwards significant ones to the configured Sinks
+type Notifier struct {
+	logger      *logrus.Entry
+	sinks       []Sink
+	eventLister listercorev1.EventLister
+	eventSynced cache.InformerSynced
+	workerqueue *workerqueue.WorkerQueue
+}
+
+// NewNotifier returns a new Notifier that delivers events to the given sinks
+func NewNotifier(health healthcheck.Handler,
+	kubeInformerFactory informers.SharedInformerFactory,
+	sinks ...Sink) *Notifier {
+
+	events := kubeInformerFactory.Core().V1().Events()
+
+	n := &Notifier{
+		sinks:       sinks,
+		eventLister: events.Lister(),
+		eventSynced: events.Informer().HasSynced,
+	}
+
+	n.logger = runtime.NewLoggerWithType(n)
+	n.workerqueue = workerqueue.NewWorkerQueue(n.syncEvent, n.logger, stable.GroupName+".Notifier")
+	health.AddLivenessCheck("notifications-workerqueue", healthcheck.Check(n.workerqueue.Healthy))
+
+	events.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			event := obj.(*corev1.Event)
+			if notable(event) {
+				n.workerqueue.Enqueue(event)
+			}
+		},
+	})
+
+	return n
+}
+
+// Run the Notifier. Will block until stop is closed
+func (n *Notifier) Run(workers int, stop <-chan struct{}) error {
+	n.logger.Info("Wait for cache sync")
+	if !cache.WaitForCacheSync(stop, n.eventSynced) {
+		return errors.New("failed to wait for caches to sync")
+	}
+
+	n.workerqueue.Run(workers, stop)
+	return nil
+}
+
+// WaitUntilRunning blocks until the Notifier is processing events,
+// or stop is closed
+func (n *Notifier) WaitUntilRunning(stop <-chan struct{}) bool {
+	return n.workerqueue.WaitUntilRunning(stop)
+}
+
+// notable returns true if this event should be forwarded to the sinks.
+// Warning events on Agones resources cover conditions such as crash looping
+// GameServers and failing allocations, which are the ones on-call needs to
+// know about.
+func notable(event *corev1.Event) bool {
+	if !strings.HasPrefix(event.InvolvedObject.APIVersion, stable.GroupName) {
+		return false
+	}
+	return event.Type == corev1.EventTypeWarning
+}
+
+// syncEvent delivers the event to each of the sinks
+func (n *Notifier) syncEvent(key string) error {
+	n.logger.WithField("key", key).Info("syncEvent")
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		// don't return an error, as we don't want this retried
+		runtime.HandleError(n.logger.WithField("key", key), errors.Wrapf(err, "invalid resource key"))
+		return nil
+	}
+
+	event, err := n.eventLister.Events(namespace).Get(name)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving event %s from namespace %s", name, namespace)
+	}
+
+	for _, s := range n.sinks {
+		if err := s.Send(event); err != nil {
+			return errors.Wrapf(err, "error sending event %s to sink", name)
+		}
+	}
+
+	return nil
+}
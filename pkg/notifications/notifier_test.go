@@ -0,0 +1,118 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifications
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/heptiolabs/healthcheck"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func eventFixture() *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-event", Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "stable.agones.dev/v1alpha1",
+			Kind:       "GameServer",
+			Name:       "test",
+			Namespace:  "default",
+		},
+		Reason:  "Unhealthy",
+		Message: "Pod has crashed",
+		Type:    corev1.EventTypeWarning,
+	}
+}
+
+func TestNotable(t *testing.T) {
+	t.Parallel()
+
+	event := eventFixture()
+	assert.True(t, notable(event))
+
+	normal := eventFixture()
+	normal.Type = corev1.EventTypeNormal
+	assert.False(t, notable(normal))
+
+	pod := eventFixture()
+	pod.InvolvedObject.APIVersion = "v1"
+	assert.False(t, notable(pod))
+}
+
+func TestNotifierSyncEvent(t *testing.T) {
+	t.Parallel()
+
+	event := eventFixture()
+
+	var received []*corev1.Event
+	sink := sinkFunc(func(e *corev1.Event) error {
+		received = append(received, e)
+		return nil
+	})
+
+	m := agtesting.NewMocks()
+	n := NewNotifier(healthcheck.NewHandler(), m.KubeInformerFactory, sink)
+
+	m.KubeClient.AddReactor("list", "events", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &corev1.EventList{Items: []corev1.Event{*event}}, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, n.eventSynced)
+	defer cancel()
+
+	err := n.syncEvent("default/test-event")
+	assert.Nil(t, err)
+	assert.Len(t, received, 1)
+	assert.Equal(t, "Unhealthy", received[0].Reason)
+}
+
+func TestWebhookSinkSend(t *testing.T) {
+	t.Parallel()
+
+	var count int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sink := &WebhookSink{URL: ts.URL}
+	err := sink.Send(eventFixture())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer bad.Close()
+
+	err = (&WebhookSink{URL: bad.URL}).Send(eventFixture())
+	assert.NotNil(t, err)
+}
+
+// sinkFunc is a function adaptor for the Sink interface
+type sinkFunc func(event *corev1.Event) error
+
+func (f sinkFunc) Send(event *corev1.Event) error {
+	return f(event)
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var client = http.Client{
+	Timeout: 15 * time.Second,
+}
+
+// WebhookSink posts events as JSON to a configured URL
+type WebhookSink struct {
+	// URL to POST events to
+	URL string
+}
+
+// Send posts the event to the webhook URL
+func (w *WebhookSink) Send(event *corev1.Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling event")
+	}
+
+	res, err := client.Post(w.URL, "application/json", strings.NewReader(string(b)))
+	if err != nil {
+		return errors.Wrapf(err, "error posting event to webhook %s", w.URL)
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("bad status code %d from webhook %s", res.StatusCode, w.URL)
+	}
+
+	return nil
+}
@@ -117,6 +117,411 @@ func (m *KeyValue) GetValue() string {
 	return ""
 }
 
+// A game defined load score. Lower values indicate a less
+// loaded GameServer.
+type Load struct {
+	Load                 float64  `protobuf:"fixed64,1,opt,name=load,proto3" json:"load,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Load) Reset()         { *m = Load{} }
+func (m *Load) String() string { return proto.CompactTextString(m) }
+func (*Load) ProtoMessage()    {}
+func (*Load) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{3}
+}
+func (m *Load) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Load.Unmarshal(m, b)
+}
+func (m *Load) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Load.Marshal(b, m, deterministic)
+}
+func (dst *Load) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Load.Merge(dst, src)
+}
+func (m *Load) XXX_Size() int {
+	return xxx_messageInfo_Load.Size(m)
+}
+func (m *Load) XXX_DiscardUnknown() {
+	xxx_messageInfo_Load.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Load proto.InternalMessageInfo
+
+func (m *Load) GetLoad() float64 {
+	if m != nil {
+		return m.Load
+	}
+	return 0
+}
+
+// A log line from the game server process.
+// Level is one of "debug", "info", "warn" or "error".
+type LogEntry struct {
+	Level                string   `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogEntry) Reset()         { *m = LogEntry{} }
+func (m *LogEntry) String() string { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()    {}
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{4}
+}
+func (m *LogEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogEntry.Unmarshal(m, b)
+}
+func (m *LogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogEntry.Marshal(b, m, deterministic)
+}
+func (dst *LogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEntry.Merge(dst, src)
+}
+func (m *LogEntry) XXX_Size() int {
+	return xxx_messageInfo_LogEntry.Size(m)
+}
+func (m *LogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogEntry proto.InternalMessageInfo
+
+func (m *LogEntry) GetLevel() string {
+	if m != nil {
+		return m.Level
+	}
+	return ""
+}
+
+func (m *LogEntry) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// A duration, in seconds
+type Duration struct {
+	Seconds              int64    `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Duration) Reset()         { *m = Duration{} }
+func (m *Duration) String() string { return proto.CompactTextString(m) }
+func (*Duration) ProtoMessage()    {}
+func (*Duration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{5}
+}
+func (m *Duration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Duration.Unmarshal(m, b)
+}
+func (m *Duration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Duration.Marshal(b, m, deterministic)
+}
+func (dst *Duration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Duration.Merge(dst, src)
+}
+func (m *Duration) XXX_Size() int {
+	return xxx_messageInfo_Duration.Size(m)
+}
+func (m *Duration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Duration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Duration proto.InternalMessageInfo
+
+func (m *Duration) GetSeconds() int64 {
+	if m != nil {
+		return m.Seconds
+	}
+	return 0
+}
+
+// The identifier of a player connected to this GameServer
+type PlayerID struct {
+	PlayerID             string   `protobuf:"bytes,1,opt,name=playerID,proto3" json:"playerID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerID) Reset()         { *m = PlayerID{} }
+func (m *PlayerID) String() string { return proto.CompactTextString(m) }
+func (*PlayerID) ProtoMessage()    {}
+func (*PlayerID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{6}
+}
+func (m *PlayerID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerID.Unmarshal(m, b)
+}
+func (m *PlayerID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerID.Marshal(b, m, deterministic)
+}
+func (dst *PlayerID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerID.Merge(dst, src)
+}
+func (m *PlayerID) XXX_Size() int {
+	return xxx_messageInfo_PlayerID.Size(m)
+}
+func (m *PlayerID) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerID proto.InternalMessageInfo
+
+func (m *PlayerID) GetPlayerID() string {
+	if m != nil {
+		return m.PlayerID
+	}
+	return ""
+}
+
+// A named game defined counter, and the count to set it to
+type Counter struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Counter) Reset()         { *m = Counter{} }
+func (m *Counter) String() string { return proto.CompactTextString(m) }
+func (*Counter) ProtoMessage()    {}
+func (*Counter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{7}
+}
+func (m *Counter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Counter.Unmarshal(m, b)
+}
+func (m *Counter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Counter.Marshal(b, m, deterministic)
+}
+func (dst *Counter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Counter.Merge(dst, src)
+}
+func (m *Counter) XXX_Size() int {
+	return xxx_messageInfo_Counter.Size(m)
+}
+func (m *Counter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Counter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Counter proto.InternalMessageInfo
+
+func (m *Counter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Counter) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// A value to append to, or remove from, a named game defined list
+type ListValue struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListValue) Reset()         { *m = ListValue{} }
+func (m *ListValue) String() string { return proto.CompactTextString(m) }
+func (*ListValue) ProtoMessage()    {}
+func (*ListValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{8}
+}
+func (m *ListValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListValue.Unmarshal(m, b)
+}
+func (m *ListValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListValue.Marshal(b, m, deterministic)
+}
+func (dst *ListValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListValue.Merge(dst, src)
+}
+func (m *ListValue) XXX_Size() int {
+	return xxx_messageInfo_ListValue.Size(m)
+}
+func (m *ListValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListValue proto.InternalMessageInfo
+
+func (m *ListValue) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// A bounded, optionally filtered, request for connected player IDs
+type ConnectedPlayersRequest struct {
+	// return at most this many IDs. 0 returns all of them
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// skip this many matching IDs before collecting the page
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// only return IDs that start with this prefix
+	Prefix               string   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectedPlayersRequest) Reset()         { *m = ConnectedPlayersRequest{} }
+func (m *ConnectedPlayersRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectedPlayersRequest) ProtoMessage()    {}
+func (*ConnectedPlayersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{9}
+}
+func (m *ConnectedPlayersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectedPlayersRequest.Unmarshal(m, b)
+}
+func (m *ConnectedPlayersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectedPlayersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ConnectedPlayersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectedPlayersRequest.Merge(dst, src)
+}
+func (m *ConnectedPlayersRequest) XXX_Size() int {
+	return xxx_messageInfo_ConnectedPlayersRequest.Size(m)
+}
+func (m *ConnectedPlayersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectedPlayersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectedPlayersRequest proto.InternalMessageInfo
+
+func (m *ConnectedPlayersRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ConnectedPlayersRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ConnectedPlayersRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// A page of connected player IDs
+type ConnectedPlayersList struct {
+	List []string `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	// the total number of matching IDs, across all pages
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectedPlayersList) Reset()         { *m = ConnectedPlayersList{} }
+func (m *ConnectedPlayersList) String() string { return proto.CompactTextString(m) }
+func (*ConnectedPlayersList) ProtoMessage()    {}
+func (*ConnectedPlayersList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{10}
+}
+func (m *ConnectedPlayersList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectedPlayersList.Unmarshal(m, b)
+}
+func (m *ConnectedPlayersList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectedPlayersList.Marshal(b, m, deterministic)
+}
+func (dst *ConnectedPlayersList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectedPlayersList.Merge(dst, src)
+}
+func (m *ConnectedPlayersList) XXX_Size() int {
+	return xxx_messageInfo_ConnectedPlayersList.Size(m)
+}
+func (m *ConnectedPlayersList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectedPlayersList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectedPlayersList proto.InternalMessageInfo
+
+func (m *ConnectedPlayersList) GetList() []string {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func (m *ConnectedPlayersList) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+// Whether a player is currently connected
+type Connected struct {
+	Connected            bool     `protobuf:"varint,1,opt,name=connected,proto3" json:"connected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Connected) Reset()         { *m = Connected{} }
+func (m *Connected) String() string { return proto.CompactTextString(m) }
+func (*Connected) ProtoMessage()    {}
+func (*Connected) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_d3fbd562dbd9fd17, []int{11}
+}
+func (m *Connected) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Connected.Unmarshal(m, b)
+}
+func (m *Connected) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Connected.Marshal(b, m, deterministic)
+}
+func (dst *Connected) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Connected.Merge(dst, src)
+}
+func (m *Connected) XXX_Size() int {
+	return xxx_messageInfo_Connected.Size(m)
+}
+func (m *Connected) XXX_DiscardUnknown() {
+	xxx_messageInfo_Connected.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Connected proto.InternalMessageInfo
+
+func (m *Connected) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
 // A GameServer Custom Resource Definition object
 // We will only export those resources that make the most
 // sense. Can always expand to more as needed.
@@ -383,6 +788,7 @@ type GameServer_Status struct {
 	State                string                    `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
 	Address              string                    `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	Ports                []*GameServer_Status_Port `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
+	Load                 float64                   `protobuf:"fixed64,4,opt,name=load,proto3" json:"load,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -433,6 +839,13 @@ func (m *GameServer_Status) GetPorts() []*GameServer_Status_Port {
 	return nil
 }
 
+func (m *GameServer_Status) GetLoad() float64 {
+	if m != nil {
+		return m.Load
+	}
+	return 0
+}
+
 type GameServer_Status_Port struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Port                 int32    `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
@@ -482,6 +895,15 @@ func (m *GameServer_Status_Port) GetPort() int32 {
 func init() {
 	proto.RegisterType((*Empty)(nil), "stable.agones.dev.sdk.Empty")
 	proto.RegisterType((*KeyValue)(nil), "stable.agones.dev.sdk.KeyValue")
+	proto.RegisterType((*Load)(nil), "stable.agones.dev.sdk.Load")
+	proto.RegisterType((*LogEntry)(nil), "stable.agones.dev.sdk.LogEntry")
+	proto.RegisterType((*Duration)(nil), "stable.agones.dev.sdk.Duration")
+	proto.RegisterType((*PlayerID)(nil), "stable.agones.dev.sdk.PlayerID")
+	proto.RegisterType((*Counter)(nil), "stable.agones.dev.sdk.Counter")
+	proto.RegisterType((*ListValue)(nil), "stable.agones.dev.sdk.ListValue")
+	proto.RegisterType((*ConnectedPlayersRequest)(nil), "stable.agones.dev.sdk.ConnectedPlayersRequest")
+	proto.RegisterType((*ConnectedPlayersList)(nil), "stable.agones.dev.sdk.ConnectedPlayersList")
+	proto.RegisterType((*Connected)(nil), "stable.agones.dev.sdk.Connected")
 	proto.RegisterType((*GameServer)(nil), "stable.agones.dev.sdk.GameServer")
 	proto.RegisterType((*GameServer_ObjectMeta)(nil), "stable.agones.dev.sdk.GameServer.ObjectMeta")
 	proto.RegisterMapType((map[string]string)(nil), "stable.agones.dev.sdk.GameServer.ObjectMeta.AnnotationsEntry")
@@ -518,6 +940,34 @@ type SDKClient interface {
 	SetLabel(ctx context.Context, in *KeyValue, opts ...grpc.CallOption) (*Empty, error)
 	// Apply a Annotation to the backing GameServer metadata
 	SetAnnotation(ctx context.Context, in *KeyValue, opts ...grpc.CallOption) (*Empty, error)
+	// Apply a game defined load score to the backing GameServer status
+	SetLoad(ctx context.Context, in *Load, opts ...grpc.CallOption) (*Empty, error)
+	// Forward a log line into the controller's logging pipeline,
+	// tagged with the GameServer name
+	Log(ctx context.Context, in *LogEntry, opts ...grpc.CallOption) (*Empty, error)
+	// Marks the GameServer as Reserved for the given duration, during which
+	// it will not be deleted on scale down or be available for allocation.
+	// After the duration has passed, it moves back to Ready
+	Reserve(ctx context.Context, in *Duration, opts ...grpc.CallOption) (*Empty, error)
+	// Record that a player has connected to this GameServer, adding
+	// them to the player tracking status
+	PlayerConnect(ctx context.Context, in *PlayerID, opts ...grpc.CallOption) (*Empty, error)
+	// Record that a player has disconnected from this GameServer,
+	// removing them from the player tracking status
+	PlayerDisconnect(ctx context.Context, in *PlayerID, opts ...grpc.CallOption) (*Empty, error)
+	// Retrieve a bounded page of the connected player IDs, optionally
+	// filtered by an ID prefix, so large player lists don't have to be
+	// transferred in one response
+	GetConnectedPlayers(ctx context.Context, in *ConnectedPlayersRequest, opts ...grpc.CallOption) (*ConnectedPlayersList, error)
+	// Check whether a specific player is currently connected, without
+	// transferring the player list
+	IsPlayerConnected(ctx context.Context, in *PlayerID, opts ...grpc.CallOption) (*Connected, error)
+	// Update the count of a named counter in the GameServer status
+	SetCounterCount(ctx context.Context, in *Counter, opts ...grpc.CallOption) (*Empty, error)
+	// Append a value to a named list in the GameServer status
+	AppendListValue(ctx context.Context, in *ListValue, opts ...grpc.CallOption) (*Empty, error)
+	// Remove a value from a named list in the GameServer status
+	RemoveListValue(ctx context.Context, in *ListValue, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type sDKClient struct {
@@ -639,6 +1089,96 @@ func (c *sDKClient) SetAnnotation(ctx context.Context, in *KeyValue, opts ...grp
 	return out, nil
 }
 
+func (c *sDKClient) SetLoad(ctx context.Context, in *Load, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/SetLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) Log(ctx context.Context, in *LogEntry, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/Log", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) Reserve(ctx context.Context, in *Duration, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/Reserve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) PlayerConnect(ctx context.Context, in *PlayerID, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/PlayerConnect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) PlayerDisconnect(ctx context.Context, in *PlayerID, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/PlayerDisconnect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) GetConnectedPlayers(ctx context.Context, in *ConnectedPlayersRequest, opts ...grpc.CallOption) (*ConnectedPlayersList, error) {
+	out := new(ConnectedPlayersList)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/GetConnectedPlayers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) IsPlayerConnected(ctx context.Context, in *PlayerID, opts ...grpc.CallOption) (*Connected, error) {
+	out := new(Connected)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/IsPlayerConnected", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) SetCounterCount(ctx context.Context, in *Counter, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/SetCounterCount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) AppendListValue(ctx context.Context, in *ListValue, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/AppendListValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) RemoveListValue(ctx context.Context, in *ListValue, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/stable.agones.dev.sdk.SDK/RemoveListValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SDKServer is the server API for SDK service.
 type SDKServer interface {
 	// Call when the GameServer is ready
@@ -655,6 +1195,34 @@ type SDKServer interface {
 	SetLabel(context.Context, *KeyValue) (*Empty, error)
 	// Apply a Annotation to the backing GameServer metadata
 	SetAnnotation(context.Context, *KeyValue) (*Empty, error)
+	// Apply a game defined load score to the backing GameServer status
+	SetLoad(context.Context, *Load) (*Empty, error)
+	// Forward a log line into the controller's logging pipeline,
+	// tagged with the GameServer name
+	Log(context.Context, *LogEntry) (*Empty, error)
+	// Marks the GameServer as Reserved for the given duration, during which
+	// it will not be deleted on scale down or be available for allocation.
+	// After the duration has passed, it moves back to Ready
+	Reserve(context.Context, *Duration) (*Empty, error)
+	// Record that a player has connected to this GameServer, adding
+	// them to the player tracking status
+	PlayerConnect(context.Context, *PlayerID) (*Empty, error)
+	// Record that a player has disconnected from this GameServer,
+	// removing them from the player tracking status
+	PlayerDisconnect(context.Context, *PlayerID) (*Empty, error)
+	// Retrieve a bounded page of the connected player IDs, optionally
+	// filtered by an ID prefix, so large player lists don't have to be
+	// transferred in one response
+	GetConnectedPlayers(context.Context, *ConnectedPlayersRequest) (*ConnectedPlayersList, error)
+	// Check whether a specific player is currently connected, without
+	// transferring the player list
+	IsPlayerConnected(context.Context, *PlayerID) (*Connected, error)
+	// Update the count of a named counter in the GameServer status
+	SetCounterCount(context.Context, *Counter) (*Empty, error)
+	// Append a value to a named list in the GameServer status
+	AppendListValue(context.Context, *ListValue) (*Empty, error)
+	// Remove a value from a named list in the GameServer status
+	RemoveListValue(context.Context, *ListValue) (*Empty, error)
 }
 
 func RegisterSDKServer(s *grpc.Server, srv SDKServer) {
@@ -798,6 +1366,186 @@ func _SDK_SetAnnotation_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SDK_SetLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Load)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).SetLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/SetLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).SetLoad(ctx, req.(*Load))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_Log_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogEntry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).Log(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/Log",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).Log(ctx, req.(*LogEntry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Duration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/Reserve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).Reserve(ctx, req.(*Duration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_PlayerConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).PlayerConnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/PlayerConnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).PlayerConnect(ctx, req.(*PlayerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_PlayerDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).PlayerDisconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/PlayerDisconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).PlayerDisconnect(ctx, req.(*PlayerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_GetConnectedPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectedPlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).GetConnectedPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/GetConnectedPlayers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).GetConnectedPlayers(ctx, req.(*ConnectedPlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_IsPlayerConnected_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).IsPlayerConnected(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/IsPlayerConnected",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).IsPlayerConnected(ctx, req.(*PlayerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_SetCounterCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Counter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).SetCounterCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/SetCounterCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).SetCounterCount(ctx, req.(*Counter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_AppendListValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).AppendListValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/AppendListValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).AppendListValue(ctx, req.(*ListValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_RemoveListValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).RemoveListValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stable.agones.dev.sdk.SDK/RemoveListValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).RemoveListValue(ctx, req.(*ListValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SDK_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "stable.agones.dev.sdk.SDK",
 	HandlerType: (*SDKServer)(nil),
@@ -822,6 +1570,46 @@ var _SDK_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetAnnotation",
 			Handler:    _SDK_SetAnnotation_Handler,
 		},
+		{
+			MethodName: "SetLoad",
+			Handler:    _SDK_SetLoad_Handler,
+		},
+		{
+			MethodName: "Log",
+			Handler:    _SDK_Log_Handler,
+		},
+		{
+			MethodName: "Reserve",
+			Handler:    _SDK_Reserve_Handler,
+		},
+		{
+			MethodName: "PlayerConnect",
+			Handler:    _SDK_PlayerConnect_Handler,
+		},
+		{
+			MethodName: "PlayerDisconnect",
+			Handler:    _SDK_PlayerDisconnect_Handler,
+		},
+		{
+			MethodName: "GetConnectedPlayers",
+			Handler:    _SDK_GetConnectedPlayers_Handler,
+		},
+		{
+			MethodName: "IsPlayerConnected",
+			Handler:    _SDK_IsPlayerConnected_Handler,
+		},
+		{
+			MethodName: "SetCounterCount",
+			Handler:    _SDK_SetCounterCount_Handler,
+		},
+		{
+			MethodName: "AppendListValue",
+			Handler:    _SDK_AppendListValue_Handler,
+		},
+		{
+			MethodName: "RemoveListValue",
+			Handler:    _SDK_RemoveListValue_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
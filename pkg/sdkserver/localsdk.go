@@ -17,6 +17,7 @@ package sdkserver
 import (
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -57,6 +58,7 @@ var (
 type LocalSDKServer struct {
 	gsMutex         sync.RWMutex
 	gs              *sdk.GameServer
+	playerIDs       []string
 	update          chan struct{}
 	updateObservers sync.Map
 }
@@ -127,6 +129,104 @@ func (l *LocalSDKServer) Shutdown(context.Context, *sdk.Empty) (*sdk.Empty, erro
 	return &sdk.Empty{}, nil
 }
 
+// Reserve logs that the reserve request has been received
+func (l *LocalSDKServer) Reserve(_ context.Context, d *sdk.Duration) (*sdk.Empty, error) {
+	logrus.WithField("duration", d.Seconds).Info("Reserve request has been received!")
+	return &sdk.Empty{}, nil
+}
+
+// PlayerConnect records the player as connected in the local player list
+func (l *LocalSDKServer) PlayerConnect(_ context.Context, id *sdk.PlayerID) (*sdk.Empty, error) {
+	logrus.WithField("playerID", id.PlayerID).Info("PlayerConnect request has been received!")
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	for _, connected := range l.playerIDs {
+		if connected == id.PlayerID {
+			return &sdk.Empty{}, nil
+		}
+	}
+	l.playerIDs = append(l.playerIDs, id.PlayerID)
+	return &sdk.Empty{}, nil
+}
+
+// PlayerDisconnect removes the player from the local player list
+func (l *LocalSDKServer) PlayerDisconnect(_ context.Context, id *sdk.PlayerID) (*sdk.Empty, error) {
+	logrus.WithField("playerID", id.PlayerID).Info("PlayerDisconnect request has been received!")
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	for i, connected := range l.playerIDs {
+		if connected == id.PlayerID {
+			l.playerIDs = append(l.playerIDs[:i], l.playerIDs[i+1:]...)
+			break
+		}
+	}
+	return &sdk.Empty{}, nil
+}
+
+// GetConnectedPlayers returns a bounded, optionally prefix filtered, page of
+// the local player list
+func (l *LocalSDKServer) GetConnectedPlayers(_ context.Context, in *sdk.ConnectedPlayersRequest) (*sdk.ConnectedPlayersList, error) {
+	logrus.WithField("limit", in.Limit).WithField("offset", in.Offset).WithField("prefix", in.Prefix).
+		Info("GetConnectedPlayers request has been received!")
+	l.gsMutex.RLock()
+	defer l.gsMutex.RUnlock()
+
+	result := &sdk.ConnectedPlayersList{}
+	skip := in.Offset
+	for _, id := range l.playerIDs {
+		if in.Prefix != "" && !strings.HasPrefix(id, in.Prefix) {
+			continue
+		}
+		result.Total++
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if in.Limit > 0 && int64(len(result.List)) >= in.Limit {
+			continue
+		}
+		result.List = append(result.List, id)
+	}
+
+	return result, nil
+}
+
+// IsPlayerConnected returns whether the player is in the local player list
+func (l *LocalSDKServer) IsPlayerConnected(_ context.Context, id *sdk.PlayerID) (*sdk.Connected, error) {
+	logrus.WithField("playerID", id.PlayerID).Info("IsPlayerConnected request has been received!")
+	l.gsMutex.RLock()
+	defer l.gsMutex.RUnlock()
+
+	result := &sdk.Connected{}
+	for _, connected := range l.playerIDs {
+		if connected == id.PlayerID {
+			result.Connected = true
+			break
+		}
+	}
+	return result, nil
+}
+
+// SetCounterCount logs that the set counter count request has been received
+func (l *LocalSDKServer) SetCounterCount(_ context.Context, counter *sdk.Counter) (*sdk.Empty, error) {
+	logrus.WithField("name", counter.Name).WithField("count", counter.Count).Info("SetCounterCount request has been received!")
+	return &sdk.Empty{}, nil
+}
+
+// AppendListValue logs that the append list value request has been received
+func (l *LocalSDKServer) AppendListValue(_ context.Context, lv *sdk.ListValue) (*sdk.Empty, error) {
+	logrus.WithField("name", lv.Name).WithField("value", lv.Value).Info("AppendListValue request has been received!")
+	return &sdk.Empty{}, nil
+}
+
+// RemoveListValue logs that the remove list value request has been received
+func (l *LocalSDKServer) RemoveListValue(_ context.Context, lv *sdk.ListValue) (*sdk.Empty, error) {
+	logrus.WithField("name", lv.Name).WithField("value", lv.Value).Info("RemoveListValue request has been received!")
+	return &sdk.Empty{}, nil
+}
+
 // Health logs each health ping that comes down the stream
 func (l *LocalSDKServer) Health(stream sdk.SDK_HealthServer) error {
 	for {
@@ -178,6 +278,27 @@ func (l *LocalSDKServer) SetAnnotation(_ context.Context, kv *sdk.KeyValue) (*sd
 	return &sdk.Empty{}, nil
 }
 
+// SetLoad applies a game defined load score to the backing GameServer status
+func (l *LocalSDKServer) SetLoad(_ context.Context, load *sdk.Load) (*sdk.Empty, error) {
+	logrus.WithField("values", load).Info("Setting load")
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	if l.gs.Status == nil {
+		l.gs.Status = &sdk.GameServer_Status{}
+	}
+
+	l.gs.Status.Load = load.Load
+	l.update <- struct{}{}
+	return &sdk.Empty{}, nil
+}
+
+// Log prints a log line from the game server process to stdout
+func (l *LocalSDKServer) Log(_ context.Context, entry *sdk.LogEntry) (*sdk.Empty, error) {
+	logrus.WithField("level", entry.Level).WithField("source", "gameserver").Info(entry.Message)
+	return &sdk.Empty{}, nil
+}
+
 // GetGameServer returns a dummy game server.
 func (l *LocalSDKServer) GetGameServer(context.Context, *sdk.Empty) (*sdk.GameServer, error) {
 	logrus.Info("getting GameServer details")
@@ -80,6 +80,39 @@ func TestLocalSDKWithGameServer(t *testing.T) {
 	assert.Equal(t, fixture.ObjectMeta.Name, gs.ObjectMeta.Name)
 }
 
+func TestLocalSDKServerPlayerTracking(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	l, err := NewLocalSDKServer("")
+	assert.Nil(t, err)
+
+	for _, id := range []string{"one", "two", "three", "two"} {
+		_, err = l.PlayerConnect(ctx, &sdk.PlayerID{PlayerID: id})
+		assert.Nil(t, err)
+	}
+
+	list, err := l.GetConnectedPlayers(ctx, &sdk.ConnectedPlayersRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, list.List)
+	assert.Equal(t, int64(3), list.Total)
+
+	list, err = l.GetConnectedPlayers(ctx, &sdk.ConnectedPlayersRequest{Limit: 1, Offset: 1, Prefix: "t"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"three"}, list.List)
+	assert.Equal(t, int64(2), list.Total)
+
+	connected, err := l.IsPlayerConnected(ctx, &sdk.PlayerID{PlayerID: "two"})
+	assert.Nil(t, err)
+	assert.True(t, connected.Connected)
+
+	_, err = l.PlayerDisconnect(ctx, &sdk.PlayerID{PlayerID: "two"})
+	assert.Nil(t, err)
+
+	connected, err = l.IsPlayerConnected(ctx, &sdk.PlayerID{PlayerID: "two"})
+	assert.Nil(t, err)
+	assert.False(t, connected.Connected)
+}
+
 // nolint:dupl
 func TestLocalSDKServerSetLabel(t *testing.T) {
 	t.Parallel()
@@ -221,6 +254,77 @@ func TestLocalSDKServerSetAnnotation(t *testing.T) {
 	}
 }
 
+// nolint:dupl
+func TestLocalSDKServerSetLoad(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		gs *v1alpha1.GameServer
+	}{
+		"default": {
+			gs: nil,
+		},
+		"no status": {
+			gs: &v1alpha1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "empty"}},
+		},
+		"empty": {
+			gs: &v1alpha1.GameServer{},
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			ctx := context.Background()
+			e := &sdk.Empty{}
+			path, err := gsToTmpFile(v.gs)
+			assert.Nil(t, err)
+
+			l, err := NewLocalSDKServer(path)
+			assert.Nil(t, err)
+
+			load := &sdk.Load{Load: 0.5}
+
+			stream := newGameServerMockStream()
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := l.WatchGameServer(e, stream)
+				assert.Nil(t, err)
+			}()
+
+			// make sure length of l.updateObservers is at least 1
+			err = wait.PollImmediate(time.Second, 10*time.Second, func() (bool, error) {
+				ret := false
+				l.updateObservers.Range(func(_, _ interface{}) bool {
+					ret = true
+					return false
+				})
+
+				return ret, nil
+			})
+			assert.Nil(t, err)
+
+			_, err = l.SetLoad(ctx, load)
+			assert.Nil(t, err)
+
+			gs, err := l.GetGameServer(ctx, e)
+			assert.Nil(t, err)
+			assert.Equal(t, 0.5, gs.Status.Load)
+
+			select {
+			case msg := <-stream.msgs:
+				assert.Equal(t, 0.5, msg.Status.Load)
+			case <-time.After(10 * time.Second):
+				assert.FailNow(t, "timeout on receiving messages")
+			}
+
+			l.Close()
+			wg.Wait()
+		})
+	}
+}
+
 func TestLocalSDKServerWatchGameServer(t *testing.T) {
 	t.Parallel()
 
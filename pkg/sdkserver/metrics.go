@@ -0,0 +1,93 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics tracks SDK usage from the game server process, so that
+// fleet wide scraping can spot game server builds with broken SDK
+// integration before they are marked Unhealthy
+type metrics struct {
+	registry    *prometheus.Registry
+	rpcTotal    *prometheus.CounterVec
+	rpcErrors   *prometheus.CounterVec
+	healthPings prometheus.Counter
+}
+
+// newMetrics returns the set of sidecar metrics, registered
+// against its own Registry, since there is only ever one
+// GameServer per sidecar
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		rpcTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "agones_sdk_rpc_total",
+			Help: "Number of SDK rpc requests received, by method.",
+		}, []string{"method"}),
+		rpcErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "agones_sdk_rpc_errors_total",
+			Help: "Number of SDK rpc requests that returned an error, by method.",
+		}, []string{"method"}),
+		healthPings: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "agones_sdk_health_pings_total",
+			Help: "Number of health pings received on the SDK Health stream.",
+		}),
+	}
+
+	m.registry.MustRegister(m.rpcTotal, m.rpcErrors, m.healthPings)
+
+	return m
+}
+
+// registerGauges registers the gauges that read state off the SDKServer
+// itself. Split out from newMetrics, as the SDKServer needs to exist first
+func (m *metrics) registerGauges(s *SDKServer) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "agones_sdk_last_health_ping_age_seconds",
+		Help: "Seconds since the last health ping was received from the game server process.",
+	}, func() float64 {
+		s.healthMutex.RLock()
+		defer s.healthMutex.RUnlock()
+		return s.clock.Now().UTC().Sub(s.healthLastUpdated).Seconds()
+	}))
+
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "agones_sdk_watchers",
+		Help: "Number of streams currently watching the backing GameServer.",
+	}, func() float64 {
+		s.streamMutex.RLock()
+		defer s.streamMutex.RUnlock()
+		return float64(len(s.connectedStreams))
+	}))
+}
+
+// handler returns the http handler that exposes the metrics
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// countRPC records an rpc request for method, and an error if
+// err is not nil
+func (m *metrics) countRPC(method string, err error) {
+	m.rpcTotal.WithLabelValues(method).Inc()
+	if err != nil {
+		m.rpcErrors.WithLabelValues(method).Inc()
+	}
+}
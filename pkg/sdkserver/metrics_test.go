@@ -0,0 +1,94 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"agones.dev/agones/pkg/sdk"
+	agtesting "agones.dev/agones/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestMetricsRPCCounts(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	sc, err := defaultSidecar(m)
+	assert.Nil(t, err)
+
+	// workerqueue isn't running, so these just enqueue and count
+	_, err = sc.Ready(context.Background(), &sdk.Empty{})
+	assert.Nil(t, err)
+	_, err = sc.Ready(context.Background(), &sdk.Empty{})
+	assert.Nil(t, err)
+	_, err = sc.SetLabel(context.Background(), &sdk.KeyValue{Key: "foo", Value: "bar"})
+	assert.Nil(t, err)
+
+	body := scrapeMetrics(t, sc)
+	assert.Contains(t, body, `agones_sdk_rpc_total{method="Ready"} 2`)
+	assert.Contains(t, body, `agones_sdk_rpc_total{method="SetLabel"} 1`)
+}
+
+func TestMetricsHealthPings(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	sc, err := defaultSidecar(m)
+	assert.Nil(t, err)
+
+	now := time.Now().UTC()
+	fc := clock.NewFakeClock(now)
+	sc.clock = fc
+
+	stream := newEmptyMockStream()
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		err := sc.Health(stream)
+		assert.Nil(t, err)
+		wg.Done()
+	}()
+
+	stream.msgs <- &sdk.Empty{}
+	err = waitForMessage(sc)
+	assert.Nil(t, err)
+	close(stream.msgs)
+	wg.Wait()
+
+	fc.Step(3 * time.Second)
+
+	body := scrapeMetrics(t, sc)
+	assert.Contains(t, body, `agones_sdk_health_pings_total 1`)
+	assert.Contains(t, body, `agones_sdk_last_health_ping_age_seconds 3`)
+	assert.Contains(t, body, `agones_sdk_watchers 0`)
+}
+
+// scrapeMetrics returns the body that the sidecar's /metrics
+// endpoint would serve
+func scrapeMetrics(t *testing.T, sc *SDKServer) string {
+	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	assert.Nil(t, err)
+	rec := httptest.NewRecorder()
+	sc.metrics.handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
@@ -51,6 +51,7 @@ func convert(gs *v1alpha1.GameServer) *sdk.GameServer {
 		Status: &sdk.GameServer_Status{
 			State:   string(status.State),
 			Address: status.Address,
+			Load:    status.Load,
 		},
 	}
 	if meta.DeletionTimestamp != nil {
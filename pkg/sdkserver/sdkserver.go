@@ -17,6 +17,7 @@ package sdkserver
 import (
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -52,6 +53,16 @@ const (
 	updateState      Operation = "updateState"
 	updateLabel      Operation = "updateLabel"
 	updateAnnotation Operation = "updateAnnotation"
+	updateLoad       Operation = "updateLoad"
+	playerConnect    Operation = "playerConnect"
+	playerDisconnect Operation = "playerDisconnect"
+	setCounterCount  Operation = "setCounterCount"
+	appendListValue  Operation = "appendListValue"
+	removeListValue  Operation = "removeListValue"
+
+	// watchBufferSize is the number of recent GameServer updates kept for
+	// replay to WatchGameServer subscribers that connect late
+	watchBufferSize = 5
 )
 
 var _ sdk.SDKServer = &SDKServer{}
@@ -76,8 +87,10 @@ type SDKServer struct {
 	workerqueue        *workerqueue.WorkerQueue
 	streamMutex        sync.RWMutex
 	connectedStreams   []sdk.SDK_WatchGameServerServer
+	watchBuffer        []*sdk.GameServer
 	stop               <-chan struct{}
 	recorder           record.EventRecorder
+	metrics            *metrics
 }
 
 // NewSDKServer creates a SDKServer that sets up an
@@ -107,7 +120,9 @@ func NewSDKServer(gameServerName, namespace string, kubeClient kubernetes.Interf
 		healthMutex:        sync.RWMutex{},
 		healthFailureCount: 0,
 		streamMutex:        sync.RWMutex{},
+		metrics:            newMetrics(),
 	}
+	s.metrics.registerGauges(s)
 
 	s.informerFactory = factory
 	s.logger = runtime.NewLoggerWithType(s)
@@ -131,6 +146,7 @@ func NewSDKServer(gameServerName, namespace string, kubeClient kubernetes.Interf
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	})
+	mux.Handle("/metrics", s.metrics.handler())
 	mux.HandleFunc("/gshealthz", func(w http.ResponseWriter, r *http.Request) {
 		if s.healthy() {
 			_, err := w.Write([]byte("ok"))
@@ -216,6 +232,18 @@ func (s *SDKServer) syncGameServer(key string) error {
 		return s.syncLabel(rest)
 	case updateAnnotation:
 		return s.syncAnnotation(rest)
+	case updateLoad:
+		return s.syncLoad(rest)
+	case playerConnect:
+		return s.syncPlayerConnect(rest)
+	case playerDisconnect:
+		return s.syncPlayerDisconnect(rest)
+	case setCounterCount:
+		return s.syncSetCounterCount(rest)
+	case appendListValue:
+		return s.syncAppendListValue(rest)
+	case removeListValue:
+		return s.syncRemoveListValue(rest)
 	}
 
 	return errors.Errorf("could not sync game server key: %s", key)
@@ -227,12 +255,24 @@ func (s *SDKServer) syncState(rest []string) error {
 		return errors.New("could not sync state, as not state provided")
 	}
 
-	return s.updateState(stablev1alpha1.GameServerState(rest[0]))
+	state := stablev1alpha1.GameServerState(rest[0])
+
+	// a Reserved state carries the duration of the reservation in seconds
+	var reserved time.Duration
+	if state == stablev1alpha1.GameServerStateReserved && len(rest) > 1 {
+		seconds, err := strconv.ParseInt(rest[1], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "could not sync state: %#v", rest)
+		}
+		reserved = time.Duration(seconds) * time.Second
+	}
+
+	return s.updateState(state, reserved)
 }
 
 // updateState sets the GameServer Status's state to the state
 // that has been passed through
-func (s *SDKServer) updateState(state stablev1alpha1.GameServerState) error {
+func (s *SDKServer) updateState(state stablev1alpha1.GameServerState, reserved time.Duration) error {
 	s.logger.WithField("state", state).Info("Updating state")
 	gameServers := s.gameServerGetter.GameServers(s.namespace)
 	gs, err := s.gameServer()
@@ -247,6 +287,13 @@ func (s *SDKServer) updateState(state stablev1alpha1.GameServerState) error {
 	}
 
 	gs.Status.State = state
+	if state == stablev1alpha1.GameServerStateReserved && reserved > 0 {
+		rt := metav1.NewTime(s.clock.Now().UTC().Add(reserved))
+		gs.Status.ReservedUntil = &rt
+	} else {
+		gs.Status.ReservedUntil = nil
+	}
+	gs.Status.RecordStateTransition()
 	_, err = gameServers.Update(gs)
 
 	// state specific work here
@@ -319,6 +366,224 @@ func (s *SDKServer) updateAnnotation(key, value string) error {
 	return err
 }
 
+// syncLoad converts the string array values into values for
+// updateLoad
+func (s *SDKServer) syncLoad(rest []string) error {
+	if len(rest) == 0 {
+		return errors.New("could not sync load, as no load provided")
+	}
+
+	load, err := strconv.ParseFloat(rest[0], 64)
+	if err != nil {
+		return errors.Wrapf(err, "could not sync load: %#v", rest)
+	}
+
+	return s.updateLoad(load)
+}
+
+// updateLoad updates the game defined load score on this
+// GameServer's Status
+func (s *SDKServer) updateLoad(load float64) error {
+	s.logger.WithField("load", load).Info("updating load")
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+
+	gsCopy := gs.DeepCopy()
+	gsCopy.Status.Load = load
+
+	_, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	return err
+}
+
+// syncPlayerConnect converts the string array values into values
+// for updatePlayerConnect
+func (s *SDKServer) syncPlayerConnect(rest []string) error {
+	if len(rest) == 0 {
+		return errors.New("could not sync player connect, as no player id provided")
+	}
+
+	return s.updatePlayerConnect(rest[0])
+}
+
+// updatePlayerConnect adds the player to this GameServer's player
+// tracking Status
+func (s *SDKServer) updatePlayerConnect(id string) error {
+	s.logger.WithField("playerID", id).Info("player connected")
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+	if gs.Status.Players == nil {
+		return errors.Errorf("player tracking is not enabled for GameServer %s/%s", s.namespace, s.gameServerName)
+	}
+
+	gsCopy := gs.DeepCopy()
+	for _, p := range gsCopy.Status.Players.IDs {
+		if p == id {
+			return nil
+		}
+	}
+	gsCopy.Status.Players.IDs = append(gsCopy.Status.Players.IDs, id)
+	gsCopy.Status.Players.Count = int64(len(gsCopy.Status.Players.IDs))
+
+	_, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	return err
+}
+
+// syncPlayerDisconnect converts the string array values into values
+// for updatePlayerDisconnect
+func (s *SDKServer) syncPlayerDisconnect(rest []string) error {
+	if len(rest) == 0 {
+		return errors.New("could not sync player disconnect, as no player id provided")
+	}
+
+	return s.updatePlayerDisconnect(rest[0])
+}
+
+// updatePlayerDisconnect removes the player from this GameServer's player
+// tracking Status
+func (s *SDKServer) updatePlayerDisconnect(id string) error {
+	s.logger.WithField("playerID", id).Info("player disconnected")
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+	if gs.Status.Players == nil {
+		return errors.Errorf("player tracking is not enabled for GameServer %s/%s", s.namespace, s.gameServerName)
+	}
+
+	gsCopy := gs.DeepCopy()
+	for i, p := range gsCopy.Status.Players.IDs {
+		if p == id {
+			gsCopy.Status.Players.IDs = append(gsCopy.Status.Players.IDs[:i], gsCopy.Status.Players.IDs[i+1:]...)
+			gsCopy.Status.Players.Count = int64(len(gsCopy.Status.Players.IDs))
+			_, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncSetCounterCount converts the string array values into values
+// for updateCounterCount
+func (s *SDKServer) syncSetCounterCount(rest []string) error {
+	if len(rest) < 2 {
+		return errors.Errorf("could not sync counter count: %#v", rest)
+	}
+
+	count, err := strconv.ParseInt(rest[1], 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "could not sync counter count: %#v", rest)
+	}
+
+	return s.updateCounterCount(rest[0], count)
+}
+
+// updateCounterCount sets the count of the named counter on this
+// GameServer's Status
+func (s *SDKServer) updateCounterCount(name string, count int64) error {
+	s.logger.WithField("name", name).WithField("count", count).Info("updating counter count")
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+	counter, ok := gs.Status.Counters[name]
+	if !ok {
+		return errors.Errorf("counter %s is not declared on GameServer %s/%s", name, s.namespace, s.gameServerName)
+	}
+	if counter.Capacity > 0 && count > counter.Capacity {
+		return errors.Errorf("count %d exceeds counter %s capacity %d", count, name, counter.Capacity)
+	}
+
+	gsCopy := gs.DeepCopy()
+	counter.Count = count
+	gsCopy.Status.Counters[name] = counter
+
+	_, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	return err
+}
+
+// syncAppendListValue converts the string array values into values
+// for updateAppendListValue
+func (s *SDKServer) syncAppendListValue(rest []string) error {
+	if len(rest) < 2 {
+		return errors.Errorf("could not sync list append: %#v", rest)
+	}
+
+	return s.updateAppendListValue(rest[0], rest[1])
+}
+
+// updateAppendListValue appends the value to the named list on this
+// GameServer's Status, if it is not already present
+func (s *SDKServer) updateAppendListValue(name, value string) error {
+	s.logger.WithField("name", name).WithField("value", value).Info("appending list value")
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+	list, ok := gs.Status.Lists[name]
+	if !ok {
+		return errors.Errorf("list %s is not declared on GameServer %s/%s", name, s.namespace, s.gameServerName)
+	}
+	for _, v := range list.Values {
+		if v == value {
+			return nil
+		}
+	}
+	if list.Capacity > 0 && int64(len(list.Values)) >= list.Capacity {
+		return errors.Errorf("list %s is at capacity %d", name, list.Capacity)
+	}
+
+	gsCopy := gs.DeepCopy()
+	list = gsCopy.Status.Lists[name]
+	list.Values = append(list.Values, value)
+	gsCopy.Status.Lists[name] = list
+
+	_, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	return err
+}
+
+// syncRemoveListValue converts the string array values into values
+// for updateRemoveListValue
+func (s *SDKServer) syncRemoveListValue(rest []string) error {
+	if len(rest) < 2 {
+		return errors.Errorf("could not sync list remove: %#v", rest)
+	}
+
+	return s.updateRemoveListValue(rest[0], rest[1])
+}
+
+// updateRemoveListValue removes the value from the named list on this
+// GameServer's Status. Removing a value that is not in the list is not
+// an error
+func (s *SDKServer) updateRemoveListValue(name, value string) error {
+	s.logger.WithField("name", name).WithField("value", value).Info("removing list value")
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+	list, ok := gs.Status.Lists[name]
+	if !ok {
+		return errors.Errorf("list %s is not declared on GameServer %s/%s", name, s.namespace, s.gameServerName)
+	}
+
+	for i, v := range list.Values {
+		if v == value {
+			gsCopy := gs.DeepCopy()
+			list = gsCopy.Status.Lists[name]
+			list.Values = append(list.Values[:i], list.Values[i+1:]...)
+			gsCopy.Status.Lists[name] = list
+			_, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // enqueueState enqueue a State change request into the
 // workerqueue
 func (s *SDKServer) enqueueState(state stablev1alpha1.GameServerState) {
@@ -330,6 +595,7 @@ func (s *SDKServer) enqueueState(state stablev1alpha1.GameServerState) {
 // the workqueue so it can be updated
 func (s *SDKServer) Ready(ctx context.Context, e *sdk.Empty) (*sdk.Empty, error) {
 	s.logger.Info("Received Ready request, adding to queue")
+	s.metrics.countRPC("Ready", nil)
 	s.enqueueState(stablev1alpha1.GameServerStateRequestReady)
 	return e, nil
 }
@@ -338,6 +604,7 @@ func (s *SDKServer) Ready(ctx context.Context, e *sdk.Empty) (*sdk.Empty, error)
 // the workqueue so it can be updated
 func (s *SDKServer) Shutdown(ctx context.Context, e *sdk.Empty) (*sdk.Empty, error) {
 	s.logger.Info("Received Shutdown request, adding to queue")
+	s.metrics.countRPC("Shutdown", nil)
 	s.enqueueState(stablev1alpha1.GameServerStateShutdown)
 	return e, nil
 }
@@ -345,6 +612,7 @@ func (s *SDKServer) Shutdown(ctx context.Context, e *sdk.Empty) (*sdk.Empty, err
 // Health receives each health ping, and tracks the last time the health
 // check was received, to track if a GameServer is healthy
 func (s *SDKServer) Health(stream sdk.SDK_HealthServer) error {
+	s.metrics.countRPC("Health", nil)
 	for {
 		_, err := stream.Recv()
 		if err == io.EOF {
@@ -352,17 +620,141 @@ func (s *SDKServer) Health(stream sdk.SDK_HealthServer) error {
 			return stream.SendAndClose(&sdk.Empty{})
 		}
 		if err != nil {
+			s.metrics.rpcErrors.WithLabelValues("Health").Inc()
 			return errors.Wrap(err, "Error with Health check")
 		}
 		s.logger.Info("Health Ping Received")
+		s.metrics.healthPings.Inc()
 		s.touchHealthLastUpdated()
 	}
 }
 
+// Reserve moves this GameServer to the Reserved state for the Duration provided,
+// during which it will not be deleted on scale down, or be available for allocation
+func (s *SDKServer) Reserve(_ context.Context, d *sdk.Duration) (*sdk.Empty, error) {
+	s.logger.WithField("duration", d.Seconds).Info("Received Reserve request, adding to queue")
+	s.metrics.countRPC("Reserve", nil)
+	key := string(updateState) + "/" + string(stablev1alpha1.GameServerStateReserved) + "/" + strconv.FormatInt(d.Seconds, 10)
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
+// PlayerConnect adds the player id to be recorded as connected on the
+// `GameServer` player tracking status
+func (s *SDKServer) PlayerConnect(_ context.Context, id *sdk.PlayerID) (*sdk.Empty, error) {
+	s.logger.WithField("playerID", id.PlayerID).Info("Adding PlayerConnect to queue")
+	s.metrics.countRPC("PlayerConnect", nil)
+	key := string(playerConnect) + "/" + id.PlayerID
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
+// PlayerDisconnect adds the player id to be removed from the `GameServer`
+// player tracking status
+func (s *SDKServer) PlayerDisconnect(_ context.Context, id *sdk.PlayerID) (*sdk.Empty, error) {
+	s.logger.WithField("playerID", id.PlayerID).Info("Adding PlayerDisconnect to queue")
+	s.metrics.countRPC("PlayerDisconnect", nil)
+	key := string(playerDisconnect) + "/" + id.PlayerID
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
+// GetConnectedPlayers returns a bounded page of the player ids currently
+// recorded as connected on the `GameServer` player tracking status, optionally
+// filtered by an id prefix. A limit of 0 returns all matching ids
+func (s *SDKServer) GetConnectedPlayers(_ context.Context, in *sdk.ConnectedPlayersRequest) (*sdk.ConnectedPlayersList, error) {
+	s.logger.WithField("limit", in.Limit).WithField("offset", in.Offset).WithField("prefix", in.Prefix).
+		Info("Received GetConnectedPlayers request")
+	gs, err := s.gameServer()
+	s.metrics.countRPC("GetConnectedPlayers", err)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sdk.ConnectedPlayersList{}
+	if gs.Status.Players == nil {
+		return result, nil
+	}
+
+	skip := in.Offset
+	for _, id := range gs.Status.Players.IDs {
+		if in.Prefix != "" && !strings.HasPrefix(id, in.Prefix) {
+			continue
+		}
+		result.Total++
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if in.Limit > 0 && int64(len(result.List)) >= in.Limit {
+			continue
+		}
+		result.List = append(result.List, id)
+	}
+
+	return result, nil
+}
+
+// IsPlayerConnected returns whether the given player id is currently recorded
+// as connected on the `GameServer` player tracking status, without returning
+// the player list
+func (s *SDKServer) IsPlayerConnected(_ context.Context, id *sdk.PlayerID) (*sdk.Connected, error) {
+	s.logger.WithField("playerID", id.PlayerID).Info("Received IsPlayerConnected request")
+	gs, err := s.gameServer()
+	s.metrics.countRPC("IsPlayerConnected", err)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sdk.Connected{}
+	if gs.Status.Players == nil {
+		return result, nil
+	}
+	for _, connected := range gs.Status.Players.IDs {
+		if connected == id.PlayerID {
+			result.Connected = true
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// SetCounterCount adds the named counter count to be set on the
+// `GameServer` status
+func (s *SDKServer) SetCounterCount(_ context.Context, counter *sdk.Counter) (*sdk.Empty, error) {
+	s.logger.WithField("values", counter).Info("Adding SetCounterCount to queue")
+	s.metrics.countRPC("SetCounterCount", nil)
+	key := string(setCounterCount) + "/" + counter.Name + "/" + strconv.FormatInt(counter.Count, 10)
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
+// AppendListValue adds the value to be appended to the named list on the
+// `GameServer` status
+func (s *SDKServer) AppendListValue(_ context.Context, lv *sdk.ListValue) (*sdk.Empty, error) {
+	s.logger.WithField("values", lv).Info("Adding AppendListValue to queue")
+	s.metrics.countRPC("AppendListValue", nil)
+	key := string(appendListValue) + "/" + lv.Name + "/" + lv.Value
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
+// RemoveListValue adds the value to be removed from the named list on the
+// `GameServer` status
+func (s *SDKServer) RemoveListValue(_ context.Context, lv *sdk.ListValue) (*sdk.Empty, error) {
+	s.logger.WithField("values", lv).Info("Adding RemoveListValue to queue")
+	s.metrics.countRPC("RemoveListValue", nil)
+	key := string(removeListValue) + "/" + lv.Name + "/" + lv.Value
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
 // SetLabel adds the Key/Value to be used to set the label with the metadataPrefix to the `GameServer`
 // metdata
 func (s *SDKServer) SetLabel(_ context.Context, kv *sdk.KeyValue) (*sdk.Empty, error) {
 	s.logger.WithField("values", kv).Info("Adding SetLabel to queue")
+	s.metrics.countRPC("SetLabel", nil)
 	key := string(updateLabel) + "/" + kv.Key + "/" + kv.Value
 	s.workerqueue.Enqueue(cache.ExplicitKey(key))
 	return &sdk.Empty{}, nil
@@ -372,15 +764,59 @@ func (s *SDKServer) SetLabel(_ context.Context, kv *sdk.KeyValue) (*sdk.Empty, e
 // metdata
 func (s *SDKServer) SetAnnotation(_ context.Context, kv *sdk.KeyValue) (*sdk.Empty, error) {
 	s.logger.WithField("values", kv).Info("Adding SetLabel to queue")
+	s.metrics.countRPC("SetAnnotation", nil)
 	key := string(updateAnnotation) + "/" + kv.Key + "/" + kv.Value
 	s.workerqueue.Enqueue(cache.ExplicitKey(key))
 	return &sdk.Empty{}, nil
 }
 
+// SetLoad adds the game defined load score to be set on the `GameServer` status
+func (s *SDKServer) SetLoad(_ context.Context, load *sdk.Load) (*sdk.Empty, error) {
+	s.logger.WithField("values", load).Info("Adding SetLoad to queue")
+	s.metrics.countRPC("SetLoad", nil)
+	key := string(updateLoad) + "/" + strconv.FormatFloat(load.Load, 'f', -1, 64)
+	s.workerqueue.Enqueue(cache.ExplicitKey(key))
+	return &sdk.Empty{}, nil
+}
+
+// Log forwards a log line from the game server process into the sidecar's
+// structured logging pipeline, tagged with the GameServer name. Warning and
+// error lines are also recorded as Events on the backing GameServer
+func (s *SDKServer) Log(_ context.Context, entry *sdk.LogEntry) (*sdk.Empty, error) {
+	s.metrics.countRPC("Log", nil)
+	logger := s.logger.WithField("gameserver", s.gameServerName).WithField("source", "gameserver")
+
+	switch strings.ToLower(entry.Level) {
+	case "debug":
+		logger.Debug(entry.Message)
+	case "warn", "warning":
+		logger.Warn(entry.Message)
+		s.recordLogEvent(corev1.EventTypeWarning, entry.Message)
+	case "error":
+		logger.Error(entry.Message)
+		s.recordLogEvent(corev1.EventTypeWarning, entry.Message)
+	default:
+		logger.Info(entry.Message)
+	}
+
+	return &sdk.Empty{}, nil
+}
+
+// recordLogEvent records a log line as an Event on the backing GameServer, best effort
+func (s *SDKServer) recordLogEvent(eventType string, message string) {
+	gs, err := s.gameServer()
+	if err != nil {
+		s.logger.WithError(err).Warn("could not record log event on GameServer")
+		return
+	}
+	s.recorder.Event(gs, eventType, "GameServerLog", message)
+}
+
 // GetGameServer returns the current GameServer configuration and state from the backing GameServer CRD
 func (s *SDKServer) GetGameServer(context.Context, *sdk.Empty) (*sdk.GameServer, error) {
 	s.logger.Info("Received GetGameServer request")
 	gs, err := s.gameServer()
+	s.metrics.countRPC("GetGameServer", err)
 	if err != nil {
 		return nil, err
 	}
@@ -389,10 +825,28 @@ func (s *SDKServer) GetGameServer(context.Context, *sdk.Empty) (*sdk.GameServer,
 }
 
 // WatchGameServer sends events through the stream when changes occur to the
-// backing GameServer configuration / status
+// backing GameServer configuration / status. On connection, recent updates are
+// replayed first, so a game process that subscribes late - for example, after
+// an allocation has already happened - still sees the current state
 func (s *SDKServer) WatchGameServer(_ *sdk.Empty, stream sdk.SDK_WatchGameServerServer) error {
 	s.logger.Info("Received WatchGameServer request, adding stream to connectedStreams")
+	s.metrics.countRPC("WatchGameServer", nil)
+
 	s.streamMutex.Lock()
+	// if nothing has been sent yet, fall back to a snapshot from the
+	// informer cache, so the subscriber doesn't start blind
+	replay := s.watchBuffer
+	if len(replay) == 0 {
+		if gs, err := s.gameServer(); err == nil {
+			replay = []*sdk.GameServer{convert(gs)}
+		}
+	}
+	for _, gs := range replay {
+		if err := stream.Send(gs); err != nil {
+			s.streamMutex.Unlock()
+			return errors.Wrap(err, "error replaying buffered game server updates")
+		}
+	}
 	s.connectedStreams = append(s.connectedStreams, stream)
 	s.streamMutex.Unlock()
 	// don't exit until we shutdown, because that will close the stream
@@ -404,11 +858,19 @@ func (s *SDKServer) WatchGameServer(_ *sdk.Empty, stream sdk.SDK_WatchGameServer
 func (s *SDKServer) sendGameServerUpdate(gs *stablev1alpha1.GameServer) {
 	s.logger.Info("Sending GameServer Event to connectedStreams")
 
-	s.streamMutex.RLock()
-	defer s.streamMutex.RUnlock()
+	update := convert(gs)
+
+	s.streamMutex.Lock()
+	defer s.streamMutex.Unlock()
+
+	// keep the update around for replay to late subscribers
+	s.watchBuffer = append(s.watchBuffer, update)
+	if len(s.watchBuffer) > watchBufferSize {
+		s.watchBuffer = s.watchBuffer[len(s.watchBuffer)-watchBufferSize:]
+	}
 
 	for _, stream := range s.connectedStreams {
-		err := stream.Send(convert(gs))
+		err := stream.Send(update)
 		// We essentially ignoring any disconnected streams.
 		// I think this is fine, as disconnections shouldn't actually happen.
 		// but we should log them, just in case they do happen, and we can track it
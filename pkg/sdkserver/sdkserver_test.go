@@ -174,6 +174,7 @@ func TestSDKServerSyncGameServer(t *testing.T) {
 		state       v1alpha1.GameServerState
 		labels      map[string]string
 		annotations map[string]string
+		load        float64
 	}
 
 	fixtures := map[string]struct {
@@ -198,6 +199,12 @@ func TestSDKServerSyncGameServer(t *testing.T) {
 				annotations: map[string]string{metadataPrefix + "test": "annotation"},
 			},
 		},
+		"load": {
+			key: string(updateLoad) + "/0.75",
+			expected: expected{
+				load: 0.75,
+			},
+		},
 	}
 
 	for k, v := range fixtures {
@@ -229,6 +236,9 @@ func TestSDKServerSyncGameServer(t *testing.T) {
 				for ann, value := range v.expected.annotations {
 					assert.Equal(t, value, gs.ObjectMeta.Annotations[ann])
 				}
+				if v.expected.load != 0 {
+					assert.Equal(t, v.expected.load, gs.Status.Load)
+				}
 
 				return true, gs, nil
 			})
@@ -246,6 +256,220 @@ func TestSDKServerSyncGameServer(t *testing.T) {
 	}
 }
 
+func TestSDKServerPlayerConnectAndDisconnect(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		key          string
+		players      []string
+		expectedIDs  []string
+		expectUpdate bool
+	}{
+		"connect": {
+			key:          string(playerConnect) + "/one",
+			players:      []string{"two"},
+			expectedIDs:  []string{"two", "one"},
+			expectUpdate: true,
+		},
+		"connect duplicate": {
+			key:     string(playerConnect) + "/one",
+			players: []string{"one"},
+		},
+		"disconnect": {
+			key:          string(playerDisconnect) + "/one",
+			players:      []string{"one", "two"},
+			expectedIDs:  []string{"two"},
+			expectUpdate: true,
+		},
+		"disconnect unknown": {
+			key:     string(playerDisconnect) + "/three",
+			players: []string{"one"},
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			m := agtesting.NewMocks()
+			sc, err := defaultSidecar(m)
+			assert.Nil(t, err)
+			updated := false
+
+			m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				gs := v1alpha1.GameServer{
+					ObjectMeta: metav1.ObjectMeta{Name: sc.gameServerName, Namespace: sc.namespace},
+					Status: v1alpha1.GameServerStatus{
+						Players: &v1alpha1.PlayerStatus{
+							Capacity: 10,
+							Count:    int64(len(v.players)),
+							IDs:      v.players,
+						},
+					},
+				}
+				return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+			})
+			m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				updated = true
+				ua := action.(k8stesting.UpdateAction)
+				gs := ua.GetObject().(*v1alpha1.GameServer)
+
+				if assert.NotNil(t, gs.Status.Players) {
+					assert.Equal(t, v.expectedIDs, gs.Status.Players.IDs)
+					assert.Equal(t, int64(len(v.expectedIDs)), gs.Status.Players.Count)
+				}
+
+				return true, gs, nil
+			})
+
+			stop := make(chan struct{})
+			defer close(stop)
+			sc.informerFactory.Start(stop)
+			assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+			err = sc.syncGameServer(v.key)
+			assert.Nil(t, err)
+			assert.Equal(t, v.expectUpdate, updated)
+		})
+	}
+}
+
+func TestSDKServerGetConnectedPlayers(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	sc, err := defaultSidecar(m)
+	assert.Nil(t, err)
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := v1alpha1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: sc.gameServerName, Namespace: sc.namespace},
+			Status: v1alpha1.GameServerStatus{
+				Players: &v1alpha1.PlayerStatus{
+					Capacity: 10,
+					Count:    3,
+					IDs:      []string{"one", "two", "three"},
+				},
+			},
+		}
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+	list, err := sc.GetConnectedPlayers(context.Background(), &sdk.ConnectedPlayersRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, list.List)
+	assert.Equal(t, int64(3), list.Total)
+
+	list, err = sc.GetConnectedPlayers(context.Background(), &sdk.ConnectedPlayersRequest{Limit: 1, Offset: 1, Prefix: "t"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"three"}, list.List)
+	assert.Equal(t, int64(2), list.Total)
+
+	connected, err := sc.IsPlayerConnected(context.Background(), &sdk.PlayerID{PlayerID: "two"})
+	assert.Nil(t, err)
+	assert.True(t, connected.Connected)
+
+	connected, err = sc.IsPlayerConnected(context.Background(), &sdk.PlayerID{PlayerID: "four"})
+	assert.Nil(t, err)
+	assert.False(t, connected.Connected)
+}
+
+func TestSDKServerCountersAndLists(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		key            string
+		expectedCount  int64
+		expectedValues []string
+		expectUpdate   bool
+		expectErr      bool
+	}{
+		"set counter count": {
+			key:           string(setCounterCount) + "/rooms/5",
+			expectedCount: 5,
+			expectUpdate:  true,
+		},
+		"counter count over capacity": {
+			key:       string(setCounterCount) + "/rooms/11",
+			expectErr: true,
+		},
+		"undeclared counter": {
+			key:       string(setCounterCount) + "/sessions/1",
+			expectErr: true,
+		},
+		"append list value": {
+			key:            string(appendListValue) + "/matches/two",
+			expectedValues: []string{"one", "two"},
+			expectUpdate:   true,
+		},
+		"append duplicate list value": {
+			key: string(appendListValue) + "/matches/one",
+		},
+		"remove list value": {
+			key:            string(removeListValue) + "/matches/one",
+			expectedValues: []string{},
+			expectUpdate:   true,
+		},
+		"remove unknown list value": {
+			key: string(removeListValue) + "/matches/three",
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			m := agtesting.NewMocks()
+			sc, err := defaultSidecar(m)
+			assert.Nil(t, err)
+			updated := false
+
+			m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				gs := v1alpha1.GameServer{
+					ObjectMeta: metav1.ObjectMeta{Name: sc.gameServerName, Namespace: sc.namespace},
+					Status: v1alpha1.GameServerStatus{
+						Counters: map[string]v1alpha1.CounterStatus{
+							"rooms": {Count: 2, Capacity: 10},
+						},
+						Lists: map[string]v1alpha1.ListStatus{
+							"matches": {Capacity: 10, Values: []string{"one"}},
+						},
+					},
+				}
+				return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+			})
+			m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				updated = true
+				ua := action.(k8stesting.UpdateAction)
+				gs := ua.GetObject().(*v1alpha1.GameServer)
+
+				if v.expectedCount > 0 {
+					assert.Equal(t, v.expectedCount, gs.Status.Counters["rooms"].Count)
+				}
+				if v.expectedValues != nil {
+					assert.Equal(t, v.expectedValues, gs.Status.Lists["matches"].Values)
+				}
+
+				return true, gs, nil
+			})
+
+			stop := make(chan struct{})
+			defer close(stop)
+			sc.informerFactory.Start(stop)
+			assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+			err = sc.syncGameServer(v.key)
+			if v.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, v.expectUpdate, updated)
+		})
+	}
+}
+
 func TestSidecarUpdateState(t *testing.T) {
 	t.Parallel()
 
@@ -275,12 +499,55 @@ func TestSidecarUpdateState(t *testing.T) {
 		sc.informerFactory.Start(stop)
 		assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
 
-		err = sc.updateState(v1alpha1.GameServerStateReady)
+		err = sc.updateState(v1alpha1.GameServerStateReady, 0)
 		assert.Nil(t, err)
 		assert.False(t, updated)
 	})
 }
 
+func TestSDKServerReserve(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	sc, err := defaultSidecar(m)
+	assert.Nil(t, err)
+
+	now := time.Now().UTC()
+	fc := clock.NewFakeClock(now)
+	sc.clock = fc
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := v1alpha1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: sc.gameServerName, Namespace: sc.namespace},
+			Status: v1alpha1.GameServerStatus{
+				State: v1alpha1.GameServerStateReady,
+			},
+		}
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{gs}}, nil
+	})
+
+	updated := false
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updated = true
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*v1alpha1.GameServer)
+		assert.Equal(t, v1alpha1.GameServerStateReserved, gs.Status.State)
+		if assert.NotNil(t, gs.Status.ReservedUntil) {
+			assert.Equal(t, now.Add(10*time.Second), gs.Status.ReservedUntil.Time)
+		}
+		return true, gs, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+	err = sc.syncState([]string{string(v1alpha1.GameServerStateReserved), "10"})
+	assert.Nil(t, err)
+	assert.True(t, updated, "GameServer should have been updated")
+}
+
 func TestSidecarHealthLastUpdated(t *testing.T) {
 	t.Parallel()
 	now := time.Now().UTC()
@@ -532,6 +799,60 @@ func TestSDKServerWatchGameServer(t *testing.T) {
 	assert.Equal(t, stream, sc.connectedStreams[1])
 }
 
+func TestSDKServerWatchGameServerReplay(t *testing.T) {
+	t.Parallel()
+
+	fixture := &v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status:     v1alpha1.GameServerStatus{State: v1alpha1.GameServerStateReady},
+	}
+
+	m := agtesting.NewMocks()
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: []v1alpha1.GameServer{*fixture}}, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	sc, err := defaultSidecar(m)
+	assert.Nil(t, err)
+	sc.stop = stop
+
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+	// before any update has been sent, a subscriber gets a snapshot from
+	// the informer cache
+	stream := newGameServerMockStream()
+	asyncWatchGameServer(t, sc, stream)
+	select {
+	case sdkGS := <-stream.msgs:
+		assert.Equal(t, string(v1alpha1.GameServerStateReady), sdkGS.Status.State)
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "should have received a snapshot on subscribe")
+	}
+
+	allocated := fixture.DeepCopy()
+	allocated.Status.State = v1alpha1.GameServerStateAllocated
+	sc.sendGameServerUpdate(allocated)
+	select {
+	case <-stream.msgs:
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "connected stream should have received the update")
+	}
+
+	// a late subscriber gets the buffered update replayed on connection
+	stream = newGameServerMockStream()
+	asyncWatchGameServer(t, sc, stream)
+	select {
+	case sdkGS := <-stream.msgs:
+		assert.Equal(t, string(v1alpha1.GameServerStateAllocated), sdkGS.Status.State)
+	case <-time.After(3 * time.Second):
+		assert.Fail(t, "should have received the buffered update on subscribe")
+	}
+}
+
 func TestSDKServerSendGameServerUpdate(t *testing.T) {
 	t.Parallel()
 	m := agtesting.NewMocks()
@@ -0,0 +1,174 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"sort"
+	"sync"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agonesfake "agones.dev/agones/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// AllocationSimulator is a small in memory implementation of GameServer
+// allocation for a fake clientset, so that code that creates
+// GameServerAllocations can be unit tested without a running cluster.
+// It tracks a set of GameServers, and allocation requests against the
+// clientset it is installed on match Ready GameServers by the allocation's
+// required and preferred selectors, the same way the real controller does
+type AllocationSimulator struct {
+	mu          sync.Mutex
+	gameServers map[string]*v1alpha1.GameServer
+}
+
+// NewAllocationSimulator returns an AllocationSimulator tracking the given
+// GameServers
+func NewAllocationSimulator(gameServers ...*v1alpha1.GameServer) *AllocationSimulator {
+	s := &AllocationSimulator{gameServers: map[string]*v1alpha1.GameServer{}}
+	s.Add(gameServers...)
+	return s
+}
+
+// Add adds GameServers to the set being tracked
+func (s *AllocationSimulator) Add(gameServers ...*v1alpha1.GameServer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, gs := range gameServers {
+		s.gameServers[gs.ObjectMeta.Namespace+"/"+gs.ObjectMeta.Name] = gs.DeepCopy()
+	}
+}
+
+// GameServer returns a copy of a tracked GameServer, or nil if it is not
+// tracked. Useful for asserting the state an allocation left it in
+func (s *AllocationSimulator) GameServer(namespace, name string) *v1alpha1.GameServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if gs, ok := s.gameServers[namespace+"/"+name]; ok {
+		return gs.DeepCopy()
+	}
+	return nil
+}
+
+// Install adds reactors to the fake clientset that serve GameServer lists
+// from the tracked set, and fulfil GameServerAllocation creation against it
+func (s *AllocationSimulator) Install(clientset *agonesfake.Clientset) {
+	clientset.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		list := &v1alpha1.GameServerList{}
+		for _, gs := range s.sorted() {
+			if ns := action.GetNamespace(); ns == "" || ns == gs.ObjectMeta.Namespace {
+				list.Items = append(list.Items, *gs.DeepCopy())
+			}
+		}
+		return true, list, nil
+	})
+
+	clientset.AddReactor("create", "gameserverallocations", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ca := action.(k8stesting.CreateAction)
+		gsa := ca.GetObject().(*v1alpha1.GameServerAllocation).DeepCopy()
+		gsa.ApplyDefaults()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		gs, err := s.allocate(action.GetNamespace(), gsa)
+		if err != nil {
+			return true, nil, err
+		}
+
+		if gs == nil {
+			gsa.Status.State = v1alpha1.GameServerAllocationUnAllocated
+			return true, gsa, nil
+		}
+
+		gs.Status.State = v1alpha1.GameServerStateAllocated
+		for key, value := range gsa.Spec.MetaPatch.Labels {
+			if gs.ObjectMeta.Labels == nil {
+				gs.ObjectMeta.Labels = map[string]string{}
+			}
+			gs.ObjectMeta.Labels[key] = value
+		}
+		for key, value := range gsa.Spec.MetaPatch.Annotations {
+			if gs.ObjectMeta.Annotations == nil {
+				gs.ObjectMeta.Annotations = map[string]string{}
+			}
+			gs.ObjectMeta.Annotations[key] = value
+		}
+
+		gsa.Status.State = v1alpha1.GameServerAllocationAllocated
+		gsa.Status.GameServerName = gs.ObjectMeta.Name
+		gsa.Status.Ports = gs.Status.Ports
+		gsa.Status.Address = gs.Status.Address
+		gsa.Status.NodeName = gs.Status.NodeName
+
+		return true, gsa, nil
+	})
+}
+
+// allocate finds the Ready GameServer to allocate: preferred selectors are
+// attempted in order over the required set, the same way the controller does.
+// Returns nil if nothing matches. Must be called under the lock
+func (s *AllocationSimulator) allocate(namespace string, gsa *v1alpha1.GameServerAllocation) (*v1alpha1.GameServer, error) {
+	required, err := metav1.LabelSelectorAsSelector(&gsa.Spec.Required)
+	if err != nil {
+		return nil, err
+	}
+	preferred, err := gsa.Spec.PreferredSelectors()
+	if err != nil {
+		return nil, err
+	}
+
+	var ready []*v1alpha1.GameServer
+	for _, gs := range s.sorted() {
+		if gs.ObjectMeta.Namespace == namespace && gs.Status.State == v1alpha1.GameServerStateReady &&
+			required.Matches(labels.Set(gs.ObjectMeta.Labels)) {
+			ready = append(ready, gs)
+		}
+	}
+
+	for _, p := range preferred {
+		for _, gs := range ready {
+			if p.Matches(labels.Set(gs.ObjectMeta.Labels)) {
+				return gs, nil
+			}
+		}
+	}
+	if len(ready) > 0 {
+		return ready[0], nil
+	}
+	return nil, nil
+}
+
+// sorted returns the tracked GameServers ordered by namespace and name, so
+// that allocation is deterministic. Must be called under the lock
+func (s *AllocationSimulator) sorted() []*v1alpha1.GameServer {
+	keys := make([]string, 0, len(s.gameServers))
+	for key := range s.gameServers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list := make([]*v1alpha1.GameServer, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, s.gameServers[key])
+	}
+	return list
+}
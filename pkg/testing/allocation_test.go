@@ -0,0 +1,85 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	gotesting "testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAllocationSimulator(t *gotesting.T) {
+	t.Parallel()
+
+	f := FleetFixture("default", "simple-udp", 3)
+
+	t.Run("allocates a ready gameserver from the fleet", func(t *gotesting.T) {
+		m := NewMocks()
+		s := NewAllocationSimulator(FleetGameServerFixture(f, "simple-udp-1"))
+		s.Install(m.AgonesClient)
+
+		gsa := &v1alpha1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa"},
+			Spec: v1alpha1.GameServerAllocationSpec{
+				Required:  metav1.LabelSelector{MatchLabels: map[string]string{v1alpha1.FleetNameLabel: f.ObjectMeta.Name}},
+				MetaPatch: v1alpha1.MetaPatch{Labels: map[string]string{"session": "1234"}},
+			}}
+
+		result, err := m.AgonesClient.StableV1alpha1().GameServerAllocations("default").Create(gsa)
+		assert.Nil(t, err)
+		assert.Equal(t, v1alpha1.GameServerAllocationAllocated, result.Status.State)
+		assert.Equal(t, "simple-udp-1", result.Status.GameServerName)
+		assert.Equal(t, "127.0.0.1", result.Status.Address)
+		assert.NotEmpty(t, result.Status.Ports)
+
+		gs := s.GameServer("default", "simple-udp-1")
+		assert.Equal(t, v1alpha1.GameServerStateAllocated, gs.Status.State)
+		assert.Equal(t, "1234", gs.ObjectMeta.Labels["session"])
+	})
+
+	t.Run("prefers gameservers matching the preferred selector", func(t *gotesting.T) {
+		m := NewMocks()
+		gs2 := FleetGameServerFixture(f, "simple-udp-2")
+		gs2.ObjectMeta.Labels["map"] = "desert"
+		s := NewAllocationSimulator(FleetGameServerFixture(f, "simple-udp-1"), gs2)
+		s.Install(m.AgonesClient)
+
+		gsa := &v1alpha1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa"},
+			Spec: v1alpha1.GameServerAllocationSpec{
+				Required:  metav1.LabelSelector{MatchLabels: map[string]string{v1alpha1.FleetNameLabel: f.ObjectMeta.Name}},
+				Preferred: []metav1.LabelSelector{{MatchLabels: map[string]string{"map": "desert"}}},
+			}}
+
+		result, err := m.AgonesClient.StableV1alpha1().GameServerAllocations("default").Create(gsa)
+		assert.Nil(t, err)
+		assert.Equal(t, v1alpha1.GameServerAllocationAllocated, result.Status.State)
+		assert.Equal(t, "simple-udp-2", result.Status.GameServerName)
+	})
+
+	t.Run("returns UnAllocated when nothing is ready", func(t *gotesting.T) {
+		m := NewMocks()
+		gs := FleetGameServerFixture(f, "simple-udp-1")
+		gs.Status.State = v1alpha1.GameServerStateAllocated
+		s := NewAllocationSimulator(gs)
+		s.Install(m.AgonesClient)
+
+		gsa := &v1alpha1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa"}}
+		result, err := m.AgonesClient.StableV1alpha1().GameServerAllocations("default").Create(gsa)
+		assert.Nil(t, err)
+		assert.Equal(t, v1alpha1.GameServerAllocationUnAllocated, result.Status.State)
+		assert.Empty(t, result.Status.GameServerName)
+	})
+}
@@ -13,4 +13,7 @@
 // limitations under the License.
 
 // Package testing is for project wide testing utilities.
+// It is also a supported library for anyone writing tests for
+// systems built on top of Agones - see the fixtures, reactor
+// helpers, AllocationSimulator and webhook AdmissionReview builders.
 package testing
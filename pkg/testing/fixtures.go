@@ -0,0 +1,89 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// Fixtures for the Agones resource types, for use by tests both in this
+// project and in operators built on top of it
+
+// GameServerFixture returns a minimal valid GameServer, with defaults
+// applied. Mutate the result to suit the test at hand
+func GameServerFixture(namespace, name string) *v1alpha1.GameServer {
+	gs := &v1alpha1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       uuid.NewUUID(),
+		},
+		Spec: v1alpha1.GameServerSpec{
+			Ports: []v1alpha1.GameServerPort{{ContainerPort: 7777}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "gameserver", Image: "gameserver/image:test"}},
+				},
+			},
+		},
+	}
+	gs.ApplyDefaults()
+	return gs
+}
+
+// ReadyGameServerFixture returns a GameServer in the Ready state, with its
+// address and ports populated, as the gameservers controller would leave it
+func ReadyGameServerFixture(namespace, name string) *v1alpha1.GameServer {
+	gs := GameServerFixture(namespace, name)
+	gs.Status.State = v1alpha1.GameServerStateReady
+	gs.Status.Address = "127.0.0.1"
+	gs.Status.NodeName = "node-1"
+	gs.Status.Ports = []v1alpha1.GameServerStatusPort{{Name: gs.Spec.Ports[0].Name, Port: 7777}}
+	return gs
+}
+
+// FleetFixture returns a minimal valid Fleet with the given number of
+// replicas, with defaults applied
+func FleetFixture(namespace, name string, replicas int32) *v1alpha1.Fleet {
+	f := &v1alpha1.Fleet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       uuid.NewUUID(),
+		},
+		Spec: v1alpha1.FleetSpec{
+			Replicas: replicas,
+			Template: v1alpha1.GameServerTemplateSpec{
+				Spec: GameServerFixture(namespace, name).Spec,
+			},
+		},
+	}
+	f.ApplyDefaults()
+	return f
+}
+
+// FleetGameServerFixture returns a Ready GameServer labelled as a member of
+// the given Fleet, the way the fleet's GameServerSet would create it
+func FleetGameServerFixture(f *v1alpha1.Fleet, name string) *v1alpha1.GameServer {
+	gs := ReadyGameServerFixture(f.ObjectMeta.Namespace, name)
+	if gs.ObjectMeta.Labels == nil {
+		gs.ObjectMeta.Labels = map[string]string{}
+	}
+	gs.ObjectMeta.Labels[v1alpha1.FleetNameLabel] = f.ObjectMeta.Name
+	return gs
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	gotesting "testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	agonesfake "agones.dev/agones/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Reactor helpers for the fake Agones clientset, so that tests don't
+// need to hand write the common ones
+
+// AddGameServerListReactor makes the fake clientset list the given
+// GameServers, which also populates informer caches on sync
+func AddGameServerListReactor(clientset *agonesfake.Clientset, gameServers ...v1alpha1.GameServer) {
+	clientset.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.GameServerList{Items: gameServers}, nil
+	})
+}
+
+// AddFleetListReactor makes the fake clientset list the given Fleets,
+// which also populates informer caches on sync
+func AddFleetListReactor(clientset *agonesfake.Clientset, fleets ...v1alpha1.Fleet) {
+	clientset.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1alpha1.FleetList{Items: fleets}, nil
+	})
+}
+
+// FailOnAction makes any request for the given verb and resource fail the
+// test immediately. Useful for asserting that code under test does not
+// perform an operation
+func FailOnAction(t *gotesting.T, clientset *agonesfake.Clientset, verb, resource string) {
+	clientset.AddReactor(verb, resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		assert.FailNow(t, "should not "+verb+" "+resource)
+		return true, nil, nil
+	})
+}
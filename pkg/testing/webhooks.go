@@ -0,0 +1,173 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"sort"
+	gotesting "testing"
+
+	"github.com/mattbaird/jsonpatch"
+	"github.com/stretchr/testify/assert"
+	admv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// update rewrites golden files with the current test output, rather than
+// comparing against them. Run `go test -update` after deliberately changing
+// what a webhook handler produces
+var update = flag.Bool("update", false, "update golden files with the current test output")
+
+// AdmissionReviewBuilder builds AdmissionReviews for passing to
+// validating and mutating webhook handlers in tests
+type AdmissionReviewBuilder struct {
+	t      *gotesting.T
+	review admv1beta1.AdmissionReview
+}
+
+// NewAdmissionReview starts building an AdmissionReview for
+// a Create operation, with an allowed Response
+func NewAdmissionReview(t *gotesting.T) *AdmissionReviewBuilder {
+	return &AdmissionReviewBuilder{
+		t: t,
+		review: admv1beta1.AdmissionReview{
+			Request: &admv1beta1.AdmissionRequest{
+				Operation: admv1beta1.Create,
+			},
+			Response: &admv1beta1.AdmissionResponse{Allowed: true},
+		},
+	}
+}
+
+// Kind sets the kind of the resource under review
+func (b *AdmissionReviewBuilder) Kind(gvk metav1.GroupVersionKind) *AdmissionReviewBuilder {
+	b.review.Request.Kind = gvk
+	return b
+}
+
+// Operation sets the operation under review. Defaults to Create
+func (b *AdmissionReviewBuilder) Operation(op admv1beta1.Operation) *AdmissionReviewBuilder {
+	b.review.Request.Operation = op
+	return b
+}
+
+// Namespace sets the namespace of the request
+func (b *AdmissionReviewBuilder) Namespace(namespace string) *AdmissionReviewBuilder {
+	b.review.Request.Namespace = namespace
+	return b
+}
+
+// Object sets the object under review, marshalling it to json.
+// Fails the test immediately if it cannot be marshalled
+func (b *AdmissionReviewBuilder) Object(obj interface{}) *AdmissionReviewBuilder {
+	b.review.Request.Object = runtime.RawExtension{Raw: b.marshal(obj)}
+	return b
+}
+
+// OldObject sets the previous version of the object under review,
+// for Update and Delete operations
+func (b *AdmissionReviewBuilder) OldObject(obj interface{}) *AdmissionReviewBuilder {
+	b.review.Request.OldObject = runtime.RawExtension{Raw: b.marshal(obj)}
+	return b
+}
+
+// Review returns the built AdmissionReview
+func (b *AdmissionReviewBuilder) Review() admv1beta1.AdmissionReview {
+	return b.review
+}
+
+func (b *AdmissionReviewBuilder) marshal(obj interface{}) []byte {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		assert.FailNow(b.t, "could not marshal object under review", "%v", err)
+	}
+	return raw
+}
+
+// PatchOperations decodes the json patch from a mutating webhook handler's
+// response. Fails the test immediately if there is no patch, or it cannot
+// be decoded
+func PatchOperations(t *gotesting.T, review admv1beta1.AdmissionReview) jsonpatch.ByPath {
+	if review.Response.Patch == nil {
+		assert.FailNow(t, "response does not contain a patch")
+	}
+
+	patch := jsonpatch.ByPath{}
+	if err := json.Unmarshal(review.Response.Patch, &patch); err != nil {
+		assert.FailNow(t, "could not decode response patch", "%v", err)
+	}
+	return patch
+}
+
+// AssertPatchContains asserts that the patch in a mutating webhook handler's
+// response contains the given operation
+func AssertPatchContains(t *gotesting.T, review admv1beta1.AdmissionReview, op jsonpatch.JsonPatchOperation) {
+	patch := PatchOperations(t, review)
+	for _, p := range patch {
+		if assert.ObjectsAreEqualValues(p, op) {
+			return
+		}
+	}
+
+	assert.Fail(t, "could not find operation", "operation %#v not in patch %v", op, patch)
+}
+
+// AssertPatchGolden asserts that the patch in a mutating webhook handler's
+// response matches the golden file at the given path. Operations are sorted
+// by path, since patch generation order is not stable. Pass -update to
+// rewrite the golden file with the current patch
+func AssertPatchGolden(t *gotesting.T, goldenPath string, review admv1beta1.AdmissionReview) {
+	patch := PatchOperations(t, review)
+	sort.Sort(patch)
+	current, err := json.MarshalIndent(patch, "", "  ")
+	if err != nil {
+		assert.FailNow(t, "could not marshal response patch", "%v", err)
+	}
+	current = append(current, '\n')
+
+	if *update {
+		if err := ioutil.WriteFile(goldenPath, current, 0644); err != nil {
+			assert.FailNow(t, "could not update golden file "+goldenPath, "%v", err)
+		}
+	}
+
+	expected, err := ioutil.ReadFile(goldenPath)
+	if err != nil {
+		assert.FailNow(t, "could not read golden file "+goldenPath+", run with -update to create it", "%v", err)
+	}
+
+	assert.Equal(t, string(expected), string(current))
+}
+
+// AssertReviewAllowed asserts that a webhook handler allowed the request
+func AssertReviewAllowed(t *gotesting.T, review admv1beta1.AdmissionReview) {
+	assert.True(t, review.Response.Allowed, "expected request to be allowed: %v", review.Response.Result)
+}
+
+// AssertReviewDenied asserts that a webhook handler denied the request,
+// and returns the causes for further inspection
+func AssertReviewDenied(t *gotesting.T, review admv1beta1.AdmissionReview) []metav1.StatusCause {
+	if !assert.False(t, review.Response.Allowed, "expected request to be denied") {
+		return nil
+	}
+
+	if review.Response.Result == nil || review.Response.Result.Details == nil {
+		assert.FailNow(t, "denied response does not contain details")
+	}
+	return review.Response.Result.Details.Causes
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"encoding/json"
+	gotesting "testing"
+
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"github.com/mattbaird/jsonpatch"
+	"github.com/stretchr/testify/assert"
+	admv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAdmissionReviewBuilder(t *gotesting.T) {
+	t.Parallel()
+
+	gs := GameServerFixture("default", "builder")
+	gvk := metav1.GroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind("GameServer"))
+
+	review := NewAdmissionReview(t).
+		Kind(gvk).
+		Operation(admv1beta1.Update).
+		Namespace("default").
+		Object(gs).
+		OldObject(gs).
+		Review()
+
+	assert.Equal(t, gvk, review.Request.Kind)
+	assert.Equal(t, admv1beta1.Update, review.Request.Operation)
+	assert.Equal(t, "default", review.Request.Namespace)
+	assert.True(t, review.Response.Allowed)
+
+	unmarshalled := &v1alpha1.GameServer{}
+	assert.Nil(t, json.Unmarshal(review.Request.Object.Raw, unmarshalled))
+	assert.Equal(t, gs.ObjectMeta.Name, unmarshalled.ObjectMeta.Name)
+	assert.Nil(t, json.Unmarshal(review.Request.OldObject.Raw, unmarshalled))
+	assert.Equal(t, gs.ObjectMeta.Name, unmarshalled.ObjectMeta.Name)
+}
+
+func TestPatchAssertions(t *gotesting.T) {
+	t.Parallel()
+
+	// mimic what a mutating handler does: diff the object under review
+	// against a defaulted copy, and respond with the patch
+	before := GameServerFixture("default", "golden")
+	before.Spec.Ports[0].Protocol = ""
+	before.Spec.Scheduling = ""
+
+	after := before.DeepCopy()
+	after.Spec.Ports[0].Protocol = "UDP"
+	after.Spec.Scheduling = v1alpha1.Packed
+
+	review := NewAdmissionReview(t).Object(before).Review()
+
+	oldJSON, err := json.Marshal(before)
+	assert.Nil(t, err)
+	newJSON, err := json.Marshal(after)
+	assert.Nil(t, err)
+	patch, err := jsonpatch.CreatePatch(oldJSON, newJSON)
+	assert.Nil(t, err)
+	review.Response.Patch, err = json.Marshal(patch)
+	assert.Nil(t, err)
+
+	assert.Len(t, PatchOperations(t, review), 2)
+	AssertPatchContains(t, review, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/ports/0/protocol", Value: "UDP"})
+	AssertPatchContains(t, review, jsonpatch.JsonPatchOperation{Operation: "add", Path: "/spec/scheduling", Value: "Packed"})
+	AssertPatchGolden(t, "testdata/mutate-gameserver.json", review)
+}
+
+func TestReviewAssertions(t *gotesting.T) {
+	t.Parallel()
+
+	allowed := NewAdmissionReview(t).Review()
+	AssertReviewAllowed(t, allowed)
+
+	denied := NewAdmissionReview(t).Review()
+	denied.Response.Allowed = false
+	denied.Response.Result = &metav1.Status{
+		Details: &metav1.StatusDetails{
+			Causes: []metav1.StatusCause{{
+				Type:  metav1.CauseTypeFieldValueInvalid,
+				Field: "container",
+			}},
+		},
+	}
+
+	causes := AssertReviewDenied(t, denied)
+	assert.Len(t, causes, 1)
+	assert.Equal(t, "container", causes[0].Field)
+}
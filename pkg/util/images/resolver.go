@@ -0,0 +1,225 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package images resolves container image tags to content digests through
+// the registry v2 API, so controllers can pin the exact bytes an image tag
+// pointed at when it was first seen.
+package images
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRegistryHost = "registry-1.docker.io"
+	manifestAccept      = "application/vnd.docker.distribution.manifest.v2+json, " +
+		"application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// Resolver resolves image references to digest pinned references.
+// Resolutions are cached for the lifetime of the Resolver, so a tag that is
+// re-pushed keeps resolving to the digest it had when it was first seen
+type Resolver struct {
+	client *http.Client
+	scheme string
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewResolver returns a Resolver that talks to registries over https
+func NewResolver() *Resolver {
+	return &Resolver{
+		client: &http.Client{Timeout: 30 * time.Second},
+		scheme: "https",
+		cache:  map[string]string{},
+	}
+}
+
+// Resolve returns the given image reference pinned to the digest its tag
+// currently points at, in `repository@digest` form. References that already
+// carry a digest are returned unchanged
+func (r *Resolver) Resolve(image string) (string, error) {
+	if strings.Contains(image, "@") {
+		return image, nil
+	}
+
+	r.mu.Lock()
+	resolved, ok := r.cache[image]
+	r.mu.Unlock()
+	if ok {
+		return resolved, nil
+	}
+
+	host, repository, tag := parseReference(image)
+	digest, err := r.digest(host, repository, tag)
+	if err != nil {
+		return "", errors.Wrapf(err, "error resolving digest for image %s", image)
+	}
+
+	name := image
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	resolved = name + "@" + digest
+
+	r.mu.Lock()
+	r.cache[image] = resolved
+	r.mu.Unlock()
+
+	return resolved, nil
+}
+
+// digest fetches the manifest digest for the given repository and tag,
+// authenticating with an anonymous bearer token if the registry asks for one
+func (r *Resolver) digest(host, repository, tag string) (string, error) {
+	url := r.scheme + "://" + host + "/v2/" + repository + "/manifests/" + tag
+
+	resp, err := r.head(url, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := r.token(resp.Header.Get("Www-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+		if resp, err = r.head(url, token); err != nil {
+			return "", err
+		}
+		resp.Body.Close() // nolint: errcheck
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("registry returned status %d for %s", resp.StatusCode, url)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", errors.Errorf("registry returned no digest for %s", url)
+	}
+	return digest, nil
+}
+
+// head performs a HEAD request for the given manifest URL
+func (r *Resolver) head(url, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating request for %s", url)
+	}
+	req.Header.Set("Accept", manifestAccept)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := r.client.Do(req)
+	return resp, errors.Wrapf(err, "error requesting %s", url)
+}
+
+// token fetches an anonymous bearer token from the realm the registry's
+// Www-Authenticate challenge points at
+func (r *Resolver) token(challenge string) (string, error) {
+	realm, params := parseChallenge(challenge)
+	if realm == "" {
+		return "", errors.Errorf("unsupported registry challenge: %s", challenge)
+	}
+
+	url := realm
+	separator := "?"
+	for k, v := range params {
+		url += separator + k + "=" + v
+		separator = "&"
+	}
+
+	resp, err := r.client.Get(url)
+	if err != nil {
+		return "", errors.Wrapf(err, "error requesting registry token from %s", url)
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("token endpoint %s returned status %d", url, resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", errors.Wrapf(err, "error decoding registry token from %s", url)
+	}
+
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseReference splits an image reference into registry host, repository
+// and tag, applying the Docker Hub conventions for short names
+func parseReference(image string) (host, repository, tag string) {
+	host = defaultRegistryHost
+	repository = image
+
+	if i := strings.Index(repository, "/"); i >= 0 &&
+		(strings.ContainsAny(repository[:i], ".:") || repository[:i] == "localhost") {
+		host = repository[:i]
+		repository = repository[i+1:]
+	}
+
+	tag = "latest"
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		tag = repository[i+1:]
+		repository = repository[:i]
+	}
+
+	if host == defaultRegistryHost && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return host, repository, tag
+}
+
+// parseChallenge extracts the realm and remaining parameters from a
+// `Bearer realm="...",service="...",scope="..."` challenge header
+func parseChallenge(challenge string) (string, map[string]string) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", nil
+	}
+
+	var realm string
+	params := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := strings.Trim(kv[1], `"`)
+		if kv[0] == "realm" {
+			realm = value
+		} else {
+			params[kv[0]] = value
+		}
+	}
+
+	return realm, params
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package images
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		host       string
+		repository string
+		tag        string
+	}{
+		"nginx":                              {defaultRegistryHost, "library/nginx", "latest"},
+		"nginx:1.2":                          {defaultRegistryHost, "library/nginx", "1.2"},
+		"agones/simple-udp:0.1":              {defaultRegistryHost, "agones/simple-udp", "0.1"},
+		"gcr.io/agones-images/agones-sdk:v1": {"gcr.io", "agones-images/agones-sdk", "v1"},
+		"localhost:5000/game:dev":            {"localhost:5000", "game", "dev"},
+	}
+
+	for image, expected := range fixtures {
+		t.Run(image, func(t *testing.T) {
+			host, repository, tag := parseReference(image)
+			assert.Equal(t, expected.host, host)
+			assert.Equal(t, expected.repository, repository)
+			assert.Equal(t, expected.tag, tag)
+		})
+	}
+}
+
+func TestResolverResolve(t *testing.T) {
+	t.Parallel()
+
+	digest := "sha256:24a0c4b4a4c0eb97a1aabb8e29f18e917d05abfe1b7a7c07857230879ce7d3d3"
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+		case strings.HasPrefix(r.URL.Path, "/v2/game/manifests/"):
+			if r.Header.Get("Authorization") != "Bearer anonymous" {
+				w.Header().Set("Www-Authenticate",
+					`Bearer realm="http://`+r.Host+`/token",service="registry"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			requests++
+			w.Header().Set("Docker-Content-Digest", digest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	resolver := NewResolver()
+	resolver.scheme = "http"
+
+	resolved, err := resolver.Resolve(host + "/game:1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, host+"/game@"+digest, resolved)
+
+	// resolutions are cached, so a re-pushed tag keeps its first digest
+	resolved, err = resolver.Resolve(host + "/game:1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, host+"/game@"+digest, resolved)
+	assert.Equal(t, 1, requests)
+
+	// references that already carry a digest pass through untouched
+	pinned := host + "/game@" + digest
+	resolved, err = resolver.Resolve(pinned)
+	assert.Nil(t, err)
+	assert.Equal(t, pinned, resolved)
+}
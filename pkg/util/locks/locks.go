@@ -0,0 +1,130 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package locks provides instrumented locking primitives
+package locks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"agones.dev/agones/pkg/util/runtime"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	logger = runtime.NewLoggerWithSource("locks")
+
+	keyLockName = mustTagKey("lock_name")
+	keyLockKey  = mustTagKey("lock_key")
+
+	lockWaitStats       = stats.Float64("locks/wait_duration", "Time spent waiting to acquire a keyed lock", "s")
+	lockContentionStats = stats.Int64("locks/contentions_total", "Number of keyed lock acquisitions that had to wait", "1")
+)
+
+func init() {
+	runtime.Must(view.Register(&view.View{
+		Name:        "locks_wait_duration_seconds",
+		Measure:     lockWaitStats,
+		Description: "The distribution of time spent waiting to acquire a keyed lock",
+		Aggregation: view.Distribution(0, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5),
+		TagKeys:     []tag.Key{keyLockName, keyLockKey},
+	}))
+
+	runtime.Must(view.Register(&view.View{
+		Name:        "locks_contentions_total",
+		Measure:     lockContentionStats,
+		Description: "The number of keyed lock acquisitions that had to wait for another holder",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{keyLockName, keyLockKey},
+	}))
+}
+
+// KeyedMutex is a set of mutexes sharded by an arbitrary string key, such as
+// a namespace or a fleet name, so that work holding the lock for one key does
+// not block work under any other key. Time spent waiting to acquire a lock is
+// recorded as a metric, tagged with the lock's name and the key, to surface
+// contention
+type KeyedMutex struct {
+	name  string
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	// refs counts holders and waiters, so the entry
+	// can be freed once the last one unlocks
+	refs int
+}
+
+// NewKeyedMutex returns a KeyedMutex. The name tags this mutex's
+// contention metrics, and should describe what it protects, e.g. "allocation"
+func NewKeyedMutex(name string) *KeyedMutex {
+	return &KeyedMutex{name: name, locks: map[string]*keyedLock{}}
+}
+
+// Lock locks the mutex for the given key, blocking until it is available.
+// Locks for different keys are acquired independently
+func (km *KeyedMutex) Lock(key string) {
+	km.mu.Lock()
+	l, ok := km.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		km.locks[key] = l
+	}
+	contended := l.refs > 0
+	l.refs++
+	km.mu.Unlock()
+
+	start := time.Now()
+	l.Lock()
+
+	mutators := []tag.Mutator{tag.Upsert(keyLockName, km.name), tag.Upsert(keyLockKey, key)}
+	ms := []stats.Measurement{lockWaitStats.M(time.Since(start).Seconds())}
+	if contended {
+		ms = append(ms, lockContentionStats.M(1))
+	}
+	if err := stats.RecordWithTags(context.Background(), mutators, ms...); err != nil {
+		logger.WithError(err).Warn("error while recording lock stats")
+	}
+}
+
+// Unlock unlocks the mutex for the given key. It is a run-time
+// error if the key is not locked
+func (km *KeyedMutex) Unlock(key string) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	l, ok := km.locks[key]
+	if !ok {
+		panic("locks: Unlock of unlocked key " + key)
+	}
+	l.refs--
+	if l.refs == 0 {
+		delete(km.locks, key)
+	}
+	l.Unlock()
+}
+
+func mustTagKey(key string) tag.Key {
+	t, err := tag.NewKey(key)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package locks
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedMutexDifferentKeys(t *testing.T) {
+	t.Parallel()
+
+	km := NewKeyedMutex("test")
+	km.Lock("default")
+	defer km.Unlock("default")
+
+	// a different key should be acquirable while "default" is held
+	acquired := make(chan struct{})
+	go func() {
+		km.Lock("other")
+		defer km.Unlock("other")
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-time.After(3 * time.Second):
+		assert.FailNow(t, "lock for a different key should not block")
+	}
+}
+
+func TestKeyedMutexSameKey(t *testing.T) {
+	t.Parallel()
+
+	km := NewKeyedMutex("test")
+
+	counter := 0
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			km.Lock("default")
+			defer km.Unlock("default")
+			counter++
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, counter)
+	// the entry should be freed once the last holder unlocks
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	assert.Empty(t, km.locks)
+}
+
+func TestKeyedMutexUnlockUnlocked(t *testing.T) {
+	t.Parallel()
+
+	km := NewKeyedMutex("test")
+	assert.Panics(t, func() { km.Unlock("default") })
+}
@@ -0,0 +1,259 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi builds OpenAPI (Swagger 2.0) documents from Go types
+// through reflection, so REST surfaces can publish a machine readable
+// description of themselves and client code in other languages can be
+// generated rather than handwritten.
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Spec is an OpenAPI (Swagger 2.0) document
+type Spec struct {
+	Swagger     string              `json:"swagger"`
+	Info        Info                `json:"info"`
+	Schemes     []string            `json:"schemes,omitempty"`
+	Consumes    []string            `json:"consumes,omitempty"`
+	Produces    []string            `json:"produces,omitempty"`
+	Paths       map[string]PathItem `json:"paths"`
+	Definitions map[string]*Schema  `json:"definitions,omitempty"`
+
+	names map[reflect.Type]string
+}
+
+// Info describes the API the document covers
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem holds the operations available on a single path
+type PathItem struct {
+	Get  *Operation `json:"get,omitempty"`
+	Post *Operation `json:"post,omitempty"`
+}
+
+// Operation describes a single HTTP operation on a path
+type Operation struct {
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a single operation parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Type        string  `json:"type,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Response describes a single response from an operation
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Schema describes the shape of a value, either inline or as a reference
+// into the document's definitions
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+}
+
+// types whose json marshalling doesn't follow their struct fields, mapped
+// to the schema they marshal as
+var overrides = map[reflect.Type]Schema{
+	reflect.TypeOf(time.Time{}):               {Type: "string", Format: "date-time"},
+	reflect.TypeOf(metav1.Time{}):             {Type: "string", Format: "date-time"},
+	reflect.TypeOf(metav1.MicroTime{}):        {Type: "string", Format: "date-time"},
+	reflect.TypeOf(metav1.Duration{}):         {Type: "string"},
+	reflect.TypeOf(resource.Quantity{}):       {Type: "string"},
+	reflect.TypeOf(intstr.IntOrString{}):      {Type: "string", Format: "int-or-string"},
+	reflect.TypeOf(k8sruntime.RawExtension{}): {Type: "object"},
+}
+
+// AddDefinition adds the definition of obj's type, and of every type it
+// references, to the spec, and returns a schema referencing it. Definitions
+// are built by reflecting over the type's fields and json tags
+func (s *Spec) AddDefinition(obj interface{}) *Schema {
+	if s.Definitions == nil {
+		s.Definitions = map[string]*Schema{}
+	}
+	return s.schemaFor(reflect.TypeOf(obj))
+}
+
+// SetExample attaches an example value to a named definition, which
+// generators and documentation browsers surface alongside the schema
+func (s *Spec) SetExample(definition string, example interface{}) {
+	if schema, ok := s.Definitions[definition]; ok {
+		schema.Example = example
+	}
+}
+
+// schemaFor returns the schema describing t, adding definitions for any
+// named struct types encountered along the way
+func (s *Spec) schemaFor(t reflect.Type) *Schema {
+	if override, ok := overrides[t]; ok {
+		copied := override
+		return &copied
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return s.schemaFor(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		name := s.nameFor(t)
+		if _, ok := s.Definitions[name]; !ok {
+			// reserve the name before recursing, so self referencing
+			// types terminate
+			placeholder := &Schema{Type: "object"}
+			s.Definitions[name] = placeholder
+			*placeholder = *s.structSchema(t)
+		}
+		return &Schema{Ref: "#/definitions/" + name}
+	default:
+		// interfaces and anything else we can't see into
+		return &Schema{Type: "object"}
+	}
+}
+
+// structSchema builds the object schema for a struct type, merging inline
+// embedded structs into the parent the way encoding/json does
+func (s *Spec) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.addFields(t, schema)
+	return schema
+}
+
+func (s *Spec) addFields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue // unexported, though embedded structs still surface
+			// their exported fields, as encoding/json promotes them
+		}
+
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+
+		omitempty := false
+		inline := f.Anonymous && name == ""
+		for _, opt := range tag[1:] {
+			switch opt {
+			case "omitempty":
+				omitempty = true
+			case "inline":
+				inline = true
+			}
+		}
+
+		if inline {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.addFields(ft, schema)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		schema.Properties[name] = s.schemaFor(f.Type)
+		if !omitempty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// nameFor returns the definition name for a type - its package's last path
+// segment and its name, such as "v1alpha1.GameServer" - extending the
+// qualifier when two packages would otherwise collide
+func (s *Spec) nameFor(t reflect.Type) string {
+	if s.names == nil {
+		s.names = map[reflect.Type]string{}
+	}
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	segments := strings.Split(t.PkgPath(), "/")
+	name := t.Name()
+	for i := len(segments) - 1; i >= 0; i-- {
+		name = segments[i] + "." + name
+		if !s.nameTaken(name) {
+			break
+		}
+	}
+	s.names[t] = name
+	return name
+}
+
+func (s *Spec) nameTaken(name string) bool {
+	for _, n := range s.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fixtureMeta struct {
+	Kind string `json:"kind,omitempty"`
+}
+
+type fixtureChild struct {
+	Name string `json:"name"`
+}
+
+type fixture struct {
+	fixtureMeta `json:",inline"`
+	Label       string            `json:"label"`
+	Count       int32             `json:"count,omitempty"`
+	Ratio       float64           `json:"ratio,omitempty"`
+	Children    []fixtureChild    `json:"children,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Created     metav1.Time       `json:"created,omitempty"`
+	Self        *fixture          `json:"self,omitempty"`
+	hidden      bool              // nolint: megacheck, structcheck
+}
+
+func TestSpecAddDefinition(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{Swagger: "2.0"}
+	ref := spec.AddDefinition(&fixture{})
+
+	assert.Equal(t, "#/definitions/openapi.fixture", ref.Ref)
+
+	def := spec.Definitions["openapi.fixture"]
+	if !assert.NotNil(t, def) {
+		return
+	}
+	assert.Equal(t, "object", def.Type)
+
+	// inline embedded structs merge into the parent
+	assert.Equal(t, "string", def.Properties["kind"].Type)
+	assert.Equal(t, "string", def.Properties["label"].Type)
+	assert.Equal(t, "integer", def.Properties["count"].Type)
+	assert.Equal(t, "int32", def.Properties["count"].Format)
+	assert.Equal(t, "number", def.Properties["ratio"].Type)
+	assert.Equal(t, "string", def.Properties["created"].Type)
+	assert.Equal(t, "date-time", def.Properties["created"].Format)
+	assert.Equal(t, []string{"label"}, def.Required)
+	assert.Nil(t, def.Properties["hidden"])
+
+	assert.Equal(t, "array", def.Properties["children"].Type)
+	assert.Equal(t, "#/definitions/openapi.fixtureChild", def.Properties["children"].Items.Ref)
+	assert.Equal(t, "string", def.Properties["tags"].AdditionalProperties.Type)
+
+	// self referencing types terminate with a reference
+	assert.Equal(t, "#/definitions/openapi.fixture", def.Properties["self"].Ref)
+
+	child := spec.Definitions["openapi.fixtureChild"]
+	if assert.NotNil(t, child) {
+		assert.Equal(t, "string", child.Properties["name"].Type)
+	}
+}
+
+func TestSpecSetExample(t *testing.T) {
+	t.Parallel()
+
+	spec := &Spec{Swagger: "2.0", Paths: map[string]PathItem{}}
+	spec.AddDefinition(fixtureChild{})
+	spec.SetExample("openapi.fixtureChild", fixtureChild{Name: "example"})
+
+	body, err := json.Marshal(spec)
+	assert.Nil(t, err)
+
+	decoded := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(body, &decoded))
+	definitions := decoded["definitions"].(map[string]interface{})
+	child := definitions["openapi.fixtureChild"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "example"}, child["example"])
+}
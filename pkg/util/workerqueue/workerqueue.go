@@ -50,6 +50,9 @@ type WorkerQueue struct {
 	mu      sync.Mutex
 	workers int
 	running int
+
+	started     chan struct{}
+	startedOnce sync.Once
 }
 
 // NewWorkerQueue returns a new worker queue for a given name
@@ -63,6 +66,7 @@ func NewWorkerQueueWithRateLimiter(handler Handler, logger *logrus.Entry, name s
 		logger:      logger.WithField("queue", name),
 		queue:       workqueue.NewNamedRateLimitingQueue(rateLimiter, name),
 		SyncHandler: handler,
+		started:     make(chan struct{}),
 	}
 }
 
@@ -96,6 +100,20 @@ func (wq *WorkerQueue) EnqueueImmediately(obj interface{}) {
 	wq.queue.Add(key)
 }
 
+// EnqueueAfter delays an Enqueue until after the given duration
+// has passed, without rate-limiting
+func (wq *WorkerQueue) EnqueueAfter(obj interface{}, duration time.Duration) {
+	var key string
+	var err error
+	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
+		err = errors.Wrap(err, "Error creating key for object")
+		runtime.HandleError(wq.logger.WithField("obj", obj), err)
+		return
+	}
+	wq.logger.WithField("key", key).WithField("duration", duration).Info("Enqueuing key after duration")
+	wq.queue.AddAfter(key, duration)
+}
+
 // runWorker is a long-running function that will continually call the
 // processNextWorkItem function in order to read and process a message on the
 // workqueue.
@@ -143,6 +161,7 @@ func (wq *WorkerQueue) Run(workers int, stop <-chan struct{}) {
 	for i := 0; i < workers; i++ {
 		go wq.run(stop)
 	}
+	wq.startedOnce.Do(func() { close(wq.started) })
 
 	<-stop
 	wq.logger.Info("...shutting down workers")
@@ -155,6 +174,19 @@ func (wq *WorkerQueue) run(stop <-chan struct{}) {
 	wait.Until(wq.runWorker, workFx, stop)
 }
 
+// WaitUntilRunning blocks until the workers have been started by Run,
+// or stop is closed. Returns false if stop was closed first. As Run is
+// the last step of a controller's startup, this can be used as a
+// readiness signal for controllers built on a WorkerQueue
+func (wq *WorkerQueue) WaitUntilRunning(stop <-chan struct{}) bool {
+	select {
+	case <-wq.started:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
 // Healthy reports whether all the worker goroutines are running.
 func (wq *WorkerQueue) Healthy() error {
 	wq.mu.Lock()
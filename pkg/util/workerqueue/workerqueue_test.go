@@ -164,3 +164,19 @@ func TestWorkQueueHealthCheck(t *testing.T) {
 	assert.Error(t, wq.Healthy())
 	f(t, url, http.StatusServiceUnavailable)
 }
+
+func TestWorkerQueueWaitUntilRunning(t *testing.T) {
+	t.Parallel()
+
+	wq := NewWorkerQueue(func(string) error { return nil }, logrus.WithField("source", "test"), "test")
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// not running yet, so stop closing first should return false
+	earlyStop := make(chan struct{})
+	close(earlyStop)
+	assert.False(t, wq.WaitUntilRunning(earlyStop))
+
+	go wq.Run(1, stop)
+	assert.True(t, wq.WaitUntilRunning(stop))
+}
@@ -73,6 +73,70 @@ func (s *SDK) Shutdown() error {
 	return errors.Wrapf(err, "could not send Shutdown message")
 }
 
+// Reserve marks the Game Server as Reserved for a given duration, at which point
+// it will return the GameServer to a Ready state.
+// Do note, the smallest unit available in the time.Duration argument is a second.
+func (s *SDK) Reserve(d time.Duration) error {
+	_, err := s.client.Reserve(s.ctx, &sdk.Duration{Seconds: int64(d.Seconds())})
+	return errors.Wrap(err, "could not send Reserve message")
+}
+
+// PlayerConnect records that the player with the given id has connected
+// to this GameServer, adding them to the player tracking status
+func (s *SDK) PlayerConnect(id string) error {
+	_, err := s.client.PlayerConnect(s.ctx, &sdk.PlayerID{PlayerID: id})
+	return errors.Wrap(err, "could not send PlayerConnect message")
+}
+
+// PlayerDisconnect records that the player with the given id has disconnected
+// from this GameServer, removing them from the player tracking status
+func (s *SDK) PlayerDisconnect(id string) error {
+	_, err := s.client.PlayerDisconnect(s.ctx, &sdk.PlayerID{PlayerID: id})
+	return errors.Wrap(err, "could not send PlayerDisconnect message")
+}
+
+// GetConnectedPlayers returns at most limit connected player ids starting at
+// offset, along with the total number of matching ids, optionally filtered to
+// ids starting with prefix. A limit of 0 returns all matching ids
+func (s *SDK) GetConnectedPlayers(limit, offset int64, prefix string) ([]string, int64, error) {
+	list, err := s.client.GetConnectedPlayers(s.ctx, &sdk.ConnectedPlayersRequest{Limit: limit, Offset: offset, Prefix: prefix})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not retrieve connected players")
+	}
+	return list.List, list.Total, nil
+}
+
+// IsPlayerConnected returns whether the player with the given id is currently
+// connected to this GameServer, without retrieving the player list
+func (s *SDK) IsPlayerConnected(id string) (bool, error) {
+	result, err := s.client.IsPlayerConnected(s.ctx, &sdk.PlayerID{PlayerID: id})
+	if err != nil {
+		return false, errors.Wrap(err, "could not check player connection")
+	}
+	return result.Connected, nil
+}
+
+// SetCounterCount sets the named game defined counter on the `GameServer`
+// status to the given count
+func (s *SDK) SetCounterCount(name string, count int64) error {
+	_, err := s.client.SetCounterCount(s.ctx, &sdk.Counter{Name: name, Count: count})
+	return errors.Wrap(err, "could not send SetCounterCount message")
+}
+
+// AppendListValue appends the value to the named game defined list on the
+// `GameServer` status
+func (s *SDK) AppendListValue(name, value string) error {
+	_, err := s.client.AppendListValue(s.ctx, &sdk.ListValue{Name: name, Value: value})
+	return errors.Wrap(err, "could not send AppendListValue message")
+}
+
+// RemoveListValue removes the value from the named game defined list on the
+// `GameServer` status
+func (s *SDK) RemoveListValue(name, value string) error {
+	_, err := s.client.RemoveListValue(s.ctx, &sdk.ListValue{Name: name, Value: value})
+	return errors.Wrap(err, "could not send RemoveListValue message")
+}
+
 // Health sends a ping to the health
 // check to indicate that this server is healthy
 func (s *SDK) Health() error {
@@ -95,6 +159,21 @@ func (s *SDK) SetAnnotation(key, value string) error {
 	return errors.Wrap(err, "could not set annotation")
 }
 
+// SetLoad sets the game defined load score on the `GameServer` status.
+// Lower values indicate a less loaded GameServer.
+func (s *SDK) SetLoad(load float64) error {
+	_, err := s.client.SetLoad(s.ctx, &sdk.Load{Load: load})
+	return errors.Wrap(err, "could not set load")
+}
+
+// Log forwards a log line to the sidecar's structured logging pipeline,
+// tagged with the GameServer name.
+// Level is one of "debug", "info", "warn" or "error".
+func (s *SDK) Log(level, message string) error {
+	_, err := s.client.Log(s.ctx, &sdk.LogEntry{Level: level, Message: message})
+	return errors.Wrap(err, "could not send log")
+}
+
 // GameServer retrieve the GameServer details
 func (s *SDK) GameServer() (*sdk.GameServer, error) {
 	gs, err := s.client.GetGameServer(s.ctx, &sdk.Empty{})
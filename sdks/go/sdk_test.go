@@ -15,6 +15,7 @@
 package sdk
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -119,6 +120,131 @@ func TestSDKSetAnnotation(t *testing.T) {
 	assert.Equal(t, expected, sm.annotations["foo"])
 }
 
+func TestSDKSetLoad(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	expected := 0.5
+	err := s.SetLoad(expected)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, sm.load)
+}
+
+func TestSDKReserve(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	err := s.Reserve(12 * time.Second)
+	assert.Nil(t, err)
+	if assert.NotNil(t, sm.reserved) {
+		assert.Equal(t, int64(12), sm.reserved.Seconds)
+	}
+}
+
+func TestSDKPlayerConnectAndDisconnect(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	err := s.PlayerConnect("one")
+	assert.Nil(t, err)
+	err = s.PlayerConnect("two")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"one", "two"}, sm.players)
+
+	err = s.PlayerDisconnect("one")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"two"}, sm.players)
+}
+
+func TestSDKGetConnectedPlayers(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{players: []string{"one", "two", "three"}}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	list, total, err := s.GetConnectedPlayers(0, 0, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, list)
+	assert.Equal(t, int64(3), total)
+
+	list, total, err = s.GetConnectedPlayers(1, 1, "t")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"three"}, list)
+	assert.Equal(t, int64(2), total)
+
+	connected, err := s.IsPlayerConnected("two")
+	assert.Nil(t, err)
+	assert.True(t, connected)
+
+	connected, err = s.IsPlayerConnected("four")
+	assert.Nil(t, err)
+	assert.False(t, connected)
+}
+
+func TestSDKSetCounterCount(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{
+		counters: map[string]int64{},
+	}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	err := s.SetCounterCount("rooms", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), sm.counters["rooms"])
+}
+
+func TestSDKListValues(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{
+		lists: map[string][]string{},
+	}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	err := s.AppendListValue("matches", "one")
+	assert.Nil(t, err)
+	err = s.AppendListValue("matches", "two")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"one", "two"}, sm.lists["matches"])
+
+	err = s.RemoveListValue("matches", "one")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"two"}, sm.lists["matches"])
+}
+
+func TestSDKLog(t *testing.T) {
+	t.Parallel()
+	sm := &sdkMock{}
+	s := SDK{
+		ctx:    context.Background(),
+		client: sm,
+	}
+
+	err := s.Log("error", "something broke")
+	assert.Nil(t, err)
+	assert.Equal(t, "error", sm.logEntry.Level)
+	assert.Equal(t, "something broke", sm.logEntry.Message)
+}
+
 var _ sdk.SDKClient = &sdkMock{}
 var _ sdk.SDK_HealthClient = &healthMock{}
 var _ sdk.SDK_WatchGameServerClient = &watchMock{}
@@ -130,6 +256,12 @@ type sdkMock struct {
 	wm          *watchMock
 	labels      map[string]string
 	annotations map[string]string
+	load        float64
+	logEntry    *sdk.LogEntry
+	reserved    *sdk.Duration
+	players     []string
+	counters    map[string]int64
+	lists       map[string][]string
 }
 
 func (m *sdkMock) SetLabel(ctx context.Context, in *sdk.KeyValue, opts ...grpc.CallOption) (*sdk.Empty, error) {
@@ -142,6 +274,87 @@ func (m *sdkMock) SetAnnotation(ctx context.Context, in *sdk.KeyValue, opts ...g
 	return &sdk.Empty{}, nil
 }
 
+func (m *sdkMock) SetLoad(ctx context.Context, in *sdk.Load, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	m.load = in.Load
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) Log(ctx context.Context, in *sdk.LogEntry, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	m.logEntry = in
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) Reserve(ctx context.Context, in *sdk.Duration, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	m.reserved = in
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) PlayerConnect(ctx context.Context, in *sdk.PlayerID, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	m.players = append(m.players, in.PlayerID)
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) PlayerDisconnect(ctx context.Context, in *sdk.PlayerID, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	for i, p := range m.players {
+		if p == in.PlayerID {
+			m.players = append(m.players[:i], m.players[i+1:]...)
+			break
+		}
+	}
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) GetConnectedPlayers(ctx context.Context, in *sdk.ConnectedPlayersRequest, opts ...grpc.CallOption) (*sdk.ConnectedPlayersList, error) {
+	result := &sdk.ConnectedPlayersList{}
+	skip := in.Offset
+	for _, p := range m.players {
+		if in.Prefix != "" && !strings.HasPrefix(p, in.Prefix) {
+			continue
+		}
+		result.Total++
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if in.Limit > 0 && int64(len(result.List)) >= in.Limit {
+			continue
+		}
+		result.List = append(result.List, p)
+	}
+	return result, nil
+}
+
+func (m *sdkMock) IsPlayerConnected(ctx context.Context, in *sdk.PlayerID, opts ...grpc.CallOption) (*sdk.Connected, error) {
+	result := &sdk.Connected{}
+	for _, p := range m.players {
+		if p == in.PlayerID {
+			result.Connected = true
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *sdkMock) SetCounterCount(ctx context.Context, in *sdk.Counter, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	m.counters[in.Name] = in.Count
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) AppendListValue(ctx context.Context, in *sdk.ListValue, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	m.lists[in.Name] = append(m.lists[in.Name], in.Value)
+	return &sdk.Empty{}, nil
+}
+
+func (m *sdkMock) RemoveListValue(ctx context.Context, in *sdk.ListValue, opts ...grpc.CallOption) (*sdk.Empty, error) {
+	for i, v := range m.lists[in.Name] {
+		if v == in.Value {
+			m.lists[in.Name] = append(m.lists[in.Name][:i], m.lists[in.Name][i+1:]...)
+			break
+		}
+	}
+	return &sdk.Empty{}, nil
+}
+
 func (m *sdkMock) WatchGameServer(ctx context.Context, in *sdk.Empty, opts ...grpc.CallOption) (sdk.SDK_WatchGameServerClient, error) {
 	return m.wm, nil
 }
@@ -48,6 +48,11 @@ type Framework struct {
 	AgonesClient    versioned.Interface
 	GameServerImage string
 	PullSecret      string
+	// RunQuarantined runs tests that have been quarantined as known
+	// flaky scenarios, which are otherwise skipped
+	RunQuarantined bool
+	// Stats collects per-test GameServer lifecycle timings across the run
+	Stats Stats
 }
 
 // New setups a testing framework using a kubeconfig path and the game server image to use for testing.
@@ -103,6 +108,7 @@ func (f *Framework) WaitForGameServerState(gs *v1alpha1.GameServer, state v1alph
 	var pollErr error
 	var readyGs *v1alpha1.GameServer
 
+	start := time.Now()
 	err := wait.PollImmediate(2*time.Second, timeout, func() (bool, error) {
 		readyGs, pollErr = f.AgonesClient.StableV1alpha1().GameServers(gs.Namespace).Get(gs.Name, metav1.GetOptions{})
 
@@ -116,6 +122,7 @@ func (f *Framework) WaitForGameServerState(gs *v1alpha1.GameServer, state v1alph
 
 		return false, nil
 	})
+	f.Stats.recordLifecycle(gs.Name, string(state), time.Since(start), err != nil)
 	if err != nil {
 		return nil, errors.Wrapf(pollErr, "waiting for GameServer to be %v %v/%v: %v",
 			state, gs.Namespace, gs.Name, err)
@@ -137,6 +144,7 @@ func (f *Framework) WaitForFleetCondition(t *testing.T, flt *v1alpha1.Fleet, con
 	})
 	if err != nil {
 		logrus.WithField("fleet", flt.Name).WithError(err).Info("error waiting for fleet condition")
+		f.DumpControllerLogs(t)
 		t.Fatal("error waiting for fleet condition")
 	}
 }
@@ -0,0 +1,171 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"bufio"
+	"bytes"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// agonesSystemNamespace is where the Agones controllers are installed
+const agonesSystemNamespace = "agones-system"
+
+// controllerLogTailLines is how much of each controller pod's log is
+// dumped when a test fails
+const controllerLogTailLines = int64(100)
+
+// lifecycleTiming records how long a single GameServer took to reach a
+// state, attributed to the test that waited for it
+type lifecycleTiming struct {
+	Test       string
+	GameServer string
+	State      string
+	Duration   time.Duration
+	Failed     bool
+}
+
+// Stats collects per-test GameServer lifecycle timings across a run, so
+// slowdowns can be spotted as the suite grows rather than showing up as
+// timeout flakes
+type Stats struct {
+	mu      sync.Mutex
+	timings []lifecycleTiming
+}
+
+// recordLifecycle records one state transition wait
+func (s *Stats) recordLifecycle(gameServer, state string, duration time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timings = append(s.timings, lifecycleTiming{
+		Test:       callingTest(),
+		GameServer: gameServer,
+		State:      state,
+		Duration:   duration,
+		Failed:     failed,
+	})
+}
+
+// Report logs a summary of the collected lifecycle timings, grouped by
+// test and state - count, failures, and min/max/average durations
+func (s *Stats) Report() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := map[string][]lifecycleTiming{}
+	for _, timing := range s.timings {
+		key := timing.Test + "/" + timing.State
+		groups[key] = append(groups[key], timing)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		timings := groups[key]
+		min, max, sum := timings[0].Duration, timings[0].Duration, time.Duration(0)
+		failed := 0
+		for _, timing := range timings {
+			if timing.Duration < min {
+				min = timing.Duration
+			}
+			if timing.Duration > max {
+				max = timing.Duration
+			}
+			sum += timing.Duration
+			if timing.Failed {
+				failed++
+			}
+		}
+		logrus.WithField("count", len(timings)).
+			WithField("failed", failed).
+			WithField("min", min).
+			WithField("max", max).
+			WithField("avg", sum/time.Duration(len(timings))).
+			Infof("lifecycle timing: %s", key)
+	}
+}
+
+// callingTest walks up the stack for the Test function the framework was
+// invoked from, so timings can be attributed without threading *testing.T
+// through every helper
+func callingTest() string {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(3, pc)
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		name := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+		if strings.HasPrefix(name, "Test") {
+			return name
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// DumpControllerLogs logs the tail of each Agones controller pod's log,
+// so a failed test carries the controller's view of what happened in its
+// own output rather than needing a separate trawl of the cluster
+func (f *Framework) DumpControllerLogs(t *testing.T) {
+	t.Helper()
+
+	pods, err := f.KubeClient.CoreV1().Pods(agonesSystemNamespace).List(metav1.ListOptions{
+		LabelSelector: "stable.agones.dev/role=controller",
+	})
+	if err != nil {
+		t.Logf("could not list controller pods: %v", err)
+		return
+	}
+
+	tail := controllerLogTailLines
+	for _, pod := range pods.Items {
+		raw, err := f.KubeClient.CoreV1().Pods(agonesSystemNamespace).
+			GetLogs(pod.ObjectMeta.Name, &corev1.PodLogOptions{TailLines: &tail}).Do().Raw()
+		if err != nil {
+			t.Logf("could not get logs for controller pod %s: %v", pod.ObjectMeta.Name, err)
+			continue
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(raw))
+		for scanner.Scan() {
+			t.Logf("%s: %s", pod.ObjectMeta.Name, scanner.Text())
+		}
+	}
+}
+
+// Quarantine skips a test that is a known-flaky scenario, unless the
+// framework was asked to run quarantined tests. Quarantined tests should
+// reference the issue tracking the flake, so they don't stay quarantined
+// forever
+func (f *Framework) Quarantine(t *testing.T, issue string) {
+	t.Helper()
+	if !f.RunQuarantined {
+		t.Skipf("quarantined as a known flaky test: %s", issue)
+	}
+}
@@ -37,6 +37,8 @@ func TestMain(m *testing.M) {
 		"gameserver image to use for those tests, gcr.io/agones-images/udp-server:0.6")
 	pullSecret := flag.String("pullsecret", "",
 		"optional secret to be used for pulling the gameserver and/or Agones SDK sidecar images")
+	runQuarantined := flag.Bool("run-quarantined", false,
+		"run tests that have been quarantined as known flaky scenarios, which are otherwise skipped")
 
 	flag.Parse()
 
@@ -49,6 +51,7 @@ func TestMain(m *testing.M) {
 		log.Printf("failed to setup framework: %v\n", err)
 		os.Exit(1)
 	}
+	framework.RunQuarantined = *runQuarantined
 
 	// run cleanup before tests, to ensure no resources from previous runs exist.
 	err = framework.CleanUp(defaultNs)
@@ -57,6 +60,7 @@ func TestMain(m *testing.M) {
 	}
 
 	defer func() {
+		framework.Stats.Report()
 		err = framework.CleanUp(defaultNs)
 		if err != nil {
 			log.Printf("failed to cleanup resources: %v\n", err)
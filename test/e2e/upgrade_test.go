@@ -0,0 +1,59 @@
+// Copyright 2018 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"testing"
+
+	"agones.dev/agones/pkg/apis/stable"
+	"agones.dev/agones/pkg/apis/stable/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpgradeNewGameServerVersionAnnotation checks that a freshly created
+// GameServer is stamped with the version of the controller that admitted it
+func TestUpgradeNewGameServerVersionAnnotation(t *testing.T) {
+	t.Parallel()
+	gs := defaultGameServer()
+	readyGs, err := framework.CreateGameServerAndWaitUntilReady(defaultNs, gs)
+	if err != nil {
+		t.Fatalf("Could not get a GameServer ready: %v", err)
+	}
+	defer framework.AgonesClient.StableV1alpha1().GameServers(defaultNs).Delete(readyGs.ObjectMeta.Name, nil) // nolint: errcheck
+
+	assert.Equal(t, readyGs.Status.State, v1alpha1.GameServerStateReady)
+	assert.NotEmpty(t, readyGs.ControllerVersion())
+}
+
+// TestUpgradeOldControllerGameServer simulates a GameServer created by a
+// previous controller version being handed to this controller - as happens
+// during an in place upgrade - and checks that it still becomes Ready with
+// its original version annotation and finalizer intact
+func TestUpgradeOldControllerGameServer(t *testing.T) {
+	t.Parallel()
+	gs := defaultGameServer()
+	gs.ObjectMeta.Annotations = map[string]string{v1alpha1.ControllerVersionAnnotation: "0.7.0"}
+	gs.ObjectMeta.Finalizers = []string{stable.GroupName}
+
+	readyGs, err := framework.CreateGameServerAndWaitUntilReady(defaultNs, gs)
+	if err != nil {
+		t.Fatalf("Could not get a GameServer ready: %v", err)
+	}
+	defer framework.AgonesClient.StableV1alpha1().GameServers(defaultNs).Delete(readyGs.ObjectMeta.Name, nil) // nolint: errcheck
+
+	assert.Equal(t, readyGs.Status.State, v1alpha1.GameServerStateReady)
+	assert.Equal(t, "0.7.0", readyGs.ControllerVersion())
+	assert.Contains(t, readyGs.ObjectMeta.Finalizers, stable.GroupName)
+}